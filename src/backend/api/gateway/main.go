@@ -14,12 +14,11 @@ import (
 
 	"github.com/go-chi/chi/v5" // v5.0.8
 	"github.com/go-chi/chi/v5/middleware" // v5.0.8
-	"github.com/go-chi/cors" // v1.2.1
-	"github.com/go-chi/compress" // v5.0.0
 	"github.com/go-chi/httprate" // v0.7.0
 	"github.com/prometheus/client_golang/prometheus" // v1.15.0
 
 	"github.com/urban-gardening/backend/config"
+	gatewayMiddleware "github.com/urban-gardening/backend/api/gateway/middleware"
 	"github.com/urban-gardening/backend/api/gateway/middleware/auth"
 	"github.com/urban-gardening/backend/api/gateway/routes/garden"
 )
@@ -97,26 +96,24 @@ func main() {
 func setupRouter(cfg *config.ServiceConfig) *chi.Mux {
 	router := chi.NewRouter()
 
-	// Core middleware
-	router.Use(middleware.RequestID)
+	// Core middleware; request IDs use the configured header and keep upstream IDs
+	router.Use(gatewayMiddleware.RequestIDMiddleware(cfg.API))
 	router.Use(middleware.RealIP)
 	router.Use(middleware.Logger)
 	router.Use(middleware.Recoverer)
 
-	// Compression for responses
-	router.Use(compress.Handler(1000))
+	// Compression for responses, configurable or disabled for low-CPU deployments
+	compression, err := gatewayMiddleware.CompressionMiddleware(cfg.API)
+	if err != nil {
+		log.Fatalf("Failed to configure compression: %v", err)
+	}
+	router.Use(compression)
 
 	// CORS configuration
-	router.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   cfg.API.AllowedOrigins,
-		AllowedMethods:   cfg.API.AllowedMethods,
-		AllowedHeaders:   cfg.API.AllowedHeaders,
-		ExposedHeaders:   []string{"Link"},
-		AllowCredentials: true,
-		MaxAge:          300,
-	}))
-
-	// Rate limiting
+	router.Use(gatewayMiddleware.CORSMiddleware(cfg.API))
+
+	// Rate limiting by IP, ahead of authentication so unauthenticated floods are
+	// rejected before any token is validated
 	router.Use(httprate.LimitByIP(
 		cfg.API.RateLimit,
 		cfg.API.RateLimitWindow,
@@ -125,6 +122,9 @@ func setupRouter(cfg *config.ServiceConfig) *chi.Mux {
 	// Authentication middleware
 	router.Use(auth.AuthMiddleware(cfg))
 
+	// Per-user rate limiting; runs after authentication so the authenticated user is available
+	router.Use(gatewayMiddleware.NewUserRateLimiter(cfg.API.UserRateLimit, cfg.API.RateLimitWindow))
+
 	// Metrics middleware
 	router.Use(func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -97,7 +97,7 @@ func AuthMiddleware(config *types.ServiceConfig) func(http.Handler) http.Handler
 			}
 
 			// Store user in request context
-			ctx = context.WithValue(ctx, userContextKey, user)
+			ctx = ContextWithUser(ctx, user)
 			r = r.WithContext(ctx)
 
 			authMetrics.WithLabelValues("success").Inc()
@@ -106,6 +106,29 @@ func AuthMiddleware(config *types.ServiceConfig) func(http.Handler) http.Handler
 	}
 }
 
+// RequireRole creates a middleware that only admits authenticated users holding the given role.
+// It must run after AuthMiddleware.
+func RequireRole(role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, err := GetUserFromContext(r)
+			if err != nil {
+				authMetrics.WithLabelValues("missing_user").Inc()
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			if user.Role != role {
+				authMetrics.WithLabelValues("forbidden").Inc()
+				http.Error(w, errors.NewError("FORBIDDEN", "Insufficient permissions").Error(), http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // extractTokenFromHeader securely extracts and validates the JWT token from the Authorization header
 func extractTokenFromHeader(r *http.Request) (string, error) {
 	// Get Authorization header
@@ -143,6 +166,11 @@ func extractTokenFromHeader(r *http.Request) (string, error) {
 	return token, nil
 }
 
+// ContextWithUser returns a copy of ctx carrying the authenticated user
+func ContextWithUser(ctx context.Context, user *dto.UserResponseDTO) context.Context {
+	return context.WithValue(ctx, userContextKey, user)
+}
+
 // GetUserFromContext safely retrieves the authenticated user from the request context
 func GetUserFromContext(r *http.Request) (*dto.UserResponseDTO, error) {
 	// Extract user from context
@@ -158,6 +186,7 @@ func GetUserFromContext(r *http.Request) (*dto.UserResponseDTO, error) {
 		Email:     user.Email,
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
+		Role:      user.Role,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 	}
@@ -0,0 +1,34 @@
+// Package middleware provides HTTP middleware components for the Urban Gardening Assistant API gateway.
+// Version: 1.0.0
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/klauspost/compress/gzhttp" // v1.15.0
+	"github.com/urban-gardening/backend/pkg/types"
+)
+
+// CompressionMiddleware creates a gzip response compression handler from the API
+// configuration. Responses smaller than CompressionMinSize are sent uncompressed, and
+// when compression is disabled the returned middleware passes responses through as is.
+func CompressionMiddleware(cfg *types.APIConfig) (func(http.Handler) http.Handler, error) {
+	if cfg == nil || !cfg.EnableCompression {
+		return func(next http.Handler) http.Handler {
+			return next
+		}, nil
+	}
+
+	wrapper, err := gzhttp.NewWrapper(
+		gzhttp.MinSize(cfg.CompressionMinSize),
+		gzhttp.CompressionLevel(cfg.CompressionLevel),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create compression middleware: %w", err)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return wrapper(next)
+	}, nil
+}
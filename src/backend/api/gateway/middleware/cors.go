@@ -79,6 +79,12 @@ func CORSMiddleware(config *types.APIConfig) func(http.Handler) http.Handler {
 		"X-Request-ID",
 	}
 
+	// Let browsers send and read a custom request ID header
+	if config.RequestIDHeader != "" {
+		allowedHeaders = append(allowedHeaders, config.RequestIDHeader)
+		exposedHeaders = append(exposedHeaders, config.RequestIDHeader)
+	}
+
 	// Create the CORS handler with secure configuration
 	corsHandler := cors.Handler(cors.Options{
 		AllowedOrigins:   allowedOrigins,
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/go-chi/chi/v5" // v5.0.8
+	"github.com/go-chi/httprate" // v0.7.0
 	"github.com/prometheus/client_golang/prometheus" // v1.16.0
 
 	"github.com/urban-gardening-assistant/backend/internal/utils/cache"
@@ -215,6 +216,42 @@ func (rl *rateLimiter) incrementRateLimit(ctx context.Context, ip string, window
 	return nil
 }
 
+// NewUserRateLimiter creates a middleware limiting each authenticated user to limit
+// requests per window, so a user spreading requests across IPs cannot exceed it. It
+// must run after AuthMiddleware and complements, rather than replaces, the per-IP
+// limit in front of authentication; requests without an authenticated user pass through.
+func NewUserRateLimiter(limit int, window time.Duration) func(http.Handler) http.Handler {
+	if limit <= 0 {
+		limit = defaultRateLimit
+	}
+	if window <= 0 {
+		window = defaultWindow
+	}
+
+	userLayer := httprate.Limit(limit, window, httprate.WithKeyFuncs(keyByUser))
+
+	return func(next http.Handler) http.Handler {
+		limitedByUser := userLayer(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, err := GetUserFromContext(r); err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			limitedByUser.ServeHTTP(w, r)
+		})
+	}
+}
+
+// keyByUser keys rate limit counters by the authenticated user ID
+func keyByUser(r *http.Request) (string, error) {
+	user, err := GetUserFromContext(r)
+	if err != nil {
+		return "", err
+	}
+	return "user:" + user.ID, nil
+}
+
 // extractIP extracts the client IP address from the request
 func extractIP(r *http.Request) string {
 	// Check X-Forwarded-For header
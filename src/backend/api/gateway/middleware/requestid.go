@@ -0,0 +1,41 @@
+// Package middleware provides HTTP middleware components for the Urban Gardening Assistant API gateway.
+// Version: 1.0.0
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware" // v5.0.8
+	"github.com/google/uuid"                            // v1.3.0
+	"github.com/urban-gardening/backend/pkg/types"
+)
+
+// maxRequestIDLength bounds upstream request IDs so oversized values are not logged or forwarded
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware assigns each request an ID read from the configured header,
+// falling back to chi's X-Request-Id. An ID supplied upstream is kept as is; otherwise
+// a new one is generated. The ID is stored where chi's GetReqID finds it, set on the
+// request header for outbound calls, and echoed on the response.
+func RequestIDMiddleware(cfg *types.APIConfig) func(http.Handler) http.Handler {
+	header := chiMiddleware.RequestIDHeader
+	if cfg != nil && cfg.RequestIDHeader != "" {
+		header = cfg.RequestIDHeader
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := strings.TrimSpace(r.Header.Get(header))
+			if requestID == "" || len(requestID) > maxRequestIDLength {
+				requestID = uuid.New().String()
+				r.Header.Set(header, requestID)
+			}
+
+			w.Header().Set(header, requestID)
+			ctx := context.WithValue(r.Context(), chiMiddleware.RequestIDKey, requestID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Package routes provides HTTP route handlers for the Urban Gardening Assistant API
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5" // v5.0.0
+
+	gatewayMiddleware "github.com/urban-gardening/backend/api/gateway/middleware"
+	"github.com/urban-gardening/backend/config"
+	"github.com/urban-gardening/backend/internal/scheduler"
+	"github.com/urban-gardening/backend/internal/utils/breaker"
+	"github.com/urban-gardening/backend/pkg/dto"
+	"github.com/urban-gardening/backend/pkg/types"
+)
+
+// adminBasePath is the base path for operator-only endpoints
+const adminBasePath = "/api/v1/admin"
+
+// AIHealthChecker performs a minimal AI call to validate configuration and connectivity
+type AIHealthChecker interface {
+	CheckAIHealth(ctx context.Context) *dto.AIHealthResponse
+}
+
+// DeadLetterLister pages through notifications that exhausted their delivery retries
+type DeadLetterLister interface {
+	ListDeadLetters(ctx context.Context, page, pageSize int, filter dto.DeadLetterFilter) (*dto.DeadLetterListResponse, error)
+}
+
+// RegisterAdminRoutes registers operator endpoints, restricted to admin users.
+// The router must already apply AuthMiddleware.
+func RegisterAdminRoutes(router chi.Router, aiChecker AIHealthChecker, deadLetters DeadLetterLister, cfg *types.ServiceConfig) {
+	if router == nil || aiChecker == nil || deadLetters == nil || cfg == nil {
+		panic("router, AI health checker, dead-letter lister, and service config are required")
+	}
+
+	router.Route(adminBasePath, func(r chi.Router) {
+		r.Use(gatewayMiddleware.RequireRole(dto.RoleAdmin))
+		r.Get("/ai/health", handleAIHealth(aiChecker))
+		r.Get("/config", handleEffectiveConfig(cfg))
+		r.Get("/breakers", handleBreakerStates(breaker.Default()))
+		r.Get("/notifications/dead-letters", handleListDeadLetters(deadLetters))
+	})
+}
+
+// handleAIHealth reports the configured model, call latency, and whether the call succeeded
+func handleAIHealth(aiChecker AIHealthChecker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		result := aiChecker.CheckAIHealth(r.Context())
+
+		status := http.StatusOK
+		if !result.Success {
+			status = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(result)
+	}
+}
+
+// handleEffectiveConfig reports the configuration the instance loaded, with secrets redacted
+func handleEffectiveConfig(cfg *types.ServiceConfig) http.HandlerFunc {
+	redacted := config.Redact(cfg)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(redacted)
+	}
+}
+
+// handleBreakerStates reports the current state of every dependency's circuit breaker
+func handleBreakerStates(registry *breaker.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(dto.BreakerStatesResponse{Breakers: registry.States()})
+	}
+}
+
+// handleListDeadLetters pages through dead-lettered notifications. Supports the page,
+// pageSize, taskType, and reason query parameters.
+func handleListDeadLetters(deadLetters DeadLetterLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		page, pageSize := 1, 0
+		if raw := query.Get("page"); raw != "" {
+			parsed, err := strconv.Atoi(raw)
+			if err != nil || parsed < 1 {
+				http.Error(w, "page must be a positive integer", http.StatusBadRequest)
+				return
+			}
+			page = parsed
+		}
+		if raw := query.Get("pageSize"); raw != "" {
+			parsed, err := strconv.Atoi(raw)
+			if err != nil || parsed < 1 {
+				http.Error(w, "pageSize must be a positive integer", http.StatusBadRequest)
+				return
+			}
+			pageSize = parsed
+		}
+
+		filter := dto.DeadLetterFilter{
+			TaskType: query.Get("taskType"),
+			Reason:   query.Get("reason"),
+		}
+
+		response, err := deadLetters.ListDeadLetters(r.Context(), page, pageSize, filter)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, scheduler.ErrInvalidRequest) {
+				status = http.StatusBadRequest
+			}
+			http.Error(w, fmt.Sprintf("failed to list dead letters: %v", err), status)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}
+}
@@ -3,8 +3,11 @@ package routes
 
 import (
     "encoding/json"
+    "errors"
+    "io"
     "net/http"
     "strconv"
+    "strings"
     "time"
 
     "github.com/go-chi/chi/v5" // v5.0.8
@@ -12,6 +15,7 @@ import (
     "github.com/go-chi/chi/v5/middleware" // v5.0.8
 
     "github.com/urban-gardening-assistant/backend/pkg/dto"
+    "github.com/urban-gardening-assistant/backend/pkg/types/common"
     "github.com/urban-gardening-assistant/backend/internal/cropmanager"
     "github.com/urban-gardening-assistant/backend/internal/utils/auth"
     customErrors "github.com/urban-gardening-assistant/backend/internal/utils/errors"
@@ -25,12 +29,18 @@ const (
     maxPerPage     = 50
 )
 
+// maxImportBytes bounds the size of an uploaded crop import CSV
+const maxImportBytes = 1 << 20
+
 // RegisterCropRoutes registers all crop-related routes with the Chi router
 func RegisterCropRoutes(r chi.Router, cropService cropmanager.CropService) {
     // Apply middleware
     r.Use(middleware.RateLimit(rateLimit, rateLimitTime))
     r.Use(middleware.RequestLogger(&middleware.DefaultLogFormatter{Logger: nil}))
 
+    // Reference data clients use instead of hardcoding accepted values
+    r.Get("/api/v1/metadata", getMetadata(cropService))
+
     // CRUD routes with authentication
     r.Group(func(r chi.Router) {
         r.Use(authMiddleware)
@@ -40,6 +50,20 @@ func RegisterCropRoutes(r chi.Router, cropService cropmanager.CropService) {
         r.Get("/api/v1/crops/{id}", getCrop(cropService))
         r.Put("/api/v1/crops/{id}", updateCrop(cropService))
         r.Delete("/api/v1/crops/{id}", deleteCrop(cropService))
+        r.Put("/api/v1/crops/{id}/star", toggleCropStar(cropService))
+        r.Get("/api/v1/crops/{id}/removal-preview", previewCropRemoval(cropService))
+        r.Put("/api/v1/crops/{id}/target-yield", setTargetYield(cropService))
+        r.Put("/api/v1/crops/{id}/task-types", setCropTaskType(cropService))
+        r.Post("/api/v1/crops/{id}/harvests", logHarvest(cropService))
+
+        r.Post("/api/v1/gardens/{id}/plan-yield", planYield(cropService))
+        r.Get("/api/v1/gardens/{id}/crop-recommendations", getCropRecommendations(cropService))
+        r.Get("/api/v1/gardens/{id}/recommendations", getGardenRecommendations(cropService))
+        r.Get("/api/v1/gardens/{id}/space-suggestions", getSpaceSuggestions(cropService))
+        r.Get("/api/v1/gardens/{id}/layout", getGardenLayout(cropService))
+        r.Get("/api/v1/gardens/{id}/efficiency", getLayoutEfficiency(cropService))
+        r.Get("/api/v1/gardens/{id}/utilization-history", getUtilizationHistory(cropService))
+        r.Post("/api/v1/gardens/{id}/crops/import", importCrops(cropService))
     })
 }
 
@@ -86,6 +110,24 @@ func authMiddleware(next http.Handler) http.Handler {
     })
 }
 
+// validationDetails lists every failing field of an aggregated validation error
+func validationDetails(err error) []dto.FieldError {
+    var validationErrs common.ValidationErrors
+    if !errors.As(err, &validationErrs) {
+        return nil
+    }
+
+    details := make([]dto.FieldError, len(validationErrs))
+    for i, fieldErr := range validationErrs {
+        details[i] = dto.FieldError{
+            Field:   fieldErr.Field,
+            Message: fieldErr.Message,
+            Value:   fieldErr.Value,
+        }
+    }
+    return details
+}
+
 // createCrop handles POST requests to create a new crop
 func createCrop(cropService cropmanager.CropService) http.HandlerFunc {
     return func(w http.ResponseWriter, r *http.Request) {
@@ -107,6 +149,7 @@ func createCrop(cropService cropmanager.CropService) http.HandlerFunc {
                 Code:    "VALIDATION_ERROR",
                 Message: "invalid crop request",
                 Error:   err.Error(),
+                Details: validationDetails(err),
             })
             return
         }
@@ -118,12 +161,14 @@ func createCrop(cropService cropmanager.CropService) http.HandlerFunc {
             status := http.StatusInternalServerError
 
             switch code {
-            case "SPACE_EXCEEDED":
+            case "SPACE_EXCEEDED", "GROW_BAG_LIMIT", "BAG_TOO_SMALL":
                 status = http.StatusUnprocessableEntity
             case "VALIDATION_ERROR":
                 status = http.StatusBadRequest
             case "NOT_FOUND":
                 status = http.StatusNotFound
+            case "DUPLICATE_CROP":
+                status = http.StatusConflict
             }
 
             render.Status(r, status)
@@ -162,6 +207,34 @@ func listCrops(cropService cropmanager.CropService) http.HandlerFunc {
             SortDir:  r.URL.Query().Get("sortDir"),
         }
 
+        if starred := r.URL.Query().Get("starred"); starred != "" {
+            value, err := strconv.ParseBool(starred)
+            if err != nil {
+                render.Status(r, http.StatusBadRequest)
+                render.JSON(w, r, dto.ErrorResponse{
+                    Code:    "INVALID_REQUEST",
+                    Message: "starred must be true or false",
+                    Error:   err.Error(),
+                })
+                return
+            }
+            params.Starred = &value
+        }
+
+        if organic := r.URL.Query().Get("organic"); organic != "" {
+            value, err := strconv.ParseBool(organic)
+            if err != nil {
+                render.Status(r, http.StatusBadRequest)
+                render.JSON(w, r, dto.ErrorResponse{
+                    Code:    "INVALID_REQUEST",
+                    Message: "organic must be true or false",
+                    Error:   err.Error(),
+                })
+                return
+            }
+            params.Organic = &value
+        }
+
         // Get crops list
         crops, err := cropService.ListCrops(r.Context(), params)
         if err != nil {
@@ -244,6 +317,7 @@ func updateCrop(cropService cropmanager.CropService) http.HandlerFunc {
                 Code:    "VALIDATION_ERROR",
                 Message: "invalid crop request",
                 Error:   err.Error(),
+                Details: validationDetails(err),
             })
             return
         }
@@ -307,4 +381,631 @@ func deleteCrop(cropService cropmanager.CropService) http.HandlerFunc {
 
         render.Status(r, http.StatusNoContent)
     }
-}
\ No newline at end of file
+}
+
+// planYield handles POST requests to plan grow bags for a weekly yield goal
+func planYield(cropService cropmanager.CropService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        gardenID := chi.URLParam(r, "id")
+        if gardenID == "" {
+            render.Status(r, http.StatusBadRequest)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    "INVALID_REQUEST",
+                Message: "missing garden ID",
+            })
+            return
+        }
+
+        var req dto.YieldPlanRequest
+        if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+            render.Status(r, http.StatusBadRequest)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    "INVALID_REQUEST",
+                Message: "invalid request body",
+                Error:   err.Error(),
+            })
+            return
+        }
+
+        plan, err := cropService.PlanForYieldGoal(r.Context(), gardenID, req.CropName, req.WeeklyTarget)
+        if err != nil {
+            status := http.StatusInternalServerError
+            code := customErrors.GetCode(err)
+
+            switch code {
+            case "NOT_FOUND":
+                status = http.StatusNotFound
+            case "VALIDATION_ERROR":
+                status = http.StatusBadRequest
+            }
+
+            render.Status(r, status)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    code,
+                Message: "failed to plan yield goal",
+                Error:   err.Error(),
+            })
+            return
+        }
+
+        render.Status(r, http.StatusOK)
+        render.JSON(w, r, plan)
+    }
+}
+
+// getCropRecommendations handles GET /api/v1/gardens/{id}/crop-recommendations. With
+// refresh=true the AI advisor is asked again instead of answering from its cache.
+func getCropRecommendations(cropService cropmanager.CropService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        gardenID := chi.URLParam(r, "id")
+        if gardenID == "" {
+            render.Status(r, http.StatusBadRequest)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    "INVALID_REQUEST",
+                Message: "missing garden ID",
+            })
+            return
+        }
+
+        recommendations, err := cropService.RecommendCrops(refreshContext(r), gardenID)
+        if err != nil {
+            status := http.StatusInternalServerError
+            code := customErrors.GetCode(err)
+
+            switch code {
+            case "NOT_FOUND":
+                status = http.StatusNotFound
+            case "INVALID_SOIL_TYPE":
+                status = http.StatusUnprocessableEntity
+            }
+
+            render.Status(r, status)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    code,
+                Message: "failed to recommend crops",
+                Error:   err.Error(),
+            })
+            return
+        }
+
+        render.Status(r, http.StatusOK)
+        render.JSON(w, r, recommendations)
+    }
+}
+
+// getGardenRecommendations handles GET /api/v1/gardens/{id}/recommendations, returning
+// one set of tips for all of the garden's crops. With refresh=true the AI advisor is
+// asked again instead of answering from its cache.
+func getGardenRecommendations(cropService cropmanager.CropService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        gardenID := chi.URLParam(r, "id")
+        if gardenID == "" {
+            render.Status(r, http.StatusBadRequest)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    "INVALID_REQUEST",
+                Message: "missing garden ID",
+            })
+            return
+        }
+
+        recommendations, err := cropService.GardenRecommendations(refreshContext(r), gardenID)
+        if err != nil {
+            status := http.StatusInternalServerError
+            code := customErrors.GetCode(err)
+            if code == "NOT_FOUND" {
+                status = http.StatusNotFound
+            }
+
+            render.Status(r, status)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    code,
+                Message: "failed to get garden recommendations",
+                Error:   err.Error(),
+            })
+            return
+        }
+
+        render.Status(r, http.StatusOK)
+        render.JSON(w, r, recommendations)
+    }
+}
+
+// getSpaceSuggestions handles GET /api/v1/gardens/{id}/space-suggestions, ranking the
+// crops that would add the most yield in the garden's free space
+func getSpaceSuggestions(cropService cropmanager.CropService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        gardenID := chi.URLParam(r, "id")
+        if gardenID == "" {
+            render.Status(r, http.StatusBadRequest)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    "INVALID_REQUEST",
+                Message: "missing garden ID",
+            })
+            return
+        }
+
+        suggestions, err := cropService.SuggestCropsForRemainingSpace(r.Context(), gardenID)
+        if err != nil {
+            status := http.StatusInternalServerError
+            code := customErrors.GetCode(err)
+
+            switch code {
+            case "NOT_FOUND":
+                status = http.StatusNotFound
+            case "INVALID_SOIL_TYPE":
+                status = http.StatusUnprocessableEntity
+            }
+
+            render.Status(r, status)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    code,
+                Message: "failed to suggest crops for remaining space",
+                Error:   err.Error(),
+            })
+            return
+        }
+
+        render.Status(r, http.StatusOK)
+        render.JSON(w, r, suggestions)
+    }
+}
+
+// getGardenLayout handles GET /api/v1/gardens/{id}/layout, computing and storing the
+// grow bag layout for the garden's crops
+func getGardenLayout(cropService cropmanager.CropService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        gardenID := chi.URLParam(r, "id")
+        if gardenID == "" {
+            render.Status(r, http.StatusBadRequest)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    "INVALID_REQUEST",
+                Message: "missing garden ID",
+            })
+            return
+        }
+
+        layout, err := cropService.ComputeGardenLayout(r.Context(), gardenID)
+        if err != nil {
+            status := http.StatusInternalServerError
+            code := customErrors.GetCode(err)
+
+            switch code {
+            case "NOT_FOUND":
+                status = http.StatusNotFound
+            case "VALIDATION_ERROR":
+                status = http.StatusUnprocessableEntity
+            }
+
+            render.Status(r, status)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    code,
+                Message: "failed to compute garden layout",
+                Error:   err.Error(),
+            })
+            return
+        }
+
+        render.Status(r, http.StatusOK)
+        render.JSON(w, r, layout)
+    }
+}
+
+// getLayoutEfficiency handles GET /api/v1/gardens/{id}/efficiency, scoring how good the
+// garden's layout is from 0 to 100 with a breakdown
+func getLayoutEfficiency(cropService cropmanager.CropService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        gardenID := chi.URLParam(r, "id")
+        if gardenID == "" {
+            render.Status(r, http.StatusBadRequest)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    "INVALID_REQUEST",
+                Message: "missing garden ID",
+            })
+            return
+        }
+
+        score, err := cropService.LayoutEfficiencyScore(r.Context(), gardenID)
+        if err != nil {
+            status := http.StatusInternalServerError
+            code := customErrors.GetCode(err)
+
+            switch code {
+            case "NOT_FOUND":
+                status = http.StatusNotFound
+            case "VALIDATION_ERROR":
+                status = http.StatusUnprocessableEntity
+            }
+
+            render.Status(r, status)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    code,
+                Message: "failed to compute layout efficiency",
+                Error:   err.Error(),
+            })
+            return
+        }
+
+        render.Status(r, http.StatusOK)
+        render.JSON(w, r, score)
+    }
+}
+
+// getUtilizationHistory handles GET /api/v1/gardens/{id}/utilization-history. The
+// optional from and to query parameters are RFC 3339 times bounding the snapshots.
+func getUtilizationHistory(cropService cropmanager.CropService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        gardenID := chi.URLParam(r, "id")
+        if gardenID == "" {
+            render.Status(r, http.StatusBadRequest)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    "INVALID_REQUEST",
+                Message: "missing garden ID",
+            })
+            return
+        }
+
+        var bounds [2]time.Time
+        for i, param := range []string{"from", "to"} {
+            value := r.URL.Query().Get(param)
+            if value == "" {
+                continue
+            }
+            parsed, err := time.Parse(time.RFC3339, value)
+            if err != nil {
+                render.Status(r, http.StatusBadRequest)
+                render.JSON(w, r, dto.ErrorResponse{
+                    Code:    "INVALID_REQUEST",
+                    Message: "invalid " + param + " time, expected RFC 3339",
+                    Error:   err.Error(),
+                })
+                return
+            }
+            bounds[i] = parsed
+        }
+
+        history, err := cropService.GetUtilizationHistory(r.Context(), gardenID, bounds[0], bounds[1])
+        if err != nil {
+            status := http.StatusInternalServerError
+            code := customErrors.GetCode(err)
+
+            switch code {
+            case "NOT_FOUND":
+                status = http.StatusNotFound
+            case "VALIDATION_ERROR":
+                status = http.StatusBadRequest
+            }
+
+            render.Status(r, status)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    code,
+                Message: "failed to get utilization history",
+                Error:   err.Error(),
+            })
+            return
+        }
+
+        render.Status(r, http.StatusOK)
+        render.JSON(w, r, history)
+    }
+}
+
+// importCrops handles POST /api/v1/gardens/{id}/crops/import. The CSV is sent either as
+// the "file" field of a multipart form or as the raw request body. A rejected import is
+// answered with 422 and the per-row results.
+func importCrops(cropService cropmanager.CropService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        gardenID := chi.URLParam(r, "id")
+        if gardenID == "" {
+            render.Status(r, http.StatusBadRequest)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    "INVALID_REQUEST",
+                Message: "missing garden ID",
+            })
+            return
+        }
+
+        r.Body = http.MaxBytesReader(w, r.Body, maxImportBytes)
+        var body io.Reader = r.Body
+        if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+            file, _, err := r.FormFile("file")
+            if err != nil {
+                if bodyTooLarge(err) {
+                    renderImportTooLarge(w, r, err)
+                    return
+                }
+                render.Status(r, http.StatusBadRequest)
+                render.JSON(w, r, dto.ErrorResponse{
+                    Code:    "INVALID_REQUEST",
+                    Message: "missing CSV file",
+                    Error:   err.Error(),
+                })
+                return
+            }
+            defer file.Close()
+            body = file
+        }
+
+        result, err := cropService.ImportCropsCSV(r.Context(), gardenID, body)
+        if err != nil {
+            if bodyTooLarge(err) {
+                renderImportTooLarge(w, r, err)
+                return
+            }
+            status := http.StatusInternalServerError
+            code := customErrors.GetCode(err)
+
+            switch code {
+            case "NOT_FOUND":
+                status = http.StatusNotFound
+            case "VALIDATION_ERROR":
+                status = http.StatusBadRequest
+            case "DUPLICATE_CROP":
+                status = http.StatusConflict
+            }
+
+            render.Status(r, status)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    code,
+                Message: "failed to import crops",
+                Error:   err.Error(),
+            })
+            return
+        }
+
+        if !result.Imported {
+            render.Status(r, http.StatusUnprocessableEntity)
+            render.JSON(w, r, result)
+            return
+        }
+
+        render.Status(r, http.StatusCreated)
+        render.JSON(w, r, result)
+    }
+}
+
+// bodyTooLarge reports whether err came from reading past a request body size limit
+func bodyTooLarge(err error) bool {
+    var maxBytesErr *http.MaxBytesError
+    return errors.As(err, &maxBytesErr)
+}
+
+// renderImportTooLarge rejects a crop import CSV larger than maxImportBytes
+func renderImportTooLarge(w http.ResponseWriter, r *http.Request, err error) {
+    render.Status(r, http.StatusRequestEntityTooLarge)
+    render.JSON(w, r, dto.ErrorResponse{
+        Code:    "PAYLOAD_TOO_LARGE",
+        Message: "CSV file exceeds the maximum import size of " + strconv.Itoa(maxImportBytes) + " bytes",
+        Error:   err.Error(),
+    })
+}
+
+// getMetadata handles GET /api/v1/metadata, returning the supported crops, bag sizes,
+// soil types, frequencies, task types, and environments
+func getMetadata(cropService cropmanager.CropService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        render.Status(r, http.StatusOK)
+        render.JSON(w, r, cropService.Metadata())
+    }
+}
+
+// toggleCropStar handles PUT /api/v1/crops/{id}/star, flipping the crop's starred flag
+func toggleCropStar(cropService cropmanager.CropService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        id := chi.URLParam(r, "id")
+        if id == "" {
+            render.Status(r, http.StatusBadRequest)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    "INVALID_REQUEST",
+                Message: "missing crop ID",
+            })
+            return
+        }
+
+        crop, err := cropService.ToggleCropStar(r.Context(), id)
+        if err != nil {
+            status := http.StatusInternalServerError
+            if customErrors.Is(err, "NOT_FOUND") {
+                status = http.StatusNotFound
+            }
+
+            render.Status(r, status)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    customErrors.GetCode(err),
+                Message: "failed to toggle crop star",
+                Error:   err.Error(),
+            })
+            return
+        }
+
+        render.Status(r, http.StatusOK)
+        render.JSON(w, r, crop)
+    }
+}
+
+// previewCropRemoval handles GET /api/v1/crops/{id}/removal-preview, reporting the space
+// and yield affected by removing the crop without removing it
+func previewCropRemoval(cropService cropmanager.CropService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        id := chi.URLParam(r, "id")
+        if id == "" {
+            render.Status(r, http.StatusBadRequest)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    "INVALID_REQUEST",
+                Message: "missing crop ID",
+            })
+            return
+        }
+
+        preview, err := cropService.PreviewCropRemoval(r.Context(), id)
+        if err != nil {
+            status := http.StatusInternalServerError
+            if customErrors.Is(err, "NOT_FOUND") {
+                status = http.StatusNotFound
+            }
+
+            render.Status(r, status)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    customErrors.GetCode(err),
+                Message: "failed to preview crop removal",
+                Error:   err.Error(),
+            })
+            return
+        }
+
+        render.Status(r, http.StatusOK)
+        render.JSON(w, r, preview)
+    }
+}
+
+// setTargetYield handles PUT /api/v1/crops/{id}/target-yield, setting the crop's harvest
+// goal and returning the crop with its progress toward it
+func setTargetYield(cropService cropmanager.CropService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        id := chi.URLParam(r, "id")
+        if id == "" {
+            render.Status(r, http.StatusBadRequest)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    "INVALID_REQUEST",
+                Message: "missing crop ID",
+            })
+            return
+        }
+
+        var req dto.TargetYieldRequest
+        if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+            render.Status(r, http.StatusBadRequest)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    "INVALID_REQUEST",
+                Message: "invalid request body",
+                Error:   err.Error(),
+            })
+            return
+        }
+
+        crop, err := cropService.SetTargetYield(r.Context(), id, &req)
+        if err != nil {
+            status := http.StatusInternalServerError
+            code := customErrors.GetCode(err)
+
+            switch code {
+            case "NOT_FOUND":
+                status = http.StatusNotFound
+            case "VALIDATION_ERROR":
+                status = http.StatusBadRequest
+            }
+
+            render.Status(r, status)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    code,
+                Message: "failed to set target yield",
+                Error:   err.Error(),
+            })
+            return
+        }
+
+        render.Status(r, http.StatusOK)
+        render.JSON(w, r, crop)
+    }
+}
+
+// setCropTaskType handles PUT /api/v1/crops/{id}/task-types, turning one maintenance task
+// type on or off for the crop
+func setCropTaskType(cropService cropmanager.CropService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        id := chi.URLParam(r, "id")
+        if id == "" {
+            render.Status(r, http.StatusBadRequest)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    "INVALID_REQUEST",
+                Message: "missing crop ID",
+            })
+            return
+        }
+
+        var req dto.TaskTypeToggleRequest
+        if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+            render.Status(r, http.StatusBadRequest)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    "INVALID_REQUEST",
+                Message: "invalid request body",
+                Error:   err.Error(),
+            })
+            return
+        }
+
+        crop, err := cropService.SetTaskTypeEnabled(r.Context(), id, &req)
+        if err != nil {
+            status := http.StatusInternalServerError
+            code := customErrors.GetCode(err)
+
+            switch code {
+            case "NOT_FOUND":
+                status = http.StatusNotFound
+            case "VALIDATION_ERROR":
+                status = http.StatusBadRequest
+            }
+
+            render.Status(r, status)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    code,
+                Message: "failed to update crop task types",
+                Error:   err.Error(),
+            })
+            return
+        }
+
+        render.Status(r, http.StatusOK)
+        render.JSON(w, r, crop)
+    }
+}
+
+// logHarvest handles POST /api/v1/crops/{id}/harvests, recording a harvest and returning
+// the crop with its updated progress toward its harvest goal
+func logHarvest(cropService cropmanager.CropService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        id := chi.URLParam(r, "id")
+        if id == "" {
+            render.Status(r, http.StatusBadRequest)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    "INVALID_REQUEST",
+                Message: "missing crop ID",
+            })
+            return
+        }
+
+        var req dto.HarvestRequest
+        if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+            render.Status(r, http.StatusBadRequest)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    "INVALID_REQUEST",
+                Message: "invalid request body",
+                Error:   err.Error(),
+            })
+            return
+        }
+
+        crop, err := cropService.LogHarvest(r.Context(), id, &req)
+        if err != nil {
+            status := http.StatusInternalServerError
+            code := customErrors.GetCode(err)
+
+            switch code {
+            case "NOT_FOUND":
+                status = http.StatusNotFound
+            case "VALIDATION_ERROR":
+                status = http.StatusBadRequest
+            }
+
+            render.Status(r, status)
+            render.JSON(w, r, dto.ErrorResponse{
+                Code:    code,
+                Message: "failed to log harvest",
+                Error:   err.Error(),
+            })
+            return
+        }
+
+        render.Status(r, http.StatusCreated)
+        render.JSON(w, r, crop)
+    }
+}
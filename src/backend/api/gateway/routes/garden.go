@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/go-chi/chi/v5" // v5.0.8
-	"github.com/go-chi/chi/middleware/compress" // v5.0.8
 	"github.com/go-chi/cache" // v1.0.0
 	"github.com/go-playground/validator/v10" // v10.11.0
 
@@ -34,8 +33,7 @@ func RegisterGardenRoutes(r chi.Router, calcService *calculator.CalculatorServic
 	// Request timeout middleware
 	r.Use(middleware.Timeout(requestTimeout))
 	
-	// Response compression for payloads >1KB
-	r.Use(compress.Handler(1000))
+	// Response compression is applied by the gateway from APIConfig
 	
 	// Response caching middleware
 	cacheMiddleware := cache.New(gardenCacheTTL)
@@ -69,6 +67,12 @@ func RegisterGardenRoutes(r chi.Router, calcService *calculator.CalculatorServic
 			middleware.RequireAuthToken,
 		).Delete("/{id}", handleDeleteGarden())
 	})
+
+	// Path area for accessibility planning; needs no stored garden
+	r.Post("/api/v1/plan/path-area", handlePathArea(calcService))
+
+	// Side-by-side layouts for different bag sizes; needs no stored garden
+	r.Post("/api/v1/calculate/compare", handleCompareLayouts(calcService))
 }
 
 // handleCreateGarden handles garden creation with validation
@@ -108,11 +112,16 @@ func handleCreateGarden(calcService *calculator.CalculatorService) http.HandlerF
 		
 		// Create garden model
 		garden := &models.Garden{
-			UserID:   userID,
-			Length:   req.Dimensions.Length,
-			Width:    req.Dimensions.Width,
-			SoilType: req.SoilType,
-			Sunlight: req.Sunlight,
+			UserID:      userID,
+			Length:      req.Dimensions.Length,
+			Width:       req.Dimensions.Width,
+			SoilType:    req.SoilType,
+			Sunlight:    req.Sunlight,
+			Environment: req.Environment,
+
+			SoilEfficiencyOverride: req.SoilEfficiencyOverride,
+			Latitude:               req.Latitude,
+			Longitude:              req.Longitude,
 		}
 		
 		// Validate garden model
@@ -135,13 +144,18 @@ func handleCreateGarden(calcService *calculator.CalculatorService) http.HandlerF
 		
 		// Generate response
 		resp := &dto.GardenResponse{
-			ID:         savedGarden.ID,
-			UserID:     savedGarden.UserID,
-			Dimensions: *savedGarden.ToDimensions(),
-			SoilType:   savedGarden.SoilType,
-			Sunlight:   savedGarden.Sunlight,
-			CreatedAt:  savedGarden.CreatedAt,
-			UpdatedAt:  savedGarden.UpdatedAt,
+			ID:          savedGarden.ID,
+			UserID:      savedGarden.UserID,
+			Dimensions:  *savedGarden.ToDimensions(),
+			SoilType:    savedGarden.SoilType,
+			Sunlight:    savedGarden.Sunlight,
+			Environment: savedGarden.Environment,
+			CreatedAt:   savedGarden.CreatedAt,
+			UpdatedAt:   savedGarden.UpdatedAt,
+
+			SoilEfficiencyOverride: savedGarden.SoilEfficiencyOverride,
+			Latitude:               savedGarden.Latitude,
+			Longitude:              savedGarden.Longitude,
 		}
 		
 		// Cache response
@@ -162,6 +176,69 @@ func handleCreateGarden(calcService *calculator.CalculatorService) http.HandlerF
 	}
 }
 
+// handlePathArea handles calculating how much of a garden maintenance paths take up
+func handlePathArea(calcService *calculator.CalculatorService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Parse request body
+		var req dto.PathAreaRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		metrics, err := calcService.PlanPathArea(req.Dimensions, req.IncludeCornerSpaces)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid garden dimensions: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		resp := &dto.PathAreaResponse{
+			TotalArea:  metrics.TotalArea,
+			PathArea:   metrics.PathArea,
+			UsableArea: metrics.UsableArea,
+			PathRatio:  metrics.PathRatio,
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// handleCompareLayouts handles comparing grow bag layouts across bag sizes
+func handleCompareLayouts(calcService *calculator.CalculatorService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Parse request body
+		var req dto.CompareLayoutsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		comparisons, err := calcService.CompareLayouts(r.Context(), req.Dimensions, req.BagSizes)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid layout comparison: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		resp := &dto.CompareLayoutsResponse{
+			Layouts: make([]dto.LayoutComparisonResponse, 0, len(comparisons)),
+		}
+		for _, comparison := range comparisons {
+			resp.Layouts = append(resp.Layouts, dto.LayoutComparisonResponse{
+				BagSize:            comparison.BagSize,
+				Viable:             comparison.Viable,
+				Capacity:           comparison.Capacity,
+				SpaceUtilization:   comparison.SpaceUtilization,
+				AccessibilityScore: comparison.AccessibilityScore,
+				ViabilityWarning:   comparison.ViabilityWarning,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
 // handleGetGardens handles retrieval of all gardens for a user
 func handleGetGardens() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -4,9 +4,11 @@ package routes
 import (
     "context"
     "encoding/json"
+    "errors"
     "fmt"
     "net/http"
     "strconv"
+    "strings"
     "time"
 
     "github.com/go-chi/chi/v5" // v5.0.0
@@ -14,7 +16,9 @@ import (
     "github.com/prometheus/client_golang/prometheus"
     "github.com/prometheus/client_golang/prometheus/promauto"
 
+    gatewayMiddleware "github.com/urban-gardening/backend/api/gateway/middleware"
     "github.com/urban-gardening/backend/pkg/dto"
+    "github.com/urban-gardening/backend/internal/ai"
     "github.com/urban-gardening/backend/internal/scheduler"
 )
 
@@ -73,13 +77,74 @@ func RegisterMaintenanceRoutes(router *chi.Mux, schedulerService *scheduler.Sche
 
     // Register routes
     r.Post("/", createMaintenanceHandler(schedulerService))
+    r.Post("/batch", createMaintenanceBatchHandler(schedulerService))
+    r.Post("/validate", validateMaintenanceHandler(schedulerService))
+    r.Post("/validate-time", validatePreferredTimeHandler(schedulerService))
+    r.Post("/preview", previewMaintenanceHandler(schedulerService))
     r.Get("/{id}", getMaintenanceHandler(schedulerService))
     r.Put("/{id}", updateMaintenanceHandler(schedulerService))
     r.Post("/{id}/complete", completeMaintenanceHandler(schedulerService))
+    r.Get("/{id}/frequency-suggestion", getFrequencySuggestionHandler(schedulerService))
+    r.Delete("/{id}", deleteMaintenanceHandler(schedulerService))
+    r.Post("/{id}/restore", restoreMaintenanceHandler(schedulerService))
     r.Get("/", listMaintenanceHandler(schedulerService))
 
     // Mount routes under base path
     router.Mount(maintenanceBasePath, r)
+
+    // Crop-scoped schedule routes
+    router.Get("/api/v1/crops/{id}/schedules", getCropSchedulesHandler(schedulerService))
+    router.Get("/api/v1/crops/{id}/schedule-history", getScheduleHistoryHandler(schedulerService))
+    router.Get("/api/v1/crops/{id}/fertilizer-recommendation", getFertilizerRecommendationHandler(schedulerService))
+    router.Get("/api/v1/crops/{id}/suggested-schedules", getSuggestedSchedulesHandler(schedulerService))
+    router.Post("/api/v1/crops/{id}/repot-reminder", scheduleRepotReminderHandler(schedulerService))
+
+    // Pest and disease incident routes
+    router.Post("/api/v1/crops/{id}/incidents", recordPestIncidentHandler(schedulerService))
+    router.Get("/api/v1/crops/{id}/incidents", listPestIncidentsHandler(schedulerService))
+    router.Get("/api/v1/incidents/{id}", getPestIncidentHandler(schedulerService))
+    router.Put("/api/v1/incidents/{id}", updatePestIncidentHandler(schedulerService))
+    router.Delete("/api/v1/incidents/{id}", deletePestIncidentHandler(schedulerService))
+
+    // Garden-scoped sensor routes
+    router.Post("/api/v1/gardens/{id}/environment", recordEnvironmentHandler(schedulerService))
+    router.Get("/api/v1/gardens/{id}/checklist", getWeeklyChecklistHandler(schedulerService))
+    router.Get("/api/v1/gardens/{id}/maintenance", listGardenMaintenanceHandler(schedulerService))
+    router.Post("/api/v1/gardens/{id}/preferred-times/shift", shiftPreferredTimesHandler(schedulerService))
+    router.Post("/api/v1/gardens/{id}/maintenance/complete-due", completeDueTasksHandler(schedulerService))
+    router.Get("/api/v1/gardens/{id}/history.csv", exportHistoryCSVHandler(schedulerService))
+
+    // Garden snapshot routes
+    router.Post("/api/v1/gardens/{id}/snapshots", createSnapshotHandler(schedulerService))
+    router.Get("/api/v1/gardens/{id}/snapshots", listSnapshotsHandler(schedulerService))
+    router.Post("/api/v1/gardens/{id}/snapshots/{snapshotId}/restore", restoreSnapshotHandler(schedulerService))
+
+    // Garden-scoped notification recipient routes
+    router.Post("/api/v1/gardens/{id}/recipients", registerRecipientHandler(schedulerService))
+    router.Get("/api/v1/gardens/{id}/recipients", listRecipientsHandler(schedulerService))
+    router.Delete("/api/v1/gardens/{id}/recipients/{recipientId}", removeRecipientHandler(schedulerService))
+    router.Get("/api/v1/gardens/{id}/notification-deliveries", listDeliveriesHandler(schedulerService))
+    router.Put("/api/v1/gardens/{id}/notification-digest", setNotificationDigestHandler(schedulerService))
+    router.Get("/api/v1/gardens/{id}/notification-digest", getNotificationDigestHandler(schedulerService))
+
+    // Notification preferences of the authenticated user; requires AuthMiddleware
+    router.Get("/api/v1/me/notification-preferences", getNotificationPreferencesHandler(schedulerService))
+    router.Put("/api/v1/me/notification-preferences", setNotificationPreferencesHandler(schedulerService))
+    router.Delete("/api/v1/me/notification-preferences", resetNotificationPreferencesHandler(schedulerService))
+    router.Put("/api/v1/me/notification-preferences/gardens", applyNotificationPreferencesToAllHandler(schedulerService))
+    router.Get("/api/v1/gardens/{id}/notification-preferences", getGardenNotificationPreferencesHandler(schedulerService))
+
+    // Tasks due today across the authenticated user's gardens; requires AuthMiddleware
+    router.Get("/api/v1/me/today", getTodayViewHandler(schedulerService))
+}
+
+// refreshContext returns the request context, marked to bypass cached AI responses when
+// the request carries refresh=true
+func refreshContext(r *http.Request) context.Context {
+    if refresh, _ := strconv.ParseBool(r.URL.Query().Get("refresh")); refresh {
+        return ai.WithRefresh(r.Context())
+    }
+    return r.Context()
 }
 
 // createMaintenanceHandler handles creation of new maintenance schedules
@@ -104,11 +169,18 @@ func createMaintenanceHandler(service *scheduler.SchedulerService) http.HandlerF
         }
 
         // Create maintenance schedule
-        ctx := r.Context()
+        ctx := refreshContext(r)
         response, err := service.CreateSchedule(ctx, &req)
         if err != nil {
             maintenanceRequestTotal.WithLabelValues("POST", "/maintenance", "error").Inc()
-            http.Error(w, fmt.Sprintf("failed to create schedule: %v", err), http.StatusInternalServerError)
+            status := http.StatusInternalServerError
+            switch {
+            case errors.Is(err, scheduler.ErrInvalidRequest):
+                status = http.StatusBadRequest
+            case errors.Is(err, scheduler.ErrNotificationLimit):
+                status = http.StatusTooManyRequests
+            }
+            http.Error(w, fmt.Sprintf("failed to create schedule: %v", err), status)
             return
         }
 
@@ -119,6 +191,68 @@ func createMaintenanceHandler(service *scheduler.SchedulerService) http.HandlerF
     }
 }
 
+// previewMaintenanceHandler handles computing when a proposed maintenance schedule would
+// first fire, without creating it
+func previewMaintenanceHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("POST", "/maintenance/preview"))
+        defer timer.ObserveDuration()
+
+        var req dto.MaintenanceRequest
+        if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+            maintenanceRequestTotal.WithLabelValues("POST", "/maintenance/preview", "error").Inc()
+            http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+            return
+        }
+
+        ctx := refreshContext(r)
+        response, err := service.PreviewNextSchedule(ctx, &req)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("POST", "/maintenance/preview", "error").Inc()
+            if errors.Is(err, scheduler.ErrInvalidRequest) {
+                http.Error(w, err.Error(), http.StatusBadRequest)
+                return
+            }
+            http.Error(w, fmt.Sprintf("failed to preview schedule: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("POST", "/maintenance/preview", "success").Inc()
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// createMaintenanceBatchHandler handles creation of several maintenance schedules at once
+func createMaintenanceBatchHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("POST", "/maintenance/batch"))
+        defer timer.ObserveDuration()
+
+        var req dto.MaintenanceBatchRequest
+        if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+            maintenanceRequestTotal.WithLabelValues("POST", "/maintenance/batch", "error").Inc()
+            http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+            return
+        }
+
+        ctx := refreshContext(r)
+        response, err := service.CreateSchedules(ctx, req.Requests)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("POST", "/maintenance/batch", "error").Inc()
+            status := http.StatusInternalServerError
+            if errors.Is(err, scheduler.ErrInvalidRequest) {
+                status = http.StatusBadRequest
+            }
+            http.Error(w, fmt.Sprintf("failed to create schedules: %v", err), status)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("POST", "/maintenance/batch", "success").Inc()
+        w.WriteHeader(http.StatusMultiStatus)
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
 // getMaintenanceHandler handles retrieval of maintenance schedules
 func getMaintenanceHandler(service *scheduler.SchedulerService) http.HandlerFunc {
     return func(w http.ResponseWriter, r *http.Request) {
@@ -175,7 +309,11 @@ func updateMaintenanceHandler(service *scheduler.SchedulerService) http.HandlerF
         response, err := service.UpdateSchedule(ctx, id, &req)
         if err != nil {
             maintenanceRequestTotal.WithLabelValues("PUT", "/maintenance/{id}", "error").Inc()
-            http.Error(w, fmt.Sprintf("failed to update schedule: %v", err), http.StatusInternalServerError)
+            status := http.StatusInternalServerError
+            if errors.Is(err, scheduler.ErrScheduleNotFound) {
+                status = http.StatusNotFound
+            }
+            http.Error(w, fmt.Sprintf("failed to update schedule: %v", err), status)
             return
         }
 
@@ -197,11 +335,30 @@ func completeMaintenanceHandler(service *scheduler.SchedulerService) http.Handle
             return
         }
 
+        // Completion time is optional; an empty body completes the task now
+        var req dto.CompleteTaskRequest
+        if r.ContentLength > 0 {
+            if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+                maintenanceRequestTotal.WithLabelValues("POST", "/maintenance/{id}/complete", "error").Inc()
+                http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+                return
+            }
+        }
+
+        // Integrations that retry may send the key as a header instead of in the body
+        if key := r.Header.Get("Idempotency-Key"); key != "" {
+            req.IdempotencyKey = key
+        }
+
         ctx := r.Context()
-        response, err := service.CompleteTask(ctx, id)
+        response, err := service.CompleteTask(ctx, id, req.CompletedAt, req.IdempotencyKey)
         if err != nil {
             maintenanceRequestTotal.WithLabelValues("POST", "/maintenance/{id}/complete", "error").Inc()
-            http.Error(w, fmt.Sprintf("failed to complete task: %v", err), http.StatusInternalServerError)
+            status := http.StatusInternalServerError
+            if errors.Is(err, scheduler.ErrInvalidRequest) {
+                status = http.StatusBadRequest
+            }
+            http.Error(w, fmt.Sprintf("failed to complete task: %v", err), status)
             return
         }
 
@@ -210,12 +367,127 @@ func completeMaintenanceHandler(service *scheduler.SchedulerService) http.Handle
     }
 }
 
-// listMaintenanceHandler handles retrieval of paginated maintenance schedules
+// getFrequencySuggestionHandler handles retrieval of a frequency change suggested by a
+// task's completion history
+func getFrequencySuggestionHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("GET", "/maintenance/{id}/frequency-suggestion"))
+        defer timer.ObserveDuration()
+
+        id := chi.URLParam(r, "id")
+        if id == "" {
+            maintenanceRequestTotal.WithLabelValues("GET", "/maintenance/{id}/frequency-suggestion", "error").Inc()
+            http.Error(w, "maintenance ID is required", http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.SuggestFrequencyAdjustment(ctx, id)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("GET", "/maintenance/{id}/frequency-suggestion", "error").Inc()
+            status := http.StatusInternalServerError
+            switch {
+            case errors.Is(err, scheduler.ErrInvalidRequest):
+                status = http.StatusBadRequest
+            case errors.Is(err, scheduler.ErrScheduleNotFound):
+                status = http.StatusNotFound
+            }
+            http.Error(w, fmt.Sprintf("failed to suggest frequency: %v", err), status)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("GET", "/maintenance/{id}/frequency-suggestion", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// deleteMaintenanceHandler handles soft deletion of maintenance schedules
+func deleteMaintenanceHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("DELETE", "/maintenance/{id}"))
+        defer timer.ObserveDuration()
+
+        id := chi.URLParam(r, "id")
+        if id == "" {
+            maintenanceRequestTotal.WithLabelValues("DELETE", "/maintenance/{id}", "error").Inc()
+            http.Error(w, "maintenance ID is required", http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        if err := service.DeleteSchedule(ctx, id); err != nil {
+            maintenanceRequestTotal.WithLabelValues("DELETE", "/maintenance/{id}", "error").Inc()
+            status := http.StatusInternalServerError
+            if errors.Is(err, scheduler.ErrScheduleNotFound) {
+                status = http.StatusNotFound
+            }
+            http.Error(w, fmt.Sprintf("failed to delete schedule: %v", err), status)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("DELETE", "/maintenance/{id}", "success").Inc()
+        w.WriteHeader(http.StatusNoContent)
+    }
+}
+
+// restoreMaintenanceHandler handles restoring soft-deleted maintenance schedules
+func restoreMaintenanceHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("POST", "/maintenance/{id}/restore"))
+        defer timer.ObserveDuration()
+
+        id := chi.URLParam(r, "id")
+        if id == "" {
+            maintenanceRequestTotal.WithLabelValues("POST", "/maintenance/{id}/restore", "error").Inc()
+            http.Error(w, "maintenance ID is required", http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.RestoreSchedule(ctx, id)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("POST", "/maintenance/{id}/restore", "error").Inc()
+            status := http.StatusInternalServerError
+            if errors.Is(err, scheduler.ErrScheduleNotFound) {
+                status = http.StatusNotFound
+            }
+            http.Error(w, fmt.Sprintf("failed to restore schedule: %v", err), status)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("POST", "/maintenance/{id}/restore", "success").Inc()
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// listMaintenanceHandler handles retrieval of paginated maintenance schedules, or of
+// specific schedules when an ids list is given
 func listMaintenanceHandler(service *scheduler.SchedulerService) http.HandlerFunc {
     return func(w http.ResponseWriter, r *http.Request) {
         timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("GET", "/maintenance"))
         defer timer.ObserveDuration()
 
+        // Fetch specific schedules when IDs are given, e.g. ?ids=a,b,c
+        if ids := r.URL.Query().Get("ids"); ids != "" {
+            ctx := r.Context()
+            response, err := service.GetSchedules(ctx, strings.Split(ids, ","))
+            if err != nil {
+                maintenanceRequestTotal.WithLabelValues("GET", "/maintenance", "error").Inc()
+                if errors.Is(err, scheduler.ErrInvalidRequest) {
+                    http.Error(w, err.Error(), http.StatusBadRequest)
+                    return
+                }
+                http.Error(w, fmt.Sprintf("failed to get schedules: %v", err), http.StatusInternalServerError)
+                return
+            }
+
+            maintenanceRequestTotal.WithLabelValues("GET", "/maintenance", "success").Inc()
+            w.Header().Set("Content-Type", "application/json")
+            json.NewEncoder(w).Encode(response)
+            return
+        }
+
         // Parse pagination parameters
         page, _ := strconv.Atoi(r.URL.Query().Get("page"))
         pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
@@ -238,4 +510,1100 @@ func listMaintenanceHandler(service *scheduler.SchedulerService) http.HandlerFun
         maintenanceRequestTotal.WithLabelValues("GET", "/maintenance", "success").Inc()
         json.NewEncoder(w).Encode(response)
     }
-}
\ No newline at end of file
+}
+
+// getCropSchedulesHandler handles retrieval of all maintenance schedules for a crop
+func getCropSchedulesHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("GET", "/crops/{id}/schedules"))
+        defer timer.ObserveDuration()
+
+        cropID := chi.URLParam(r, "id")
+        if cropID == "" {
+            maintenanceRequestTotal.WithLabelValues("GET", "/crops/{id}/schedules", "error").Inc()
+            http.Error(w, "crop ID is required", http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.GetCropSchedules(ctx, cropID)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("GET", "/crops/{id}/schedules", "error").Inc()
+            http.Error(w, fmt.Sprintf("failed to get crop schedules: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("GET", "/crops/{id}/schedules", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// getScheduleHistoryHandler handles retrieval of a crop's schedule change history
+func getScheduleHistoryHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("GET", "/crops/{id}/schedule-history"))
+        defer timer.ObserveDuration()
+
+        cropID := chi.URLParam(r, "id")
+        if cropID == "" {
+            maintenanceRequestTotal.WithLabelValues("GET", "/crops/{id}/schedule-history", "error").Inc()
+            http.Error(w, "crop ID is required", http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.GetScheduleChangeLog(ctx, cropID)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("GET", "/crops/{id}/schedule-history", "error").Inc()
+            http.Error(w, fmt.Sprintf("failed to get schedule history: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("GET", "/crops/{id}/schedule-history", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// getFertilizerRecommendationHandler handles retrieval of a growth-stage fertilizer suggestion for a crop
+func getFertilizerRecommendationHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("GET", "/crops/{id}/fertilizer-recommendation"))
+        defer timer.ObserveDuration()
+
+        cropID := chi.URLParam(r, "id")
+        if cropID == "" {
+            maintenanceRequestTotal.WithLabelValues("GET", "/crops/{id}/fertilizer-recommendation", "error").Inc()
+            http.Error(w, "crop ID is required", http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.RecommendFertilizerSchedule(ctx, cropID)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("GET", "/crops/{id}/fertilizer-recommendation", "error").Inc()
+            status := http.StatusInternalServerError
+            if errors.Is(err, scheduler.ErrCropNotFound) {
+                status = http.StatusNotFound
+            }
+            http.Error(w, fmt.Sprintf("failed to recommend fertilizer schedule: %v", err), status)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("GET", "/crops/{id}/fertilizer-recommendation", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// scheduleRepotReminderHandler handles scheduling a one-off reminder to move a crop into a
+// larger grow bag once it is expected to have outgrown its current one
+func scheduleRepotReminderHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("POST", "/crops/{id}/repot-reminder"))
+        defer timer.ObserveDuration()
+
+        cropID := chi.URLParam(r, "id")
+        if cropID == "" {
+            maintenanceRequestTotal.WithLabelValues("POST", "/crops/{id}/repot-reminder", "error").Inc()
+            http.Error(w, "crop ID is required", http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.ScheduleRepotReminder(ctx, cropID)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("POST", "/crops/{id}/repot-reminder", "error").Inc()
+            status := http.StatusInternalServerError
+            switch {
+            case errors.Is(err, scheduler.ErrCropNotFound):
+                status = http.StatusNotFound
+            case errors.Is(err, scheduler.ErrInvalidRequest):
+                status = http.StatusBadRequest
+            }
+            http.Error(w, fmt.Sprintf("failed to schedule re-pot reminder: %v", err), status)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("POST", "/crops/{id}/repot-reminder", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        w.WriteHeader(http.StatusCreated)
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// getSuggestedSchedulesHandler handles retrieval of suggested Water, Fertilizer, and Pruning
+// schedules for a crop, which are returned for confirmation rather than created
+func getSuggestedSchedulesHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("GET", "/crops/{id}/suggested-schedules"))
+        defer timer.ObserveDuration()
+
+        cropID := chi.URLParam(r, "id")
+        if cropID == "" {
+            maintenanceRequestTotal.WithLabelValues("GET", "/crops/{id}/suggested-schedules", "error").Inc()
+            http.Error(w, "crop ID is required", http.StatusBadRequest)
+            return
+        }
+
+        ctx := refreshContext(r)
+        response, err := service.SuggestSchedulesForCrop(ctx, cropID)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("GET", "/crops/{id}/suggested-schedules", "error").Inc()
+            status := http.StatusInternalServerError
+            if errors.Is(err, scheduler.ErrCropNotFound) {
+                status = http.StatusNotFound
+            }
+            http.Error(w, fmt.Sprintf("failed to suggest schedules: %v", err), status)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("GET", "/crops/{id}/suggested-schedules", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// recordPestIncidentHandler handles recording a pest or disease incident on a crop
+func recordPestIncidentHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("POST", "/crops/{id}/incidents"))
+        defer timer.ObserveDuration()
+
+        cropID := chi.URLParam(r, "id")
+        if cropID == "" {
+            maintenanceRequestTotal.WithLabelValues("POST", "/crops/{id}/incidents", "error").Inc()
+            http.Error(w, "crop ID is required", http.StatusBadRequest)
+            return
+        }
+
+        var req dto.PestIncidentRequest
+        if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+            maintenanceRequestTotal.WithLabelValues("POST", "/crops/{id}/incidents", "error").Inc()
+            http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.RecordPestIncident(ctx, cropID, &req)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("POST", "/crops/{id}/incidents", "error").Inc()
+            switch {
+            case errors.Is(err, scheduler.ErrInvalidRequest):
+                http.Error(w, err.Error(), http.StatusBadRequest)
+            case errors.Is(err, scheduler.ErrCropNotFound):
+                http.Error(w, err.Error(), http.StatusNotFound)
+            default:
+                http.Error(w, fmt.Sprintf("failed to record pest incident: %v", err), http.StatusInternalServerError)
+            }
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("POST", "/crops/{id}/incidents", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        w.WriteHeader(http.StatusCreated)
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// listPestIncidentsHandler handles retrieval of a crop's pest and disease incidents
+func listPestIncidentsHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("GET", "/crops/{id}/incidents"))
+        defer timer.ObserveDuration()
+
+        cropID := chi.URLParam(r, "id")
+        if cropID == "" {
+            maintenanceRequestTotal.WithLabelValues("GET", "/crops/{id}/incidents", "error").Inc()
+            http.Error(w, "crop ID is required", http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.ListPestIncidents(ctx, cropID)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("GET", "/crops/{id}/incidents", "error").Inc()
+            http.Error(w, fmt.Sprintf("failed to list pest incidents: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("GET", "/crops/{id}/incidents", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// getPestIncidentHandler handles retrieval of a single pest incident
+func getPestIncidentHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("GET", "/incidents/{id}"))
+        defer timer.ObserveDuration()
+
+        incidentID := chi.URLParam(r, "id")
+        if incidentID == "" {
+            maintenanceRequestTotal.WithLabelValues("GET", "/incidents/{id}", "error").Inc()
+            http.Error(w, "incident ID is required", http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.GetPestIncident(ctx, incidentID)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("GET", "/incidents/{id}", "error").Inc()
+            if errors.Is(err, scheduler.ErrIncidentNotFound) {
+                http.Error(w, err.Error(), http.StatusNotFound)
+                return
+            }
+            http.Error(w, fmt.Sprintf("failed to get pest incident: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("GET", "/incidents/{id}", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// updatePestIncidentHandler handles replacing the details of a pest incident
+func updatePestIncidentHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("PUT", "/incidents/{id}"))
+        defer timer.ObserveDuration()
+
+        incidentID := chi.URLParam(r, "id")
+        if incidentID == "" {
+            maintenanceRequestTotal.WithLabelValues("PUT", "/incidents/{id}", "error").Inc()
+            http.Error(w, "incident ID is required", http.StatusBadRequest)
+            return
+        }
+
+        var req dto.PestIncidentRequest
+        if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+            maintenanceRequestTotal.WithLabelValues("PUT", "/incidents/{id}", "error").Inc()
+            http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.UpdatePestIncident(ctx, incidentID, &req)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("PUT", "/incidents/{id}", "error").Inc()
+            switch {
+            case errors.Is(err, scheduler.ErrInvalidRequest):
+                http.Error(w, err.Error(), http.StatusBadRequest)
+            case errors.Is(err, scheduler.ErrIncidentNotFound):
+                http.Error(w, err.Error(), http.StatusNotFound)
+            default:
+                http.Error(w, fmt.Sprintf("failed to update pest incident: %v", err), http.StatusInternalServerError)
+            }
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("PUT", "/incidents/{id}", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// deletePestIncidentHandler handles deleting a pest incident
+func deletePestIncidentHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("DELETE", "/incidents/{id}"))
+        defer timer.ObserveDuration()
+
+        incidentID := chi.URLParam(r, "id")
+        if incidentID == "" {
+            maintenanceRequestTotal.WithLabelValues("DELETE", "/incidents/{id}", "error").Inc()
+            http.Error(w, "incident ID is required", http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        if err := service.DeletePestIncident(ctx, incidentID); err != nil {
+            maintenanceRequestTotal.WithLabelValues("DELETE", "/incidents/{id}", "error").Inc()
+            if errors.Is(err, scheduler.ErrIncidentNotFound) {
+                http.Error(w, err.Error(), http.StatusNotFound)
+                return
+            }
+            http.Error(w, fmt.Sprintf("failed to delete pest incident: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("DELETE", "/incidents/{id}", "success").Inc()
+        w.WriteHeader(http.StatusNoContent)
+    }
+}
+
+// getWeeklyChecklistHandler handles retrieval of a garden's care tasks for the coming week
+func getWeeklyChecklistHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("GET", "/gardens/{id}/checklist"))
+        defer timer.ObserveDuration()
+
+        gardenID := chi.URLParam(r, "id")
+        if gardenID == "" {
+            maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/checklist", "error").Inc()
+            http.Error(w, "garden ID is required", http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.WeeklyChecklist(ctx, gardenID)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/checklist", "error").Inc()
+            http.Error(w, fmt.Sprintf("failed to build weekly checklist: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/checklist", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// shiftPreferredTimesHandler handles moving a garden's tasks from one time range to a new time
+func shiftPreferredTimesHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("POST", "/gardens/{id}/preferred-times/shift"))
+        defer timer.ObserveDuration()
+
+        gardenID := chi.URLParam(r, "id")
+        if gardenID == "" {
+            maintenanceRequestTotal.WithLabelValues("POST", "/gardens/{id}/preferred-times/shift", "error").Inc()
+            http.Error(w, "garden ID is required", http.StatusBadRequest)
+            return
+        }
+
+        var req dto.ShiftPreferredTimesRequest
+        if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+            maintenanceRequestTotal.WithLabelValues("POST", "/gardens/{id}/preferred-times/shift", "error").Inc()
+            http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.ShiftPreferredTimes(ctx, gardenID, req.From, req.ToTime)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("POST", "/gardens/{id}/preferred-times/shift", "error").Inc()
+            switch {
+            case errors.Is(err, scheduler.ErrInvalidRequest):
+                http.Error(w, err.Error(), http.StatusBadRequest)
+            case errors.Is(err, scheduler.ErrGardenNotFound):
+                http.Error(w, err.Error(), http.StatusNotFound)
+            default:
+                http.Error(w, fmt.Sprintf("failed to shift preferred times: %v", err), http.StatusInternalServerError)
+            }
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("POST", "/gardens/{id}/preferred-times/shift", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// completeDueTasksHandler handles completing every due maintenance task in a garden,
+// responding with how many were completed, failed, or left unstarted
+func completeDueTasksHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("POST", "/gardens/{id}/maintenance/complete-due"))
+        defer timer.ObserveDuration()
+
+        gardenID := chi.URLParam(r, "id")
+        if gardenID == "" {
+            maintenanceRequestTotal.WithLabelValues("POST", "/gardens/{id}/maintenance/complete-due", "error").Inc()
+            http.Error(w, "garden ID is required", http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.CompleteDueTasks(ctx, gardenID)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("POST", "/gardens/{id}/maintenance/complete-due", "error").Inc()
+            if errors.Is(err, scheduler.ErrInvalidRequest) {
+                http.Error(w, err.Error(), http.StatusBadRequest)
+                return
+            }
+            http.Error(w, fmt.Sprintf("failed to complete due tasks: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("POST", "/gardens/{id}/maintenance/complete-due", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// validateMaintenanceHandler handles checking a full maintenance request, including its
+// cross-field rules, before it is submitted. Nothing is persisted.
+func validateMaintenanceHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("POST", "/maintenance/validate"))
+        defer timer.ObserveDuration()
+
+        var req dto.MaintenanceRequest
+        if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+            maintenanceRequestTotal.WithLabelValues("POST", "/maintenance/validate", "error").Inc()
+            http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+            return
+        }
+
+        response, err := service.ValidateMaintenanceRequest(&req)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("POST", "/maintenance/validate", "error").Inc()
+            http.Error(w, fmt.Sprintf("failed to validate maintenance request: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        // An invalid request is still a successful check; the field errors are in the body
+        maintenanceRequestTotal.WithLabelValues("POST", "/maintenance/validate", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// validatePreferredTimeHandler handles checking whether a preferred time is allowed for a
+// growing environment before a schedule is submitted
+func validatePreferredTimeHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("POST", "/maintenance/validate-time"))
+        defer timer.ObserveDuration()
+
+        var req dto.PreferredTimeValidationRequest
+        if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+            maintenanceRequestTotal.WithLabelValues("POST", "/maintenance/validate-time", "error").Inc()
+            http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+            return
+        }
+
+        response, err := service.ValidatePreferredTime(&req)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("POST", "/maintenance/validate-time", "error").Inc()
+            if errors.Is(err, scheduler.ErrInvalidRequest) {
+                http.Error(w, err.Error(), http.StatusBadRequest)
+                return
+            }
+            http.Error(w, fmt.Sprintf("failed to validate preferred time: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        // A disallowed time is still a successful check; the reason is in the body
+        maintenanceRequestTotal.WithLabelValues("POST", "/maintenance/validate-time", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// exportHistoryCSVHandler handles downloading a garden's maintenance history as CSV,
+// optionally bounded by RFC 3339 from and to query parameters
+func exportHistoryCSVHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("GET", "/gardens/{id}/history.csv"))
+        defer timer.ObserveDuration()
+
+        gardenID := chi.URLParam(r, "id")
+        if gardenID == "" {
+            maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/history.csv", "error").Inc()
+            http.Error(w, "garden ID is required", http.StatusBadRequest)
+            return
+        }
+
+        var from, to time.Time
+        for name, bound := range map[string]*time.Time{"from": &from, "to": &to} {
+            value := r.URL.Query().Get(name)
+            if value == "" {
+                continue
+            }
+            parsed, err := time.Parse(time.RFC3339, value)
+            if err != nil {
+                maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/history.csv", "error").Inc()
+                http.Error(w, fmt.Sprintf("%s must be an RFC 3339 timestamp", name), http.StatusBadRequest)
+                return
+            }
+            *bound = parsed
+        }
+
+        ctx := r.Context()
+        history, err := service.ExportHistoryCSV(ctx, gardenID, from, to)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/history.csv", "error").Inc()
+            if errors.Is(err, scheduler.ErrInvalidRequest) {
+                http.Error(w, err.Error(), http.StatusBadRequest)
+                return
+            }
+            http.Error(w, fmt.Sprintf("failed to export maintenance history: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/history.csv", "success").Inc()
+        w.Header().Set("Content-Type", "text/csv")
+        w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "garden-"+gardenID+"-history.csv"))
+        w.Write(history)
+    }
+}
+
+// listGardenMaintenanceHandler handles listing a garden's active tasks whose completion
+// rate is below the completionBelow percentage
+func listGardenMaintenanceHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("GET", "/gardens/{id}/maintenance"))
+        defer timer.ObserveDuration()
+
+        gardenID := chi.URLParam(r, "id")
+        if gardenID == "" {
+            maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/maintenance", "error").Inc()
+            http.Error(w, "garden ID is required", http.StatusBadRequest)
+            return
+        }
+
+        belowPct, err := strconv.ParseFloat(r.URL.Query().Get("completionBelow"), 64)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/maintenance", "error").Inc()
+            http.Error(w, "completionBelow must be a percentage between 0 and 100", http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        tasks, err := service.ListTasksByCompletionRate(ctx, gardenID, belowPct)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/maintenance", "error").Inc()
+            if errors.Is(err, scheduler.ErrInvalidRequest) {
+                http.Error(w, err.Error(), http.StatusBadRequest)
+                return
+            }
+            http.Error(w, fmt.Sprintf("failed to list garden maintenance: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/maintenance", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(tasks)
+    }
+}
+
+// createSnapshotHandler handles saving a garden's current crops and schedules as a
+// snapshot, with an optional label in the request body
+func createSnapshotHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("POST", "/gardens/{id}/snapshots"))
+        defer timer.ObserveDuration()
+
+        gardenID := chi.URLParam(r, "id")
+        if gardenID == "" {
+            maintenanceRequestTotal.WithLabelValues("POST", "/gardens/{id}/snapshots", "error").Inc()
+            http.Error(w, "garden ID is required", http.StatusBadRequest)
+            return
+        }
+
+        var req dto.GardenSnapshotRequest
+        if r.ContentLength > 0 {
+            if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+                maintenanceRequestTotal.WithLabelValues("POST", "/gardens/{id}/snapshots", "error").Inc()
+                http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+                return
+            }
+        }
+
+        ctx := r.Context()
+        response, err := service.SnapshotGarden(ctx, gardenID, req.Label)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("POST", "/gardens/{id}/snapshots", "error").Inc()
+            switch {
+            case errors.Is(err, scheduler.ErrInvalidRequest):
+                http.Error(w, err.Error(), http.StatusBadRequest)
+            case errors.Is(err, scheduler.ErrGardenNotFound):
+                http.Error(w, err.Error(), http.StatusNotFound)
+            default:
+                http.Error(w, fmt.Sprintf("failed to snapshot garden: %v", err), http.StatusInternalServerError)
+            }
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("POST", "/gardens/{id}/snapshots", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        w.WriteHeader(http.StatusCreated)
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// listSnapshotsHandler handles retrieval of a garden's snapshots, newest first
+func listSnapshotsHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("GET", "/gardens/{id}/snapshots"))
+        defer timer.ObserveDuration()
+
+        gardenID := chi.URLParam(r, "id")
+        if gardenID == "" {
+            maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/snapshots", "error").Inc()
+            http.Error(w, "garden ID is required", http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.ListGardenSnapshots(ctx, gardenID)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/snapshots", "error").Inc()
+            if errors.Is(err, scheduler.ErrInvalidRequest) {
+                http.Error(w, err.Error(), http.StatusBadRequest)
+                return
+            }
+            http.Error(w, fmt.Sprintf("failed to list garden snapshots: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/snapshots", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// restoreSnapshotHandler handles returning a garden's crops and schedules to a snapshot
+func restoreSnapshotHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("POST", "/gardens/{id}/snapshots/{snapshotId}/restore"))
+        defer timer.ObserveDuration()
+
+        gardenID := chi.URLParam(r, "id")
+        snapshotID := chi.URLParam(r, "snapshotId")
+        if gardenID == "" || snapshotID == "" {
+            maintenanceRequestTotal.WithLabelValues("POST", "/gardens/{id}/snapshots/{snapshotId}/restore", "error").Inc()
+            http.Error(w, "garden ID and snapshot ID are required", http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.RestoreSnapshot(ctx, gardenID, snapshotID)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("POST", "/gardens/{id}/snapshots/{snapshotId}/restore", "error").Inc()
+            switch {
+            case errors.Is(err, scheduler.ErrInvalidRequest):
+                http.Error(w, err.Error(), http.StatusBadRequest)
+            case errors.Is(err, scheduler.ErrSnapshotNotFound):
+                http.Error(w, err.Error(), http.StatusNotFound)
+            default:
+                http.Error(w, fmt.Sprintf("failed to restore garden snapshot: %v", err), http.StatusInternalServerError)
+            }
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("POST", "/gardens/{id}/snapshots/{snapshotId}/restore", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// registerRecipientHandler handles adding a notification recipient to a garden
+func registerRecipientHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("POST", "/gardens/{id}/recipients"))
+        defer timer.ObserveDuration()
+
+        gardenID := chi.URLParam(r, "id")
+        if gardenID == "" {
+            maintenanceRequestTotal.WithLabelValues("POST", "/gardens/{id}/recipients", "error").Inc()
+            http.Error(w, "garden ID is required", http.StatusBadRequest)
+            return
+        }
+
+        var req dto.NotificationRecipient
+        if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+            maintenanceRequestTotal.WithLabelValues("POST", "/gardens/{id}/recipients", "error").Inc()
+            http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.RegisterNotificationRecipient(ctx, gardenID, &req)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("POST", "/gardens/{id}/recipients", "error").Inc()
+            if errors.Is(err, scheduler.ErrInvalidRecipient) {
+                http.Error(w, err.Error(), http.StatusBadRequest)
+                return
+            }
+            http.Error(w, fmt.Sprintf("failed to register recipient: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("POST", "/gardens/{id}/recipients", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        w.WriteHeader(http.StatusCreated)
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// listRecipientsHandler handles retrieval of a garden's notification recipients
+func listRecipientsHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("GET", "/gardens/{id}/recipients"))
+        defer timer.ObserveDuration()
+
+        gardenID := chi.URLParam(r, "id")
+        if gardenID == "" {
+            maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/recipients", "error").Inc()
+            http.Error(w, "garden ID is required", http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.ListNotificationRecipients(ctx, gardenID)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/recipients", "error").Inc()
+            http.Error(w, fmt.Sprintf("failed to list recipients: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/recipients", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// listDeliveriesHandler handles listing a garden's delivered notifications and the
+// channel that delivered each
+func listDeliveriesHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("GET", "/gardens/{id}/notification-deliveries"))
+        defer timer.ObserveDuration()
+
+        gardenID := chi.URLParam(r, "id")
+        if gardenID == "" {
+            maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/notification-deliveries", "error").Inc()
+            http.Error(w, "garden ID is required", http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.ListNotificationDeliveries(ctx, gardenID)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/notification-deliveries", "error").Inc()
+            http.Error(w, fmt.Sprintf("failed to list deliveries: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/notification-deliveries", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// removeRecipientHandler handles removing a notification recipient from a garden
+func removeRecipientHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("DELETE", "/gardens/{id}/recipients/{recipientId}"))
+        defer timer.ObserveDuration()
+
+        gardenID := chi.URLParam(r, "id")
+        recipientID := chi.URLParam(r, "recipientId")
+        if gardenID == "" || recipientID == "" {
+            maintenanceRequestTotal.WithLabelValues("DELETE", "/gardens/{id}/recipients/{recipientId}", "error").Inc()
+            http.Error(w, "garden ID and recipient ID are required", http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        if err := service.RemoveNotificationRecipient(ctx, gardenID, recipientID); err != nil {
+            maintenanceRequestTotal.WithLabelValues("DELETE", "/gardens/{id}/recipients/{recipientId}", "error").Inc()
+            http.Error(w, fmt.Sprintf("failed to remove recipient: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("DELETE", "/gardens/{id}/recipients/{recipientId}", "success").Inc()
+        w.WriteHeader(http.StatusNoContent)
+    }
+}
+
+// setNotificationDigestHandler handles enabling or disabling a garden's daily digest
+func setNotificationDigestHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("PUT", "/gardens/{id}/notification-digest"))
+        defer timer.ObserveDuration()
+
+        gardenID := chi.URLParam(r, "id")
+        if gardenID == "" {
+            maintenanceRequestTotal.WithLabelValues("PUT", "/gardens/{id}/notification-digest", "error").Inc()
+            http.Error(w, "garden ID is required", http.StatusBadRequest)
+            return
+        }
+
+        var req dto.DigestSettings
+        if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+            maintenanceRequestTotal.WithLabelValues("PUT", "/gardens/{id}/notification-digest", "error").Inc()
+            http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.SetNotificationDigest(ctx, gardenID, &req)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("PUT", "/gardens/{id}/notification-digest", "error").Inc()
+            if errors.Is(err, scheduler.ErrInvalidDigestSettings) {
+                http.Error(w, err.Error(), http.StatusBadRequest)
+                return
+            }
+            http.Error(w, fmt.Sprintf("failed to save digest settings: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("PUT", "/gardens/{id}/notification-digest", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// getNotificationDigestHandler handles retrieval of a garden's daily digest settings
+func getNotificationDigestHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("GET", "/gardens/{id}/notification-digest"))
+        defer timer.ObserveDuration()
+
+        gardenID := chi.URLParam(r, "id")
+        if gardenID == "" {
+            maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/notification-digest", "error").Inc()
+            http.Error(w, "garden ID is required", http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.GetNotificationDigest(ctx, gardenID)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/notification-digest", "error").Inc()
+            http.Error(w, fmt.Sprintf("failed to get digest settings: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("GET", "/gardens/{id}/notification-digest", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// recordEnvironmentHandler handles sensor readings pushed for a garden
+func recordEnvironmentHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("POST", "/gardens/{id}/environment"))
+        defer timer.ObserveDuration()
+
+        gardenID := chi.URLParam(r, "id")
+        if gardenID == "" {
+            maintenanceRequestTotal.WithLabelValues("POST", "/gardens/{id}/environment", "error").Inc()
+            http.Error(w, "garden ID is required", http.StatusBadRequest)
+            return
+        }
+
+        var req dto.EnvironmentReadingRequest
+        if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+            maintenanceRequestTotal.WithLabelValues("POST", "/gardens/{id}/environment", "error").Inc()
+            http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.RecordEnvironmentReading(ctx, gardenID, &req)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("POST", "/gardens/{id}/environment", "error").Inc()
+            if errors.Is(err, scheduler.ErrInvalidRequest) {
+                http.Error(w, err.Error(), http.StatusBadRequest)
+                return
+            }
+            http.Error(w, fmt.Sprintf("failed to record environment reading: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("POST", "/gardens/{id}/environment", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        w.WriteHeader(http.StatusCreated)
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// getTodayViewHandler handles retrieval of the tasks due today across the authenticated user's gardens
+func getTodayViewHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("GET", "/me/today"))
+        defer timer.ObserveDuration()
+
+        user, err := gatewayMiddleware.GetUserFromContext(r)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("GET", "/me/today", "error").Inc()
+            http.Error(w, "authentication required", http.StatusUnauthorized)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.TodayView(ctx, user.ID)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("GET", "/me/today", "error").Inc()
+            http.Error(w, fmt.Sprintf("failed to build today view: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("GET", "/me/today", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// getNotificationPreferencesHandler handles retrieval of the authenticated user's notification preferences
+func getNotificationPreferencesHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("GET", "/me/notification-preferences"))
+        defer timer.ObserveDuration()
+
+        user, err := gatewayMiddleware.GetUserFromContext(r)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("GET", "/me/notification-preferences", "error").Inc()
+            http.Error(w, "authentication required", http.StatusUnauthorized)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.GetNotificationPreferences(ctx, user.ID)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("GET", "/me/notification-preferences", "error").Inc()
+            http.Error(w, fmt.Sprintf("failed to get notification preferences: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("GET", "/me/notification-preferences", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// setNotificationPreferencesHandler handles replacing the authenticated user's notification preferences
+func setNotificationPreferencesHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("PUT", "/me/notification-preferences"))
+        defer timer.ObserveDuration()
+
+        user, err := gatewayMiddleware.GetUserFromContext(r)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("PUT", "/me/notification-preferences", "error").Inc()
+            http.Error(w, "authentication required", http.StatusUnauthorized)
+            return
+        }
+
+        var req dto.NotificationPreferences
+        if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+            maintenanceRequestTotal.WithLabelValues("PUT", "/me/notification-preferences", "error").Inc()
+            http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.SetNotificationPreferences(ctx, user.ID, &req)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("PUT", "/me/notification-preferences", "error").Inc()
+            if errors.Is(err, scheduler.ErrInvalidRequest) {
+                http.Error(w, err.Error(), http.StatusBadRequest)
+                return
+            }
+            http.Error(w, fmt.Sprintf("failed to save notification preferences: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("PUT", "/me/notification-preferences", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// applyNotificationPreferencesToAllHandler handles applying one set of notification
+// preferences to every garden the authenticated user owns
+func applyNotificationPreferencesToAllHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("PUT", "/me/notification-preferences/gardens"))
+        defer timer.ObserveDuration()
+
+        user, err := gatewayMiddleware.GetUserFromContext(r)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("PUT", "/me/notification-preferences/gardens", "error").Inc()
+            http.Error(w, "authentication required", http.StatusUnauthorized)
+            return
+        }
+
+        var req dto.NotificationPreferences
+        if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+            maintenanceRequestTotal.WithLabelValues("PUT", "/me/notification-preferences/gardens", "error").Inc()
+            http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.ApplyNotificationPreferenceToAll(ctx, user.ID, &req)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("PUT", "/me/notification-preferences/gardens", "error").Inc()
+            if errors.Is(err, scheduler.ErrInvalidRequest) {
+                http.Error(w, err.Error(), http.StatusBadRequest)
+                return
+            }
+            http.Error(w, fmt.Sprintf("failed to apply notification preferences: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("PUT", "/me/notification-preferences/gardens", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// getGardenNotificationPreferencesHandler handles retrieval of the notification preferences
+// that apply to one of the authenticated user's gardens
+func getGardenNotificationPreferencesHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("GET", "/gardens/notification-preferences"))
+        defer timer.ObserveDuration()
+
+        user, err := gatewayMiddleware.GetUserFromContext(r)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("GET", "/gardens/notification-preferences", "error").Inc()
+            http.Error(w, "authentication required", http.StatusUnauthorized)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.GetGardenNotificationPreferences(ctx, user.ID, chi.URLParam(r, "id"))
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("GET", "/gardens/notification-preferences", "error").Inc()
+            if errors.Is(err, scheduler.ErrInvalidRequest) {
+                http.Error(w, err.Error(), http.StatusBadRequest)
+                return
+            }
+            http.Error(w, fmt.Sprintf("failed to get notification preferences: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("GET", "/gardens/notification-preferences", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
+
+// resetNotificationPreferencesHandler handles clearing the authenticated user's notification
+// preferences, returning the defaults that now apply
+func resetNotificationPreferencesHandler(service *scheduler.SchedulerService) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        timer := prometheus.NewTimer(maintenanceRequestDuration.WithLabelValues("DELETE", "/me/notification-preferences"))
+        defer timer.ObserveDuration()
+
+        user, err := gatewayMiddleware.GetUserFromContext(r)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("DELETE", "/me/notification-preferences", "error").Inc()
+            http.Error(w, "authentication required", http.StatusUnauthorized)
+            return
+        }
+
+        ctx := r.Context()
+        response, err := service.ResetNotificationPreferences(ctx, user.ID)
+        if err != nil {
+            maintenanceRequestTotal.WithLabelValues("DELETE", "/me/notification-preferences", "error").Inc()
+            http.Error(w, fmt.Sprintf("failed to reset notification preferences: %v", err), http.StatusInternalServerError)
+            return
+        }
+
+        maintenanceRequestTotal.WithLabelValues("DELETE", "/me/notification-preferences", "success").Inc()
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(response)
+    }
+}
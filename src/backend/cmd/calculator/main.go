@@ -12,13 +12,14 @@ import (
 
     "github.com/go-chi/chi/v5" // v5.0.8
     "github.com/go-chi/chi/v5/middleware" // v5.0.8
-    "github.com/go-chi/cors" // v1.2.1
     "github.com/prometheus/client_golang/prometheus" // v1.15.0
     "github.com/prometheus/client_golang/prometheus/promhttp" // v1.15.0
 
+    gatewayMiddleware "github.com/urban-gardening-assistant/backend/api/gateway/middleware"
     "github.com/urban-gardening-assistant/backend/internal/calculator/service"
     "github.com/urban-gardening-assistant/backend/config"
     "github.com/urban-gardening-assistant/backend/internal/utils/logger"
+    "github.com/urban-gardening-assistant/backend/pkg/types"
 )
 
 const (
@@ -62,9 +63,19 @@ func main() {
         log.Error("Failed to initialize calculator service", err)
         os.Exit(1)
     }
+    if cfg.Calculator != nil {
+        calculatorService.SetAllowNonViableLayouts(cfg.Calculator.AllowNonViableLayouts)
+    }
 
     // Set up HTTP router with middleware
-    router := setupRouter(calculatorService, metrics, log)
+    router := setupRouter(calculatorService, cfg.API, metrics, log)
+
+    // Load the TLS certificate up front so a bad cert/key pair fails at startup
+    tlsConfig, err := config.ServerTLSConfig(cfg.API)
+    if err != nil {
+        log.Error("Invalid TLS configuration", err)
+        os.Exit(1)
+    }
 
     // Configure server
     server := &http.Server{
@@ -73,12 +84,13 @@ func main() {
         ReadTimeout:  readTimeout,
         WriteTimeout: writeTimeout,
         IdleTimeout:  idleTimeout,
+        TLSConfig:    tlsConfig,
     }
 
     // Start server in goroutine
     go func() {
-        log.Info("Starting calculator service", "port", defaultPort)
-        if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+        log.Info("Starting calculator service", "port", defaultPort, "tls", tlsConfig != nil)
+        if err := config.ListenAndServe(server); err != nil && err != http.ErrServerClosed {
             log.Error("Server failed", err)
             os.Exit(1)
         }
@@ -105,11 +117,11 @@ func main() {
 }
 
 // setupRouter configures the HTTP router with all necessary middleware and routes
-func setupRouter(svc *service.CalculatorService, metrics *prometheus.Registry, log *logger.Logger) *chi.Mux {
+func setupRouter(svc *service.CalculatorService, apiConfig *types.APIConfig, metrics *prometheus.Registry, log *logger.Logger) *chi.Mux {
     router := chi.NewRouter()
 
     // Add core middleware
-    router.Use(middleware.RequestID)
+    router.Use(gatewayMiddleware.RequestIDMiddleware(apiConfig))
     router.Use(middleware.RealIP)
     router.Use(middleware.Logger)
     router.Use(middleware.Recoverer)
@@ -120,21 +132,19 @@ func setupRouter(svc *service.CalculatorService, metrics *prometheus.Registry, l
     router.Use(middleware.SetHeader("X-Content-Type-Options", "nosniff"))
     router.Use(middleware.SetHeader("X-Frame-Options", "deny"))
 
-    // Configure CORS
-    router.Use(cors.Handler(cors.Options{
-        AllowedOrigins:   []string{"https://*", "http://*"},
-        AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-        AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-        ExposedHeaders:   []string{"Link"},
-        AllowCredentials: true,
-        MaxAge:          300,
-    }))
+    // Configure CORS from the shared API configuration
+    router.Use(gatewayMiddleware.CORSMiddleware(apiConfig))
 
     // Add timeout middleware
     router.Use(middleware.Timeout(30 * time.Second))
 
-    // Add compression middleware
-    router.Use(middleware.Compress(5))
+    // Add compression middleware configured from the shared API configuration
+    compression, err := gatewayMiddleware.CompressionMiddleware(apiConfig)
+    if err != nil {
+        log.Error("Failed to configure compression", err)
+        os.Exit(1)
+    }
+    router.Use(compression)
 
     // Health check endpoint
     router.Get("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -5,17 +5,21 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
 	"github.com/patrickmn/go-cache" // v2.1.0
+	"github.com/prometheus/client_golang/prometheus" // v1.15.0
+	"github.com/prometheus/client_golang/prometheus/promhttp" // v1.15.0
 	"go.uber.org/zap"              // v1.24.0
 	"gorm.io/gorm"                // v1.25.0
 
 	"github.com/urban-gardening-assistant/backend/config"
 	"github.com/urban-gardening-assistant/backend/internal/cropmanager"
+	"github.com/urban-gardening-assistant/backend/internal/utils/database"
 	"github.com/urban-gardening-assistant/backend/internal/utils/logger"
 )
 
@@ -29,8 +33,7 @@ const (
 	dbRetryDelay    = 5 * time.Second
 
 	// Cache configuration
-	cacheTTL           = 1 * time.Hour
-	cacheCleanupInterval = 2 * time.Hour
+	cacheTTL = 1 * time.Hour
 )
 
 func main() {
@@ -60,18 +63,72 @@ func main() {
 			zap.Error(err))
 	}
 
-	// Initialize cache
-	cacheInstance := cache.New(cacheTTL, cacheCleanupInterval)
+	// Initialize cache; the crop service sweeps expired entries until it is closed
+	cacheInstance := cache.New(cacheTTL, 0)
 
 	// Initialize crop management service
 	cropService := cropmanager.NewCropService(db, cacheInstance, log)
+	if cfg.CropManager != nil {
+		if err := cropService.SetSoilConfig(cropmanager.SoilConfig{
+			UnknownSoilFactor: cfg.CropManager.UnknownSoilFactor,
+			RejectUnknownSoil: cfg.CropManager.RejectUnknownSoil,
+		}); err != nil {
+			log.Fatal("Invalid crop manager configuration",
+				zap.Error(err))
+		}
+		cropService.SetYieldConfig(cropmanager.YieldConfig{
+			AdjustForEnvironment: cfg.CropManager.AdjustYieldForEnvironment,
+		})
+		cropService.SetReadConfig(cropmanager.ReadConfig{
+			ServeStale: cfg.CropManager.ServeStaleReads,
+		})
+		if err := cropService.SetBagLimitConfig(cropmanager.BagLimitConfig{
+			Policy: cfg.CropManager.GrowBagLimitPolicy,
+		}); err != nil {
+			log.Fatal("Invalid crop manager configuration",
+				zap.Error(err))
+		}
+		if err := cropService.SetBagFitConfig(cropmanager.BagFitConfig{
+			Policy: cfg.CropManager.UndersizedBagPolicy,
+		}); err != nil {
+			log.Fatal("Invalid crop manager configuration",
+				zap.Error(err))
+		}
+		if err := cropService.SetCapacityConfig(cropmanager.CapacityConfig{
+			WarningThreshold:  cfg.CropManager.CapacityWarningPercent / 100,
+			AllowOverCapacity: cfg.CropManager.AllowOverCapacity,
+		}); err != nil {
+			log.Fatal("Invalid crop manager configuration",
+				zap.Error(err))
+		}
+		cropService.SetDuplicateNameConfig(cropmanager.DuplicateNameConfig{
+			RejectDuplicates: cfg.CropManager.RejectDuplicateNames,
+		})
+		cropService.SetCropNameConfig(cropmanager.CropNameConfig{
+			AllowedNames: cfg.CropManager.AllowedCropNames,
+			AllowUnknown: cfg.CropManager.AllowUnknownCrops,
+		})
+		if cfg.CropManager.CapacityWebhookURL != "" {
+			thresholds := make([]float64, 0, len(cfg.CropManager.CapacityWebhookPercents))
+			for _, percent := range cfg.CropManager.CapacityWebhookPercents {
+				thresholds = append(thresholds, percent/100)
+			}
+			if err := cropService.SetCapacityWebhook(
+				cropmanager.NewHTTPCapacityWebhook(cfg.CropManager.CapacityWebhookURL, 0),
+				cropmanager.CapacityWebhookConfig{Thresholds: thresholds},
+			); err != nil {
+				log.Fatal("Invalid crop manager configuration",
+					zap.Error(err))
+			}
+		}
+	}
 
 	// Set up graceful shutdown
 	ctx, cancel := setupGracefulShutdown(log, db, cropService)
 	defer cancel()
 
 	// Start the service
-	if err := startService(ctx, cfg, cropService, log); err != nil {
+	if err := startService(ctx, cfg, cropService, db, log); err != nil {
 		log.Fatal("Failed to start service",
 			zap.Error(err))
 	}
@@ -142,6 +199,12 @@ func setupGracefulShutdown(log *zap.Logger, db *gorm.DB, service *cropmanager.Cr
 		log.Info("Initiating graceful shutdown",
 			zap.Duration("timeout", shutdownTimeout))
 
+		// Drain in-flight crop operations and release the cache before the database closes
+		if err := service.Close(shutdownCtx); err != nil {
+			log.Error("Error closing crop service",
+				zap.Error(err))
+		}
+
 		// Close database connection
 		if sqlDB, err := db.DB(); err == nil {
 			if err := sqlDB.Close(); err != nil {
@@ -165,14 +228,15 @@ func setupGracefulShutdown(log *zap.Logger, db *gorm.DB, service *cropmanager.Cr
 }
 
 // startService initializes and starts the service
-func startService(ctx context.Context, cfg *config.ServiceConfig, service *cropmanager.CropService, log *zap.Logger) error {
+func startService(ctx context.Context, cfg *config.ServiceConfig, service *cropmanager.CropService, db *gorm.DB, log *zap.Logger) error {
 	// Initialize metrics collector
-	if err := initMetrics(cfg); err != nil {
+	metrics, err := initMetrics(service, db)
+	if err != nil {
 		return fmt.Errorf("failed to initialize metrics: %w", err)
 	}
 
-	// Start health check endpoint
-	if err := startHealthCheck(cfg); err != nil {
+	// Start health check and metrics endpoints
+	if err := startHealthCheck(ctx, cfg, metrics, log); err != nil {
 		return fmt.Errorf("failed to start health check: %w", err)
 	}
 
@@ -183,16 +247,72 @@ func startService(ctx context.Context, cfg *config.ServiceConfig, service *cropm
 	return nil
 }
 
-// initMetrics initializes the metrics collection
-func initMetrics(cfg *config.ServiceConfig) error {
-	// Initialize Prometheus metrics
-	// Implementation details would go here
-	return nil
+// initMetrics registers runtime, crop operation and database query metrics and starts
+// recording crop operations and queries in the returned registry
+func initMetrics(service *cropmanager.CropService, db *gorm.DB) (*prometheus.Registry, error) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+	)
+
+	metrics, err := cropmanager.NewMetrics(registry)
+	if err != nil {
+		return nil, err
+	}
+	service.SetMetrics(metrics)
+
+	queryMetrics, err := database.NewQueryMetrics(registry)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Use(queryMetrics); err != nil {
+		return nil, err
+	}
+	return registry, nil
 }
 
-// startHealthCheck starts the health check endpoint
-func startHealthCheck(cfg *config.ServiceConfig) error {
-	// Initialize health check endpoint
-	// Implementation details would go here
+// startHealthCheck starts the health check and /metrics endpoints, serving HTTPS when
+// TLS is enabled in the API configuration. The server is shut down when ctx is cancelled.
+func startHealthCheck(ctx context.Context, cfg *config.ServiceConfig, metrics *prometheus.Registry, log *zap.Logger) error {
+	// Load the TLS certificate up front so a bad cert/key pair fails at startup
+	tlsConfig, err := config.ServerTLSConfig(cfg.API)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("healthy"))
+	})
+	mux.Handle("/metrics", promhttp.HandlerFor(metrics, promhttp.HandlerOpts{}))
+
+	server := &http.Server{
+		Addr:         fmt.Sprintf("%s:%d", cfg.API.Host, cfg.API.Port),
+		Handler:      mux,
+		ReadTimeout:  cfg.API.ReadTimeout,
+		WriteTimeout: cfg.API.WriteTimeout,
+		IdleTimeout:  cfg.API.IdleTimeout,
+		TLSConfig:    tlsConfig,
+	}
+
+	go func() {
+		log.Info("Starting health check endpoint",
+			zap.String("addr", server.Addr),
+			zap.Bool("tls", tlsConfig != nil))
+		if err := config.ListenAndServe(server); err != nil && err != http.ErrServerClosed {
+			log.Error("Health check server failed",
+				zap.Error(err))
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
 	return nil
-}
\ No newline at end of file
+}
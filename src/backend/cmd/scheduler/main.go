@@ -3,6 +3,7 @@ package main
 
 import (
     "context"
+    "errors"
     "log"
     "os"
     "os/signal"
@@ -67,6 +68,15 @@ func main() {
     }
     defer database.CloseConnection()
 
+    // Record query latency per operation and model on /metrics
+    queryMetrics, err := database.NewQueryMetrics(prometheus.DefaultRegisterer)
+    if err != nil {
+        log.Fatalf("Failed to initialize database metrics: %v", err)
+    }
+    if err := db.Use(queryMetrics); err != nil {
+        log.Fatalf("Failed to register database metrics: %v", err)
+    }
+
     // Initialize Redis client
     redisClient, err := initializeRedis(cfg)
     if err != nil {
@@ -74,12 +84,21 @@ func main() {
     }
     defer redisClient.Close()
 
-    // Initialize AI service
-    aiService, err := ai.NewAIClient(cfg, os.Getenv("OPENAI_API_KEY"))
+    // Initialize the configured AI provider
+    aiService, err := ai.NewProvider(cfg, os.Getenv("OPENAI_API_KEY"))
     if err != nil {
         log.Fatalf("Failed to initialize AI service: %v", err)
     }
 
+    // Enforce the monthly AI budget shared across instances on providers that bill per token
+    if client, ok := aiService.(*ai.AIClient); ok && cfg.AI != nil && cfg.AI.MonthlyBudget > 0 {
+        spendTracker, err := ai.NewSpendTracker(redisClient, cfg.AI.MonthlyBudget, cfg.AI.CostPer1KTokens)
+        if err != nil {
+            log.Fatalf("Failed to initialize AI spend tracker: %v", err)
+        }
+        client.SetSpendTracker(spendTracker)
+    }
+
     // Initialize recommendation service
     recService, err := ai.NewRecommendationService(aiService, 3*time.Second)
     if err != nil {
@@ -100,6 +119,15 @@ func main() {
         ShutdownTimeout: 30 * time.Second,
     }
 
+    if cfg.Scheduler != nil {
+        notifConfig.MinGap = cfg.Scheduler.MinNotificationGap
+        notifConfig.MaxActivePerUser = cfg.Scheduler.MaxActiveNotificationsPerUser
+        notifConfig.EvictOnLimit = cfg.Scheduler.NotificationLimitPolicy == scheduler.NotificationLimitEvict
+        notifConfig.TaskTypePriorities = cfg.Scheduler.TaskTypePriorities
+        notifConfig.TimeSource = cfg.Scheduler.NotificationTimeSource
+        notifConfig.ClockSkewTolerance = cfg.Scheduler.ClockSkewTolerance
+    }
+
     notificationMgr, err := scheduler.NewNotificationManager(redisClient, notifConfig)
     if err != nil {
         log.Fatalf("Failed to initialize notification manager: %v", err)
@@ -119,6 +147,11 @@ func main() {
     // Start metrics collection
     go collectMetrics(ctx)
 
+    // Purge expired completion history; replicas take turns through a Redis lock
+    if cfg.Scheduler != nil && cfg.Scheduler.CompletionHistoryRetention > 0 {
+        go purgeCompletionHistory(ctx, schedulerService, cfg.Scheduler.HistoryPurgeInterval)
+    }
+
     // Setup health check endpoint
     http.HandleFunc("/health", healthCheckHandler)
     http.Handle("/metrics", promhttp.Handler())
@@ -266,4 +299,27 @@ func collectMetrics(ctx context.Context) {
             // Additional metric collection could be added here
         }
     }
-}
\ No newline at end of file
+}
+
+// purgeCompletionHistory periodically removes completion history past its retention window
+func purgeCompletionHistory(ctx context.Context, service *scheduler.SchedulerService, interval time.Duration) {
+    ticker := time.NewTicker(interval)
+    defer ticker.Stop()
+
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            purged, err := service.PurgeCompletionHistory(ctx, time.Now())
+            switch {
+            case errors.Is(err, scheduler.ErrPurgeInProgress):
+                // Another replica is purging this round
+            case err != nil:
+                log.Printf("Completion history purge failed: %v", err)
+            case purged > 0:
+                log.Printf("Purged %d completion history events", purged)
+            }
+        }
+    }
+}
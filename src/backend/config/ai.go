@@ -0,0 +1,190 @@
+// Package config provides AI client configuration initialization and management
+// for the Urban Gardening Assistant backend services.
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urban-gardening/backend/pkg/types/config"
+)
+
+// Default AI configuration values; token limits, retryable statuses, and the duplicate
+// prompt window default to config.DefaultAIConfig, shared with the AI client
+const (
+	maxCompletionTokens      = 4000
+	defaultAIMonthlyBudget   = 0.0 // unlimited
+	defaultAICostPer1KTokens = 0.002
+	maxDuplicatePromptWindow = time.Minute
+	defaultAIProvider        = "openai"
+)
+
+// AI environment variable names
+const (
+	envAIProvider                = "AI_PROVIDER"
+	envAIMaxPromptTokens         = "AI_MAX_PROMPT_TOKENS"
+	envAITruncatePrompts         = "AI_TRUNCATE_OVERSIZED_PROMPTS"
+	envAIRecommendationMaxTokens = "AI_RECOMMENDATION_MAX_TOKENS"
+	envAIScheduleMaxTokens       = "AI_SCHEDULE_MAX_TOKENS"
+	envAICropSuggestionMaxTokens = "AI_CROP_SUGGESTION_MAX_TOKENS"
+	envAIMonthlyBudget           = "AI_MONTHLY_BUDGET"
+	envAICostPer1KTokens         = "AI_COST_PER_1K_TOKENS"
+	envAIRetryableStatusCodes    = "AI_RETRYABLE_STATUS_CODES"
+	envAIRefineSchedules         = "AI_REFINE_SCHEDULES"
+	envAIDuplicatePromptWindow   = "AI_DUPLICATE_PROMPT_WINDOW"
+
+	// Per-environment overrides, formatted with the upper-cased growing environment,
+	// e.g. AI_GREENHOUSE_MODEL
+	envAIEnvironmentModel         = "AI_%s_MODEL"
+	envAIEnvironmentTemperature   = "AI_%s_TEMPERATURE"
+	envAIEnvironmentPromptVariant = "AI_%s_PROMPT_VARIANT"
+)
+
+// Valid AI providers
+var validAIProviders = []string{"openai", "stub"}
+
+// aiGrowingEnvironments lists the growing environments that accept AI setting overrides
+var aiGrowingEnvironments = []string{"Indoor", "Outdoor", "Greenhouse"}
+
+// maxAITemperature is the highest sampling temperature accepted by the completion API
+const maxAITemperature = 2.0
+
+// loadAIConfig loads AI client configuration from environment variables.
+func loadAIConfig() (*config.AIConfig, error) {
+	defaults := config.DefaultAIConfig()
+	cfg := &config.AIConfig{
+		Provider:                 strings.ToLower(strings.TrimSpace(getEnvOrDefault(envAIProvider, defaultAIProvider))),
+		MaxPromptTokens:          getEnvIntOrDefault(envAIMaxPromptTokens, defaults.MaxPromptTokens),
+		TruncateOversizedPrompts: getEnvBoolOrDefault(envAITruncatePrompts, defaults.TruncateOversizedPrompts),
+		RecommendationMaxTokens:  getEnvIntOrDefault(envAIRecommendationMaxTokens, defaults.RecommendationMaxTokens),
+		ScheduleMaxTokens:        getEnvIntOrDefault(envAIScheduleMaxTokens, defaults.ScheduleMaxTokens),
+		CropSuggestionMaxTokens:  getEnvIntOrDefault(envAICropSuggestionMaxTokens, defaults.CropSuggestionMaxTokens),
+		MonthlyBudget:            getEnvFloatOrDefault(envAIMonthlyBudget, defaultAIMonthlyBudget),
+		CostPer1KTokens:          getEnvFloatOrDefault(envAICostPer1KTokens, defaultAICostPer1KTokens),
+		RetryableStatusCodes:     defaults.RetryableStatusCodes,
+		RefineSchedules:          getEnvBoolOrDefault(envAIRefineSchedules, false),
+		DuplicatePromptWindow:    getDurationOrDefault(envAIDuplicatePromptWindow, defaults.DuplicatePromptWindow),
+	}
+
+	if value := getEnvOrDefault(envAIRetryableStatusCodes, ""); value != "" {
+		statusCodes, err := parseStatusCodes(value)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RetryableStatusCodes = statusCodes
+	}
+
+	environmentSettings, err := loadAIEnvironmentSettings()
+	if err != nil {
+		return nil, err
+	}
+	cfg.EnvironmentSettings = environmentSettings
+
+	if err := validateAIConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// validateAIConfig validates AI client configuration values.
+func validateAIConfig(cfg *config.AIConfig) error {
+	if cfg == nil {
+		return fmt.Errorf("AI configuration cannot be nil")
+	}
+
+	validProvider := false
+	for _, provider := range validAIProviders {
+		if cfg.Provider == provider {
+			validProvider = true
+			break
+		}
+	}
+	if !validProvider {
+		return fmt.Errorf("invalid AI provider %q: must be one of %v", cfg.Provider, validAIProviders)
+	}
+
+	if cfg.MaxPromptTokens <= 0 {
+		return fmt.Errorf("max prompt tokens must be positive")
+	}
+
+	completionLimits := map[string]int{
+		"recommendation":  cfg.RecommendationMaxTokens,
+		"schedule":        cfg.ScheduleMaxTokens,
+		"crop suggestion": cfg.CropSuggestionMaxTokens,
+	}
+	for name, limit := range completionLimits {
+		if limit <= 0 || limit > maxCompletionTokens {
+			return fmt.Errorf("%s max tokens must be between 1 and %d", name, maxCompletionTokens)
+		}
+	}
+
+	if cfg.MonthlyBudget < 0 {
+		return fmt.Errorf("monthly AI budget must not be negative")
+	}
+	if cfg.MonthlyBudget > 0 && cfg.CostPer1KTokens <= 0 {
+		return fmt.Errorf("AI cost per 1K tokens must be positive when a monthly budget is set")
+	}
+
+	for _, code := range cfg.RetryableStatusCodes {
+		if code < 400 || code > 599 {
+			return fmt.Errorf("retryable AI status code %d must be an HTTP error status between 400 and 599", code)
+		}
+	}
+
+	if cfg.DuplicatePromptWindow < 0 || cfg.DuplicatePromptWindow > maxDuplicatePromptWindow {
+		return fmt.Errorf("duplicate AI prompt window must be between 0 and %s", maxDuplicatePromptWindow)
+	}
+
+	for environment, settings := range cfg.EnvironmentSettings {
+		if settings.Temperature != nil && (*settings.Temperature < 0 || *settings.Temperature > maxAITemperature) {
+			return fmt.Errorf("%s AI temperature must be between 0 and %.0f", environment, maxAITemperature)
+		}
+	}
+
+	return nil
+}
+
+// loadAIEnvironmentSettings loads the per-environment AI overrides. Environments without
+// any override set are omitted so they use the client defaults.
+func loadAIEnvironmentSettings() (map[string]config.AIEnvironmentSettings, error) {
+	result := make(map[string]config.AIEnvironmentSettings)
+	for _, environment := range aiGrowingEnvironments {
+		prefix := strings.ToUpper(environment)
+		settings := config.AIEnvironmentSettings{
+			Model:         strings.TrimSpace(os.Getenv(fmt.Sprintf(envAIEnvironmentModel, prefix))),
+			PromptVariant: strings.TrimSpace(os.Getenv(fmt.Sprintf(envAIEnvironmentPromptVariant, prefix))),
+		}
+
+		if value := strings.TrimSpace(os.Getenv(fmt.Sprintf(envAIEnvironmentTemperature, prefix))); value != "" {
+			temperature, err := strconv.ParseFloat(value, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s AI temperature %q: %w", environment, value, err)
+			}
+			t := float32(temperature)
+			settings.Temperature = &t
+		}
+
+		if settings.Model != "" || settings.Temperature != nil || settings.PromptVariant != "" {
+			result[environment] = settings
+		}
+	}
+	return result, nil
+}
+
+// parseStatusCodes parses a comma-separated list of HTTP status codes.
+func parseStatusCodes(value string) ([]int, error) {
+	entries := splitList(value)
+	codes := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		code, err := strconv.Atoi(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid AI status code %q: %w", entry, err)
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
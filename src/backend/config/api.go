@@ -0,0 +1,188 @@
+// Package config provides API server configuration initialization and management
+// for the Urban Gardening Assistant backend services.
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/urban-gardening/backend/pkg/types/config"
+)
+
+// Default API configuration values
+const (
+	defaultAPIHost            = "0.0.0.0"
+	defaultAPIPort            = 8080
+	defaultAPIReadTimeout     = 15 * time.Second
+	defaultAPIWriteTimeout    = 15 * time.Second
+	defaultAPIIdleTimeout     = 60 * time.Second
+	defaultAPIShutdownTimeout = 30 * time.Second
+	defaultAPIMaxRequestSize  = 1 << 20 // 1MB
+	defaultAPIMaxHeaderSize   = 1 << 20 // 1MB
+	defaultAPIRateLimit       = 100
+	defaultAPIUserRateLimit   = 100
+	defaultAPIRateLimitWindow = time.Minute
+	defaultCompressionLevel   = 5
+	defaultCompressionMinSize = 1000 // bytes
+	defaultAllowedOrigins     = "http://localhost:3000"
+	defaultAllowedMethods     = "GET,POST,PUT,DELETE,OPTIONS"
+	defaultAllowedHeaders     = "Accept,Authorization,Content-Type,X-Request-ID"
+	defaultRequestIDHeader    = "X-Request-Id"
+)
+
+// Gzip compression level bounds
+const (
+	minCompressionLevel = 1
+	maxCompressionLevel = 9
+)
+
+// API environment variable names
+const (
+	envAPIHost            = "API_HOST"
+	envAPIPort            = "API_PORT"
+	envAPIReadTimeout     = "API_READ_TIMEOUT"
+	envAPIWriteTimeout    = "API_WRITE_TIMEOUT"
+	envAPIIdleTimeout     = "API_IDLE_TIMEOUT"
+	envAPIShutdownTimeout = "API_SHUTDOWN_TIMEOUT"
+	envAPIMaxRequestSize  = "API_MAX_REQUEST_SIZE"
+	envAPIMaxHeaderSize   = "API_MAX_HEADER_SIZE"
+	envAPIRateLimit       = "API_RATE_LIMIT"
+	envAPIUserRateLimit   = "API_USER_RATE_LIMIT"
+	envAPIRateLimitWindow = "API_RATE_LIMIT_WINDOW"
+	envAPITLSEnabled      = "API_TLS_ENABLED"
+	envAPITLSCertPath     = "API_TLS_CERT_PATH"
+	envAPITLSKeyPath      = "API_TLS_KEY_PATH"
+	envCORSEnabled        = "CORS_ENABLED"
+	envCORSOrigins        = "CORS_ALLOWED_ORIGINS"
+	envCORSMethods        = "CORS_ALLOWED_METHODS"
+	envCORSHeaders        = "CORS_ALLOWED_HEADERS"
+	envRequestLogging     = "API_REQUEST_LOGGING"
+	envMetricsEnabled     = "API_METRICS_ENABLED"
+	envCompressionEnabled = "API_COMPRESSION_ENABLED"
+	envCompressionLevel   = "API_COMPRESSION_LEVEL"
+	envCompressionMinSize = "API_COMPRESSION_MIN_SIZE"
+	envRequestIDHeader    = "API_REQUEST_ID_HEADER"
+)
+
+// loadAPIConfig loads API server configuration from environment variables with secure defaults.
+func loadAPIConfig() (*config.APIConfig, error) {
+	cfg := &config.APIConfig{
+		Host:                 getEnvOrDefault(envAPIHost, defaultAPIHost),
+		Port:                 getEnvIntOrDefault(envAPIPort, defaultAPIPort),
+		ReadTimeout:          getDurationOrDefault(envAPIReadTimeout, defaultAPIReadTimeout),
+		WriteTimeout:         getDurationOrDefault(envAPIWriteTimeout, defaultAPIWriteTimeout),
+		IdleTimeout:          getDurationOrDefault(envAPIIdleTimeout, defaultAPIIdleTimeout),
+		ShutdownTimeout:      getDurationOrDefault(envAPIShutdownTimeout, defaultAPIShutdownTimeout),
+		MaxRequestSize:       getEnvIntOrDefault(envAPIMaxRequestSize, defaultAPIMaxRequestSize),
+		MaxHeaderSize:        getEnvIntOrDefault(envAPIMaxHeaderSize, defaultAPIMaxHeaderSize),
+		EnableCORS:           getEnvBoolOrDefault(envCORSEnabled, true),
+		AllowedOrigins:       splitList(getEnvOrDefault(envCORSOrigins, defaultAllowedOrigins)),
+		AllowedMethods:       splitList(getEnvOrDefault(envCORSMethods, defaultAllowedMethods)),
+		AllowedHeaders:       splitList(getEnvOrDefault(envCORSHeaders, defaultAllowedHeaders)),
+		EnableTLS:            getEnvBoolOrDefault(envAPITLSEnabled, false),
+		TLSCertPath:          os.Getenv(envAPITLSCertPath),
+		TLSKeyPath:           os.Getenv(envAPITLSKeyPath),
+		EnableRequestLogging: getEnvBoolOrDefault(envRequestLogging, true),
+		EnableMetrics:        getEnvBoolOrDefault(envMetricsEnabled, true),
+		EnableCompression:    getEnvBoolOrDefault(envCompressionEnabled, true),
+		CompressionLevel:     getEnvIntOrDefault(envCompressionLevel, defaultCompressionLevel),
+		CompressionMinSize:   getEnvIntOrDefault(envCompressionMinSize, defaultCompressionMinSize),
+		RequestIDHeader:      strings.TrimSpace(getEnvOrDefault(envRequestIDHeader, defaultRequestIDHeader)),
+		RateLimit:            getEnvIntOrDefault(envAPIRateLimit, defaultAPIRateLimit),
+		UserRateLimit:        getEnvIntOrDefault(envAPIUserRateLimit, defaultAPIUserRateLimit),
+		RateLimitWindow:      getDurationOrDefault(envAPIRateLimitWindow, defaultAPIRateLimitWindow),
+	}
+
+	if err := validateAPIConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// validateAPIConfig performs environment-independent validation of API configuration values.
+func validateAPIConfig(cfg *config.APIConfig) error {
+	if cfg == nil {
+		return fmt.Errorf("API configuration cannot be nil")
+	}
+
+	if cfg.Port < minPort || cfg.Port > maxPort {
+		return fmt.Errorf("API port must be between %d and %d", minPort, maxPort)
+	}
+
+	if cfg.ReadTimeout <= 0 || cfg.WriteTimeout <= 0 || cfg.IdleTimeout <= 0 {
+		return fmt.Errorf("API timeouts must be positive")
+	}
+
+	if cfg.RateLimit <= 0 || cfg.UserRateLimit <= 0 || cfg.RateLimitWindow <= 0 {
+		return fmt.Errorf("API rate limits and window must be positive")
+	}
+
+	if cfg.EnableCompression {
+		if cfg.CompressionLevel < minCompressionLevel || cfg.CompressionLevel > maxCompressionLevel {
+			return fmt.Errorf("API compression level must be between %d and %d", minCompressionLevel, maxCompressionLevel)
+		}
+		if cfg.CompressionMinSize < 0 {
+			return fmt.Errorf("API compression minimum size cannot be negative")
+		}
+	}
+
+	if !isValidHeaderName(cfg.RequestIDHeader) {
+		return fmt.Errorf("API request ID header %q is not a valid header name", cfg.RequestIDHeader)
+	}
+
+	if cfg.EnableCORS {
+		for _, origin := range cfg.AllowedOrigins {
+			if strings.TrimSpace(origin) == "" {
+				return fmt.Errorf("CORS allowed origins cannot contain empty entries")
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateAllowedOrigins validates CORS origins for the given environment. Wildcard
+// origins are rejected in production so that every allowed origin is explicit.
+func ValidateAllowedOrigins(environment string, origins []string) error {
+	if environment != "production" {
+		return nil
+	}
+
+	for _, origin := range origins {
+		if strings.Contains(origin, "*") {
+			return fmt.Errorf("wildcard CORS origin %q is not allowed in production", origin)
+		}
+	}
+
+	return nil
+}
+
+// isValidHeaderName reports whether name is a non-empty HTTP header field name made of
+// RFC 7230 token characters.
+func isValidHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("!#$%&'*+-.^_`|~", r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitList splits a comma-separated environment value into trimmed, non-empty entries.
+func splitList(value string) []string {
+	parts := strings.Split(value, ",")
+	result := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
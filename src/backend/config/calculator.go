@@ -0,0 +1,20 @@
+// Package config provides space calculator configuration initialization and management
+// for the Urban Gardening Assistant backend services.
+package config
+
+import (
+	"github.com/urban-gardening/backend/pkg/types/config"
+)
+
+// Calculator environment variable names
+const (
+	envAllowNonViableLayouts = "CALCULATOR_ALLOW_NON_VIABLE_LAYOUTS"
+)
+
+// loadCalculatorConfig loads space calculator configuration from environment variables.
+func loadCalculatorConfig() *config.CalculatorConfig {
+	return &config.CalculatorConfig{
+		// Off by default so layouts below the accessibility minimum are still rejected
+		AllowNonViableLayouts: getEnvBoolOrDefault(envAllowNonViableLayouts, false),
+	}
+}
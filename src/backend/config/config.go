@@ -68,6 +68,30 @@ func LoadConfig() (*config.ServiceConfig, error) {
 	}
 	cfg.API = apiConfig
 
+	// Load scheduler configuration
+	schedulerConfig, err := loadSchedulerConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load scheduler configuration: %w", err)
+	}
+	cfg.Scheduler = schedulerConfig
+
+	// Load crop manager configuration
+	cropManagerConfig, err := loadCropManagerConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load crop manager configuration: %w", err)
+	}
+	cfg.CropManager = cropManagerConfig
+
+	// Load space calculator configuration
+	cfg.Calculator = loadCalculatorConfig()
+
+	// Load AI configuration
+	aiConfig, err := loadAIConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AI configuration: %w", err)
+	}
+	cfg.AI = aiConfig
+
 	// Load feature flags
 	featureFlags := os.Getenv(envFeatureFlags)
 	if featureFlags != "" {
@@ -130,6 +154,28 @@ func ValidateConfig(cfg *config.ServiceConfig) error {
 		return fmt.Errorf("API configuration invalid: %w", err)
 	}
 
+	// Validate CORS origins for the target environment
+	if cfg.API.EnableCORS {
+		if err := ValidateAllowedOrigins(cfg.Environment, cfg.API.AllowedOrigins); err != nil {
+			return fmt.Errorf("API configuration invalid: %w", err)
+		}
+	}
+
+	// Validate scheduler configuration
+	if err := validateSchedulerConfig(cfg.Scheduler); err != nil {
+		return fmt.Errorf("scheduler configuration invalid: %w", err)
+	}
+
+	// Validate crop manager configuration
+	if err := validateCropManagerConfig(cfg.CropManager); err != nil {
+		return fmt.Errorf("crop manager configuration invalid: %w", err)
+	}
+
+	// Validate AI configuration
+	if err := validateAIConfig(cfg.AI); err != nil {
+		return fmt.Errorf("AI configuration invalid: %w", err)
+	}
+
 	// Validate feature flags
 	if err := validateFeatureFlags(cfg.FeatureFlags); err != nil {
 		return fmt.Errorf("feature flags invalid: %w", err)
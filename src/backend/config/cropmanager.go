@@ -0,0 +1,136 @@
+// Package config provides crop manager configuration initialization and management
+// for the Urban Gardening Assistant backend services.
+package config
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/urban-gardening/backend/pkg/types/config"
+)
+
+// Default crop manager configuration values
+const (
+	defaultUnknownSoilFactor = 1.0
+	maxUnknownSoilFactor     = 2.0
+	defaultGrowBagLimit      = "suggest"
+	defaultUndersizedBag     = "warn"
+	defaultCapacityWarning   = 80.0
+	defaultCapacityWebhooks  = "80,95"
+)
+
+// Valid grow bag limit policies
+var validGrowBagLimitPolicies = []string{"suggest", "clamp", "global"}
+
+// Valid undersized grow bag policies
+var validUndersizedBagPolicies = []string{"warn", "block"}
+
+// Crop manager environment variable names
+const (
+	envUnknownSoilFactor = "CROP_UNKNOWN_SOIL_FACTOR"
+	envRejectUnknownSoil = "CROP_REJECT_UNKNOWN_SOIL"
+	envAdjustYieldForEnv = "CROP_ADJUST_YIELD_FOR_ENVIRONMENT"
+	envCropStaleReads    = "CROP_SERVE_STALE_READS"
+	envGrowBagLimit      = "CROP_GROW_BAG_LIMIT_POLICY"
+	envUndersizedBag     = "CROP_UNDERSIZED_BAG_POLICY"
+	envCapacityWarning   = "CROP_CAPACITY_WARNING_PERCENT"
+	envAllowOverCapacity = "CROP_ALLOW_OVER_CAPACITY"
+	envRejectDuplicates  = "CROP_REJECT_DUPLICATE_NAMES"
+	envAllowedCropNames  = "CROP_ALLOWED_NAMES"
+	envAllowUnknownCrops = "CROP_ALLOW_UNKNOWN_NAMES"
+	envCapacityWebhook   = "CROP_CAPACITY_WEBHOOK_URL"
+	envCapacityWebhookAt = "CROP_CAPACITY_WEBHOOK_PERCENTS"
+)
+
+// loadCropManagerConfig loads crop manager configuration from environment variables.
+func loadCropManagerConfig() (*config.CropManagerConfig, error) {
+	cfg := &config.CropManagerConfig{
+		UnknownSoilFactor: getEnvFloatOrDefault(envUnknownSoilFactor, defaultUnknownSoilFactor),
+		RejectUnknownSoil: getEnvBoolOrDefault(envRejectUnknownSoil, false),
+		// Off by default so existing yield estimates are unchanged
+		AdjustYieldForEnvironment: getEnvBoolOrDefault(envAdjustYieldForEnv, false),
+		ServeStaleReads:           getEnvBoolOrDefault(envCropStaleReads, true),
+		GrowBagLimitPolicy:        getEnvOrDefault(envGrowBagLimit, defaultGrowBagLimit),
+		UndersizedBagPolicy:       getEnvOrDefault(envUndersizedBag, defaultUndersizedBag),
+		CapacityWarningPercent:    getEnvFloatOrDefault(envCapacityWarning, defaultCapacityWarning),
+		// Off by default so over-capacity crops are still rejected
+		AllowOverCapacity: getEnvBoolOrDefault(envAllowOverCapacity, false),
+		// Off by default so gardeners can grow several batches of the same crop
+		RejectDuplicateNames: getEnvBoolOrDefault(envRejectDuplicates, false),
+		AllowedCropNames:     splitList(getEnvOrDefault(envAllowedCropNames, "")),
+		// Off by default so misspelt crops are caught instead of silently using the default yield
+		AllowUnknownCrops:  getEnvBoolOrDefault(envAllowUnknownCrops, false),
+		CapacityWebhookURL: getEnvOrDefault(envCapacityWebhook, ""),
+	}
+
+	percents, err := parsePercents(getEnvOrDefault(envCapacityWebhookAt, defaultCapacityWebhooks))
+	if err != nil {
+		return nil, err
+	}
+	cfg.CapacityWebhookPercents = percents
+
+	if err := validateCropManagerConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// validateCropManagerConfig validates crop manager configuration values.
+func validateCropManagerConfig(cfg *config.CropManagerConfig) error {
+	if cfg == nil {
+		return fmt.Errorf("crop manager configuration cannot be nil")
+	}
+
+	if cfg.UnknownSoilFactor <= 0 || cfg.UnknownSoilFactor > maxUnknownSoilFactor {
+		return fmt.Errorf("unknown soil factor must be greater than 0 and at most %.1f", maxUnknownSoilFactor)
+	}
+
+	validPolicy := false
+	for _, policy := range validGrowBagLimitPolicies {
+		if cfg.GrowBagLimitPolicy == policy {
+			validPolicy = true
+			break
+		}
+	}
+	if !validPolicy {
+		return fmt.Errorf("invalid grow bag limit policy %q: must be one of %v", cfg.GrowBagLimitPolicy, validGrowBagLimitPolicies)
+	}
+
+	validPolicy = false
+	for _, policy := range validUndersizedBagPolicies {
+		if cfg.UndersizedBagPolicy == policy {
+			validPolicy = true
+			break
+		}
+	}
+	if !validPolicy {
+		return fmt.Errorf("invalid undersized bag policy %q: must be one of %v", cfg.UndersizedBagPolicy, validUndersizedBagPolicies)
+	}
+
+	if cfg.CapacityWarningPercent <= 0 || cfg.CapacityWarningPercent >= 100 {
+		return fmt.Errorf("capacity warning percent must be between 0 and 100")
+	}
+
+	for _, percent := range cfg.CapacityWebhookPercents {
+		if percent <= 0 {
+			return fmt.Errorf("capacity webhook percents must be positive")
+		}
+	}
+
+	return nil
+}
+
+// parsePercents parses a comma-separated list of percentages.
+func parsePercents(value string) ([]float64, error) {
+	entries := splitList(value)
+	percents := make([]float64, 0, len(entries))
+	for _, entry := range entries {
+		percent, err := strconv.ParseFloat(entry, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid capacity webhook percent %q: %w", entry, err)
+		}
+		percents = append(percents, percent)
+	}
+	return percents, nil
+}
@@ -0,0 +1,57 @@
+// Package config provides redaction of loaded configuration for operator inspection.
+package config
+
+import (
+	"github.com/urban-gardening/backend/pkg/types/config"
+)
+
+// RedactedValue replaces secret values in redacted configuration
+const RedactedValue = "[REDACTED]"
+
+// Redact returns a copy of cfg with secrets masked, safe to expose to operators.
+// Secrets that are set are replaced with RedactedValue; unset secrets stay empty so
+// a missing value remains visible. The JWT signing secret and AI API key are read
+// directly from the environment by their consumers and are never part of cfg.
+func Redact(cfg *config.ServiceConfig) *config.ServiceConfig {
+	if cfg == nil {
+		return nil
+	}
+
+	redacted := *cfg
+
+	if cfg.Database != nil {
+		database := *cfg.Database
+		database.Password = redactSecret(database.Password)
+		redacted.Database = &database
+	}
+
+	if cfg.Redis != nil {
+		redis := *cfg.Redis
+		redis.Password = redactSecret(redis.Password)
+		redacted.Redis = &redis
+	}
+
+	if cfg.CropManager != nil {
+		// The capacity webhook URL may carry a token in its path or query
+		cropManager := *cfg.CropManager
+		cropManager.CapacityWebhookURL = redactSecret(cropManager.CapacityWebhookURL)
+		redacted.CropManager = &cropManager
+	}
+
+	if cfg.FeatureFlags != nil {
+		redacted.FeatureFlags = make(map[string]string, len(cfg.FeatureFlags))
+		for key, value := range cfg.FeatureFlags {
+			redacted.FeatureFlags[key] = value
+		}
+	}
+
+	return &redacted
+}
+
+// redactSecret masks a secret value, leaving unset values empty
+func redactSecret(value string) string {
+	if value == "" {
+		return ""
+	}
+	return RedactedValue
+}
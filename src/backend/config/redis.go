@@ -34,6 +34,7 @@ const (
 	minRetries       = 1
 	maxRetries       = 10
 	minPasswordLen   = 8
+	maxTTLJitterPercent = 50.0
 )
 
 // LoadRedisConfig loads Redis configuration from environment variables with validation
@@ -50,6 +51,7 @@ func LoadRedisConfig() (*config.RedisConfig, error) {
 		MaxRetries:   getEnvIntOrDefault("REDIS_MAX_RETRIES", defaultMaxRetries),
 		PoolSize:     getEnvIntOrDefault("REDIS_POOL_SIZE", defaultPoolSize),
 		EnableTLS:    getEnvBoolOrDefault("REDIS_TLS_ENABLED", false),
+		TTLJitterPercent: getEnvFloatOrDefault("REDIS_TTL_JITTER_PERCENT", 0),
 	}
 
 	// Validate the configuration
@@ -112,6 +114,12 @@ func ValidateRedisConfig(cfg *config.RedisConfig) error {
 			"Redis max retries must be between 1 and 10")
 	}
 
+	// Validate TTL jitter
+	if cfg.TTLJitterPercent < 0 || cfg.TTLJitterPercent > maxTTLJitterPercent {
+		return errors.NewError("VALIDATION_ERROR", 
+			"Redis TTL jitter percent must be between 0 and 50")
+	}
+
 	// Validate TLS configuration if enabled
 	if cfg.EnableTLS {
 		if err := validateTLSConfig(); err != nil {
@@ -142,6 +150,16 @@ func getEnvIntOrDefault(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvFloatOrDefault retrieves a float environment variable or returns the default value
+func getEnvFloatOrDefault(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 // getEnvBoolOrDefault retrieves a boolean environment variable or returns the default value
 func getEnvBoolOrDefault(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
@@ -0,0 +1,331 @@
+// Package config provides maintenance scheduler configuration initialization and management
+// for the Urban Gardening Assistant backend services.
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urban-gardening/backend/pkg/types/config"
+)
+
+// Default scheduler configuration values
+const (
+	defaultAIWorkerPoolSize  = 5
+	maxAIWorkerPoolSize      = 50
+	defaultMaxBatchSize      = 50
+	maxBatchSizeLimit        = 500
+	defaultStaleReadTTL      = 24 * time.Hour
+	defaultMinNotifyGap      = 30 * time.Minute
+	defaultMaxCompletions    = 5
+	maxConcurrentCompletions = 50
+	defaultAIAmountPolicy    = "clamp"
+	defaultMaxActiveNotify   = 500
+	defaultNotifyLimitPolicy = "reject"
+	defaultMaxAcceleration   = 12 * time.Hour
+	defaultTimeSource        = "local"
+	defaultPurgeInterval     = 24 * time.Hour
+	minPurgeInterval         = time.Minute
+	defaultPurgeBatchSize    = 1000
+)
+
+// Valid policies for out-of-bounds AI-recommended amounts
+var validAIAmountPolicies = []string{"clamp", "discard"}
+
+// Valid policies for notifications beyond a user's active notification limit
+var validNotificationLimitPolicies = []string{"reject", "evict"}
+
+// Valid sources of the current time for notification processing
+var validTimeSources = []string{"local", "redis"}
+
+// Valid crop growth rates for re-pot lead times
+var validGrowthRates = []string{"fast", "medium", "slow"}
+
+// Scheduler environment variable names
+const (
+	envAIWorkerPoolSize     = "SCHEDULER_AI_WORKER_POOL_SIZE"
+	envSchedulerBatchSize   = "SCHEDULER_MAX_BATCH_SIZE"
+	envSchedulerStaleReads  = "SCHEDULER_SERVE_STALE_READS"
+	envSchedulerStaleTTL    = "SCHEDULER_STALE_READ_TTL"
+	envSchedulerNotifyGap   = "SCHEDULER_MIN_NOTIFICATION_GAP"
+	envSchedulerFrequency   = "SCHEDULER_DEFAULT_FREQUENCIES"
+	envSchedulerCompletions = "SCHEDULER_MAX_CONCURRENT_COMPLETIONS"
+	envSchedulerAIAmount    = "SCHEDULER_AI_AMOUNT_POLICY"
+	envSchedulerMaxNotify   = "SCHEDULER_MAX_ACTIVE_NOTIFICATIONS"
+	envSchedulerNotifyLimit = "SCHEDULER_NOTIFICATION_LIMIT_POLICY"
+	envSchedulerPriorities  = "SCHEDULER_TASK_TYPE_PRIORITIES"
+	envSchedulerAccelRules  = "SCHEDULER_ACCELERATION_RULES"
+	envSchedulerMaxAccel    = "SCHEDULER_MAX_ACCELERATION"
+	envSchedulerTimeSource  = "SCHEDULER_NOTIFICATION_TIME_SOURCE"
+	envSchedulerSkew        = "SCHEDULER_CLOCK_SKEW_TOLERANCE"
+	envSchedulerRetention   = "SCHEDULER_COMPLETION_HISTORY_RETENTION"
+	envSchedulerPurgeEvery  = "SCHEDULER_HISTORY_PURGE_INTERVAL"
+	envSchedulerPurgeBatch  = "SCHEDULER_HISTORY_PURGE_BATCH_SIZE"
+	envSchedulerRepotDays   = "SCHEDULER_REPOT_DAYS"
+	envSchedulerAllowPast   = "SCHEDULER_ALLOW_PAST_SCHEDULES"
+)
+
+// loadSchedulerConfig loads maintenance scheduler configuration from environment variables.
+func loadSchedulerConfig() (*config.SchedulerConfig, error) {
+	cfg := &config.SchedulerConfig{
+		AIWorkerPoolSize:              getEnvIntOrDefault(envAIWorkerPoolSize, defaultAIWorkerPoolSize),
+		MaxBatchSize:                  getEnvIntOrDefault(envSchedulerBatchSize, defaultMaxBatchSize),
+		ServeStaleReads:               getEnvBoolOrDefault(envSchedulerStaleReads, true),
+		StaleReadTTL:                  getDurationOrDefault(envSchedulerStaleTTL, defaultStaleReadTTL),
+		MinNotificationGap:            getDurationOrDefault(envSchedulerNotifyGap, defaultMinNotifyGap),
+		MaxConcurrentCompletions:      getEnvIntOrDefault(envSchedulerCompletions, defaultMaxCompletions),
+		AIAmountPolicy:                getEnvOrDefault(envSchedulerAIAmount, defaultAIAmountPolicy),
+		MaxActiveNotificationsPerUser: getEnvIntOrDefault(envSchedulerMaxNotify, defaultMaxActiveNotify),
+		NotificationLimitPolicy:       getEnvOrDefault(envSchedulerNotifyLimit, defaultNotifyLimitPolicy),
+		MaxScheduleAcceleration:       getDurationOrDefault(envSchedulerMaxAccel, defaultMaxAcceleration),
+		NotificationTimeSource:        getEnvOrDefault(envSchedulerTimeSource, defaultTimeSource),
+		ClockSkewTolerance:            getDurationOrDefault(envSchedulerSkew, 0),
+		CompletionHistoryRetention:    getDurationOrDefault(envSchedulerRetention, 0),
+		HistoryPurgeInterval:          getDurationOrDefault(envSchedulerPurgeEvery, defaultPurgeInterval),
+		HistoryPurgeBatchSize:         getEnvIntOrDefault(envSchedulerPurgeBatch, defaultPurgeBatchSize),
+		AllowPastSchedules:            getEnvBoolOrDefault(envSchedulerAllowPast, false),
+	}
+
+	frequencies, err := parseDefaultFrequencies(getEnvOrDefault(envSchedulerFrequency, ""))
+	if err != nil {
+		return nil, err
+	}
+	cfg.DefaultFrequencies = frequencies
+
+	priorities, err := parseTaskTypePriorities(getEnvOrDefault(envSchedulerPriorities, ""))
+	if err != nil {
+		return nil, err
+	}
+	cfg.TaskTypePriorities = priorities
+
+	repotDays, err := parseRepotDays(getEnvOrDefault(envSchedulerRepotDays, ""))
+	if err != nil {
+		return nil, err
+	}
+	cfg.RepotDays = repotDays
+
+	rules, err := parseAccelerationRules(getEnvOrDefault(envSchedulerAccelRules, ""))
+	if err != nil {
+		return nil, err
+	}
+	cfg.AccelerationRules = rules
+
+	if err := validateSchedulerConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// validateSchedulerConfig validates maintenance scheduler configuration values.
+func validateSchedulerConfig(cfg *config.SchedulerConfig) error {
+	if cfg == nil {
+		return fmt.Errorf("scheduler configuration cannot be nil")
+	}
+
+	if cfg.AIWorkerPoolSize < 1 || cfg.AIWorkerPoolSize > maxAIWorkerPoolSize {
+		return fmt.Errorf("AI worker pool size must be between 1 and %d", maxAIWorkerPoolSize)
+	}
+
+	if cfg.MaxBatchSize < 1 || cfg.MaxBatchSize > maxBatchSizeLimit {
+		return fmt.Errorf("max batch size must be between 1 and %d", maxBatchSizeLimit)
+	}
+
+	if cfg.ServeStaleReads && cfg.StaleReadTTL <= 0 {
+		return fmt.Errorf("stale read TTL must be positive when stale reads are enabled")
+	}
+
+	if cfg.MinNotificationGap < 0 {
+		return fmt.Errorf("minimum notification gap cannot be negative")
+	}
+
+	if cfg.MaxConcurrentCompletions < 1 || cfg.MaxConcurrentCompletions > maxConcurrentCompletions {
+		return fmt.Errorf("max concurrent completions must be between 1 and %d", maxConcurrentCompletions)
+	}
+
+	validPolicy := false
+	for _, policy := range validAIAmountPolicies {
+		if cfg.AIAmountPolicy == policy {
+			validPolicy = true
+			break
+		}
+	}
+	if !validPolicy {
+		return fmt.Errorf("invalid AI amount policy %q: must be one of %v", cfg.AIAmountPolicy, validAIAmountPolicies)
+	}
+
+	if cfg.MaxActiveNotificationsPerUser < 0 {
+		return fmt.Errorf("max active notifications per user cannot be negative")
+	}
+
+	validPolicy = false
+	for _, policy := range validNotificationLimitPolicies {
+		if cfg.NotificationLimitPolicy == policy {
+			validPolicy = true
+			break
+		}
+	}
+	if !validPolicy {
+		return fmt.Errorf("invalid notification limit policy %q: must be one of %v", cfg.NotificationLimitPolicy, validNotificationLimitPolicies)
+	}
+
+	for taskType, priority := range cfg.TaskTypePriorities {
+		if priority < 1 {
+			return fmt.Errorf("priority for task type %q must be at least 1", taskType)
+		}
+	}
+
+	validSource := false
+	for _, source := range validTimeSources {
+		if cfg.NotificationTimeSource == source {
+			validSource = true
+			break
+		}
+	}
+	if !validSource {
+		return fmt.Errorf("invalid notification time source %q: must be one of %v", cfg.NotificationTimeSource, validTimeSources)
+	}
+
+	if cfg.ClockSkewTolerance < 0 {
+		return fmt.Errorf("clock skew tolerance cannot be negative")
+	}
+
+	if cfg.CompletionHistoryRetention < 0 {
+		return fmt.Errorf("completion history retention cannot be negative")
+	}
+	if cfg.CompletionHistoryRetention > 0 && cfg.HistoryPurgeInterval < minPurgeInterval {
+		return fmt.Errorf("history purge interval must be at least %s", minPurgeInterval)
+	}
+	if cfg.CompletionHistoryRetention > 0 && cfg.HistoryPurgeBatchSize < 1 {
+		return fmt.Errorf("history purge batch size must be at least 1")
+	}
+
+	for rate, days := range cfg.RepotDays {
+		validRate := false
+		for _, growthRate := range validGrowthRates {
+			if rate == growthRate {
+				validRate = true
+				break
+			}
+		}
+		if !validRate {
+			return fmt.Errorf("invalid re-pot growth rate %q: must be one of %v", rate, validGrowthRates)
+		}
+		if days < 1 {
+			return fmt.Errorf("re-pot days for %s growth must be at least 1", rate)
+		}
+	}
+
+	if cfg.MaxScheduleAcceleration < 0 {
+		return fmt.Errorf("maximum schedule acceleration cannot be negative")
+	}
+
+	for _, rule := range cfg.AccelerationRules {
+		if rule.Advance <= 0 {
+			return fmt.Errorf("acceleration for task type %q must be positive", rule.TaskType)
+		}
+	}
+
+	return nil
+}
+
+// parseDefaultFrequencies parses per-task-type frequency overrides written as
+// comma-separated TaskType=Frequency pairs, e.g. "Water=Twice-Daily,Fertilizer=Bi-weekly".
+// Task types and frequencies are checked by the scheduler.
+func parseDefaultFrequencies(value string) (map[string]string, error) {
+	frequencies := make(map[string]string)
+	for _, entry := range splitList(value) {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("invalid default frequency %q: must be TaskType=Frequency", entry)
+		}
+		frequencies[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return frequencies, nil
+}
+
+// parseTaskTypePriorities parses per-task-type notification priorities written as
+// comma-separated TaskType=Priority pairs, e.g. "Pest Control=5,Water=3".
+func parseTaskTypePriorities(value string) (map[string]int, error) {
+	priorities := make(map[string]int)
+	for _, entry := range splitList(value) {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid task type priority %q: must be TaskType=Priority", entry)
+		}
+		priority, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid task type priority %q: %w", entry, err)
+		}
+		priorities[strings.TrimSpace(parts[0])] = priority
+	}
+	return priorities, nil
+}
+
+// parseRepotDays parses per-growth-rate re-pot lead times written as comma-separated
+// GrowthRate=Days pairs, e.g. "fast=21,slow=60".
+func parseRepotDays(value string) (map[string]int, error) {
+	repotDays := make(map[string]int)
+	for _, entry := range splitList(value) {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid re-pot days %q: must be GrowthRate=Days", entry)
+		}
+		days, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid re-pot days %q: %w", entry, err)
+		}
+		repotDays[strings.ToLower(strings.TrimSpace(parts[0]))] = days
+	}
+	return repotDays, nil
+}
+
+// parseAccelerationRules parses schedule acceleration rules written as comma-separated
+// TaskType:factor>threshold=advance or TaskType:factor<threshold=advance entries, e.g.
+// "Water:temperature>30=6h,Water:humidity<40=3h". Task types and factors are checked by
+// the scheduler.
+func parseAccelerationRules(value string) ([]config.ScheduleAccelerationRule, error) {
+	entries := splitList(value)
+	rules := make([]config.ScheduleAccelerationRule, 0, len(entries))
+	for _, entry := range entries {
+		invalid := fmt.Errorf("invalid acceleration rule %q: must be TaskType:factor>threshold=advance", entry)
+
+		taskType, condition, ok := strings.Cut(entry, ":")
+		if !ok || strings.TrimSpace(taskType) == "" {
+			return nil, invalid
+		}
+		condition, advanceValue, ok := strings.Cut(condition, "=")
+		if !ok {
+			return nil, invalid
+		}
+
+		comparison, separator := "above", ">"
+		if !strings.Contains(condition, ">") {
+			comparison, separator = "below", "<"
+		}
+		factor, thresholdValue, ok := strings.Cut(condition, separator)
+		if !ok || strings.TrimSpace(factor) == "" {
+			return nil, invalid
+		}
+
+		threshold, err := strconv.ParseFloat(strings.TrimSpace(thresholdValue), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid acceleration rule %q: %w", entry, err)
+		}
+		advance, err := time.ParseDuration(strings.TrimSpace(advanceValue))
+		if err != nil {
+			return nil, fmt.Errorf("invalid acceleration rule %q: %w", entry, err)
+		}
+
+		rules = append(rules, config.ScheduleAccelerationRule{
+			TaskType:   strings.TrimSpace(taskType),
+			Factor:     strings.TrimSpace(factor),
+			Comparison: comparison,
+			Threshold:  threshold,
+			Advance:    advance,
+		})
+	}
+	return rules, nil
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/urban-gardening/backend/pkg/types/config"
+)
+
+// ServerTLSConfig returns the TLS configuration for an API server, or nil when TLS is
+// disabled. The certificate and key are loaded here so that a missing or mismatched pair
+// fails at startup instead of on the first handshake.
+func ServerTLSConfig(cfg *config.APIConfig) (*tls.Config, error) {
+	if cfg == nil || !cfg.EnableTLS {
+		return nil, nil
+	}
+
+	if cfg.TLSCertPath == "" || cfg.TLSKeyPath == "" {
+		return nil, fmt.Errorf("API TLS is enabled but %s and %s must both be set", envAPITLSCertPath, envAPITLSKeyPath)
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.TLSCertPath, cfg.TLSKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API TLS certificate or key: %w", err)
+	}
+
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}, nil
+}
+
+// ListenAndServe listens on srv.Addr and serves HTTPS when srv.TLSConfig is set, or
+// plaintext HTTP otherwise.
+func ListenAndServe(srv *http.Server) error {
+	ln, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		return err
+	}
+	return Serve(srv, ln)
+}
+
+// Serve serves srv on ln over HTTPS when srv.TLSConfig is set, or plaintext HTTP
+// otherwise. The certificates come from srv.TLSConfig, see ServerTLSConfig.
+func Serve(srv *http.Server, ln net.Listener) error {
+	if srv.TLSConfig != nil {
+		return srv.ServeTLS(ln, "", "")
+	}
+	return srv.Serve(ln)
+}
@@ -0,0 +1,70 @@
+package ai
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// charsPerToken approximates how many characters of English text make up one token
+const charsPerToken = 4
+
+// EstimateTokens returns a rough token count for text, rounding up
+func EstimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + charsPerToken - 1) / charsPerToken
+}
+
+// PromptBudget bounds the estimated size of prompts built from caller-supplied conditions
+type PromptBudget struct {
+	MaxTokens int  // Maximum estimated prompt tokens
+	Truncate  bool // Drop conditions to fit instead of rejecting
+}
+
+// Fit builds a prompt from conditions and checks it against the budget. Oversized prompts
+// are rejected with ErrPromptTooLarge unless truncation is enabled, in which case the
+// largest conditions are dropped until the prompt fits. A prompt that cannot fit with at
+// least one condition remaining is always rejected.
+func (b PromptBudget) Fit(conditions map[string]string, build func(map[string]string) string) (string, error) {
+	prompt := build(conditions)
+	estimate := EstimateTokens(prompt)
+	if b.MaxTokens <= 0 || estimate <= b.MaxTokens {
+		return prompt, nil
+	}
+
+	if !b.Truncate {
+		return "", fmt.Errorf("%w: estimated %d tokens, limit is %d", ErrPromptTooLarge, estimate, b.MaxTokens)
+	}
+
+	// Drop the largest conditions first so the most entries survive
+	keys := make([]string, 0, len(conditions))
+	for key := range conditions {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		sizeI := len(keys[i]) + len(conditions[keys[i]])
+		sizeJ := len(keys[j]) + len(conditions[keys[j]])
+		if sizeI != sizeJ {
+			return sizeI > sizeJ
+		}
+		return keys[i] < keys[j]
+	})
+
+	remaining := make(map[string]string, len(conditions))
+	for key, value := range conditions {
+		remaining[key] = value
+	}
+
+	for _, key := range keys {
+		delete(remaining, key)
+		if len(remaining) == 0 {
+			break
+		}
+		prompt = build(remaining)
+		if EstimateTokens(prompt) <= b.MaxTokens {
+			return prompt, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: estimated %d tokens, limit is %d even after truncation",
+		ErrPromptTooLarge, estimate, b.MaxTokens)
+}
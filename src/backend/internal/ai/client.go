@@ -13,6 +13,9 @@ import (
 
 	"github.com/sashabaranov/go-openai" // v1.17.9
 	"github.com/patrickmn/go-cache" // v2.1.0
+	"github.com/sony/gobreaker"     // v2.0.0
+	"github.com/urban-gardening/backend/internal/utils/breaker"
+	"github.com/urban-gardening/backend/pkg/dto"
 	"github.com/urban-gardening/backend/pkg/types"
 )
 
@@ -25,6 +28,14 @@ var (
 	baseDelay = time.Duration(100 * time.Millisecond)
 	// Maximum jitter for retry delays
 	maxJitter = time.Duration(50 * time.Millisecond)
+	// Model used for completions unless the growing environment overrides it
+	completionModel = openai.GPT3Dot5Turbo
+	// Timeout for runtime health check calls
+	healthCheckTimeout = time.Duration(10 * time.Second)
+	// Consecutive failed completion calls that open the circuit breaker
+	breakerFailureThreshold = uint32(5)
+	// Time the circuit breaker stays open before letting a trial call through
+	breakerOpenTimeout = time.Duration(30 * time.Second)
 
 	// Error definitions
 	ErrInvalidConfig = errors.New("invalid configuration")
@@ -32,16 +43,26 @@ var (
 	ErrTimeout = errors.New("operation timeout")
 	ErrRateLimited = errors.New("rate limit exceeded")
 	ErrInvalidInput = errors.New("invalid input parameters")
+	ErrPromptTooLarge = errors.New("prompt exceeds token budget")
 )
 
 // AIClient handles interactions with OpenAI API for gardening recommendations
 type AIClient struct {
-	client        *openai.Client
+	client        CompletionClient
 	config        *types.ServiceConfig
 	timeout       time.Duration
 	rateLimiter   sync.Mutex
 	responseCache *cache.Cache
 	lastRequest   time.Time
+	budget        PromptBudget
+	limits        types.AIConfig
+	spend         *SpendTracker
+	// retryableStatus holds the API statuses treated as transient
+	retryableStatus map[int]bool
+	// breaker stops calls to the API while it is failing
+	breaker *gobreaker.CircuitBreaker
+	// prompts shares one upstream call among identical prompts
+	prompts *promptDeduper
 }
 
 // NewAIClient creates a new instance of AIClient with validation
@@ -55,14 +76,6 @@ func NewAIClient(cfg *types.ServiceConfig, apiKey string) (*AIClient, error) {
 	}
 
 	client := openai.NewClient(apiKey)
-	
-	ai := &AIClient{
-		client:        client,
-		config:        cfg,
-		timeout:       defaultTimeout,
-		responseCache: cache.New(1*time.Hour, 2*time.Hour),
-		lastRequest:   time.Now(),
-	}
 
 	// Verify client connectivity
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -73,7 +86,52 @@ func NewAIClient(cfg *types.ServiceConfig, apiKey string) (*AIClient, error) {
 		return nil, fmt.Errorf("failed to verify API connectivity: %w", err)
 	}
 
-	return ai, nil
+	return NewAIClientWithCompletionClient(cfg, client)
+}
+
+// NewAIClientWithCompletionClient creates an AIClient that sends completions through
+// client, without verifying connectivity
+func NewAIClientWithCompletionClient(cfg *types.ServiceConfig, client CompletionClient) (*AIClient, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("%w: config is nil", ErrInvalidConfig)
+	}
+	if client == nil {
+		return nil, fmt.Errorf("%w: completion client is nil", ErrInvalidConfig)
+	}
+
+	limits := types.DefaultAIConfig()
+	if cfg.AI != nil {
+		limits = *cfg.AI
+	}
+	if err := validateEnvironmentSettings(limits.EnvironmentSettings); err != nil {
+		return nil, err
+	}
+
+	a := &AIClient{
+		client:          client,
+		config:          cfg,
+		timeout:         defaultTimeout,
+		responseCache:   cache.New(1*time.Hour, 2*time.Hour),
+		lastRequest:     time.Now(),
+		budget:          PromptBudget{MaxTokens: limits.MaxPromptTokens, Truncate: limits.TruncateOversizedPrompts},
+		limits:          limits,
+		retryableStatus: statusCodeSet(limits.RetryableStatusCodes),
+		prompts:         newPromptDeduper(limits.DuplicatePromptWindow, defaultTimeout),
+	}
+	a.breaker = breaker.New(gobreaker.Settings{
+		Name:    "ai",
+		Timeout: breakerOpenTimeout,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= breakerFailureThreshold
+		},
+		// Only transient errors mean the API is failing; a rejected request such as a
+		// bad key or prompt would fail the same way against a healthy API
+		IsSuccessful: func(err error) bool {
+			return err == nil || !a.isRetryable(err)
+		},
+	})
+
+	return a, nil
 }
 
 // GetGardeningRecommendations retrieves AI-powered gardening recommendations
@@ -83,13 +141,19 @@ func (a *AIClient) GetGardeningRecommendations(ctx context.Context, plantType st
 	}
 
 	cacheKey := fmt.Sprintf("rec_%s_%v", plantType, conditions)
-	if cached, found := a.responseCache.Get(cacheKey); found {
+	if cached, found := a.responseCache.Get(cacheKey); found && !RefreshRequested(ctx) {
 		return cached.([]string), nil
 	}
 
-	prompt := a.buildRecommendationPrompt(plantType, conditions)
+	settings := a.settingsFor(conditions)
+	prompt, err := a.budget.Fit(conditions, func(c map[string]string) string {
+		return a.buildRecommendationPrompt(plantType, c) + settings.guidance
+	})
+	if err != nil {
+		return nil, err
+	}
 	
-	completion, err := a.makeAPICallWithRetry(ctx, prompt)
+	completion, err := a.makeAPICallWithRetry(ctx, prompt, a.limits.RecommendationMaxTokens, settings)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get recommendations: %w", err)
 	}
@@ -110,13 +174,19 @@ func (a *AIClient) GetMaintenanceSchedule(ctx context.Context, gardenConditions
 	}
 
 	cacheKey := fmt.Sprintf("schedule_%v_%v", gardenConditions, plantTypes)
-	if cached, found := a.responseCache.Get(cacheKey); found {
+	if cached, found := a.responseCache.Get(cacheKey); found && !RefreshRequested(ctx) {
 		return cached.(map[string]interface{}), nil
 	}
 
-	prompt := a.buildSchedulePrompt(gardenConditions, plantTypes)
+	settings := a.settingsFor(gardenConditions)
+	prompt, err := a.budget.Fit(gardenConditions, func(c map[string]string) string {
+		return a.buildSchedulePrompt(c, plantTypes) + settings.guidance
+	})
+	if err != nil {
+		return nil, err
+	}
 	
-	completion, err := a.makeAPICallWithRetry(ctx, prompt)
+	completion, err := a.makeAPICallWithRetry(ctx, prompt, a.limits.ScheduleMaxTokens, settings)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate schedule: %w", err)
 	}
@@ -126,12 +196,103 @@ func (a *AIClient) GetMaintenanceSchedule(ctx context.Context, gardenConditions
 		return nil, fmt.Errorf("failed to parse schedule: %w", err)
 	}
 
+	if a.limits.RefineSchedules {
+		schedule = a.refineSchedule(ctx, schedule, gardenConditions, plantTypes, settings)
+	}
+
 	a.responseCache.Set(cacheKey, schedule, cache.DefaultExpiration)
 	return schedule, nil
 }
 
-// makeAPICallWithRetry implements exponential backoff retry mechanism
-func (a *AIClient) makeAPICallWithRetry(ctx context.Context, prompt string) (string, error) {
+// refineSchedule feeds a generated schedule back to the AI with the garden conditions and
+// returns the refined schedule. The draft already passed validation, so it is kept when
+// the refining pass fails or returns an invalid schedule.
+func (a *AIClient) refineSchedule(ctx context.Context, draft map[string]interface{}, gardenConditions map[string]string, plantTypes []string, settings completionSettings) map[string]interface{} {
+	draftJSON, err := json.Marshal(draft)
+	if err != nil {
+		return draft
+	}
+
+	prompt, err := a.budget.Fit(gardenConditions, func(c map[string]string) string {
+		return a.buildRefinementPrompt(string(draftJSON), c, plantTypes) + settings.guidance
+	})
+	if err != nil {
+		return draft
+	}
+
+	completion, err := a.makeAPICallWithRetry(ctx, prompt, a.limits.ScheduleMaxTokens, settings)
+	if err != nil {
+		return draft
+	}
+
+	refined, err := a.parseAndValidateSchedule(completion)
+	if err != nil {
+		return draft
+	}
+	return refined
+}
+
+// SuggestCrops asks the AI for crop names suited to the given garden conditions, best first
+func (a *AIClient) SuggestCrops(ctx context.Context, conditions map[string]string) ([]string, error) {
+	if len(conditions) == 0 {
+		return nil, ErrInvalidInput
+	}
+
+	cacheKey := fmt.Sprintf("crops_%v", conditions)
+	if cached, found := a.responseCache.Get(cacheKey); found && !RefreshRequested(ctx) {
+		return cached.([]string), nil
+	}
+
+	settings := a.settingsFor(conditions)
+	prompt, err := a.budget.Fit(conditions, func(c map[string]string) string {
+		return a.buildCropSuggestionPrompt(c) + settings.guidance
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	completion, err := a.makeAPICallWithRetry(ctx, prompt, a.limits.CropSuggestionMaxTokens, settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest crops: %w", err)
+	}
+
+	var crops []string
+	if err := json.Unmarshal([]byte(completion), &crops); err != nil {
+		return nil, fmt.Errorf("invalid crop suggestion format: %w", err)
+	}
+	if len(crops) == 0 {
+		return nil, errors.New("no crops suggested")
+	}
+
+	a.responseCache.Set(cacheKey, crops, cache.DefaultExpiration)
+	return crops, nil
+}
+
+// SetSpendTracker enables per-period AI spend tracking. Once the tracker's budget is
+// spent, calls fail with ErrBudgetExceeded without contacting the API.
+func (a *AIClient) SetSpendTracker(tracker *SpendTracker) {
+	a.spend = tracker
+}
+
+// makeAPICallWithRetry sends prompt upstream, capping the completion at maxTokens and
+// using the model and temperature from settings. Identical calls made while one is in
+// flight, or within the configured duplicate window of it succeeding, share its result.
+func (a *AIClient) makeAPICallWithRetry(ctx context.Context, prompt string, maxTokens int, settings completionSettings) (string, error) {
+	key := fmt.Sprintf("%s|%v|%d|%s", settings.model, settings.temperature, maxTokens, prompt)
+	return a.prompts.do(ctx, key, func(callCtx context.Context) (string, error) {
+		return a.callWithRetry(callCtx, prompt, maxTokens, settings)
+	})
+}
+
+// callWithRetry implements exponential backoff retry mechanism. Only transient failures
+// are retried; see isRetryable.
+func (a *AIClient) callWithRetry(ctx context.Context, prompt string, maxTokens int, settings completionSettings) (string, error) {
+	if a.spend != nil {
+		if err := a.spend.Allow(ctx); err != nil {
+			return "", err
+		}
+	}
+
 	var lastErr error
 	for attempt := 0; attempt < maxRetries; attempt++ {
 		select {
@@ -151,17 +312,31 @@ func (a *AIClient) makeAPICallWithRetry(ctx context.Context, prompt string) (str
 			a.lastRequest = time.Now()
 			a.rateLimiter.Unlock()
 
-			resp, err := a.client.CreateCompletion(ctx, openai.CompletionRequest{
-				Model:       openai.GPT3Dot5Turbo,
-				Prompt:      prompt,
-				MaxTokens:   500,
-				Temperature: 0.7,
+			// An open breaker fails the call immediately and is not retried
+			result, err := a.breaker.Execute(func() (interface{}, error) {
+				return a.client.CreateCompletion(ctx, openai.CompletionRequest{
+					Model:       settings.model,
+					Prompt:      prompt,
+					MaxTokens:   maxTokens,
+					Temperature: settings.temperature,
+				})
 			})
+			resp, _ := result.(openai.CompletionResponse)
 
 			if err == nil && len(resp.Choices) > 0 {
+				a.recordSpend(ctx, prompt, resp)
 				return resp.Choices[0].Text, nil
 			}
+			if err == nil {
+				err = errEmptyCompletion
+			}
 
+			if ctx.Err() != nil {
+				return "", ctx.Err()
+			}
+			if !a.isRetryable(err) {
+				return "", fmt.Errorf("non-retryable AI error: %w", err)
+			}
 			lastErr = err
 		}
 	}
@@ -169,6 +344,48 @@ func (a *AIClient) makeAPICallWithRetry(ctx context.Context, prompt string) (str
 	return "", fmt.Errorf("max retries exceeded: %w", lastErr)
 }
 
+// recordSpend adds a completion's token usage to the spend tracker, estimating it from
+// the text when the API does not report usage. Tracking failures never fail the call.
+func (a *AIClient) recordSpend(ctx context.Context, prompt string, resp openai.CompletionResponse) {
+	if a.spend == nil {
+		return
+	}
+
+	tokens := resp.Usage.TotalTokens
+	if tokens == 0 {
+		tokens = EstimateTokens(prompt) + EstimateTokens(resp.Choices[0].Text)
+	}
+	_, _ = a.spend.Record(ctx, tokens)
+}
+
+// CheckHealth performs a minimal completion call, without retries or caching, to
+// confirm the configured API key and model are usable
+func (a *AIClient) CheckHealth(ctx context.Context) *dto.AIHealthResponse {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := a.client.CreateCompletion(ctx, openai.CompletionRequest{
+		Model:     completionModel,
+		Prompt:    "ping",
+		MaxTokens: 1,
+	})
+
+	result := &dto.AIHealthResponse{
+		Model:     completionModel,
+		LatencyMs: time.Since(start).Milliseconds(),
+		Success:   err == nil && len(resp.Choices) > 0,
+		CheckedAt: time.Now(),
+	}
+	if err != nil {
+		result.Error = err.Error()
+	} else if !result.Success {
+		result.Error = "empty completion response"
+	}
+
+	return result
+}
+
 // calculateBackoff calculates exponential backoff with jitter
 func (a *AIClient) calculateBackoff(attempt int) time.Duration {
 	delay := baseDelay * time.Duration(1<<uint(attempt))
@@ -185,13 +402,39 @@ func (a *AIClient) buildRecommendationPrompt(plantType string, conditions map[st
 	)
 }
 
-// buildSchedulePrompt creates a structured prompt for maintenance scheduling
+// buildSchedulePrompt creates a structured prompt for maintenance scheduling. Organically
+// grown gardens are limited to organic fertilizers and pest controls.
 func (a *AIClient) buildSchedulePrompt(conditions map[string]string, plantTypes []string) string {
-	return fmt.Sprintf(
+	prompt := fmt.Sprintf(
 		"Create a detailed maintenance schedule for an urban garden with these plants: %v "+
 			"under these conditions: %v. Include watering, fertilizing, and general care tasks.",
 		plantTypes, conditions,
 	)
+	if conditions["growingMethod"] == dto.GrowingMethodOrganic {
+		prompt += " The garden is grown organically: recommend only organic fertilizers such as " +
+			"compost, worm castings, fish emulsion, or bone meal, and no synthetic fertilizers or pesticides."
+	}
+	return prompt
+}
+
+// buildRefinementPrompt creates a structured prompt asking the AI to review a draft schedule
+func (a *AIClient) buildRefinementPrompt(draft string, conditions map[string]string, plantTypes []string) string {
+	return fmt.Sprintf(
+		"Review this draft maintenance schedule for an urban garden with these plants: %v "+
+			"under these conditions: %v. Draft: %s. Correct any tasks, frequencies, or amounts "+
+			"that do not suit the conditions and respond only with the refined schedule as JSON "+
+			"with the same fields.",
+		plantTypes, conditions, draft,
+	)
+}
+
+// buildCropSuggestionPrompt creates a structured prompt for crop suggestions
+func (a *AIClient) buildCropSuggestionPrompt(conditions map[string]string) string {
+	return fmt.Sprintf(
+		"Suggest vegetable crops for an urban container garden with these conditions: %v. "+
+			"Respond only with a JSON array of crop names ordered from most to least suitable.",
+		conditions,
+	)
 }
 
 // parseAndValidateRecommendations processes and validates AI recommendations
@@ -203,11 +446,12 @@ func (a *AIClient) parseAndValidateRecommendations(completion string) ([]string,
 		recommendations = a.splitAndCleanRecommendations(completion)
 	}
 
+	recommendations = a.validateRecommendations(recommendations)
 	if len(recommendations) == 0 {
 		return nil, errors.New("no valid recommendations generated")
 	}
 
-	return a.validateRecommendations(recommendations), nil
+	return recommendations, nil
 }
 
 // parseAndValidateSchedule processes and validates maintenance schedule
@@ -229,7 +473,7 @@ func (a *AIClient) parseAndValidateSchedule(completion string) (map[string]inter
 func (a *AIClient) validateRecommendations(recommendations []string) []string {
 	validated := make([]string, 0, len(recommendations))
 	for _, rec := range recommendations {
-		if len(rec) > 10 && len(rec) < 500 {
+		if len(rec) > minRecommendationLength && len(rec) < maxRecommendationLength {
 			validated = append(validated, rec)
 		}
 	}
@@ -247,9 +491,7 @@ func (a *AIClient) validateSchedule(schedule map[string]interface{}) error {
 	return nil
 }
 
-// splitAndCleanRecommendations splits text into individual recommendations
+// splitAndCleanRecommendations splits non-JSON text into individual recommendations
 func (a *AIClient) splitAndCleanRecommendations(text string) []string {
-	// Implementation for splitting and cleaning text recommendations
-	// This would include logic to split on common delimiters and clean the resulting strings
-	return nil // Placeholder
+	return SplitRecommendations(text)
 }
\ No newline at end of file
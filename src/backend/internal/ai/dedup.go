@@ -0,0 +1,102 @@
+package ai
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// promptCall is one upstream completion shared by every identical prompt made while it
+// runs, and for the duplicate window after it succeeds
+type promptCall struct {
+	done     chan struct{}
+	text     string
+	err      error
+	finished time.Time
+}
+
+// promptDeduper makes identical prompts share a single upstream call. Concurrent
+// duplicates always wait for the call in flight; duplicates arriving within window of a
+// successful call reuse its completion, covering the gap before callers cache it.
+type promptDeduper struct {
+	mu      sync.Mutex
+	window  time.Duration
+	timeout time.Duration
+	calls   map[string]*promptCall
+}
+
+// newPromptDeduper creates a deduper sharing successful completions for window, and
+// bounding each shared call by timeout
+func newPromptDeduper(window, timeout time.Duration) *promptDeduper {
+	return &promptDeduper{
+		window:  window,
+		timeout: timeout,
+		calls:   make(map[string]*promptCall),
+	}
+}
+
+// do returns the completion for key, calling fn only when no identical call is in flight
+// or recently succeeded. Refresh requests join a call in flight but do not reuse one
+// that has already finished. fn runs on a context detached from every caller's, bounded
+// by the deduper's timeout, so each caller, including the one that started the call,
+// stops waiting only when its own context ends and never fails the call for the others.
+func (d *promptDeduper) do(ctx context.Context, key string, fn func(context.Context) (string, error)) (string, error) {
+	d.mu.Lock()
+	if call, ok := d.calls[key]; ok {
+		inFlight := call.finished.IsZero()
+		if inFlight || (!RefreshRequested(ctx) && time.Since(call.finished) < d.window) {
+			d.mu.Unlock()
+			return call.wait(ctx)
+		}
+	}
+
+	d.pruneLocked()
+	call := &promptCall{done: make(chan struct{})}
+	d.calls[key] = call
+	d.mu.Unlock()
+
+	go d.run(context.WithoutCancel(ctx), key, call, fn)
+	return call.wait(ctx)
+}
+
+// run makes the shared call for key and records its result for every waiter
+func (d *promptDeduper) run(ctx context.Context, key string, call *promptCall, fn func(context.Context) (string, error)) {
+	if d.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.timeout)
+		defer cancel()
+	}
+
+	text, err := fn(ctx)
+
+	d.mu.Lock()
+	call.text, call.err = text, err
+	call.finished = time.Now()
+	// Failures are not shared past the call, so the next duplicate tries again
+	if err != nil || d.window <= 0 {
+		if d.calls[key] == call {
+			delete(d.calls, key)
+		}
+	}
+	d.mu.Unlock()
+	close(call.done)
+}
+
+// pruneLocked drops finished calls whose duplicate window has passed. d.mu must be held.
+func (d *promptDeduper) pruneLocked() {
+	for key, call := range d.calls {
+		if !call.finished.IsZero() && time.Since(call.finished) >= d.window {
+			delete(d.calls, key)
+		}
+	}
+}
+
+// wait blocks until the call finishes or ctx ends
+func (c *promptCall) wait(ctx context.Context) (string, error) {
+	select {
+	case <-c.done:
+		return c.text, c.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
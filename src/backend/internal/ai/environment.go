@@ -0,0 +1,72 @@
+package ai
+
+import (
+	"fmt"
+
+	"github.com/urban-gardening/backend/pkg/types"
+)
+
+// environmentConditionKey is the condition naming the growing environment that selects
+// per-environment completion settings
+const environmentConditionKey = "growingEnvironment"
+
+// defaultTemperature is the sampling temperature used when no environment overrides it
+const defaultTemperature = float32(0.7)
+
+// Prompt variants adding environment-specific guidance to prompts
+const (
+	PromptVariantStandard          = "standard"           // No additional guidance
+	PromptVariantClimateControlled = "climate_controlled" // Humidity, ventilation, and heat management
+	PromptVariantLowLight          = "low_light"          // Grow lights, airflow, and reduced watering
+)
+
+// promptVariantGuidance is the text appended to prompts for each prompt variant
+var promptVariantGuidance = map[string]string{
+	PromptVariantStandard: "",
+	PromptVariantClimateControlled: " The plants grow in a climate-controlled structure: account for high humidity, " +
+		"ventilation to prevent mildew, and heat build-up on sunny days.",
+	PromptVariantLowLight: " The plants grow indoors with limited natural light: account for supplemental grow " +
+		"lights, gentle airflow, and slower evaporation when sizing watering amounts.",
+}
+
+// completionSettings are the model, temperature, and prompt guidance used for one completion
+type completionSettings struct {
+	model       string
+	temperature float32
+	guidance    string
+}
+
+// defaultCompletionSettings are used for requests without a configured growing environment
+var defaultCompletionSettings = completionSettings{
+	model:       completionModel,
+	temperature: defaultTemperature,
+}
+
+// validateEnvironmentSettings rejects per-environment settings with an unknown prompt variant
+func validateEnvironmentSettings(settings map[string]types.AIEnvironmentSettings) error {
+	for environment, s := range settings {
+		if _, ok := promptVariantGuidance[s.PromptVariant]; s.PromptVariant != "" && !ok {
+			return fmt.Errorf("%w: unknown prompt variant %q for %s", ErrInvalidConfig, s.PromptVariant, environment)
+		}
+	}
+	return nil
+}
+
+// settingsFor returns the completion settings for the growing environment named in
+// conditions, filling anything the environment does not override from the defaults
+func (a *AIClient) settingsFor(conditions map[string]string) completionSettings {
+	settings := defaultCompletionSettings
+	override, ok := a.limits.EnvironmentSettings[conditions[environmentConditionKey]]
+	if !ok {
+		return settings
+	}
+
+	if override.Model != "" {
+		settings.model = override.Model
+	}
+	if override.Temperature != nil {
+		settings.temperature = *override.Temperature
+	}
+	settings.guidance = promptVariantGuidance[override.PromptVariant]
+	return settings
+}
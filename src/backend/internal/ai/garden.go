@@ -0,0 +1,74 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/patrickmn/go-cache" // v2.1.0
+)
+
+// GetGardenRecommendations retrieves gardening recommendations for a whole garden in a
+// single completion call. cropConditions holds each crop's own conditions keyed by crop
+// name and is sent alongside the garden-wide conditions, so the recommendations cover
+// every crop at the cost of one call.
+func (a *AIClient) GetGardenRecommendations(ctx context.Context, gardenConditions map[string]string, cropConditions map[string]map[string]string) ([]string, error) {
+	if gardenConditions == nil || len(cropConditions) == 0 {
+		return nil, ErrInvalidInput
+	}
+
+	cropSummary := summarizeCropConditions(cropConditions)
+	cacheKey := fmt.Sprintf("garden_rec_%v_%s", gardenConditions, cropSummary)
+	if cached, found := a.responseCache.Get(cacheKey); found && !RefreshRequested(ctx) {
+		return cached.([]string), nil
+	}
+
+	settings := a.settingsFor(gardenConditions)
+	prompt, err := a.budget.Fit(gardenConditions, func(c map[string]string) string {
+		return a.buildGardenRecommendationPrompt(c, cropSummary) + settings.guidance
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	completion, err := a.makeAPICallWithRetry(ctx, prompt, a.limits.RecommendationMaxTokens, settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get garden recommendations: %w", err)
+	}
+
+	recommendations, err := a.parseAndValidateRecommendations(completion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse garden recommendations: %w", err)
+	}
+
+	a.responseCache.Set(cacheKey, recommendations, cache.DefaultExpiration)
+	return recommendations, nil
+}
+
+// buildGardenRecommendationPrompt creates a structured prompt for garden-wide recommendations
+func (a *AIClient) buildGardenRecommendationPrompt(conditions map[string]string, cropSummary string) string {
+	return fmt.Sprintf(
+		"Provide consolidated gardening recommendations for an urban container garden under "+
+			"these conditions: %v. The garden grows these crops: %s. Give one combined set of "+
+			"practical, actionable tips covering all of the crops, noting which crops a tip "+
+			"applies to when it does not apply to all of them.",
+		conditions, cropSummary,
+	)
+}
+
+// summarizeCropConditions renders each crop with its conditions in name order, so the
+// prompt and cache key do not depend on map iteration order
+func summarizeCropConditions(cropConditions map[string]map[string]string) string {
+	names := make([]string, 0, len(cropConditions))
+	for name := range cropConditions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s %v", name, cropConditions[name]))
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,50 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urban-gardening/backend/pkg/dto"
+	"github.com/urban-gardening/backend/pkg/types"
+)
+
+// Supported AI providers, selected by AIConfig.Provider
+const (
+	ProviderOpenAI = "openai"
+	ProviderStub   = "stub"
+)
+
+// AIProvider generates gardening recommendations and maintenance schedules. AIClient is
+// the OpenAI implementation; other models can be used by implementing this interface.
+type AIProvider interface {
+	// GetGardeningRecommendations returns recommendations for plantType under conditions
+	GetGardeningRecommendations(ctx context.Context, plantType string, conditions map[string]string) ([]string, error)
+
+	// GetMaintenanceSchedule returns a schedule for plantTypes with at least the tasks,
+	// frequency, and duration fields
+	GetMaintenanceSchedule(ctx context.Context, gardenConditions map[string]string, plantTypes []string) (map[string]interface{}, error)
+
+	// CheckHealth reports whether the provider is currently usable
+	CheckHealth(ctx context.Context) *dto.AIHealthResponse
+}
+
+// NewProvider creates the AI provider named by the configuration, defaulting to OpenAI.
+// apiKey is only used by providers that call an external API.
+func NewProvider(cfg *types.ServiceConfig, apiKey string) (AIProvider, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("%w: config is nil", ErrInvalidConfig)
+	}
+
+	provider := ProviderOpenAI
+	if cfg.AI != nil && cfg.AI.Provider != "" {
+		provider = cfg.AI.Provider
+	}
+
+	switch provider {
+	case ProviderOpenAI:
+		return NewAIClient(cfg, apiKey)
+	case ProviderStub:
+		return NewStubProvider(), nil
+	}
+	return nil, fmt.Errorf("%w: unknown AI provider %q", ErrInvalidConfig, provider)
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"sync"
 	"time"
+
+	"github.com/urban-gardening/backend/pkg/dto"
 )
 
 var (
@@ -36,14 +38,15 @@ Focus on container gardening with practical maintenance advice.`
 
 // RecommendationService handles AI-powered gardening recommendations with caching
 type RecommendationService struct {
-	client              *AIClient
+	client              AIProvider
 	timeout             time.Duration
 	recommendationCache sync.Map
 	maxRetries         int
 }
 
-// NewRecommendationService creates a new instance of RecommendationService
-func NewRecommendationService(client *AIClient, timeout time.Duration) (*RecommendationService, error) {
+// NewRecommendationService creates a new instance of RecommendationService backed by
+// the given AI provider
+func NewRecommendationService(client AIProvider, timeout time.Duration) (*RecommendationService, error) {
 	if client == nil {
 		return nil, ErrNilClient
 	}
@@ -65,9 +68,9 @@ func (s *RecommendationService) GetPlantRecommendations(ctx context.Context, pla
 		return nil, ErrInvalidPlantType
 	}
 
-	// Check cache first
+	// Check cache first unless the caller asked for a fresh answer
 	cacheKey := fmt.Sprintf("%s_%v_%s_%s", plantType, space, soilType, sunlight)
-	if cached, ok := s.recommendationCache.Load(cacheKey); ok {
+	if cached, ok := s.recommendationCache.Load(cacheKey); ok && !RefreshRequested(ctx) {
 		return cached.([]string), nil
 	}
 
@@ -90,6 +93,9 @@ func (s *RecommendationService) GetPlantRecommendations(ctx context.Context, pla
 		if err == nil {
 			break
 		}
+		if errors.Is(err, ErrBudgetExceeded) {
+			return nil, err
+		}
 
 		if attempt < s.maxRetries-1 {
 			time.Sleep(retryBackoff)
@@ -125,6 +131,9 @@ func (s *RecommendationService) GenerateMaintenanceSchedule(ctx context.Context,
 		if err == nil {
 			break
 		}
+		if errors.Is(err, ErrBudgetExceeded) {
+			return nil, err
+		}
 
 		if attempt < s.maxRetries-1 {
 			time.Sleep(retryBackoff)
@@ -141,6 +150,11 @@ func (s *RecommendationService) GenerateMaintenanceSchedule(ctx context.Context,
 	return schedule, nil
 }
 
+// CheckAIHealth verifies AI connectivity and configuration with a minimal call
+func (s *RecommendationService) CheckAIHealth(ctx context.Context) *dto.AIHealthResponse {
+	return s.client.CheckHealth(ctx)
+}
+
 // validateAndEnhanceSchedule ensures schedule completeness and adds seasonal adjustments
 func (s *RecommendationService) validateAndEnhanceSchedule(schedule map[string]interface{}) error {
 	requiredFields := []string{"tasks", "frequency", "duration"}
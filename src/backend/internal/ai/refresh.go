@@ -0,0 +1,18 @@
+package ai
+
+import "context"
+
+// refreshKey marks a context whose AI lookups must not be served from cache
+type refreshKey struct{}
+
+// WithRefresh returns a context that makes AI lookups skip cached responses. The
+// fresh results are still cached for later requests.
+func WithRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, refreshKey{}, true)
+}
+
+// RefreshRequested reports whether ctx asks for fresh AI results instead of cached ones
+func RefreshRequested(ctx context.Context) bool {
+	refresh, _ := ctx.Value(refreshKey{}).(bool)
+	return refresh
+}
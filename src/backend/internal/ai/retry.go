@@ -0,0 +1,55 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/sashabaranov/go-openai" // v1.17.9
+
+	"github.com/urban-gardening/backend/pkg/types"
+)
+
+// errEmptyCompletion is returned when the API succeeds without any completion choices
+var errEmptyCompletion = errors.New("empty completion response")
+
+// CompletionClient creates text completions. *openai.Client satisfies it; tests and
+// alternative transports may supply their own.
+type CompletionClient interface {
+	CreateCompletion(ctx context.Context, request openai.CompletionRequest) (openai.CompletionResponse, error)
+}
+
+// statusCodeSet builds the lookup of retryable statuses, falling back to the defaults
+func statusCodeSet(codes []int) map[int]bool {
+	if len(codes) == 0 {
+		codes = types.DefaultAIConfig().RetryableStatusCodes
+	}
+	set := make(map[int]bool, len(codes))
+	for _, code := range codes {
+		set[code] = true
+	}
+	return set
+}
+
+// isRetryable reports whether a failed completion call may succeed if repeated. API
+// errors are retried only for the configured transient statuses, so an invalid key or
+// request fails fast. Network failures and timeouts are retried; errors raised before
+// sending, such as an unsupported model or an open circuit breaker, are not.
+func (a *AIClient) isRetryable(err error) bool {
+	if errors.Is(err, errEmptyCompletion) {
+		return true
+	}
+
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		return a.retryableStatus[apiErr.HTTPStatusCode]
+	}
+
+	var reqErr *openai.RequestError
+	if errors.As(err, &reqErr) {
+		return a.retryableStatus[reqErr.HTTPStatusCode]
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded)
+}
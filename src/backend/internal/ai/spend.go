@@ -0,0 +1,117 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8" // v8.11.5
+
+	"github.com/urban-gardening/backend/internal/utils/clock"
+)
+
+// ErrBudgetExceeded is returned instead of calling the AI once the period's spend
+// has reached the configured budget. Callers fall back to rule-based results.
+var ErrBudgetExceeded = errors.New("AI spend budget exceeded for the current period")
+
+// spendKeyPrefix namespaces the per-period spend totals in Redis
+const spendKeyPrefix = "ai_spend:"
+
+// spendRetention keeps a finished period's total around for reporting after it ends
+const spendRetention = 31 * 24 * time.Hour
+
+// SpendTracker accumulates estimated AI cost per calendar month (UTC) in Redis, so the
+// budget is shared by every service instance and resets when a new month begins
+type SpendTracker struct {
+	redisClient *redis.Client
+	budget      float64 // Maximum spend per period; 0 disables the limit
+	costPer1K   float64 // Estimated cost per 1,000 tokens
+	clock       clock.Clock
+}
+
+// NewSpendTracker creates a tracker enforcing budget per month at costPer1K per 1,000 tokens
+func NewSpendTracker(redisClient *redis.Client, budget, costPer1K float64) (*SpendTracker, error) {
+	if redisClient == nil {
+		return nil, errors.New("redis client is required")
+	}
+	if budget < 0 || costPer1K < 0 {
+		return nil, fmt.Errorf("%w: budget and cost must not be negative", ErrInvalidConfig)
+	}
+
+	return &SpendTracker{
+		redisClient: redisClient,
+		budget:      budget,
+		costPer1K:   costPer1K,
+		clock:       clock.Real(),
+	}, nil
+}
+
+// SetClock replaces the time source used to pick the billing period
+func (t *SpendTracker) SetClock(c clock.Clock) {
+	t.clock = clock.OrReal(c)
+}
+
+// Allow returns ErrBudgetExceeded when the current period's spend has reached the budget
+func (t *SpendTracker) Allow(ctx context.Context) error {
+	if t.budget <= 0 {
+		return nil
+	}
+
+	spent, err := t.Spent(ctx)
+	if err != nil {
+		return err
+	}
+	if spent >= t.budget {
+		return fmt.Errorf("%w: spent %.4f of %.4f", ErrBudgetExceeded, spent, t.budget)
+	}
+	return nil
+}
+
+// Record adds the estimated cost of tokens to the current period and returns the new total
+func (t *SpendTracker) Record(ctx context.Context, tokens int) (float64, error) {
+	if tokens <= 0 {
+		return t.Spent(ctx)
+	}
+
+	now := t.clock.Now().UTC()
+	key := spendKey(now)
+	cost := float64(tokens) / 1000 * t.costPer1K
+
+	pipe := t.redisClient.TxPipeline()
+	total := pipe.IncrByFloat(ctx, key, cost)
+	pipe.ExpireAt(ctx, key, periodEnd(now).Add(spendRetention))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to record AI spend: %w", err)
+	}
+
+	return total.Val(), nil
+}
+
+// Spent returns the estimated spend so far in the current period
+func (t *SpendTracker) Spent(ctx context.Context) (float64, error) {
+	value, err := t.redisClient.Get(ctx, spendKey(t.clock.Now().UTC())).Result()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read AI spend: %w", err)
+	}
+
+	spent, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid AI spend value %q: %w", value, err)
+	}
+	return spent, nil
+}
+
+// spendKey returns the Redis key holding the spend for the month containing now
+func spendKey(now time.Time) string {
+	return spendKeyPrefix + now.Format("2006-01")
+}
+
+// periodEnd returns the start of the month after now
+func periodEnd(now time.Time) time.Time {
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 0)
+}
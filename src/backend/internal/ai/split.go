@@ -0,0 +1,84 @@
+package ai
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Exclusive length limits for a single recommendation; shorter text is usually a heading
+// or list fragment, longer text is an essay rather than an actionable tip
+const (
+	minRecommendationLength = 10
+	maxRecommendationLength = 500
+)
+
+var (
+	// listMarker matches a leading bullet or number such as "1.", "2)", "(3)", "-", "*", or "•"
+	listMarker = regexp.MustCompile(`^(?:\(?\d+[.)]|[-*•+])\s+`)
+	// inlineListMarker matches a number starting a new item inside a single line of text
+	inlineListMarker = regexp.MustCompile(`\s\(?\d+[.)]\s+`)
+	// whitespaceRun matches runs of whitespace collapsed to a single space
+	whitespaceRun = regexp.MustCompile(`\s+`)
+)
+
+// SplitRecommendations splits free-form AI text into individual recommendations. It
+// understands numbered and bulleted lists, including items wrapped over several lines,
+// and plain one-per-line text. Introductory lines ending in a colon, markdown emphasis,
+// and items outside the recommendation length limits are dropped.
+func SplitRecommendations(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+
+	// A single line may still hold an inline numbered list, e.g. "1. Water daily 2. Mulch"
+	if len(lines) == 1 {
+		lines = strings.Split(inlineListMarker.ReplaceAllStringFunc(lines[0], func(marker string) string {
+			return "\n" + strings.TrimSpace(marker) + " "
+		}), "\n")
+	}
+
+	hasMarkers := false
+	for _, line := range lines {
+		if listMarker.MatchString(strings.TrimSpace(line)) {
+			hasMarkers = true
+			break
+		}
+	}
+
+	var items []string
+	inItem := false
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			inItem = false
+		case listMarker.MatchString(line):
+			items = append(items, listMarker.ReplaceAllString(line, ""))
+			inItem = true
+		case hasMarkers && inItem:
+			// Continuation of a list item wrapped onto the next line
+			items[len(items)-1] += " " + line
+		default:
+			items = append(items, line)
+		}
+	}
+
+	recommendations := make([]string, 0, len(items))
+	for _, item := range items {
+		item = cleanRecommendation(item)
+		if strings.HasSuffix(item, ":") {
+			continue
+		}
+		if len(item) <= minRecommendationLength || len(item) >= maxRecommendationLength {
+			continue
+		}
+		recommendations = append(recommendations, item)
+	}
+	return recommendations
+}
+
+// cleanRecommendation strips markdown emphasis and quotes and collapses whitespace
+func cleanRecommendation(item string) string {
+	item = strings.NewReplacer("**", "", "__", "", "`", "").Replace(item)
+	item = whitespaceRun.ReplaceAllString(item, " ")
+	return strings.Trim(item, " \"'")
+}
@@ -0,0 +1,64 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/urban-gardening/backend/pkg/dto"
+)
+
+// stubModel is reported as the model by StubProvider health checks
+const stubModel = "stub"
+
+// StubProvider is an AIProvider that answers from fixed templates without calling any
+// external API. It keeps the service usable in development and when no model is
+// available, at the cost of generic advice.
+type StubProvider struct{}
+
+// NewStubProvider creates a StubProvider
+func NewStubProvider() *StubProvider {
+	return &StubProvider{}
+}
+
+// GetGardeningRecommendations returns general container gardening recommendations for plantType
+func (p *StubProvider) GetGardeningRecommendations(ctx context.Context, plantType string, conditions map[string]string) ([]string, error) {
+	if plantType == "" || conditions == nil {
+		return nil, ErrInvalidInput
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return []string{
+		fmt.Sprintf("Water %s when the top inch of soil in its grow bag is dry", plantType),
+		fmt.Sprintf("Feed %s with a balanced fertilizer every two weeks during active growth", plantType),
+		fmt.Sprintf("Check %s weekly for pests on the undersides of leaves", plantType),
+	}, nil
+}
+
+// GetMaintenanceSchedule returns a daily watering and weekly feeding schedule for plantTypes
+func (p *StubProvider) GetMaintenanceSchedule(ctx context.Context, gardenConditions map[string]string, plantTypes []string) (map[string]interface{}, error) {
+	if len(plantTypes) == 0 || gardenConditions == nil {
+		return nil, ErrInvalidInput
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"tasks":     append([]string(nil), plantTypes...),
+		"frequency": dto.FrequencyDaily,
+		"duration":  "15min",
+		"timing":    "morning",
+	}, nil
+}
+
+// CheckHealth always reports the stub as healthy, since it has no dependencies
+func (p *StubProvider) CheckHealth(ctx context.Context) *dto.AIHealthResponse {
+	return &dto.AIHealthResponse{
+		Model:     stubModel,
+		Success:   true,
+		CheckedAt: time.Now(),
+	}
+}
@@ -10,6 +10,12 @@ import (
 	"github.com/urban-gardening-assistant/src/backend/pkg/types/common"
 )
 
+// maxComparedBagSizes bounds the number of bag sizes compared in one call
+const maxComparedBagSizes = 10
+
+// inchesPerFoot converts bag sizes, quoted in inches, to the feet used for layouts
+const inchesPerFoot = 12.0
+
 var (
 	ErrInvalidContext     = errors.New("invalid context")
 	ErrInvalidConverter   = errors.New("invalid unit converter")
@@ -21,6 +27,7 @@ type CalculatorService struct {
 	ctx           context.Context
 	unitConverter common.UnitConverter
 	cache         map[string]interface{}
+	allowNonViable bool // Return warned layouts when none meets the accessibility minimum
 	mu           sync.RWMutex
 }
 
@@ -41,6 +48,15 @@ func NewCalculatorService(ctx context.Context, converter common.UnitConverter) (
 	}, nil
 }
 
+// SetAllowNonViableLayouts configures whether layout planning returns the most accessible
+// layout with a ViabilityWarning, rather than an error, when no layout meets the
+// accessibility minimum
+func (s *CalculatorService) SetAllowNonViableLayouts(allow bool) {
+	s.mu.Lock()
+	s.allowNonViable = allow
+	s.mu.Unlock()
+}
+
 // CalculateGardenSpace calculates usable garden space with unit conversion
 func (s *CalculatorService) CalculateGardenSpace(dims common.Dimensions, unit string) (float64, error) {
 	// Generate cache key
@@ -81,13 +97,29 @@ func (s *CalculatorService) CalculateGardenSpace(dims common.Dimensions, unit st
 	return usableArea, nil
 }
 
-// PlanGrowBagLayout plans optimal grow bag layout with accessibility scoring
+// PlanGrowBagLayout plans optimal grow bag layout with accessibility scoring. The bag
+// diameter and the layout's spacing and positions are in the garden's unit.
 func (s *CalculatorService) PlanGrowBagLayout(dims common.Dimensions, bagDiameter float64, prioritizeAccess bool) (*GrowBagLayout, error) {
+	return s.PlanGrowBagLayoutIn(dims, bagDiameter, prioritizeAccess, dims.Unit)
+}
+
+// PlanGrowBagLayoutIn plans optimal grow bag layout like PlanGrowBagLayout, reporting
+// the layout's spacing and positions in unit, e.g. meters for a metric user
+func (s *CalculatorService) PlanGrowBagLayoutIn(dims common.Dimensions, bagDiameter float64, prioritizeAccess bool, unit string) (*GrowBagLayout, error) {
 	// Validate dimensions
 	if err := ValidateGardenDimensions(&dims); err != nil {
 		return nil, fmt.Errorf("dimension validation failed: %w", err)
 	}
 
+	// Layouts are planned in feet
+	if dims.Unit != "" && dims.Unit != "feet" {
+		feet, err := convertLength(bagDiameter, dims.Unit, "feet", s.unitConverter)
+		if err != nil {
+			return nil, fmt.Errorf("unit conversion failed: %w", err)
+		}
+		bagDiameter = feet
+	}
+
 	// Configure optimization parameters
 	config := OptimizationConfig{
 		IncludeCornerSpaces:  !prioritizeAccess,
@@ -95,6 +127,9 @@ func (s *CalculatorService) PlanGrowBagLayout(dims common.Dimensions, bagDiamete
 		PreferredOrientation: "horizontal",
 		SpacingMultiplier:    1.0,
 	}
+	s.mu.RLock()
+	config.AllowNonViable = s.allowNonViable
+	s.mu.RUnlock()
 
 	// Adjust configuration based on accessibility priority
 	if prioritizeAccess {
@@ -114,7 +149,84 @@ func (s *CalculatorService) PlanGrowBagLayout(dims common.Dimensions, bagDiamete
 		return nil, fmt.Errorf("space utilization below target: %.2f%%", metrics.UtilizationRate*100)
 	}
 
-	return layout, nil
+	if unit == "" {
+		return layout, nil
+	}
+	converted, err := layout.InUnit(unit, s.unitConverter)
+	if err != nil {
+		return nil, fmt.Errorf("unit conversion failed: %w", err)
+	}
+
+	return converted, nil
+}
+
+// PlanPathArea reports how much of a garden maintenance paths take up, with the area and
+// ratio left for growing
+func (s *CalculatorService) PlanPathArea(dims common.Dimensions, includeCornerSpaces bool) (*PathAreaMetrics, error) {
+	// Validate dimensions
+	if err := ValidateGardenDimensions(&dims); err != nil {
+		return nil, fmt.Errorf("dimension validation failed: %w", err)
+	}
+
+	metrics, err := CalculatePathArea(dims, includeCornerSpaces)
+	if err != nil {
+		return nil, fmt.Errorf("path area calculation failed: %w", err)
+	}
+
+	return metrics, nil
+}
+
+// CompareLayouts plans the standard layout for each grow bag size, given as diameters in
+// inches, so sizes can be compared side by side. Results follow the order of bagSizes; a
+// size with no layout meeting the accessibility minimum is reported as not viable, with
+// its most accessible layout when non-viable layouts are allowed.
+func (s *CalculatorService) CompareLayouts(ctx context.Context, dims common.Dimensions, bagSizes []float64) ([]LayoutComparison, error) {
+	if len(bagSizes) == 0 {
+		return nil, errors.New("at least one bag size is required")
+	}
+	if len(bagSizes) > maxComparedBagSizes {
+		return nil, fmt.Errorf("cannot compare more than %d bag sizes", maxComparedBagSizes)
+	}
+	for _, size := range bagSizes {
+		if size <= 0 {
+			return nil, fmt.Errorf("bag size must be positive, got %v", size)
+		}
+	}
+
+	// Validate dimensions
+	if err := ValidateGardenDimensions(&dims); err != nil {
+		return nil, fmt.Errorf("dimension validation failed: %w", err)
+	}
+
+	config := OptimizationConfig{
+		IncludeCornerSpaces:  true,
+		MinPathWidth:         MinimumPathWidth,
+		PreferredOrientation: "horizontal",
+		SpacingMultiplier:    1.0,
+	}
+	s.mu.RLock()
+	config.AllowNonViable = s.allowNonViable
+	s.mu.RUnlock()
+
+	comparisons := make([]LayoutComparison, 0, len(bagSizes))
+	for _, size := range bagSizes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		comparison := LayoutComparison{BagSize: size}
+		layout, err := OptimizeGrowBagLayout(dims, size/inchesPerFoot, config)
+		if err == nil {
+			comparison.Viable = layout.ViabilityWarning == ""
+			comparison.ViabilityWarning = layout.ViabilityWarning
+			comparison.Capacity = layout.Rows * layout.Columns
+			comparison.SpaceUtilization = layout.SpaceUtilization
+			comparison.AccessibilityScore = layout.AccessibilityScore
+		}
+		comparisons = append(comparisons, comparison)
+	}
+
+	return comparisons, nil
 }
 
 // ValidateGrowBagPlan validates grow bag plan with capacity analysis
@@ -3,7 +3,9 @@ package calculator
 
 import (
 	"errors"
+	"fmt"
 	"math"
+	"sort"
 
 	"github.com/urban-gardening-assistant/backend/pkg/constants/garden"
 	"github.com/urban-gardening-assistant/backend/pkg/types/common"
@@ -11,11 +13,15 @@ import (
 
 // Constants for space calculation and optimization
 const (
-	DefaultGrowBagSpacing = 0.5  // Minimum spacing between grow bags in feet
-	MinimumPathWidth      = 1.0  // Minimum path width for maintenance access in feet
-	MaxSpaceUtilization   = 0.95 // Maximum space utilization factor
+	DefaultGrowBagSpacing   = 0.5  // Minimum spacing between grow bags in feet
+	MinimumPathWidth        = 1.0  // Minimum path width for maintenance access in feet
+	MaxSpaceUtilization     = 0.95 // Maximum space utilization factor
+	DefaultMinAccessibility = 0.8  // Minimum accessibility score for an acceptable layout
 )
 
+// feetPerMeter converts metric garden dimensions to the feet used for layouts
+const feetPerMeter = 3.28084
+
 // Point represents a 2D coordinate for grow bag positioning
 type Point struct {
 	X float64
@@ -28,6 +34,8 @@ type OptimizationConfig struct {
 	MinPathWidth         float64 // Minimum width for maintenance paths
 	PreferredOrientation string  // "horizontal" or "vertical" layout preference
 	SpacingMultiplier    float64 // Multiplier for default spacing (1.0 = default)
+	MinAccessibility     float64 // Minimum accessibility score to accept a layout (0 = DefaultMinAccessibility)
+	AllowNonViable       bool    // Return the most accessible layout with a warning when none meets MinAccessibility
 }
 
 // GrowBagLayout represents an optimized arrangement of grow bags
@@ -39,6 +47,29 @@ type GrowBagLayout struct {
 	SpaceUtilization  float64   // Percentage of space utilized
 	AccessibilityScore float64   // Score representing maintenance accessibility
 	OptimizedPositions []Point  // Optimized positions for grow bags
+	Unit              string    // Unit of the spacing and positions, "feet" or "meters"
+	ViabilityWarning  string    // Why the layout falls short of the accessibility minimum; empty when viable
+}
+
+// BagGroup is a set of identical grow bags to place in a mixed layout
+type BagGroup struct {
+	Label    string  // Identifies the group in placements, e.g. a crop ID
+	Diameter float64 // Bag diameter in feet
+	Count    int     // Number of bags in the group
+}
+
+// BagPlacement is the position of a single grow bag in a mixed layout
+type BagPlacement struct {
+	Label    string  // Label of the group the bag belongs to
+	Diameter float64 // Bag diameter in feet
+	Position Point   // Centre of the bag
+}
+
+// MixedLayout represents an arrangement of grow bags of differing sizes
+type MixedLayout struct {
+	Placements       []BagPlacement // Positions of every bag that fits
+	Unplaced         map[string]int // Bags per group label that did not fit
+	SpaceUtilization float64        // Fraction of the garden area covered by bags
 }
 
 // LayoutMetrics contains comprehensive metrics for layout evaluation
@@ -50,86 +81,207 @@ type LayoutMetrics struct {
 	AccessibilityRate float64 // Accessibility rating
 }
 
-// CalculateUsableArea calculates the optimized usable growing area
-func CalculateUsableArea(dims common.Dimensions, includeCornerSpaces bool) (float64, error) {
+// PathAreaMetrics describes how much of a garden maintenance paths take up, in square feet
+type PathAreaMetrics struct {
+	TotalArea  float64 // Total garden area
+	PathArea   float64 // Area taken by maintenance paths and corner spaces
+	UsableArea float64 // Area left for growing, before the space utilization factor
+	PathRatio  float64 // Fraction of the total area taken by paths (0-1)
+}
+
+// LayoutComparison summarises the best layout for one grow bag size when comparing sizes
+type LayoutComparison struct {
+	BagSize            float64 // Bag diameter in inches, as requested
+	Viable             bool    // Whether any layout met the accessibility minimum
+	Capacity           int     // Number of bags in the best layout
+	SpaceUtilization   float64 // Fraction of the garden area covered by bags
+	AccessibilityScore float64 // Maintenance accessibility of the best layout
+	ViabilityWarning   string  // Why the best layout falls short of the accessibility minimum, if it does
+}
+
+// CalculatePathArea calculates the space maintenance paths take up in a garden of the
+// given dimensions, for accessibility planning
+func CalculatePathArea(dims common.Dimensions, includeCornerSpaces bool) (*PathAreaMetrics, error) {
 	// Validate input dimensions
 	if err := common.ValidateDimensions(&dims); err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	// Convert to feet if dimensions are in meters
-	length := dims.Length
-	width := dims.Width
-	if dims.Unit == "meters" {
-		length *= 3.28084
-		width *= 3.28084
-	}
+	feet := dimensionsInFeet(dims)
+	length := feet.Length
+	width := feet.Width
 
 	// Validate against garden-specific constraints
 	totalArea := length * width
 	if totalArea < garden.MinGardenArea || totalArea > garden.MaxGardenArea {
-		return 0, errors.New("garden area outside acceptable range")
+		return nil, errors.New("garden area outside acceptable range")
 	}
 
 	// Calculate path requirements
 	pathArea := calculatePathArea(length, width, includeCornerSpaces)
-	usableArea := totalArea - pathArea
+
+	return &PathAreaMetrics{
+		TotalArea:  totalArea,
+		PathArea:   pathArea,
+		UsableArea: totalArea - pathArea,
+		PathRatio:  pathArea / totalArea,
+	}, nil
+}
+
+// CalculateUsableArea calculates the optimized usable growing area
+func CalculateUsableArea(dims common.Dimensions, includeCornerSpaces bool) (float64, error) {
+	metrics, err := CalculatePathArea(dims, includeCornerSpaces)
+	if err != nil {
+		return 0, err
+	}
 
 	// Apply space utilization factor
-	optimizedArea := usableArea * MaxSpaceUtilization
+	optimizedArea := metrics.UsableArea * MaxSpaceUtilization
 
 	return math.Floor(optimizedArea*100) / 100, nil
 }
 
-// OptimizeGrowBagLayout generates optimal grow bag arrangement
+// dimensionsInFeet returns garden dimensions converted to feet
+func dimensionsInFeet(dims common.Dimensions) common.Dimensions {
+	if dims.Unit == "meters" {
+		return common.Dimensions{Length: dims.Length * feetPerMeter, Width: dims.Width * feetPerMeter, Unit: "feet"}
+	}
+	return dims
+}
+
+// OptimizeGrowBagLayout generates optimal grow bag arrangement. The bag diameter is in
+// feet and the garden is laid out in feet whatever its unit; use InUnit to report the
+// layout in another unit. When no layout meets the accessibility minimum it fails, or,
+// with AllowNonViable set, returns the most accessible layout with a ViabilityWarning.
 func OptimizeGrowBagLayout(dims common.Dimensions, bagDiameter float64, config OptimizationConfig) (*GrowBagLayout, error) {
 	usableArea, err := CalculateUsableArea(dims, config.IncludeCornerSpaces)
 	if err != nil {
 		return nil, err
 	}
 
+	if config.MinAccessibility < 0 {
+		return nil, errors.New("minimum accessibility cannot be negative")
+	}
+
+	// Lower thresholds trade maintenance access for capacity
+	minAccessibility := config.MinAccessibility
+	if minAccessibility == 0 {
+		minAccessibility = DefaultMinAccessibility
+	}
+
 	// Calculate effective spacing
 	effectiveSpacing := DefaultGrowBagSpacing * config.SpacingMultiplier
 	
 	// Calculate maximum possible rows and columns
-	maxRows := int(dims.Length / (bagDiameter + effectiveSpacing))
-	maxCols := int(dims.Width / (bagDiameter + effectiveSpacing))
+	feet := dimensionsInFeet(dims)
+	maxRows := int(feet.Length / (bagDiameter + effectiveSpacing))
+	maxCols := int(feet.Width / (bagDiameter + effectiveSpacing))
 
-	// Initialize best layout
+	// Initialize best layout, and the best of those short of the accessibility minimum
 	bestLayout := &GrowBagLayout{
 		SpaceUtilization: 0,
 		AccessibilityScore: 0,
 	}
+	fallback := &GrowBagLayout{}
 
 	// Try different configurations to find optimal layout
 	for rows := 1; rows <= maxRows; rows++ {
 		for cols := 1; cols <= maxCols; cols++ {
-			layout := calculateLayoutMetrics(rows, cols, bagDiameter, effectiveSpacing, dims, config)
+			layout := calculateLayoutMetrics(rows, cols, bagDiameter, effectiveSpacing, feet, config)
 			if layout.SpaceUtilization > bestLayout.SpaceUtilization && 
-			   layout.AccessibilityScore >= 0.8 { // Ensure good accessibility
+			   layout.AccessibilityScore >= minAccessibility { // Ensure acceptable accessibility
 				bestLayout = layout
 			}
+			// Prefer the most accessible fallback, then the densest
+			if layout.AccessibilityScore < minAccessibility &&
+				(layout.AccessibilityScore > fallback.AccessibilityScore ||
+					(layout.AccessibilityScore == fallback.AccessibilityScore && layout.SpaceUtilization > fallback.SpaceUtilization)) {
+				fallback = layout
+			}
 		}
 	}
 
 	if bestLayout.SpaceUtilization == 0 {
-		return nil, errors.New("could not find viable layout configuration")
+		if !config.AllowNonViable || fallback.SpaceUtilization == 0 {
+			return nil, errors.New("could not find viable layout configuration")
+		}
+		fallback.ViabilityWarning = fmt.Sprintf("no layout meets the minimum accessibility of %.2f; the most accessible layout scores %.2f",
+			minAccessibility, fallback.AccessibilityScore)
+		return fallback, nil
 	}
 
 	return bestLayout, nil
 }
 
-// CalculateMetrics computes comprehensive layout metrics
+// CalculateMetrics computes comprehensive layout metrics, with areas in the square of
+// the layout's unit
 func (l *GrowBagLayout) CalculateMetrics() LayoutMetrics {
+	// Paths are planned in feet, so measure them in feet and scale back
+	scale := 1.0
+	if l.Unit == "meters" {
+		scale = feetPerMeter
+	}
+	pathArea := calculatePathArea(float64(l.Rows)*l.RowSpacing*scale, float64(l.Columns)*l.ColumnSpacing*scale, true)
+
 	return LayoutMetrics{
 		TotalBags:         l.Rows * l.Columns,
 		UsableArea:        float64(l.Rows*l.Columns) * l.RowSpacing * l.ColumnSpacing,
-		PathArea:          calculatePathArea(float64(l.Rows)*l.RowSpacing, float64(l.Columns)*l.ColumnSpacing, true),
+		PathArea:          pathArea / (scale * scale),
 		UtilizationRate:   l.SpaceUtilization,
 		AccessibilityRate: l.AccessibilityScore,
 	}
 }
 
+// InUnit returns a copy of the layout with its spacing and positions converted to unit.
+// Utilization and accessibility are ratios and carry over unchanged.
+func (l *GrowBagLayout) InUnit(unit string, converter common.UnitConverter) (*GrowBagLayout, error) {
+	converted := *l
+	converted.OptimizedPositions = append([]Point(nil), l.OptimizedPositions...)
+	if l.Unit == unit {
+		return &converted, nil
+	}
+
+	convert := func(value float64) (float64, error) {
+		return convertLength(value, l.Unit, unit, converter)
+	}
+
+	var err error
+	if converted.RowSpacing, err = convert(l.RowSpacing); err != nil {
+		return nil, err
+	}
+	if converted.ColumnSpacing, err = convert(l.ColumnSpacing); err != nil {
+		return nil, err
+	}
+	for i, position := range l.OptimizedPositions {
+		if converted.OptimizedPositions[i].X, err = convert(position.X); err != nil {
+			return nil, err
+		}
+		if converted.OptimizedPositions[i].Y, err = convert(position.Y); err != nil {
+			return nil, err
+		}
+	}
+	converted.Unit = unit
+
+	return &converted, nil
+}
+
+// convertLength converts a length between units, handling feet and meters directly and
+// deferring to converter for any other unit
+func convertLength(value float64, fromUnit, toUnit string, converter common.UnitConverter) (float64, error) {
+	switch {
+	case fromUnit == toUnit:
+		return value, nil
+	case fromUnit == "meters" && toUnit == "feet":
+		return value * feetPerMeter, nil
+	case fromUnit == "feet" && toUnit == "meters":
+		return value / feetPerMeter, nil
+	case converter == nil:
+		return 0, fmt.Errorf("a unit converter is required to convert %s to %s", fromUnit, toUnit)
+	}
+	return converter.Convert(value, fromUnit, toUnit)
+}
+
 // calculatePathArea determines required path space
 func calculatePathArea(length, width float64, includeCorners bool) float64 {
 	// Calculate main paths
@@ -152,6 +304,7 @@ func calculateLayoutMetrics(rows, cols int, bagDiameter, spacing float64, dims c
 		Columns:       cols,
 		RowSpacing:    bagDiameter + spacing,
 		ColumnSpacing: bagDiameter + spacing,
+		Unit:          "feet",
 	}
 
 	// Calculate positions
@@ -188,4 +341,71 @@ func calculatePathAccessibility(layout *GrowBagLayout, minPathWidth float64) flo
 	colAccessibility := math.Min(layout.ColumnSpacing/minPathWidth, 2.0)
 	
 	return (rowAccessibility + colAccessibility) / 2
-}
\ No newline at end of file
+}
+
+// OptimizeMixedLayout arranges grow bags of differing sizes in rows across the garden
+// width, largest bags first so rows stay as uniform as possible. Bags within a row are
+// separated by the configured spacing and rows by at least the minimum path width.
+// Bags that do not fit are reported per group in Unplaced rather than as an error.
+func OptimizeMixedLayout(dims common.Dimensions, groups []BagGroup, config OptimizationConfig) (*MixedLayout, error) {
+	if err := common.ValidateDimensions(&dims); err != nil {
+		return nil, err
+	}
+
+	spacingMultiplier := config.SpacingMultiplier
+	if spacingMultiplier <= 0 {
+		spacingMultiplier = 1.0
+	}
+	spacing := DefaultGrowBagSpacing * spacingMultiplier
+	rowGap := math.Max(spacing, config.MinPathWidth)
+
+	// Expand groups into individual bags, largest first
+	type bag struct {
+		label    string
+		diameter float64
+	}
+	var bags []bag
+	for _, group := range groups {
+		if group.Diameter <= 0 || group.Count < 0 {
+			return nil, errors.New("bag groups must have a positive diameter and non-negative count")
+		}
+		for i := 0; i < group.Count; i++ {
+			bags = append(bags, bag{label: group.Label, diameter: group.Diameter})
+		}
+	}
+	sort.SliceStable(bags, func(i, j int) bool {
+		return bags[i].diameter > bags[j].diameter
+	})
+
+	layout := &MixedLayout{
+		Placements: make([]BagPlacement, 0, len(bags)),
+		Unplaced:   make(map[string]int),
+	}
+
+	// Shelf packing: fill a row along the width, then start a new row further along the length
+	rowStart, rowDepth, cursor := 0.0, 0.0, 0.0
+	usedArea := 0.0
+	for _, b := range bags {
+		if cursor > 0 && cursor+b.diameter > dims.Width {
+			rowStart += rowDepth + rowGap
+			rowDepth, cursor = 0, 0
+		}
+		if b.diameter > dims.Width || rowStart+b.diameter > dims.Length {
+			layout.Unplaced[b.label]++
+			continue
+		}
+
+		layout.Placements = append(layout.Placements, BagPlacement{
+			Label:    b.label,
+			Diameter: b.diameter,
+			Position: Point{X: cursor + b.diameter/2, Y: rowStart + b.diameter/2},
+		})
+		usedArea += math.Pi * math.Pow(b.diameter/2, 2)
+		rowDepth = math.Max(rowDepth, b.diameter)
+		cursor += b.diameter + spacing
+	}
+
+	layout.SpaceUtilization = usedArea / (dims.Length * dims.Width)
+
+	return layout, nil
+}
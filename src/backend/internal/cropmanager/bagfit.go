@@ -0,0 +1,56 @@
+package cropmanager
+
+import (
+	"fmt"
+
+	"github.com/urban-gardening-assistant/backend/internal/models"
+	customErrors "github.com/urban-gardening-assistant/backend/internal/utils/errors"
+)
+
+// Policies for crops assigned a grow bag below their minimum size
+const (
+	BagFitWarn  = "warn"  // Accept with a warning and a reduced yield estimate
+	BagFitBlock = "block" // Reject the crop
+)
+
+// BagFitConfig controls how crops in grow bags too small for them are handled
+type BagFitConfig struct {
+	Policy string
+}
+
+// SetBagFitConfig configures how crops assigned a grow bag below their minimum size are
+// handled. They are accepted with a warning by default.
+func (s *CropService) SetBagFitConfig(cfg BagFitConfig) error {
+	switch cfg.Policy {
+	case BagFitWarn, BagFitBlock:
+	default:
+		return customErrors.NewError("VALIDATION_ERROR", "unknown undersized bag policy "+cfg.Policy)
+	}
+
+	s.mu.Lock()
+	s.bagFit = cfg
+	s.mu.Unlock()
+	return nil
+}
+
+// checkBagFit returns a warning when a crop's grow bag is below its minimum size, or ""
+// when the bag is large enough. Under the block policy an undersized bag is rejected.
+func (s *CropService) checkBagFit(crop *models.Crop) (string, error) {
+	factor := crop.UndersizedBagFactor()
+	if factor >= 1 {
+		return "", nil
+	}
+	minSize, _ := models.MinBagSize(crop.Name)
+
+	s.mu.RLock()
+	policy := s.bagFit.Policy
+	s.mu.RUnlock()
+
+	if policy == BagFitBlock {
+		return "", customErrors.NewError("BAG_TOO_SMALL", fmt.Sprintf(
+			"%s need grow bags of at least %s; a %s bag is too small", crop.Name, minSize, crop.BagSize))
+	}
+
+	return fmt.Sprintf("%s bags are below the %s minimum for %s; estimated yield is reduced to %.0f%%",
+		crop.BagSize, minSize, crop.Name, factor*100), nil
+}
@@ -0,0 +1,78 @@
+package cropmanager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urban-gardening-assistant/backend/internal/models"
+	customErrors "github.com/urban-gardening-assistant/backend/internal/utils/errors"
+)
+
+// Grow bag limit policies for requests above a crop's maximum for its garden
+const (
+	BagLimitSuggest = "suggest" // Reject with the largest count that fits
+	BagLimitClamp   = "clamp"   // Reduce the count to the largest that fits
+	BagLimitGlobal  = "global"  // Only enforce the global maximum
+)
+
+// BagLimitConfig controls how per-crop grow bag maximums are enforced. A crop's maximum
+// is the number of its bags, with canopy clearance, that fit in the garden's area.
+type BagLimitConfig struct {
+	Policy string
+}
+
+// SetBagLimitConfig configures how requests above a crop's maximum grow bags are handled.
+// Requests are rejected with a suggested count by default.
+func (s *CropService) SetBagLimitConfig(cfg BagLimitConfig) error {
+	switch cfg.Policy {
+	case BagLimitSuggest, BagLimitClamp, BagLimitGlobal:
+	default:
+		return customErrors.NewError("VALIDATION_ERROR", "unknown grow bag limit policy "+cfg.Policy)
+	}
+
+	s.mu.Lock()
+	s.bagLimits = cfg
+	s.mu.Unlock()
+	return nil
+}
+
+// applyGrowBagLimit holds a new crop to the number of its grow bags that fit the garden.
+// Under the clamp policy the crop's count is reduced and the requested count returned;
+// otherwise zero is returned.
+func (s *CropService) applyGrowBagLimit(ctx context.Context, crop *models.Crop) (int, error) {
+	s.mu.RLock()
+	policy := s.bagLimits.Policy
+	s.mu.RUnlock()
+
+	if policy == BagLimitGlobal {
+		return 0, nil
+	}
+
+	garden, err := s.getGarden(ctx, crop.GardenID)
+	if err != nil {
+		return 0, customErrors.WrapError(err, "failed to get garden")
+	}
+	gardenArea, err := garden.CalculateArea()
+	if err != nil {
+		return 0, customErrors.WrapError(err, "failed to calculate garden area")
+	}
+
+	maxBags := crop.MaxGrowBagsFor(gardenArea)
+	if crop.GrowBags <= maxBags {
+		return 0, nil
+	}
+	if maxBags < 1 {
+		return 0, customErrors.NewError("GROW_BAG_LIMIT", fmt.Sprintf(
+			"a single %s grow bag of %s does not fit a %.2f sq ft garden", crop.BagSize, crop.Name, gardenArea))
+	}
+
+	if policy == BagLimitClamp {
+		requested := crop.GrowBags
+		crop.GrowBags = maxBags
+		return requested, nil
+	}
+
+	return 0, customErrors.NewError("GROW_BAG_LIMIT", fmt.Sprintf(
+		"%d %s grow bags of %s exceed the %d that fit a %.2f sq ft garden. Try %d grow bags or a smaller bag size.",
+		crop.GrowBags, crop.BagSize, crop.Name, maxBags, gardenArea, maxBags))
+}
@@ -192,8 +192,8 @@ func calculateEstimatedYield(crop *models.Crop) float64 {
 		"Eggplant": 0.225,  // 200-250g per day
 	}
 
-	// Get base yield or use default
-	yield := baseYield[crop.Name]
+	// Get base yield or use default, matching names case-insensitively and by synonym
+	yield := baseYield[dto.NormalizeCropName(crop.Name)]
 	if yield == 0 {
 		yield = 0.150 // Default conservative estimate
 	}
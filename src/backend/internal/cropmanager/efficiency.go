@@ -0,0 +1,192 @@
+package cropmanager
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/urban-gardening-assistant/backend/internal/calculator"
+	"github.com/urban-gardening-assistant/backend/internal/models"
+	"github.com/urban-gardening-assistant/backend/pkg/dto"
+	"github.com/urban-gardening-assistant/backend/pkg/types/common"
+	customErrors "github.com/urban-gardening-assistant/backend/internal/utils/errors"
+)
+
+// Weighting of each part of the layout efficiency score
+const (
+	spaceEfficiencyWeight      = 0.4
+	accessEfficiencyWeight     = 0.3
+	compatibleEfficiencyWeight = 0.3
+)
+
+// neutralSunScore is the sunlight suitability assumed for crops without a profile
+const neutralSunScore = 0.5
+
+// companionEffects adjusts compatibility for pairs of crops sharing a garden. Shade-tolerant
+// greens benefit from taller fruiting crops; crops of the same family share pests and blight.
+var companionEffects = map[[2]string]float64{
+	{dto.CropLettuce, dto.CropTomatoes}:  0.1,
+	{dto.CropLettuce, dto.CropPeppers}:   0.1,
+	{dto.CropSpinach, dto.CropEggplant}:  0.1,
+	{dto.CropEggplant, dto.CropTomatoes}: -0.2,
+	{dto.CropEggplant, dto.CropPeppers}:  -0.1,
+}
+
+// LayoutEfficiencyScore rates how good a garden's layout is on a 0-100 scale, combining
+// three parts that are each scored 0-100:
+//   - space utilization: area covered by the laid-out bags relative to the densest layout
+//     of the garden's most common bag size
+//   - accessibility: share of the crops' bags placed facing a maintenance path; bags that
+//     do not fit count as inaccessible
+//   - compatibility: how well the crops suit the garden's sunlight, adjusted for
+//     companion crops that help or hinder each other
+//
+// A garden without crops scores 0. The layout is computed but not stored.
+func (s *CropService) LayoutEfficiencyScore(ctx context.Context, gardenID string) (*dto.LayoutEfficiencyResponse, error) {
+	if err := s.acquire(); err != nil {
+		return nil, err
+	}
+	defer s.release()
+
+	layout, garden, crops, err := s.layoutGarden(ctx, gardenID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.LayoutEfficiencyResponse{
+		GardenID:   gardenID,
+		ComputedAt: time.Now(),
+	}
+	if layout.RequiredBags == 0 {
+		return response, nil
+	}
+
+	space, err := spaceEfficiency(garden, crops, layout)
+	if err != nil {
+		return nil, err
+	}
+	breakdown := dto.LayoutEfficiencyBreakdown{
+		SpaceUtilization: roundScore(space * 100),
+		Accessibility:    roundScore(accessEfficiency(garden, layout) * 100),
+		Compatibility:    roundScore(compatibleEfficiency(garden, crops) * 100),
+	}
+	response.Breakdown = breakdown
+	response.Score = roundScore(breakdown.SpaceUtilization*spaceEfficiencyWeight +
+		breakdown.Accessibility*accessEfficiencyWeight +
+		breakdown.Compatibility*compatibleEfficiencyWeight)
+
+	return response, nil
+}
+
+// spaceEfficiency compares the layout's space utilization with the densest layout of
+// the garden's most common bag size, capped at 1
+func spaceEfficiency(garden *models.Garden, crops []models.Crop, layout *dto.GardenLayoutResponse) (float64, error) {
+	bagsBySize := make(map[string]int)
+	for i := range crops {
+		bagsBySize[crops[i].BagSize] += crops[i].GrowBags
+	}
+	commonSize := ""
+	for size, count := range bagsBySize {
+		if count > bagsBySize[commonSize] || (count == bagsBySize[commonSize] && size < commonSize) {
+			commonSize = size
+		}
+	}
+	diameter := bagDiameters[commonSize]
+
+	// Offer more bags than could ever fit so the optimizer fills the garden
+	capacity := int(garden.Length/diameter+1) * int(garden.Width/diameter+1)
+	dims := common.Dimensions{Length: garden.Length, Width: garden.Width, Unit: "feet"}
+	densest, err := calculator.OptimizeMixedLayout(dims, []calculator.BagGroup{
+		{Label: commonSize, Diameter: diameter, Count: capacity},
+	}, calculator.OptimizationConfig{
+		MinPathWidth:      calculator.MinimumPathWidth,
+		SpacingMultiplier: 1.0,
+	})
+	if err != nil {
+		return 0, customErrors.WrapError(err, "failed to optimize garden layout")
+	}
+	if densest.SpaceUtilization <= 0 {
+		return 0, nil
+	}
+
+	return math.Min(layout.SpaceUtilization/densest.SpaceUtilization, 1), nil
+}
+
+// accessEfficiency returns the share of required bags placed in a row that faces a
+// maintenance path. Rows are separated by paths at least the minimum path width wide,
+// so only a lone row filling the garden's length lacks one.
+func accessEfficiency(garden *models.Garden, layout *dto.GardenLayoutResponse) float64 {
+	type row struct {
+		start, depth float64
+		bags         int
+	}
+	rowsByStart := make(map[float64]*row)
+	for _, placement := range layout.Placements {
+		diameter := bagDiameters[placement.BagSize]
+		start := math.Round((placement.Y-diameter/2)*1000) / 1000
+		r, ok := rowsByStart[start]
+		if !ok {
+			r = &row{start: start}
+			rowsByStart[start] = r
+		}
+		r.depth = math.Max(r.depth, diameter)
+		r.bags++
+	}
+
+	rows := make([]*row, 0, len(rowsByStart))
+	for _, r := range rowsByStart {
+		rows = append(rows, r)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].start < rows[j].start })
+
+	accessible := 0
+	for i, r := range rows {
+		pathBefore := i > 0 || r.start >= calculator.MinimumPathWidth
+		pathAfter := i < len(rows)-1 || garden.Length-(r.start+r.depth) >= calculator.MinimumPathWidth
+		if pathBefore || pathAfter {
+			accessible += r.bags
+		}
+	}
+
+	return float64(accessible) / float64(layout.RequiredBags)
+}
+
+// compatibleEfficiency averages the crops' sunlight suitability for the garden, weighted
+// by grow bags, then applies companion effects between the distinct crops, capped to 0-1
+func compatibleEfficiency(garden *models.Garden, crops []models.Crop) float64 {
+	profiles := make(map[string]cropProfile, len(cropCatalog))
+	for _, profile := range cropCatalog {
+		profiles[profile.name] = profile
+	}
+
+	sunTotal, bags := 0.0, 0
+	names := make(map[string]bool)
+	for i := range crops {
+		name := dto.NormalizeCropName(crops[i].Name)
+		sun := neutralSunScore
+		if profile, ok := profiles[name]; ok {
+			sun = profile.sunlight[garden.Sunlight]
+		}
+		sunTotal += sun * float64(crops[i].GrowBags)
+		bags += crops[i].GrowBags
+		names[name] = true
+	}
+	if bags == 0 {
+		return 0
+	}
+
+	compatibility := sunTotal / float64(bags)
+	for pair, effect := range companionEffects {
+		if names[pair[0]] && names[pair[1]] {
+			compatibility += effect
+		}
+	}
+
+	return math.Max(0, math.Min(compatibility, 1))
+}
+
+// roundScore rounds a score to one decimal place
+func roundScore(score float64) float64 {
+	return math.Round(score*10) / 10
+}
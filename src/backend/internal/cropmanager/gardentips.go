@@ -0,0 +1,154 @@
+package cropmanager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap" // v1.24.0
+
+	"github.com/urban-gardening-assistant/backend/internal/models"
+	"github.com/urban-gardening-assistant/backend/pkg/dto"
+	customErrors "github.com/urban-gardening-assistant/backend/internal/utils/errors"
+)
+
+// generalGardenTips are given with rule-based garden recommendations whatever the crops
+var generalGardenTips = []string{
+	"Water grow bags when the top inch of soil is dry, checking daily in hot weather",
+	"Top up grow bags with compost every few weeks to replace nutrients lost to watering",
+}
+
+// GardenAdvisor gives consolidated recommendations for all of a garden's crops in one
+// call. cropConditions holds each crop's conditions keyed by crop name.
+type GardenAdvisor interface {
+	GetGardenRecommendations(ctx context.Context, gardenConditions map[string]string, cropConditions map[string]map[string]string) ([]string, error)
+}
+
+// SetGardenAdvisor configures the AI advisor used by GardenRecommendations. Without
+// one, garden recommendations are purely rule-based.
+func (s *CropService) SetGardenAdvisor(advisor GardenAdvisor) {
+	s.mu.Lock()
+	s.gardenAdvisor = advisor
+	s.mu.Unlock()
+}
+
+// GardenRecommendations returns one consolidated set of tips for all of a garden's
+// crops. The conditions of every crop are sent to the AI advisor in a single call; when
+// no advisor is configured or it fails, tips are derived from the crop catalog instead.
+func (s *CropService) GardenRecommendations(ctx context.Context, gardenID string) (*dto.GardenRecommendationsResponse, error) {
+	if err := s.acquire(); err != nil {
+		return nil, err
+	}
+	defer s.release()
+
+	garden, err := s.getGarden(ctx, gardenID)
+	if err != nil {
+		return nil, customErrors.WrapError(err, "failed to get garden")
+	}
+
+	var crops []models.Crop
+	if err := s.db.WithContext(ctx).
+		Where("garden_id = ? AND deleted_at IS NULL", gardenID).
+		Order("created_at ASC").
+		Find(&crops).Error; err != nil {
+		return nil, customErrors.WrapError(err, "failed to get garden crops")
+	}
+
+	response := &dto.GardenRecommendationsResponse{
+		GardenID: gardenID,
+		Source:   dto.RecommendationSourceRules,
+		Crops:    make([]string, 0, len(crops)),
+	}
+	for _, crop := range crops {
+		response.Crops = append(response.Crops, crop.Name)
+	}
+
+	if tips := s.adviseGarden(ctx, garden, crops); len(tips) > 0 {
+		response.Source = dto.RecommendationSourceAI
+		response.Recommendations = tips
+	} else {
+		response.Recommendations = ruleBasedGardenTips(garden, crops)
+	}
+
+	return response, nil
+}
+
+// adviseGarden asks the garden advisor for tips covering every crop at once. It returns
+// nil when no advisor is set, the garden has no crops, or the advisor fails.
+func (s *CropService) adviseGarden(ctx context.Context, garden *models.Garden, crops []models.Crop) []string {
+	s.mu.RLock()
+	advisor := s.gardenAdvisor
+	s.mu.RUnlock()
+
+	if advisor == nil || len(crops) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, advisorTimeout)
+	defer cancel()
+
+	// Crops sharing a name are grouped, adding up their grow bags
+	cropConditions := make(map[string]map[string]string, len(crops))
+	bags := make(map[string]int, len(crops))
+	for _, crop := range crops {
+		method := dto.GrowingMethodConventional
+		if crop.Organic {
+			method = dto.GrowingMethodOrganic
+		}
+		bags[crop.Name] += crop.GrowBags
+		cropConditions[crop.Name] = map[string]string{
+			"bagSize":       crop.BagSize,
+			"growBags":      fmt.Sprintf("%d", bags[crop.Name]),
+			"growingMethod": method,
+		}
+	}
+
+	tips, err := advisor.GetGardenRecommendations(ctx, map[string]string{
+		"soilType":   garden.SoilType,
+		"sunlight":   garden.Sunlight,
+		"dimensions": fmt.Sprintf("%.1f x %.1f ft", garden.Length, garden.Width),
+	}, cropConditions)
+	if err != nil {
+		s.logger.Warn("garden advisor failed, falling back to rule-based recommendations",
+			zap.String("gardenId", garden.ID),
+			zap.Error(err))
+		return nil
+	}
+
+	return tips
+}
+
+// ruleBasedGardenTips flags crops the garden's sunlight or soil does not suit well,
+// followed by general container gardening tips
+func ruleBasedGardenTips(garden *models.Garden, crops []models.Crop) []string {
+	if len(crops) == 0 {
+		return []string{"Add crops to this garden to get recommendations tailored to them"}
+	}
+
+	sunlight := strings.ReplaceAll(garden.Sunlight, "_", " ")
+	soil := strings.ReplaceAll(garden.SoilType, "_", " ")
+
+	var tips []string
+	seen := make(map[string]bool, len(crops))
+	for _, crop := range crops {
+		name := dto.NormalizeCropName(crop.Name)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		for _, profile := range cropCatalog {
+			if profile.name != name {
+				continue
+			}
+			if profile.sunlight[garden.Sunlight] < goodFitScore {
+				tips = append(tips, fmt.Sprintf("%s may struggle in %s; move its grow bags to the best-lit spot available", name, sunlight))
+			}
+			if profile.soils[garden.SoilType] < goodFitScore {
+				tips = append(tips, fmt.Sprintf("%s grows poorly in %s; mix compost into its grow bags", name, soil))
+			}
+		}
+	}
+
+	return append(tips, generalGardenTips...)
+}
@@ -0,0 +1,159 @@
+package cropmanager
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/pkg/errors" // v0.9.1
+	"gorm.io/gorm"          // v1.25.0
+
+	"github.com/urban-gardening-assistant/backend/internal/models"
+	"github.com/urban-gardening-assistant/backend/pkg/dto"
+	customErrors "github.com/urban-gardening-assistant/backend/internal/utils/errors"
+)
+
+// gramsPerUnit converts harvest goal and harvest quantities to grams
+var gramsPerUnit = map[string]float64{
+	dto.YieldUnitGrams:     1,
+	dto.YieldUnitKilograms: 1000,
+}
+
+// goalPeriodDays is the length of the rolling window each goal period covers
+var goalPeriodDays = map[string]int{
+	dto.YieldPeriodWeek:  7,
+	dto.YieldPeriodMonth: 30,
+}
+
+// SetTargetYield sets a crop's harvest goal, e.g. 2 kg per week, and returns the crop
+// with its progress toward the goal
+func (s *CropService) SetTargetYield(ctx context.Context, cropID string, req *dto.TargetYieldRequest) (_ *dto.CropResponse, err error) {
+	if err := s.acquire(); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	defer s.observeOperation(OperationUpdate, time.Now(), &err)
+
+	if req == nil || req.Quantity <= 0 || math.IsNaN(req.Quantity) || math.IsInf(req.Quantity, 0) {
+		return nil, customErrors.NewError("VALIDATION_ERROR", "target quantity must be a positive number")
+	}
+	if _, ok := gramsPerUnit[req.Unit]; !ok {
+		return nil, customErrors.NewError("VALIDATION_ERROR", "target unit must be g or kg")
+	}
+	if _, ok := goalPeriodDays[req.Period]; !ok {
+		return nil, customErrors.NewError("VALIDATION_ERROR", "target period must be week or month")
+	}
+
+	crop := &models.Crop{}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(crop, "id = ? AND deleted_at IS NULL", cropID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return customErrors.NewError("NOT_FOUND", "crop not found")
+			}
+			return customErrors.WrapError(err, "failed to query crop")
+		}
+
+		crop.TargetYield = req.Quantity
+		crop.TargetYieldUnit = req.Unit
+		crop.TargetYieldPeriod = req.Period
+		// UpdateColumns skips the update hooks; the goal changes no yield or space inputs
+		if err := tx.Model(crop).UpdateColumns(map[string]interface{}{
+			"target_yield":        crop.TargetYield,
+			"target_yield_unit":   crop.TargetYieldUnit,
+			"target_yield_period": crop.TargetYieldPeriod,
+		}).Error; err != nil {
+			return customErrors.WrapError(err, "failed to update crop")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.updateCropCache(crop)
+	return s.withTargetProgress(ctx, crop)
+}
+
+// LogHarvest records produce picked from a crop and returns the crop with its updated
+// progress toward its harvest goal
+func (s *CropService) LogHarvest(ctx context.Context, cropID string, req *dto.HarvestRequest) (*dto.CropResponse, error) {
+	if err := s.acquire(); err != nil {
+		return nil, err
+	}
+	defer s.release()
+
+	if req == nil || req.Quantity <= 0 || math.IsNaN(req.Quantity) || math.IsInf(req.Quantity, 0) {
+		return nil, customErrors.NewError("VALIDATION_ERROR", "harvest quantity must be a positive number")
+	}
+	factor, ok := gramsPerUnit[req.Unit]
+	if !ok {
+		return nil, customErrors.NewError("VALIDATION_ERROR", "harvest unit must be g or kg")
+	}
+
+	s.mu.RLock()
+	now := s.clock.Now()
+	s.mu.RUnlock()
+
+	harvestedAt := now
+	if req.HarvestedAt != nil {
+		if req.HarvestedAt.After(now) {
+			return nil, customErrors.NewError("VALIDATION_ERROR", "harvest time cannot be in the future")
+		}
+		harvestedAt = *req.HarvestedAt
+	}
+
+	crop := &models.Crop{}
+	if err := s.db.WithContext(ctx).First(crop, "id = ? AND deleted_at IS NULL", cropID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.NewError("NOT_FOUND", "crop not found")
+		}
+		return nil, customErrors.WrapError(err, "failed to query crop")
+	}
+
+	harvest := &models.Harvest{
+		CropID:      crop.ID,
+		Grams:       req.Quantity * factor,
+		HarvestedAt: harvestedAt,
+	}
+	if err := s.db.WithContext(ctx).Create(harvest).Error; err != nil {
+		return nil, customErrors.WrapError(err, "failed to save harvest")
+	}
+
+	return s.withTargetProgress(ctx, crop)
+}
+
+// withTargetProgress converts crop to a response carrying its progress toward its harvest
+// goal; crops without a goal are returned as is
+func (s *CropService) withTargetProgress(ctx context.Context, crop *models.Crop) (*dto.CropResponse, error) {
+	resp := crop.ToResponse()
+
+	factor, ok := gramsPerUnit[crop.TargetYieldUnit]
+	days, hasPeriod := goalPeriodDays[crop.TargetYieldPeriod]
+	if crop.TargetYield <= 0 || !ok || !hasPeriod {
+		return resp, nil
+	}
+
+	s.mu.RLock()
+	now := s.clock.Now()
+	s.mu.RUnlock()
+	periodStart := now.AddDate(0, 0, -days)
+
+	var grams float64
+	if err := s.db.WithContext(ctx).Model(&models.Harvest{}).
+		Select("COALESCE(SUM(grams), 0)").
+		Where("crop_id = ? AND harvested_at > ? AND harvested_at <= ?", crop.ID, periodStart, now).
+		Scan(&grams).Error; err != nil {
+		return nil, customErrors.WrapError(err, "failed to total harvests")
+	}
+
+	harvested := grams / factor
+	resp.TargetYield = &dto.TargetYieldProgress{
+		Quantity:      crop.TargetYield,
+		Unit:          crop.TargetYieldUnit,
+		Period:        crop.TargetYieldPeriod,
+		PeriodStart:   periodStart,
+		Harvested:     harvested,
+		PercentToGoal: harvested / crop.TargetYield * 100,
+	}
+	return resp, nil
+}
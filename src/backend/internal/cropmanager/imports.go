@@ -0,0 +1,364 @@
+package cropmanager
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urban-gardening-assistant/backend/internal/models"
+	customErrors "github.com/urban-gardening-assistant/backend/internal/utils/errors"
+	"github.com/urban-gardening-assistant/backend/pkg/dto"
+	"github.com/urban-gardening-assistant/backend/pkg/types/common"
+)
+
+// maxImportRows bounds the crop rows accepted in a single CSV import
+const maxImportRows = 500
+
+// CSV columns required in a crop import header, matched case-insensitively in any order
+const (
+	importColumnName     = "name"
+	importColumnQuantity = "quantity"
+	importColumnGrowBags = "growbags"
+	importColumnBagSize  = "bagsize"
+)
+
+var importColumns = []string{importColumnName, importColumnQuantity, importColumnGrowBags, importColumnBagSize}
+
+// importRow is a parsed CSV row awaiting validation and creation
+type importRow struct {
+	result dto.CropImportRowResult
+	req    *dto.CropRequest
+	crop   *models.Crop
+	resp   *dto.CropResponse
+}
+
+// ImportCropsCSV bulk-creates crops in a garden from CSV with name, quantity, growBags,
+// and bagSize columns. Every row is validated as a crop request, then the rows' combined
+// space is checked against the garden's free capacity. The import is all-or-nothing: an
+// invalid row or a space shortfall creates no crops and is reported per row in the
+// response rather than as an error.
+func (s *CropService) ImportCropsCSV(ctx context.Context, gardenID string, r io.Reader) (resp *dto.CropImportResponse, err error) {
+	if err := s.acquire(); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	defer s.observeOperation(OperationImport, time.Now(), &err)
+
+	garden, err := s.getGarden(ctx, gardenID)
+	if err != nil {
+		return nil, customErrors.WrapError(err, "failed to get garden")
+	}
+
+	rows, err := parseCropImportCSV(gardenID, r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rows may not repeat a crop already in the garden or earlier in the CSV when
+	// duplicate names are rejected
+	var names map[string]string
+	if s.rejectsDuplicateNames() {
+		if names, err = gardenCropNames(s.db.WithContext(ctx), gardenID); err != nil {
+			return nil, err
+		}
+	}
+
+	response := &dto.CropImportResponse{GardenID: gardenID, Rows: make([]dto.CropImportRowResult, len(rows))}
+	requiredSpace := 0.0
+	for _, row := range rows {
+		if row.req == nil {
+			continue
+		}
+		row.crop, row.resp = s.validateImportRow(ctx, row)
+		if row.crop != nil && names != nil {
+			key := cropNameKey(row.crop.Name)
+			if existing, ok := names[key]; ok {
+				row.result.Errors = append(row.result.Errors, duplicateNameError(existing).Error())
+				row.crop, row.resp = nil, nil
+			} else {
+				names[key] = row.crop.Name
+			}
+		}
+		if row.crop != nil {
+			requiredSpace += row.crop.CalculateSpaceRequired()
+		}
+	}
+	for i, row := range rows {
+		if row.crop == nil {
+			row.result.Status = dto.ImportRowInvalid
+			response.InvalidCount++
+		} else {
+			row.result.Status = dto.ImportRowValid
+		}
+		response.Rows[i] = row.result
+	}
+
+	if response.InvalidCount > 0 {
+		response.Message = fmt.Sprintf("%d of %d rows are invalid; no crops were imported", response.InvalidCount, len(rows))
+		return response, nil
+	}
+
+	validation, err := s.validateImportSpace(ctx, garden, requiredSpace)
+	if err != nil {
+		return nil, err
+	}
+	response.SpaceValidation = validation
+
+	s.mu.RLock()
+	allowOverCapacity := s.capacity.AllowOverCapacity
+	s.mu.RUnlock()
+	if !validation.IsValid && !allowOverCapacity {
+		response.Message = validation.Message
+		return response, nil
+	}
+
+	if err := s.createImportedCrops(ctx, gardenID, rows); err != nil {
+		return nil, err
+	}
+
+	for i, row := range rows {
+		row.result.Status = dto.ImportRowCreated
+		row.result.Crop = row.resp
+		response.Rows[i] = row.result
+	}
+	response.Imported = true
+	response.CreatedCount = len(rows)
+	if !validation.IsValid {
+		response.Message = fmt.Sprintf("WARNING: garden is over capacity at %.2f%% used; crops will be overcrowded and yields reduced. %s",
+			validation.SpaceUtilization, validation.Message)
+	}
+	return response, nil
+}
+
+// parseCropImportCSV reads the header and crop rows of an import. Rows whose values do
+// not parse are returned without a request and with their errors recorded; an unreadable
+// CSV or header is an error.
+func parseCropImportCSV(gardenID string, r io.Reader) ([]*importRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = true // Accept unquoted inch marks such as 12"
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, customErrors.NewError("VALIDATION_ERROR", "CSV is empty")
+	}
+	if err != nil {
+		var parseErr *csv.ParseError
+		if !errors.As(err, &parseErr) {
+			return nil, customErrors.WrapError(err, "failed to read CSV")
+		}
+		return nil, customErrors.NewError("VALIDATION_ERROR", fmt.Sprintf("invalid CSV header: %v", err))
+	}
+
+	positions := make(map[string]int, len(header))
+	for i, column := range header {
+		positions[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	for _, column := range importColumns {
+		if _, ok := positions[column]; !ok {
+			return nil, customErrors.NewError("VALIDATION_ERROR", fmt.Sprintf(
+				"CSV header must include name, quantity, growBags, and bagSize columns; %q is missing", column))
+		}
+	}
+
+	var rows []*importRow
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if len(rows) == maxImportRows {
+			return nil, customErrors.NewError("VALIDATION_ERROR", fmt.Sprintf("CSV exceeds the maximum of %d crop rows", maxImportRows))
+		}
+
+		row := &importRow{result: dto.CropImportRowResult{Row: line}}
+		rows = append(rows, row)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, customErrors.WrapError(err, "failed to read CSV")
+			}
+			row.result.Errors = []string{parseErr.Err.Error()}
+			continue
+		}
+		if len(record) != len(header) {
+			row.result.Errors = []string{fmt.Sprintf("expected %d fields, got %d", len(header), len(record))}
+			continue
+		}
+
+		value := func(column string) string {
+			return strings.TrimSpace(record[positions[column]])
+		}
+		row.result.Name = value(importColumnName)
+
+		quantity, err := strconv.Atoi(value(importColumnQuantity))
+		if err != nil {
+			row.result.Errors = append(row.result.Errors, fmt.Sprintf("quantity: %q is not a whole number", value(importColumnQuantity)))
+		}
+		growBags, err := strconv.Atoi(value(importColumnGrowBags))
+		if err != nil {
+			row.result.Errors = append(row.result.Errors, fmt.Sprintf("growBags: %q is not a whole number", value(importColumnGrowBags)))
+		}
+		if len(row.result.Errors) > 0 {
+			continue
+		}
+
+		row.req = &dto.CropRequest{
+			GardenID:       gardenID,
+			Name:           row.result.Name,
+			QuantityNeeded: quantity,
+			GrowBags:       growBags,
+			BagSize:        normalizeImportBagSize(value(importColumnBagSize)),
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil, customErrors.NewError("VALIDATION_ERROR", "CSV contains no crop rows")
+	}
+	return rows, nil
+}
+
+// normalizeImportBagSize accepts bag sizes written with or without the inch mark, as
+// spreadsheets often drop it
+func normalizeImportBagSize(bagSize string) string {
+	if bagSize == "" || strings.HasSuffix(bagSize, "\"") {
+		return bagSize
+	}
+	return bagSize + "\""
+}
+
+// validateImportRow applies the checks crop creation makes to a single row, recording
+// failures on the row. It returns the crop to create and its partial response, or nils
+// when the row is invalid.
+func (s *CropService) validateImportRow(ctx context.Context, row *importRow) (*models.Crop, *dto.CropResponse) {
+	if err := dto.ValidateCropRequest(row.req); err != nil {
+		var validationErrs common.ValidationErrors
+		if !errors.As(err, &validationErrs) {
+			row.result.Errors = append(row.result.Errors, err.Error())
+			return nil, nil
+		}
+		for _, fieldErr := range validationErrs {
+			row.result.Errors = append(row.result.Errors, fmt.Sprintf("%s: %s", fieldErr.Field, fieldErr.Message))
+		}
+		return nil, nil
+	}
+
+	if err := s.checkCropName(row.req.Name); err != nil {
+		row.result.Errors = append(row.result.Errors, err.Error())
+		return nil, nil
+	}
+
+	crop := &models.Crop{}
+	if err := crop.FromDTO(row.req); err != nil {
+		row.result.Errors = append(row.result.Errors, err.Error())
+		return nil, nil
+	}
+
+	bagSizeWarning, err := s.checkBagFit(crop)
+	if err != nil {
+		row.result.Errors = append(row.result.Errors, err.Error())
+		return nil, nil
+	}
+
+	requestedGrowBags, err := s.applyGrowBagLimit(ctx, crop)
+	if err != nil {
+		row.result.Errors = append(row.result.Errors, err.Error())
+		return nil, nil
+	}
+
+	return crop, &dto.CropResponse{RequestedGrowBags: requestedGrowBags, BagSizeWarning: bagSizeWarning}
+}
+
+// validateImportSpace checks the combined space of the imported crops against the
+// garden's capacity, adjusted for soil efficiency as single crops are
+func (s *CropService) validateImportSpace(ctx context.Context, garden *models.Garden, requiredSpace float64) (*dto.SpaceValidationResponse, error) {
+	current, err := s.ValidateSpaceCapacity(ctx, garden.ID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	soilEfficiency, err := s.calculateSoilEfficiency(garden)
+	if err != nil {
+		return nil, err
+	}
+
+	adjustedSpace := (current.UsedSpace + requiredSpace) / soilEfficiency
+	response := &dto.SpaceValidationResponse{
+		IsValid:          adjustedSpace <= current.TotalSpace,
+		TotalSpace:       current.TotalSpace,
+		UsedSpace:        current.UsedSpace,
+		RequiredSpace:    requiredSpace,
+		AvailableSpace:   current.TotalSpace - current.UsedSpace,
+		SpaceUtilization: adjustedSpace / current.TotalSpace * 100,
+	}
+	if !response.IsValid {
+		response.Message = fmt.Sprintf(
+			"Garden capacity exceeded. Required: %.2f sq ft, Available: %.2f sq ft. Consider importing fewer crops or using smaller grow bags.",
+			adjustedSpace,
+			current.TotalSpace-current.UsedSpace,
+		)
+	}
+	return response, nil
+}
+
+// createImportedCrops saves every validated crop in one transaction, with a single
+// utilization snapshot for the whole import, and fills in each row's response
+func (s *CropService) createImportedCrops(ctx context.Context, gardenID string, rows []*importRow) error {
+	tx := s.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return customErrors.WrapError(tx.Error, "failed to start transaction")
+	}
+	defer tx.Rollback()
+
+	// Recheck names under the garden lock in case a crop was added since validation
+	if s.rejectsDuplicateNames() {
+		if err := lockGardenCrops(tx, gardenID); err != nil {
+			return err
+		}
+		names, err := gardenCropNames(tx, gardenID)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if existing, ok := names[cropNameKey(row.crop.Name)]; ok {
+				return duplicateNameError(existing)
+			}
+		}
+	}
+
+	importedSpace := 0.0
+	for _, row := range rows {
+		if err := s.validateYieldAccuracy(row.crop.CalculateYield()); err != nil {
+			return err
+		}
+		if err := tx.Create(row.crop).Error; err != nil {
+			return customErrors.WrapError(err, fmt.Sprintf("failed to save crop from row %d", row.result.Row))
+		}
+		importedSpace += row.crop.CalculateSpaceRequired()
+	}
+
+	crossings, err := s.recordUtilization(ctx, tx, gardenID, models.UtilizationTriggerCropsImported, "", importedSpace)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return customErrors.WrapError(err, "failed to commit transaction")
+	}
+	s.fireCapacityWebhooks(ctx, crossings)
+
+	for _, row := range rows {
+		s.updateCropCache(row.crop)
+		resp := row.crop.ToResponse()
+		resp.RequestedGrowBags = row.resp.RequestedGrowBags
+		resp.BagSizeWarning = row.resp.BagSizeWarning
+		row.resp = resp
+	}
+	return nil
+}
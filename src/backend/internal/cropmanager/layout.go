@@ -0,0 +1,139 @@
+package cropmanager
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm/clause" // v1.25.0
+
+	"github.com/urban-gardening-assistant/backend/internal/calculator"
+	"github.com/urban-gardening-assistant/backend/internal/models"
+	"github.com/urban-gardening-assistant/backend/pkg/dto"
+	"github.com/urban-gardening-assistant/backend/pkg/types/common"
+	customErrors "github.com/urban-gardening-assistant/backend/internal/utils/errors"
+)
+
+// bagDiameters maps grow bag sizes to their diameter in feet
+var bagDiameters = map[string]float64{
+	dto.BagSize8:  8.0 / 12,
+	dto.BagSize10: 10.0 / 12,
+	dto.BagSize12: 12.0 / 12,
+	dto.BagSize14: 14.0 / 12,
+}
+
+// ComputeGardenLayout arranges the grow bags of every crop in a garden using the mixed
+// layout optimizer and stores the result, replacing any earlier layout for the garden.
+// Bags that do not fit are reported as a per-crop shortfall rather than an error.
+func (s *CropService) ComputeGardenLayout(ctx context.Context, gardenID string) (*dto.GardenLayoutResponse, error) {
+	if err := s.acquire(); err != nil {
+		return nil, err
+	}
+	defer s.release()
+
+	response, _, _, err := s.layoutGarden(ctx, gardenID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.saveGardenLayout(ctx, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// layoutGarden arranges the grow bags of every crop in a garden without storing the
+// result, returning the garden and its crops alongside the layout
+func (s *CropService) layoutGarden(ctx context.Context, gardenID string) (*dto.GardenLayoutResponse, *models.Garden, []models.Crop, error) {
+	garden, err := s.getGarden(ctx, gardenID)
+	if err != nil {
+		return nil, nil, nil, customErrors.WrapError(err, "failed to get garden")
+	}
+
+	var crops []models.Crop
+	if err := s.db.WithContext(ctx).
+		Where("garden_id = ? AND deleted_at IS NULL", gardenID).
+		Order("created_at ASC").
+		Find(&crops).Error; err != nil {
+		return nil, nil, nil, customErrors.WrapError(err, "failed to get garden crops")
+	}
+
+	groups := make([]calculator.BagGroup, 0, len(crops))
+	cropsByID := make(map[string]*models.Crop, len(crops))
+	requiredBags := 0
+	for i := range crops {
+		crop := &crops[i]
+		diameter, ok := bagDiameters[crop.BagSize]
+		if !ok {
+			return nil, nil, nil, customErrors.NewError("VALIDATION_ERROR", "crop "+crop.ID+" has unsupported bag size "+crop.BagSize)
+		}
+		groups = append(groups, calculator.BagGroup{Label: crop.ID, Diameter: diameter, Count: crop.GrowBags})
+		cropsByID[crop.ID] = crop
+		requiredBags += crop.GrowBags
+	}
+
+	dims := common.Dimensions{Length: garden.Length, Width: garden.Width, Unit: "feet"}
+	layout, err := calculator.OptimizeMixedLayout(dims, groups, calculator.OptimizationConfig{
+		MinPathWidth:      calculator.MinimumPathWidth,
+		SpacingMultiplier: 1.0,
+	})
+	if err != nil {
+		return nil, nil, nil, customErrors.WrapError(err, "failed to optimize garden layout")
+	}
+
+	response := &dto.GardenLayoutResponse{
+		GardenID:         gardenID,
+		RequiredBags:     requiredBags,
+		PlacedBags:       len(layout.Placements),
+		Complete:         len(layout.Placements) == requiredBags,
+		SpaceUtilization: layout.SpaceUtilization,
+		Placements:       make([]dto.LayoutPlacement, 0, len(layout.Placements)),
+		ComputedAt:       time.Now(),
+	}
+	for _, placement := range layout.Placements {
+		crop := cropsByID[placement.Label]
+		response.Placements = append(response.Placements, dto.LayoutPlacement{
+			CropID:   crop.ID,
+			CropName: crop.Name,
+			BagSize:  crop.BagSize,
+			X:        placement.Position.X,
+			Y:        placement.Position.Y,
+		})
+	}
+	for i := range crops {
+		if unplaced := layout.Unplaced[crops[i].ID]; unplaced > 0 {
+			response.Shortfall = append(response.Shortfall, dto.LayoutShortfall{
+				CropID:       crops[i].ID,
+				CropName:     crops[i].Name,
+				BagSize:      crops[i].BagSize,
+				UnplacedBags: unplaced,
+			})
+		}
+	}
+
+	return response, garden, crops, nil
+}
+
+// saveGardenLayout upserts the garden's stored layout
+func (s *CropService) saveGardenLayout(ctx context.Context, response *dto.GardenLayoutResponse) error {
+	placements, err := json.Marshal(response.Placements)
+	if err != nil {
+		return customErrors.WrapError(err, "failed to encode garden layout")
+	}
+
+	record := &models.GardenLayout{
+		GardenID:     response.GardenID,
+		Placements:   placements,
+		RequiredBags: response.RequiredBags,
+		PlacedBags:   response.PlacedBags,
+	}
+	if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "garden_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"placements", "required_bags", "placed_bags", "updated_at"}),
+	}).Create(record).Error; err != nil {
+		return customErrors.WrapError(err, "failed to save garden layout")
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package cropmanager
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/urban-gardening-assistant/backend/internal/models"
+	"github.com/urban-gardening-assistant/backend/pkg/constants/garden"
+	"github.com/urban-gardening-assistant/backend/pkg/dto"
+)
+
+// Metadata returns the crops, bag sizes, soil types, and maintenance options the service
+// accepts, read from the same tables used for validation and yield estimates, together
+// with the configured yield profile
+func (s *CropService) Metadata() *dto.MetadataResponse {
+	s.mu.RLock()
+	yield := s.yield
+	soil := s.soil
+	s.mu.RUnlock()
+
+	baseYields := models.CropBaseYields()
+	crops := make([]dto.CropMetadata, 0, len(baseYields))
+	for name, baseYield := range baseYields {
+		minBagSize, _ := models.MinBagSize(name)
+		crops = append(crops, dto.CropMetadata{Name: name, BaseYieldPerBag: baseYield, MinBagSize: minBagSize})
+	}
+	sort.Slice(crops, func(i, j int) bool { return crops[i].Name < crops[j].Name })
+
+	multipliers := models.BagSizeMultipliers()
+	bagSizes := make([]dto.BagSizeMetadata, 0, len(multipliers))
+	for size, multiplier := range multipliers {
+		bagSizes = append(bagSizes, dto.BagSizeMetadata{Size: size, YieldMultiplier: multiplier})
+	}
+	sort.Slice(bagSizes, func(i, j int) bool {
+		return bagSizeInches(bagSizes[i].Size) < bagSizeInches(bagSizes[j].Size)
+	})
+
+	efficiencies := models.SoilEfficiencyFactors()
+	soilTypes := make([]dto.SoilTypeMetadata, 0, len(efficiencies))
+	for _, soilType := range garden.ValidSoilTypes() {
+		soilTypes = append(soilTypes, dto.SoilTypeMetadata{Name: soilType, Efficiency: efficiencies[soilType]})
+	}
+
+	validTaskTypes := models.ValidTaskTypes()
+	taskTypes := make([]dto.TaskTypeMetadata, 0, len(validTaskTypes))
+	for _, taskType := range validTaskTypes {
+		taskTypes = append(taskTypes, dto.TaskTypeMetadata{Name: taskType, Unit: models.TaskUnit(taskType)})
+	}
+
+	return &dto.MetadataResponse{
+		Crops:              crops,
+		BagSizes:           bagSizes,
+		SoilTypes:          soilTypes,
+		SunlightConditions: garden.ValidSunlightConditions(),
+		Environments:       garden.ValidEnvironments(),
+		Frequencies:        models.ValidFrequencies(),
+		TaskTypes:          taskTypes,
+		YieldProfile: dto.YieldProfile{
+			DefaultBaseYieldPerBag: models.DefaultCropBaseYield,
+			AdjustForEnvironment:   yield.AdjustForEnvironment,
+			UnknownSoilFactor:      soil.UnknownSoilFactor,
+			RejectUnknownSoil:      soil.RejectUnknownSoil,
+		},
+	}
+}
+
+// bagSizeInches parses a bag size such as 12" into its diameter in inches
+func bagSizeInches(size string) int {
+	inches, _ := strconv.Atoi(strings.TrimSuffix(size, "\""))
+	return inches
+}
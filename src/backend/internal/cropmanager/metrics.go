@@ -0,0 +1,84 @@
+package cropmanager
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus" // v1.15.0
+)
+
+// Crop operations recorded in metrics
+const (
+	OperationCreate  = "create"
+	OperationUpdate  = "update"
+	OperationDelete  = "delete"
+	OperationRestore = "restore"
+	OperationImport  = "import"
+)
+
+// Operation outcomes recorded in metrics
+const (
+	operationSuccess = "success"
+	operationError   = "error"
+)
+
+// Metrics records crop operation counts and latencies for Prometheus
+type Metrics struct {
+	operations *prometheus.CounterVec
+	duration   *prometheus.HistogramVec
+}
+
+// NewMetrics creates the crop operation metrics and registers them with registerer
+func NewMetrics(registerer prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		operations: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "cropmanager",
+				Name:      "crop_operations_total",
+				Help:      "Total number of crop operations by operation and outcome",
+			},
+			[]string{"operation", "status"},
+		),
+		duration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "cropmanager",
+				Name:      "crop_operation_duration_seconds",
+				Help:      "Duration of crop operations in seconds",
+				Buckets:   prometheus.DefBuckets,
+			},
+			[]string{"operation"},
+		),
+	}
+
+	for _, collector := range []prometheus.Collector{m.operations, m.duration} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// SetMetrics sets where crop operations are recorded. Operations are not recorded
+// until metrics are set.
+func (s *CropService) SetMetrics(m *Metrics) {
+	s.mu.Lock()
+	s.metrics = m
+	s.mu.Unlock()
+}
+
+// observeOperation records an operation that started at start and failed if *err is
+// set. It is deferred with the operation's named error result.
+func (s *CropService) observeOperation(operation string, start time.Time, err *error) {
+	s.mu.RLock()
+	m := s.metrics
+	s.mu.RUnlock()
+	if m == nil {
+		return
+	}
+
+	status := operationSuccess
+	if *err != nil {
+		status = operationError
+	}
+	m.operations.WithLabelValues(operation, status).Inc()
+	m.duration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,243 @@
+package cropmanager
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"        // v1.25.0
+	"gorm.io/gorm/clause" // v1.25.0
+
+	"github.com/urban-gardening-assistant/backend/internal/models"
+	customErrors "github.com/urban-gardening-assistant/backend/internal/utils/errors"
+	"github.com/urban-gardening-assistant/backend/pkg/dto"
+)
+
+// DuplicateNameConfig controls whether a garden may hold more than one crop of the same
+// name. Names are compared after normalization, so "Tomatoes" duplicates "tomato".
+type DuplicateNameConfig struct {
+	RejectDuplicates bool // Reject crops named like an existing crop in the garden
+}
+
+// SetDuplicateNameConfig configures whether duplicate crop names within a garden are
+// rejected. Duplicates are allowed by default.
+func (s *CropService) SetDuplicateNameConfig(cfg DuplicateNameConfig) {
+	s.mu.Lock()
+	s.names = cfg
+	s.mu.Unlock()
+}
+
+// rejectsDuplicateNames reports whether duplicate crop names are currently rejected
+func (s *CropService) rejectsDuplicateNames() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.names.RejectDuplicates
+}
+
+// cropNameKey returns the key two crop names share when they name the same crop
+func cropNameKey(name string) string {
+	return strings.ToLower(dto.NormalizeCropName(name))
+}
+
+// gardenCropNames returns the names of the garden's active crops keyed by cropNameKey
+func gardenCropNames(tx *gorm.DB, gardenID string) (map[string]string, error) {
+	var crops []models.Crop
+	if err := tx.Where("garden_id = ? AND deleted_at IS NULL", gardenID).Find(&crops).Error; err != nil {
+		return nil, customErrors.WrapError(err, "failed to get existing crops")
+	}
+
+	names := make(map[string]string, len(crops))
+	for i := range crops {
+		names[cropNameKey(crops[i].Name)] = crops[i].Name
+	}
+	return names, nil
+}
+
+// duplicateNameError reports that a garden already has a crop named like the new one
+func duplicateNameError(existing string) error {
+	return customErrors.NewError("DUPLICATE_CROP",
+		fmt.Sprintf("garden already has a crop named %q; duplicate crop names are not allowed", existing))
+}
+
+// lockGardenCrops locks the garden's row for the rest of tx, so concurrent transactions
+// checking and adding crop names in the same garden run one after the other
+func lockGardenCrops(tx *gorm.DB, gardenID string) error {
+	var garden models.Garden
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&garden, "id = ?", gardenID).Error; err != nil {
+		return customErrors.WrapError(err, "failed to lock garden")
+	}
+	return nil
+}
+
+// checkDuplicateName rejects a crop whose name matches one of the garden's active crops
+// when duplicates are disallowed. The garden stays locked until tx ends, so a concurrent
+// create of the same name waits and then sees this one.
+func (s *CropService) checkDuplicateName(tx *gorm.DB, gardenID, name string) error {
+	if !s.rejectsDuplicateNames() {
+		return nil
+	}
+
+	if err := lockGardenCrops(tx, gardenID); err != nil {
+		return err
+	}
+	names, err := gardenCropNames(tx, gardenID)
+	if err != nil {
+		return err
+	}
+	if existing, ok := names[cropNameKey(name)]; ok {
+		return duplicateNameError(existing)
+	}
+	return nil
+}
+
+// Fuzzy crop name suggestion limits
+const (
+	maxCropNameSuggestions = 3
+	minSuggestionDistance  = 2 // Edits always tolerated, so short names still get suggestions
+)
+
+// CropNameConfig controls which crop names are accepted. Crops with known yields are
+// always accepted; AllowedNames extends the allowlist with crops that use the default
+// yield. Names outside the allowlist are rejected with suggested close matches unless
+// AllowUnknown is set.
+type CropNameConfig struct {
+	AllowedNames []string
+	AllowUnknown bool // Accept any name, using the default yield for unrecognised crops
+}
+
+// SetCropNameConfig configures the crop name allowlist. Only crops with known yields are
+// accepted by default.
+func (s *CropService) SetCropNameConfig(cfg CropNameConfig) {
+	allowed := make([]string, 0, len(cfg.AllowedNames))
+	for _, name := range cfg.AllowedNames {
+		if name = strings.Join(strings.Fields(name), " "); name != "" {
+			allowed = append(allowed, name)
+		}
+	}
+	cfg.AllowedNames = allowed
+
+	s.mu.Lock()
+	s.cropNames = cfg
+	s.mu.Unlock()
+}
+
+// allowedCropNames returns the crops with known yields followed by the configured
+// allowlist, sorted within each group
+func (s *CropService) allowedCropNames() []string {
+	s.mu.RLock()
+	extra := append([]string(nil), s.cropNames.AllowedNames...)
+	s.mu.RUnlock()
+
+	baseYields := models.CropBaseYields()
+	names := make([]string, 0, len(baseYields)+len(extra))
+	for name := range baseYields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	sort.Strings(extra)
+	return append(names, extra...)
+}
+
+// checkCropName rejects a crop name outside the allowlist, suggesting the closest allowed
+// names, unless unknown crops are allowed
+func (s *CropService) checkCropName(name string) error {
+	s.mu.RLock()
+	allowUnknown := s.cropNames.AllowUnknown
+	s.mu.RUnlock()
+	if allowUnknown {
+		return nil
+	}
+
+	allowed := s.allowedCropNames()
+	key := cropNameKey(name)
+	for _, candidate := range allowed {
+		if cropNameKey(candidate) == key {
+			return nil
+		}
+	}
+
+	suggestions := suggestCropNames(name, allowed)
+	if len(suggestions) == 0 {
+		return customErrors.NewError("VALIDATION_ERROR",
+			fmt.Sprintf("unknown crop %q; supported crops are %s", name, strings.Join(allowed, ", ")))
+	}
+	return customErrors.NewError("VALIDATION_ERROR",
+		fmt.Sprintf("unknown crop %q; did you mean %s?", name, quoteList(suggestions)))
+}
+
+// suggestCropNames returns up to maxCropNameSuggestions candidates within a few edits
+// of name, closest first. A candidate may be a third of its length in edits away.
+func suggestCropNames(name string, candidates []string) []string {
+	type match struct {
+		name     string
+		distance int
+	}
+
+	target := strings.ToLower(strings.Join(strings.Fields(name), " "))
+	var matches []match
+	for _, candidate := range candidates {
+		limit := len(candidate) / 3
+		if limit < minSuggestionDistance {
+			limit = minSuggestionDistance
+		}
+		if distance := editDistance(target, strings.ToLower(candidate)); distance <= limit {
+			matches = append(matches, match{name: candidate, distance: distance})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].distance < matches[j].distance })
+
+	suggestions := make([]string, 0, maxCropNameSuggestions)
+	for i := 0; i < len(matches) && i < maxCropNameSuggestions; i++ {
+		suggestions = append(suggestions, matches[i].name)
+	}
+	return suggestions
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// min3 returns the smallest of three ints
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// quoteList formats names as "a", "b" or "c"
+func quoteList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = strconv.Quote(name)
+	}
+	if len(quoted) == 1 {
+		return quoted[0]
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " or " + quoted[len(quoted)-1]
+}
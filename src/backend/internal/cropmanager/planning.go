@@ -0,0 +1,179 @@
+package cropmanager
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/urban-gardening-assistant/backend/internal/models"
+	"github.com/urban-gardening-assistant/backend/pkg/dto"
+	customErrors "github.com/urban-gardening-assistant/backend/internal/utils/errors"
+)
+
+// planBagSizes lists the bag sizes considered when planning towards a yield goal
+var planBagSizes = []string{dto.BagSize8, dto.BagSize10, dto.BagSize12, dto.BagSize14}
+
+// PlanForYieldGoal inverts the yield formula to find the number of grow bags needed
+// to reach a weekly yield target (kg/week) and checks the result against free garden space
+func (s *CropService) PlanForYieldGoal(ctx context.Context, gardenID, cropName string, weeklyTarget float64) (*dto.YieldPlanResponse, error) {
+	if err := s.acquire(); err != nil {
+		return nil, err
+	}
+	defer s.release()
+
+	cropName = strings.TrimSpace(cropName)
+	if cropName == "" {
+		return nil, customErrors.NewError("VALIDATION_ERROR", "crop name is required")
+	}
+	if weeklyTarget <= 0 || math.IsNaN(weeklyTarget) || math.IsInf(weeklyTarget, 0) {
+		return nil, customErrors.NewError("VALIDATION_ERROR", "weekly target must be a positive number")
+	}
+
+	garden, err := s.getGarden(ctx, gardenID)
+	if err != nil {
+		return nil, customErrors.WrapError(err, "failed to get garden")
+	}
+
+	availableSpace, soilEfficiency, err := s.availableSpace(ctx, garden)
+	if err != nil {
+		return nil, err
+	}
+
+	dailyTarget := weeklyTarget / 7
+	response := &dto.YieldPlanResponse{
+		GardenID:       gardenID,
+		CropName:       cropName,
+		WeeklyTarget:   weeklyTarget,
+		AvailableSpace: availableSpace,
+		Options:        make([]dto.YieldPlanOption, 0, len(planBagSizes)),
+	}
+
+	for _, bagSize := range planBagSizes {
+		probe := &models.Crop{Name: cropName, BagSize: bagSize, GrowBags: 1, Garden: garden}
+		probe.SetSoilEfficiency(soilEfficiency)
+		perBagYield := probe.CalculateYield()
+		if perBagYield <= 0 {
+			continue
+		}
+
+		probe.GrowBags = int(math.Ceil(dailyTarget / perBagYield))
+		option := dto.YieldPlanOption{
+			BagSize:       bagSize,
+			GrowBags:      probe.GrowBags,
+			SpaceRequired: probe.CalculateSpaceRequired(),
+			WeeklyYield:   probe.CalculateYield() * 7,
+		}
+		option.Fits = probe.GrowBags <= dto.MaxGrowBags && option.SpaceRequired <= availableSpace
+		response.Options = append(response.Options, option)
+
+		// Prefer the option that leaves the most room for other crops
+		if option.Fits && (response.Recommended == nil || option.SpaceRequired < response.Recommended.SpaceRequired) {
+			recommended := option
+			response.Recommended = &recommended
+		}
+	}
+
+	response.Feasible = response.Recommended != nil
+	if !response.Feasible {
+		response.Message = fmt.Sprintf(
+			"A weekly target of %.2f kg of %s does not fit in the %.2f sq ft available. Consider lowering the target or freeing up space.",
+			weeklyTarget,
+			cropName,
+			availableSpace,
+		)
+	}
+
+	return response, nil
+}
+
+// SuggestCropsForRemainingSpace suggests crops to fill a garden's free space, each in the
+// bag size and number of bags giving it the most expected yield within that space, ranked
+// by expected weekly yield. Crops the garden's light cannot support are left out.
+func (s *CropService) SuggestCropsForRemainingSpace(ctx context.Context, gardenID string) (*dto.RemainingSpaceSuggestionResponse, error) {
+	if err := s.acquire(); err != nil {
+		return nil, err
+	}
+	defer s.release()
+
+	garden, err := s.getGarden(ctx, gardenID)
+	if err != nil {
+		return nil, customErrors.WrapError(err, "failed to get garden")
+	}
+
+	availableSpace, soilEfficiency, err := s.availableSpace(ctx, garden)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.RemainingSpaceSuggestionResponse{
+		GardenID:       gardenID,
+		AvailableSpace: availableSpace,
+		Suggestions:    make([]dto.RemainingSpaceSuggestion, 0, len(cropCatalog)),
+	}
+
+	for _, profile := range rankByRules(garden) {
+		var best *dto.RemainingSpaceSuggestion
+		for _, bagSize := range planBagSizes {
+			probe := &models.Crop{Name: profile.name, BagSize: bagSize, GrowBags: 1, Garden: garden}
+			probe.SetSoilEfficiency(soilEfficiency)
+			spacePerBag := probe.CalculateSpaceRequired()
+			if spacePerBag <= 0 {
+				continue
+			}
+
+			probe.GrowBags = int(math.Min(math.Floor(availableSpace/spacePerBag), dto.MaxGrowBags))
+			if probe.GrowBags < 1 {
+				continue
+			}
+
+			option := dto.RemainingSpaceSuggestion{
+				Name:                profile.name,
+				BagSize:             bagSize,
+				GrowBags:            probe.GrowBags,
+				SpaceRequired:       probe.CalculateSpaceRequired(),
+				ExpectedWeeklyYield: probe.CalculateYield() * 7,
+			}
+			// On equal yield prefer the option leaving more room
+			if best == nil || option.ExpectedWeeklyYield > best.ExpectedWeeklyYield ||
+				(option.ExpectedWeeklyYield == best.ExpectedWeeklyYield && option.SpaceRequired < best.SpaceRequired) {
+				best = &option
+			}
+		}
+		if best != nil {
+			response.Suggestions = append(response.Suggestions, *best)
+		}
+	}
+
+	sort.SliceStable(response.Suggestions, func(i, j int) bool {
+		return response.Suggestions[i].ExpectedWeeklyYield > response.Suggestions[j].ExpectedWeeklyYield
+	})
+	for i := range response.Suggestions {
+		response.Suggestions[i].Rank = i + 1
+	}
+
+	if len(response.Suggestions) == 0 {
+		response.Message = fmt.Sprintf("No crop fits in the %.2f sq ft available. Consider freeing up space.", availableSpace)
+	}
+
+	return response, nil
+}
+
+// availableSpace returns the raw space (sq ft) new crops may still occupy in a garden,
+// and the garden's soil efficiency for estimating their yield. Capacity checks divide
+// by soil efficiency, so the space is the garden area scaled by that factor minus what
+// is already used.
+func (s *CropService) availableSpace(ctx context.Context, garden *models.Garden) (float64, float64, error) {
+	capacity, err := s.ValidateSpaceCapacity(ctx, garden.ID, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	soilEfficiency, err := s.calculateSoilEfficiency(garden)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return math.Max(capacity.TotalSpace*soilEfficiency-capacity.UsedSpace, 0), soilEfficiency, nil
+}
@@ -0,0 +1,206 @@
+package cropmanager
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap" // v1.24.0
+
+	"github.com/urban-gardening-assistant/backend/internal/models"
+	"github.com/urban-gardening-assistant/backend/pkg/dto"
+	customErrors "github.com/urban-gardening-assistant/backend/internal/utils/errors"
+)
+
+// advisorTimeout bounds how long crop recommendations wait on the AI advisor
+const advisorTimeout = 3 * time.Second
+
+// Weighting of sunlight versus soil suitability in rule-based scores
+const (
+	sunlightWeight = 0.6
+	soilWeight     = 0.4
+	goodFitScore   = 0.8
+)
+
+// CropAdvisor suggests crop names suited to a garden's conditions, best first
+type CropAdvisor interface {
+	SuggestCrops(ctx context.Context, conditions map[string]string) ([]string, error)
+}
+
+// cropProfile captures how well a crop tolerates each sunlight condition and soil type (0-1)
+type cropProfile struct {
+	name     string
+	sunlight map[string]float64
+	soils    map[string]float64
+}
+
+// cropCatalog lists the crops with known yield data that can be recommended
+var cropCatalog = []cropProfile{
+	{
+		name:     "Tomatoes",
+		sunlight: map[string]float64{"full_sun": 1.0, "partial_shade": 0.4},
+		soils:    map[string]float64{"loamy_soil": 1.0, "black_soil": 0.9, "red_soil": 0.8, "sandy_soil": 0.6, "clay_soil": 0.4},
+	},
+	{
+		name:     "Peppers",
+		sunlight: map[string]float64{"full_sun": 1.0, "partial_shade": 0.5},
+		soils:    map[string]float64{"loamy_soil": 1.0, "sandy_soil": 0.8, "black_soil": 0.8, "red_soil": 0.7, "clay_soil": 0.4},
+	},
+	{
+		name:     "Eggplant",
+		sunlight: map[string]float64{"full_sun": 1.0, "partial_shade": 0.3},
+		soils:    map[string]float64{"loamy_soil": 1.0, "black_soil": 0.9, "red_soil": 0.7, "sandy_soil": 0.6, "clay_soil": 0.4},
+	},
+	{
+		name:     "Spinach",
+		sunlight: map[string]float64{"full_sun": 0.7, "partial_shade": 1.0, "full_shade": 0.6},
+		soils:    map[string]float64{"loamy_soil": 1.0, "black_soil": 0.9, "clay_soil": 0.8, "red_soil": 0.6, "sandy_soil": 0.5},
+	},
+	{
+		name:     "Lettuce",
+		sunlight: map[string]float64{"full_sun": 0.6, "partial_shade": 1.0, "full_shade": 0.7},
+		soils:    map[string]float64{"loamy_soil": 1.0, "sandy_soil": 0.8, "black_soil": 0.8, "clay_soil": 0.7, "red_soil": 0.6},
+	},
+}
+
+// SetCropAdvisor configures the AI advisor used by RecommendCrops. Without one,
+// recommendations are purely rule-based.
+func (s *CropService) SetCropAdvisor(advisor CropAdvisor) {
+	s.mu.Lock()
+	s.advisor = advisor
+	s.mu.Unlock()
+}
+
+// RecommendCrops suggests crops suited to a garden's soil, sunlight, and free space,
+// ranked best first with expected yields. AI suggestions are used when an advisor is
+// configured and returns known crops; otherwise rule-based scoring is used.
+func (s *CropService) RecommendCrops(ctx context.Context, gardenID string) (*dto.CropRecommendationResponse, error) {
+	if err := s.acquire(); err != nil {
+		return nil, err
+	}
+	defer s.release()
+
+	garden, err := s.getGarden(ctx, gardenID)
+	if err != nil {
+		return nil, customErrors.WrapError(err, "failed to get garden")
+	}
+
+	availableSpace, soilEfficiency, err := s.availableSpace(ctx, garden)
+	if err != nil {
+		return nil, err
+	}
+
+	source := dto.RecommendationSourceRules
+	profiles := s.suggestWithAdvisor(ctx, garden)
+	if len(profiles) > 0 {
+		source = dto.RecommendationSourceAI
+	} else {
+		profiles = rankByRules(garden)
+	}
+
+	response := &dto.CropRecommendationResponse{
+		GardenID:        gardenID,
+		Source:          source,
+		Recommendations: make([]dto.CropRecommendation, 0, len(profiles)),
+	}
+
+	for i, profile := range profiles {
+		// Split free space evenly so the suggested plantings fit together, giving
+		// sprawling crops fewer bags within their share
+		spacePerBag := (&models.Crop{Name: profile.name, BagSize: dto.BagSize12, GrowBags: 1}).CalculateSpaceRequired()
+		growBags := int(math.Min(math.Floor(availableSpace/float64(len(profiles))/spacePerBag), dto.MaxGrowBags))
+
+		probe := &models.Crop{Name: profile.name, BagSize: dto.BagSize12, GrowBags: growBags, Garden: garden}
+		probe.SetSoilEfficiency(soilEfficiency)
+		response.Recommendations = append(response.Recommendations, dto.CropRecommendation{
+			Rank:                i + 1,
+			Name:                profile.name,
+			Score:               profile.score(garden),
+			BagSize:             dto.BagSize12,
+			SuggestedGrowBags:   growBags,
+			ExpectedWeeklyYield: probe.CalculateYield() * 7,
+			Reasons:             profile.reasons(garden),
+		})
+	}
+
+	return response, nil
+}
+
+// suggestWithAdvisor asks the AI advisor for crops and keeps those in the catalog, in
+// the advisor's order. It returns nil when no advisor is set or nothing usable came back.
+func (s *CropService) suggestWithAdvisor(ctx context.Context, garden *models.Garden) []cropProfile {
+	s.mu.RLock()
+	advisor := s.advisor
+	s.mu.RUnlock()
+
+	if advisor == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, advisorTimeout)
+	defer cancel()
+
+	names, err := advisor.SuggestCrops(ctx, map[string]string{
+		"soilType":   garden.SoilType,
+		"sunlight":   garden.Sunlight,
+		"dimensions": fmt.Sprintf("%.1f x %.1f ft", garden.Length, garden.Width),
+	})
+	if err != nil {
+		s.logger.Warn("crop advisor failed, falling back to rule-based recommendations",
+			zap.String("gardenId", garden.ID),
+			zap.Error(err))
+		return nil
+	}
+
+	profiles := make([]cropProfile, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		for _, profile := range cropCatalog {
+			if dto.NormalizeCropName(name) == profile.name && !seen[profile.name] {
+				// Drop AI suggestions the garden's light cannot support
+				if profile.sunlight[garden.Sunlight] > 0 {
+					profiles = append(profiles, profile)
+				}
+				seen[profile.name] = true
+			}
+		}
+	}
+
+	return profiles
+}
+
+// rankByRules orders catalog crops by suitability, leaving out crops the garden's light cannot support
+func rankByRules(garden *models.Garden) []cropProfile {
+	profiles := make([]cropProfile, 0, len(cropCatalog))
+	for _, profile := range cropCatalog {
+		if profile.sunlight[garden.Sunlight] > 0 {
+			profiles = append(profiles, profile)
+		}
+	}
+
+	sort.SliceStable(profiles, func(i, j int) bool {
+		return profiles[i].score(garden) > profiles[j].score(garden)
+	})
+
+	return profiles
+}
+
+// score combines sunlight and soil suitability into a 0-1 score
+func (p cropProfile) score(garden *models.Garden) float64 {
+	return p.sunlight[garden.Sunlight]*sunlightWeight + p.soils[garden.SoilType]*soilWeight
+}
+
+// reasons explains which of the garden's conditions suit the crop well
+func (p cropProfile) reasons(garden *models.Garden) []string {
+	var reasons []string
+	if p.sunlight[garden.Sunlight] >= goodFitScore {
+		reasons = append(reasons, "well suited to "+strings.ReplaceAll(garden.Sunlight, "_", " "))
+	}
+	if p.soils[garden.SoilType] >= goodFitScore {
+		reasons = append(reasons, "grows well in "+strings.ReplaceAll(garden.SoilType, "_", " "))
+	}
+	return reasons
+}
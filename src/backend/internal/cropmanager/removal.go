@@ -0,0 +1,108 @@
+package cropmanager
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors" // v0.9.1
+	"gorm.io/gorm"          // v1.25.0
+
+	"github.com/urban-gardening-assistant/backend/internal/models"
+	"github.com/urban-gardening-assistant/backend/pkg/dto"
+	customErrors "github.com/urban-gardening-assistant/backend/internal/utils/errors"
+)
+
+// DeleteCrop soft-deletes a crop and records the garden's utilization once it is gone
+func (s *CropService) DeleteCrop(ctx context.Context, cropID string) (err error) {
+	if err := s.acquire(); err != nil {
+		return err
+	}
+	defer s.release()
+	defer s.observeOperation(OperationDelete, time.Now(), &err)
+
+	crop := &models.Crop{}
+	var crossings []dto.CapacityThresholdEvent
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(crop, "id = ? AND deleted_at IS NULL", cropID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return customErrors.NewError("NOT_FOUND", "crop not found")
+			}
+			return customErrors.WrapError(err, "failed to query crop")
+		}
+
+		// UpdateColumn skips the update hooks; deletion changes no yield or space inputs
+		if err := tx.Model(crop).UpdateColumn("deleted_at", s.clock.Now()).Error; err != nil {
+			return customErrors.WrapError(err, "failed to delete crop")
+		}
+
+		var err error
+		crossings, err = s.recordUtilization(ctx, tx, crop.GardenID, models.UtilizationTriggerCropDeleted, crop.ID, -crop.CalculateSpaceRequired())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	s.fireCapacityWebhooks(ctx, crossings)
+
+	s.mu.Lock()
+	s.cache.Delete(cropCachePrefix + crop.ID)
+	s.mu.Unlock()
+	return nil
+}
+
+// PreviewCropRemoval reports the space a crop would free, the garden's utilization
+// before and after removing it, and the daily yield that would be lost. Nothing is
+// changed.
+func (s *CropService) PreviewCropRemoval(ctx context.Context, cropID string) (*dto.CropRemovalPreviewResponse, error) {
+	if err := s.acquire(); err != nil {
+		return nil, err
+	}
+	defer s.release()
+
+	crop := &models.Crop{}
+	if err := s.db.WithContext(ctx).First(crop, "id = ? AND deleted_at IS NULL", cropID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.NewError("NOT_FOUND", "crop not found")
+		}
+		return nil, customErrors.WrapError(err, "failed to query crop")
+	}
+
+	garden, err := s.getGarden(ctx, crop.GardenID)
+	if err != nil {
+		return nil, customErrors.WrapError(err, "failed to get garden")
+	}
+	crop.Garden = garden
+
+	capacity, err := s.ValidateSpaceCapacity(ctx, garden.ID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	soilEfficiency, err := s.calculateSoilEfficiency(garden)
+	if err != nil {
+		return nil, err
+	}
+	crop.SetSoilEfficiency(soilEfficiency)
+
+	// Utilization is measured the same way as ValidateSpaceCapacity: used space
+	// adjusted for soil efficiency over the garden area
+	freedSpace := crop.CalculateSpaceRequired()
+	remaining := capacity.UsedSpace - freedSpace
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	response := &dto.CropRemovalPreviewResponse{
+		CropID:             crop.ID,
+		GardenID:           garden.ID,
+		CropName:           crop.Name,
+		FreedSpace:         freedSpace,
+		CurrentUtilization: capacity.SpaceUtilization,
+		LostDailyYield:     crop.CalculateYield(),
+	}
+	if capacity.TotalSpace > 0 {
+		response.ResultingUtilization = (remaining / soilEfficiency / capacity.TotalSpace) * 100
+	}
+
+	return response, nil
+}
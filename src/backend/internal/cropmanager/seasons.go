@@ -0,0 +1,82 @@
+package cropmanager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/urban-gardening-assistant/backend/pkg/dto"
+	customErrors "github.com/urban-gardening-assistant/backend/internal/utils/errors"
+	"github.com/urban-gardening/backend/internal/utils/clock"
+)
+
+// plantingWindow is an inclusive range of months for planting outdoors. A window whose
+// end comes before its start wraps around the new year.
+type plantingWindow struct {
+	Start time.Month
+	End   time.Month
+}
+
+// contains reports whether month falls within the window
+func (w plantingWindow) contains(month time.Month) bool {
+	if w.Start <= w.End {
+		return month >= w.Start && month <= w.End
+	}
+	return month >= w.Start || month <= w.End
+}
+
+// String formats the window as a month range, e.g. "March-June"
+func (w plantingWindow) String() string {
+	return w.Start.String() + "-" + w.End.String()
+}
+
+// plantingWindows lists the months each crop can be planted outdoors in a temperate
+// northern hemisphere climate. Warm-season fruiting crops need frost-free months; leafy
+// greens bolt in midsummer heat, so they have a spring and an autumn window.
+var plantingWindows = map[string][]plantingWindow{
+	dto.CropTomatoes: {{Start: time.March, End: time.June}},
+	dto.CropPeppers:  {{Start: time.March, End: time.June}},
+	dto.CropEggplant: {{Start: time.April, End: time.June}},
+	dto.CropSpinach:  {{Start: time.February, End: time.May}, {Start: time.August, End: time.October}},
+	dto.CropLettuce:  {{Start: time.February, End: time.May}, {Start: time.August, End: time.September}},
+}
+
+// SetClock replaces the time source used for planting windows and harvest goal periods
+func (s *CropService) SetClock(c clock.Clock) {
+	s.mu.Lock()
+	s.clock = clock.OrReal(c)
+	s.mu.Unlock()
+}
+
+// seasonalWarning returns a warning when cropName is being planted in an outdoor garden
+// outside its planting window, or "" otherwise. Indoor and greenhouse gardens and crops
+// without a known window are never warned.
+func (s *CropService) seasonalWarning(ctx context.Context, gardenID, cropName string) (string, error) {
+	windows, ok := plantingWindows[dto.NormalizeCropName(cropName)]
+	if !ok {
+		return "", nil
+	}
+
+	garden, err := s.getGarden(ctx, gardenID)
+	if err != nil {
+		return "", customErrors.WrapError(err, "failed to get garden")
+	}
+	if garden.IsSheltered() {
+		return "", nil
+	}
+
+	s.mu.RLock()
+	month := s.clock.Now().Month()
+	s.mu.RUnlock()
+
+	ranges := make([]string, len(windows))
+	for i, window := range windows {
+		if window.contains(month) {
+			return "", nil
+		}
+		ranges[i] = window.String()
+	}
+	return fmt.Sprintf("%s planted outdoors in %s is outside the recommended planting window (%s)",
+		dto.NormalizeCropName(cropName), month, strings.Join(ranges, ", ")), nil
+}
@@ -5,6 +5,7 @@ package cropmanager
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/urban-gardening-assistant/backend/internal/models"
 	"github.com/urban-gardening-assistant/backend/pkg/dto"
 	customErrors "github.com/urban-gardening-assistant/backend/internal/utils/errors"
+	"github.com/urban-gardening/backend/internal/utils/clock"
 )
 
 // Cache TTL constants
@@ -32,45 +34,229 @@ const (
 	defaultYield        = 0.150 // Default conservative yield estimate in kg/day
 )
 
+// defaultUnknownSoilFactor is the soil efficiency applied to unrecognised soil types
+const defaultUnknownSoilFactor = 1.0
+
+// Transaction retry constants
+const (
+	maxTxAttempts     = 3                     // Bounded attempts for serialization failures
+	txRetryBaseDelay  = 50 * time.Millisecond // Linear backoff between attempts
+	sqlStateSerialize = "40001"               // serialization_failure
+	sqlStateDeadlock  = "40P01"               // deadlock_detected
+)
+
+// SoilConfig controls how soil types without a known efficiency factor are handled
+type SoilConfig struct {
+	UnknownSoilFactor float64 // Efficiency factor for unrecognised soil types
+	RejectUnknownSoil bool    // Reject unrecognised soil types instead of falling back
+}
+
+// YieldConfig controls optional refinements to yield estimates
+type YieldConfig struct {
+	AdjustForEnvironment bool // Apply request growing conditions to yield estimates
+}
+
+// ReadConfig controls how crop reads degrade when the database is unavailable
+type ReadConfig struct {
+	ServeStale bool // Serve cached crops flagged as stale when the database fails
+}
+
+// CapacityConfig controls when created crops carry an approaching-capacity warning and
+// whether crops that would exceed the garden's capacity are created at all
+type CapacityConfig struct {
+	WarningThreshold  float64 // Fraction of garden capacity (0-1) at which to warn
+	AllowOverCapacity bool    // Create over-capacity crops with a warning instead of rejecting them
+}
+
 // CropService implements sophisticated crop management functionality
 type CropService struct {
-	db     *gorm.DB
-	cache  *cache.Cache
-	logger *zap.Logger
-	mu     sync.RWMutex // Protects concurrent cache operations
+	db            *gorm.DB
+	cache         *cache.Cache
+	logger        *zap.Logger
+	soil          SoilConfig
+	yield         YieldConfig
+	reads         ReadConfig
+	capacity      CapacityConfig
+	bagLimits     BagLimitConfig
+	bagFit        BagFitConfig
+	names         DuplicateNameConfig
+	cropNames     CropNameConfig
+	metrics       *Metrics        // Optional crop operation metrics
+	advisor       CropAdvisor     // Optional AI advisor for crop recommendations
+	gardenAdvisor GardenAdvisor   // Optional AI advisor for garden-wide recommendations
+	webhook       CapacityWebhook // Optional webhook for capacity threshold crossings
+	webhookAt     []float64       // Capacity fractions that fire the webhook, ascending
+	webhookTries  int             // Attempts made to deliver each capacity event
+	webhookDelay  time.Duration   // Wait before the first capacity event retry, doubling after
+	clock         clock.Clock     // Source of the current date for planting windows and harvest goals
+	mu            sync.RWMutex    // Protects concurrent cache operations
+	inFlight      sync.WaitGroup  // Operations that may still write to the cache, and webhook deliveries
+	closed        bool            // Set by Close; rejects new operations
+	closing       chan struct{}   // Closed by Close to stop cache sweeps and capacity webhook retries
 }
 
-// NewCropService creates a new instance of CropService with enhanced capabilities
+// NewCropService creates a new instance of CropService with enhanced capabilities. The
+// service sweeps expired entries from cache itself until it is closed, so the cache
+// should be created without a cleanup interval of its own.
 func NewCropService(db *gorm.DB, cache *cache.Cache, logger *zap.Logger) *CropService {
-	return &CropService{
-		db:     db,
-		cache:  cache,
-		logger: logger.Named("crop-service"),
+	s := &CropService{
+		db:        db,
+		cache:     cache,
+		logger:    logger.Named("crop-service"),
+		soil:      SoilConfig{UnknownSoilFactor: defaultUnknownSoilFactor},
+		reads:     ReadConfig{ServeStale: true},
+		capacity:  CapacityConfig{WarningThreshold: capacityThresholds.warning},
+		bagLimits: BagLimitConfig{Policy: BagLimitSuggest},
+		bagFit:    BagFitConfig{Policy: BagFitWarn},
+		clock:     clock.Real(),
+		closing:   make(chan struct{}),
 	}
+	go s.sweepCache(cacheCleanupInterval)
+	return s
+}
+
+// sweepCache deletes expired cache entries every interval until Close is called
+func (s *CropService) sweepCache(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.cache.DeleteExpired()
+		case <-s.closing:
+			return
+		}
+	}
+}
+
+// SetSoilConfig overrides how unrecognised soil types are handled in space calculations
+func (s *CropService) SetSoilConfig(cfg SoilConfig) error {
+	if cfg.UnknownSoilFactor <= 0 {
+		return customErrors.NewError("VALIDATION_ERROR", "unknown soil factor must be positive")
+	}
+
+	s.mu.Lock()
+	s.soil = cfg
+	s.mu.Unlock()
+	return nil
+}
+
+// SetYieldConfig configures optional yield estimate refinements. Environmental
+// adjustment is disabled unless enabled here.
+func (s *CropService) SetYieldConfig(cfg YieldConfig) {
+	s.mu.Lock()
+	s.yield = cfg
+	s.mu.Unlock()
+}
+
+// SetReadConfig configures how crop reads degrade when the database is unavailable.
+// Stale reads are enabled by default.
+func (s *CropService) SetReadConfig(cfg ReadConfig) {
+	s.mu.Lock()
+	s.reads = cfg
+	s.mu.Unlock()
+}
+
+// SetCapacityConfig sets the utilization at which created crops carry an
+// approaching-capacity warning, and whether crops exceeding the garden's capacity are
+// allowed. The default is to warn at 80% and reject over-capacity crops.
+func (s *CropService) SetCapacityConfig(cfg CapacityConfig) error {
+	if cfg.WarningThreshold <= 0 || cfg.WarningThreshold >= 1 {
+		return customErrors.NewError("VALIDATION_ERROR", "capacity warning threshold must be between 0 and 1")
+	}
+
+	s.mu.Lock()
+	s.capacity = cfg
+	s.mu.Unlock()
+	return nil
+}
+
+// Close stops accepting new operations and sweeping the cache, waits for in-flight
+// operations, their cache writes, and capacity webhook deliveries already under way to
+// finish, abandoning their remaining retries, then flushes the cache. It is safe to call
+// more than once.
+func (s *CropService) Close(ctx context.Context) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.closing)
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return customErrors.WrapError(ctx.Err(), "timed out waiting for in-flight crop operations")
+	}
+
+	s.cache.Flush()
+
+	s.logger.Info("crop service closed")
+	return nil
+}
+
+// acquire registers an in-flight operation, failing once the service is closed
+func (s *CropService) acquire() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return customErrors.NewError("SERVICE_CLOSED", "crop service is shutting down")
+	}
+	s.inFlight.Add(1)
+	return nil
+}
+
+// release marks an operation registered by acquire as finished
+func (s *CropService) release() {
+	s.inFlight.Done()
 }
 
 // CreateCrop implements sophisticated crop creation with yield calculations
-func (s *CropService) CreateCrop(ctx context.Context, req *dto.CropRequest) (*dto.CropResponse, error) {
-	// Start transaction
-	tx := s.db.WithContext(ctx).Begin()
-	if tx.Error != nil {
-		return nil, customErrors.WrapError(tx.Error, "failed to start transaction")
+func (s *CropService) CreateCrop(ctx context.Context, req *dto.CropRequest) (resp *dto.CropResponse, err error) {
+	if err := s.acquire(); err != nil {
+		return nil, err
 	}
-	defer tx.Rollback()
+	defer s.release()
+	defer s.observeOperation(OperationCreate, time.Now(), &err)
 
 	// Validate request
 	if err := dto.ValidateCropRequest(req); err != nil {
 		return nil, customErrors.WrapError(err, "invalid crop request")
 	}
+	if err := s.checkCropName(req.Name); err != nil {
+		return nil, err
+	}
 
-	// Validate space capacity
-	validationResp, err := s.ValidateSpaceCapacity(ctx, req.GardenID, req.GrowBags)
+	err = s.withSerializationRetry(ctx, func() error {
+		var txErr error
+		resp, txErr = s.createCropTx(ctx, req)
+		return txErr
+	})
 	if err != nil {
 		return nil, err
 	}
-	if !validationResp.IsValid {
-		return nil, customErrors.NewError("SPACE_EXCEEDED", validationResp.Message)
+
+	return resp, nil
+}
+
+// createCropTx runs a single crop creation attempt inside its own transaction
+func (s *CropService) createCropTx(ctx context.Context, req *dto.CropRequest) (*dto.CropResponse, error) {
+	// Start transaction
+	tx := s.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, customErrors.WrapError(tx.Error, "failed to start transaction")
 	}
+	defer tx.Rollback()
 
 	// Create crop model
 	crop := &models.Crop{}
@@ -78,6 +264,43 @@ func (s *CropService) CreateCrop(ctx context.Context, req *dto.CropRequest) (*dt
 		return nil, customErrors.WrapError(err, "failed to create crop model")
 	}
 
+	// Keep crop names unique within the garden when configured to
+	if err := s.checkDuplicateName(tx, req.GardenID, crop.Name); err != nil {
+		return nil, err
+	}
+
+	// Check the bag is large enough for the crop to grow to full size
+	bagSizeWarning, err := s.checkBagFit(crop)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hold large crops to the number of bags the garden can practically fit
+	requestedGrowBags, err := s.applyGrowBagLimit(ctx, crop)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate space capacity against the space this crop's bags and canopy take up
+	validationResp, err := s.validateSpaceCapacity(ctx, req.GardenID, crop.CalculateSpaceRequired())
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	allowOverCapacity := s.capacity.AllowOverCapacity
+	s.mu.RUnlock()
+	if !validationResp.IsValid && !allowOverCapacity {
+		return nil, customErrors.NewError("SPACE_EXCEEDED", validationResp.Message)
+	}
+
+	// Refine the estimate with measured conditions only when enabled
+	s.mu.RLock()
+	adjustForEnvironment := s.yield.AdjustForEnvironment
+	s.mu.RUnlock()
+	if adjustForEnvironment {
+		crop.Conditions = req.Conditions
+	}
+
 	// Calculate yield with accuracy validation
 	estimatedYield := crop.CalculateYield()
 	if err := s.validateYieldAccuracy(estimatedYield); err != nil {
@@ -89,6 +312,11 @@ func (s *CropService) CreateCrop(ctx context.Context, req *dto.CropRequest) (*dt
 		return nil, customErrors.WrapError(err, "failed to save crop")
 	}
 
+	crossings, err := s.recordUtilization(ctx, tx, req.GardenID, models.UtilizationTriggerCropCreated, crop.ID, crop.CalculateSpaceRequired())
+	if err != nil {
+		return nil, err
+	}
+
 	// Update cache
 	s.updateCropCache(crop)
 
@@ -96,12 +324,239 @@ func (s *CropService) CreateCrop(ctx context.Context, req *dto.CropRequest) (*dt
 	if err := tx.Commit().Error; err != nil {
 		return nil, customErrors.WrapError(err, "failed to commit transaction")
 	}
+	s.fireCapacityWebhooks(ctx, crossings)
+
+	resp := crop.ToResponse()
+	resp.RequestedGrowBags = requestedGrowBags
+	resp.BagSizeWarning = bagSizeWarning
+
+	if validationResp.IsValid {
+		resp.CapacityWarning = s.capacityWarning(validationResp.SpaceUtilization)
+	} else {
+		// Only reached when over-capacity crops are allowed
+		resp.OverCapacity = true
+		resp.CapacityWarning = fmt.Sprintf("WARNING: garden is over capacity at %.2f%% used; crops will be overcrowded and yields reduced. %s",
+			validationResp.SpaceUtilization, validationResp.Message)
+	}
+
+	seasonalWarning, err := s.seasonalWarning(ctx, req.GardenID, crop.Name)
+	if err != nil {
+		return nil, err
+	}
+	resp.SeasonalWarning = seasonalWarning
+	return resp, nil
+}
+
+// capacityWarning returns an approaching-capacity warning when utilization, a percentage
+// adjusted for soil efficiency, reaches the configured share of the garden's area, or ""
+// otherwise
+func (s *CropService) capacityWarning(utilization float64) string {
+	s.mu.RLock()
+	warningThreshold := s.capacity.WarningThreshold
+	s.mu.RUnlock()
+
+	if utilization < warningThreshold*100 {
+		return ""
+	}
+	return fmt.Sprintf("approaching garden capacity: %.2f%% used", utilization)
+}
+
+// ListCrops returns a page of crops, optionally filtered by garden, starred flag, and
+// growing method
+func (s *CropService) ListCrops(ctx context.Context, params dto.PaginationParams) (*dto.CropListResponse, error) {
+	if err := s.acquire(); err != nil {
+		return nil, err
+	}
+	defer s.release()
+
+	if params.Page < 1 || params.PerPage < 1 {
+		return nil, customErrors.NewError("VALIDATION_ERROR", "page and perPage must be positive")
+	}
+
+	query := s.db.WithContext(ctx).Model(&models.Crop{}).Where("deleted_at IS NULL")
+	if params.GardenID != "" {
+		query = query.Where("garden_id = ?", params.GardenID)
+	}
+	if params.Starred != nil {
+		query = query.Where("starred = ?", *params.Starred)
+	}
+	if params.Organic != nil {
+		query = query.Where("organic = ?", *params.Organic)
+	}
 
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, customErrors.WrapError(err, "failed to count crops")
+	}
+
+	var crops []models.Crop
+	if err := query.
+		Order(cropSortOrder(params.SortBy, params.SortDir)).
+		Offset((params.Page - 1) * params.PerPage).
+		Limit(params.PerPage).
+		Find(&crops).Error; err != nil {
+		return nil, customErrors.WrapError(err, "failed to list crops")
+	}
+
+	response := &dto.CropListResponse{
+		Crops:   make([]dto.CropResponse, len(crops)),
+		Total:   int(total),
+		Page:    params.Page,
+		PerPage: params.PerPage,
+	}
+	for i := range crops {
+		response.Crops[i] = *crops[i].ToResponse()
+	}
+
+	return response, nil
+}
+
+// ToggleCropStar flips the starred flag on a crop and returns the updated crop
+func (s *CropService) ToggleCropStar(ctx context.Context, cropID string) (_ *dto.CropResponse, err error) {
+	if err := s.acquire(); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	defer s.observeOperation(OperationUpdate, time.Now(), &err)
+
+	crop := &models.Crop{}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(crop, "id = ? AND deleted_at IS NULL", cropID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return customErrors.NewError("NOT_FOUND", "crop not found")
+			}
+			return customErrors.WrapError(err, "failed to query crop")
+		}
+
+		crop.Starred = !crop.Starred
+		// UpdateColumn skips the update hooks; starring changes no yield or space inputs
+		if err := tx.Model(crop).UpdateColumn("starred", crop.Starred).Error; err != nil {
+			return customErrors.WrapError(err, "failed to update crop")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.updateCropCache(crop)
 	return crop.ToResponse(), nil
 }
 
-// ValidateSpaceCapacity performs detailed space capacity validation
+// GetCrop retrieves a crop by ID and refreshes its cached copy. When stale reads are
+// enabled and the database fails for a reason other than a missing record, the cached
+// crop is returned with Stale set instead of an error.
+func (s *CropService) GetCrop(ctx context.Context, cropID string) (*dto.CropResponse, error) {
+	if err := s.acquire(); err != nil {
+		return nil, err
+	}
+	defer s.release()
+
+	crop := &models.Crop{}
+	if err := s.db.WithContext(ctx).First(crop, "id = ? AND deleted_at IS NULL", cropID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.NewError("NOT_FOUND", "crop not found")
+		}
+
+		s.mu.RLock()
+		serveStale := s.reads.ServeStale
+		cached, found := s.cache.Get(fmt.Sprintf("%s%s", cropCachePrefix, cropID))
+		s.mu.RUnlock()
+		if serveStale && found {
+			s.logger.Warn("serving stale crop after database error",
+				zap.String("cropId", cropID),
+				zap.Error(err))
+			response := cached.(*models.Crop).ToResponse()
+			response.Stale = true
+			return response, nil
+		}
+
+		return nil, customErrors.WrapError(err, "failed to query crop")
+	}
+
+	s.updateCropCache(crop)
+	return s.withTargetProgress(ctx, crop)
+}
+
+// cropSortOrder maps API sort parameters to a safe ORDER BY clause, newest first by default
+func cropSortOrder(sortBy, sortDir string) string {
+	columns := map[string]string{
+		"name":           "name",
+		"createdAt":      "created_at",
+		"estimatedYield": "estimated_yield",
+	}
+
+	column, ok := columns[sortBy]
+	if !ok {
+		column = "created_at"
+	}
+
+	direction := "DESC"
+	if strings.EqualFold(sortDir, "asc") {
+		direction = "ASC"
+	}
+
+	return column + " " + direction
+}
+
+// withSerializationRetry re-runs fn while it fails with a retryable transaction
+// conflict, up to maxTxAttempts attempts
+func (s *CropService) withSerializationRetry(ctx context.Context, fn func() error) error {
+	var err error
+	for attempt := 1; attempt <= maxTxAttempts; attempt++ {
+		if err = fn(); err == nil || !isSerializationFailure(err) {
+			return err
+		}
+
+		s.logger.Warn("transaction serialization failure, retrying",
+			zap.Int("attempt", attempt),
+			zap.Int("max_attempts", maxTxAttempts),
+			zap.Error(err))
+
+		if attempt == maxTxAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return customErrors.WrapError(ctx.Err(), "transaction retry cancelled")
+		case <-time.After(time.Duration(attempt) * txRetryBaseDelay):
+		}
+	}
+
+	return customErrors.WrapError(err, fmt.Sprintf("transaction failed after %d attempts", maxTxAttempts))
+}
+
+// isSerializationFailure reports whether err is a retryable Postgres
+// serialization or deadlock failure
+func isSerializationFailure(err error) bool {
+	var sqlErr interface{ SQLState() string }
+	if errors.As(err, &sqlErr) {
+		code := sqlErr.SQLState()
+		return code == sqlStateSerialize || code == sqlStateDeadlock
+	}
+
+	msg := err.Error()
+	return strings.Contains(msg, sqlStateSerialize) ||
+		strings.Contains(msg, "could not serialize access") ||
+		strings.Contains(msg, "deadlock detected")
+}
+
+// ValidateSpaceCapacity performs detailed space capacity validation for adding
+// newGrowBags standard 12" grow bags to the garden
 func (s *CropService) ValidateSpaceCapacity(ctx context.Context, gardenID string, newGrowBags int) (*dto.SpaceValidationResponse, error) {
+	if err := s.acquire(); err != nil {
+		return nil, err
+	}
+	defer s.release()
+
+	newCrop := &models.Crop{GrowBags: newGrowBags, BagSize: dto.BagSize12}
+	return s.validateSpaceCapacity(ctx, gardenID, newCrop.CalculateSpaceRequired())
+}
+
+// validateSpaceCapacity checks that newSpace square feet fit in the garden alongside its
+// existing crops, adjusted for soil efficiency
+func (s *CropService) validateSpaceCapacity(ctx context.Context, gardenID string, newSpace float64) (*dto.SpaceValidationResponse, error) {
 	// Get garden from cache or database
 	garden, err := s.getGarden(ctx, gardenID)
 	if err != nil {
@@ -123,16 +578,16 @@ func (s *CropService) ValidateSpaceCapacity(ctx context.Context, gardenID string
 	// Calculate current space usage
 	currentSpace := 0.0
 	for _, crop := range existingCrops {
-		currentSpace += crop.calculateSpaceRequired()
+		currentSpace += crop.CalculateSpaceRequired()
 	}
 
-	// Calculate new space requirement
-	newCrop := &models.Crop{GrowBags: newGrowBags}
-	newSpace := newCrop.calculateSpaceRequired()
 	totalRequired := currentSpace + newSpace
 
 	// Apply soil efficiency factor
-	soilEfficiency := s.calculateSoilEfficiency(garden.SoilType)
+	soilEfficiency, err := s.calculateSoilEfficiency(garden)
+	if err != nil {
+		return nil, err
+	}
 	adjustedSpace := totalRequired / soilEfficiency
 
 	// Generate validation response
@@ -211,18 +666,26 @@ func (s *CropService) updateCropCache(crop *models.Crop) {
 	s.mu.Unlock()
 }
 
-// calculateSoilEfficiency returns soil efficiency factor for space calculations
-func (s *CropService) calculateSoilEfficiency(soilType string) float64 {
-	efficiencyFactors := map[string]float64{
-		"red_soil":    1.0,
-		"sandy_soil":  0.8,
-		"loamy_soil":  1.2,
-		"clay_soil":   0.9,
-		"black_soil":  1.1,
+// calculateSoilEfficiency returns a garden's soil efficiency factor for space calculations,
+// taking the garden's override over its soil type's factor. Unknown soil types fall back
+// to the configured factor, or are rejected in strict mode.
+func (s *CropService) calculateSoilEfficiency(garden *models.Garden) (float64, error) {
+	if factor, exists := garden.SoilEfficiency(); exists {
+		return factor, nil
 	}
+	soilType := garden.SoilType
+
+	s.mu.RLock()
+	soil := s.soil
+	s.mu.RUnlock()
 
-	if factor, exists := efficiencyFactors[soilType]; exists {
-		return factor
+	if soil.RejectUnknownSoil {
+		return 0, customErrors.NewError("INVALID_SOIL_TYPE", fmt.Sprintf("unknown soil type %q", soilType))
 	}
-	return 1.0 // Default factor for unknown soil types
+
+	s.logger.Warn("unknown soil type, using fallback efficiency factor",
+		zap.String("soilType", soilType),
+		zap.Float64("factor", soil.UnknownSoilFactor))
+
+	return soil.UnknownSoilFactor, nil
 }
\ No newline at end of file
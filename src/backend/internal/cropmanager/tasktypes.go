@@ -0,0 +1,62 @@
+package cropmanager
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors" // v0.9.1
+	"gorm.io/gorm"          // v1.25.0
+
+	"github.com/urban-gardening-assistant/backend/internal/models"
+	customErrors "github.com/urban-gardening-assistant/backend/internal/utils/errors"
+	"github.com/urban-gardening-assistant/backend/pkg/dto"
+)
+
+// SetTaskTypeEnabled turns one maintenance task type on or off for a crop, e.g. turning
+// off watering for cacti, and returns the updated crop. Schedule suggestions leave out
+// disabled task types and new schedules of those types are rejected; existing schedules
+// are left as they are.
+func (s *CropService) SetTaskTypeEnabled(ctx context.Context, cropID string, req *dto.TaskTypeToggleRequest) (_ *dto.CropResponse, err error) {
+	if err := s.acquire(); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	defer s.observeOperation(OperationUpdate, time.Now(), &err)
+
+	if req == nil || models.TaskUnit(req.TaskType) == "" {
+		return nil, customErrors.NewError("VALIDATION_ERROR", "task type must be one of Fertilizer, Water, Composting, Pruning, or Pest Control")
+	}
+
+	crop := &models.Crop{}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(crop, "id = ? AND deleted_at IS NULL", cropID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return customErrors.NewError("NOT_FOUND", "crop not found")
+			}
+			return customErrors.WrapError(err, "failed to query crop")
+		}
+
+		disabled := make([]string, 0, len(crop.DisabledTaskTypeList())+1)
+		for _, taskType := range crop.DisabledTaskTypeList() {
+			if taskType != req.TaskType {
+				disabled = append(disabled, taskType)
+			}
+		}
+		if !req.Enabled {
+			disabled = append(disabled, req.TaskType)
+		}
+		crop.SetDisabledTaskTypes(disabled)
+
+		// UpdateColumn skips the update hooks; task types change no yield or space inputs
+		if err := tx.Model(crop).UpdateColumn("disabled_task_types", crop.DisabledTaskTypes).Error; err != nil {
+			return customErrors.WrapError(err, "failed to update crop")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.updateCropCache(crop)
+	return crop.ToResponse(), nil
+}
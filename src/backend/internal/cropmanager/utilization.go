@@ -0,0 +1,176 @@
+package cropmanager
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"go.uber.org/zap" // v1.24.0
+	"gorm.io/gorm"    // v1.25.0
+
+	"github.com/urban-gardening-assistant/backend/internal/models"
+	"github.com/urban-gardening-assistant/backend/pkg/dto"
+	customErrors "github.com/urban-gardening-assistant/backend/internal/utils/errors"
+)
+
+// recordUtilization saves a snapshot of a garden's space utilization within tx, after a
+// change to its crops in the same transaction. Utilization is measured as
+// ValidateSpaceCapacity measures it: used space adjusted for soil efficiency over the
+// garden area. changedSpace is the space the change added, negative for removals; it
+// gives the utilization before the change, and the capacity thresholds crossed between
+// the two are returned for firing once the transaction commits.
+func (s *CropService) recordUtilization(ctx context.Context, tx *gorm.DB, gardenID, trigger, cropID string, changedSpace float64) ([]dto.CapacityThresholdEvent, error) {
+	garden, err := s.getGarden(ctx, gardenID)
+	if err != nil {
+		return nil, customErrors.WrapError(err, "failed to get garden")
+	}
+
+	gardenArea, err := garden.CalculateArea()
+	if err != nil {
+		return nil, customErrors.WrapError(err, "failed to calculate garden area")
+	}
+
+	soilEfficiency, err := s.calculateSoilEfficiency(garden)
+	if err != nil {
+		return nil, err
+	}
+
+	var crops []models.Crop
+	if err := tx.Where("garden_id = ? AND deleted_at IS NULL", gardenID).Find(&crops).Error; err != nil {
+		return nil, customErrors.WrapError(err, "failed to get existing crops")
+	}
+
+	usedSpace := 0.0
+	for i := range crops {
+		usedSpace += crops[i].CalculateSpaceRequired()
+	}
+
+	snapshot := &models.UtilizationSnapshot{
+		GardenID:   gardenID,
+		CropID:     cropID,
+		Trigger:    trigger,
+		CropCount:  len(crops),
+		UsedSpace:  usedSpace,
+		TotalSpace: gardenArea,
+		RecordedAt: s.clock.Now(),
+	}
+	previousUtilization := 0.0
+	if gardenArea > 0 {
+		snapshot.Utilization = usedSpace / soilEfficiency / gardenArea * 100
+		previousUtilization = math.Max(usedSpace-changedSpace, 0) / soilEfficiency / gardenArea * 100
+	}
+
+	if err := tx.Create(snapshot).Error; err != nil {
+		return nil, customErrors.WrapError(err, "failed to record utilization snapshot")
+	}
+	return s.capacityCrossings(gardenID, trigger, cropID, previousUtilization, snapshot.Utilization), nil
+}
+
+// CropsRestored catches the service up with crops a garden snapshot restore wrote
+// directly. The restored and removed crops are dropped from the cache, and the garden's
+// utilization is recorded against its last snapshot from before the restore, firing the
+// capacity webhook for any threshold crossed. The restore has already been applied, so
+// failures are logged rather than returned.
+func (s *CropService) CropsRestored(ctx context.Context, gardenID string, cropIDs []string) {
+	if err := s.acquire(); err != nil {
+		return
+	}
+	defer s.release()
+
+	var err error
+	defer s.observeOperation(OperationRestore, time.Now(), &err)
+
+	s.mu.Lock()
+	for _, cropID := range cropIDs {
+		s.cache.Delete(cropCachePrefix + cropID)
+	}
+	s.mu.Unlock()
+
+	var crossings []dto.CapacityThresholdEvent
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Utilization is recorded with every crop change, so the latest snapshot holds the
+		// space used before the restore
+		previousSpace := 0.0
+		var previous models.UtilizationSnapshot
+		err := tx.Where("garden_id = ?", gardenID).Order("recorded_at DESC").First(&previous).Error
+		switch {
+		case err == nil:
+			previousSpace = previous.UsedSpace
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return customErrors.WrapError(err, "failed to get latest utilization snapshot")
+		}
+
+		var crops []models.Crop
+		if err := tx.Where("garden_id = ? AND deleted_at IS NULL", gardenID).Find(&crops).Error; err != nil {
+			return customErrors.WrapError(err, "failed to get restored crops")
+		}
+		usedSpace := 0.0
+		for i := range crops {
+			usedSpace += crops[i].CalculateSpaceRequired()
+		}
+
+		crossings, err = s.recordUtilization(ctx, tx, gardenID, models.UtilizationTriggerRestored, "", usedSpace-previousSpace)
+		return err
+	})
+	if err != nil {
+		s.logger.Error("failed to record utilization after snapshot restore",
+			zap.String("gardenId", gardenID),
+			zap.Error(err))
+		return
+	}
+	s.fireCapacityWebhooks(ctx, crossings)
+}
+
+// GetUtilizationHistory returns the garden's utilization snapshots recorded at or after
+// from and before to, oldest first. A zero bound leaves that end of the range open.
+func (s *CropService) GetUtilizationHistory(ctx context.Context, gardenID string, from, to time.Time) (*dto.UtilizationHistoryResponse, error) {
+	if err := s.acquire(); err != nil {
+		return nil, err
+	}
+	defer s.release()
+
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return nil, customErrors.NewError("VALIDATION_ERROR", "from must be before to")
+	}
+
+	if _, err := s.getGarden(ctx, gardenID); err != nil {
+		return nil, err
+	}
+
+	query := s.db.WithContext(ctx).Where("garden_id = ?", gardenID)
+	if !from.IsZero() {
+		query = query.Where("recorded_at >= ?", from)
+	}
+	if !to.IsZero() {
+		query = query.Where("recorded_at < ?", to)
+	}
+
+	var snapshots []models.UtilizationSnapshot
+	if err := query.Order("recorded_at ASC").Find(&snapshots).Error; err != nil {
+		return nil, customErrors.WrapError(err, "failed to get utilization history")
+	}
+
+	response := &dto.UtilizationHistoryResponse{
+		GardenID:  gardenID,
+		Snapshots: make([]dto.UtilizationSnapshot, len(snapshots)),
+	}
+	if !from.IsZero() {
+		response.From = &from
+	}
+	if !to.IsZero() {
+		response.To = &to
+	}
+	for i, snapshot := range snapshots {
+		response.Snapshots[i] = dto.UtilizationSnapshot{
+			Trigger:     snapshot.Trigger,
+			CropID:      snapshot.CropID,
+			CropCount:   snapshot.CropCount,
+			UsedSpace:   snapshot.UsedSpace,
+			TotalSpace:  snapshot.TotalSpace,
+			Utilization: snapshot.Utilization,
+			RecordedAt:  snapshot.RecordedAt,
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,221 @@
+package cropmanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"go.uber.org/zap" // v1.24.0
+
+	"github.com/urban-gardening-assistant/backend/pkg/dto"
+	customErrors "github.com/urban-gardening-assistant/backend/internal/utils/errors"
+	"github.com/urban-gardening/backend/internal/utils/clock"
+)
+
+// Directions in which a garden's utilization can cross a capacity threshold
+const (
+	ThresholdRising  = "rising"
+	ThresholdFalling = "falling"
+)
+
+// defaultWebhookTimeout bounds a single capacity webhook request
+const defaultWebhookTimeout = 5 * time.Second
+
+// Capacity webhook retry defaults used when the config leaves them unset
+const (
+	defaultWebhookAttempts   = 3
+	defaultWebhookRetryDelay = 1 * time.Second
+)
+
+// CapacityWebhook delivers capacity threshold events to an external endpoint
+type CapacityWebhook interface {
+	Deliver(ctx context.Context, event dto.CapacityThresholdEvent) error
+}
+
+// CapacityWebhookConfig controls which utilization levels fire the capacity webhook
+type CapacityWebhookConfig struct {
+	Thresholds  []float64     // Fractions of garden capacity; empty uses the 80% and 95% thresholds
+	MaxAttempts int           // Deliveries tried per event; zero uses 3
+	RetryDelay  time.Duration // Wait before the first retry, doubling after each; zero uses 1 second
+}
+
+// HTTPCapacityWebhook posts capacity threshold events as JSON to a URL
+type HTTPCapacityWebhook struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPCapacityWebhook creates a webhook posting to url, with each request bounded by
+// timeout, or 5 seconds when timeout is zero
+func NewHTTPCapacityWebhook(url string, timeout time.Duration) *HTTPCapacityWebhook {
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+	return &HTTPCapacityWebhook{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Deliver posts the event, failing on transport errors and non-2xx responses
+func (w *HTTPCapacityWebhook) Deliver(ctx context.Context, event dto.CapacityThresholdEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return customErrors.WrapError(err, "failed to marshal capacity event")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return customErrors.WrapError(err, "failed to create capacity webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return customErrors.WrapError(err, "failed to deliver capacity webhook")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return customErrors.NewError("WEBHOOK_FAILED", fmt.Sprintf("capacity webhook returned status %d", resp.StatusCode))
+	}
+	return nil
+}
+
+// SetCapacityWebhook configures the webhook fired when creating or deleting crops moves a
+// garden's utilization across a threshold, in either direction. A nil webhook disables it.
+func (s *CropService) SetCapacityWebhook(webhook CapacityWebhook, cfg CapacityWebhookConfig) error {
+	thresholds := cfg.Thresholds
+	if len(thresholds) == 0 {
+		thresholds = []float64{capacityThresholds.warning, capacityThresholds.critical}
+	}
+	for _, threshold := range thresholds {
+		if threshold <= 0 {
+			return customErrors.NewError("VALIDATION_ERROR", "capacity webhook thresholds must be positive")
+		}
+	}
+	if cfg.MaxAttempts < 0 || cfg.RetryDelay < 0 {
+		return customErrors.NewError("VALIDATION_ERROR", "capacity webhook retries must not be negative")
+	}
+	sorted := append([]float64(nil), thresholds...)
+	sort.Float64s(sorted)
+
+	attempts := cfg.MaxAttempts
+	if attempts == 0 {
+		attempts = defaultWebhookAttempts
+	}
+	delay := cfg.RetryDelay
+	if delay == 0 {
+		delay = defaultWebhookRetryDelay
+	}
+
+	s.mu.Lock()
+	s.webhook = webhook
+	s.webhookAt = sorted
+	s.webhookTries = attempts
+	s.webhookDelay = delay
+	s.mu.Unlock()
+	return nil
+}
+
+// waitForRetry waits delay on clk before retrying a capacity webhook delivery, reporting
+// false when Close starts or ctx ends first
+func (s *CropService) waitForRetry(ctx context.Context, clk clock.Clock, delay time.Duration) bool {
+	select {
+	case <-clock.After(clk, delay):
+		return true
+	case <-s.closing:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// capacityCrossings returns an event for each configured threshold that a garden's
+// utilization crossed between before and after, both percentages, lowest threshold first
+func (s *CropService) capacityCrossings(gardenID, trigger, cropID string, before, after float64) []dto.CapacityThresholdEvent {
+	s.mu.RLock()
+	webhook, thresholds := s.webhook, s.webhookAt
+	s.mu.RUnlock()
+	if webhook == nil {
+		return nil
+	}
+
+	var events []dto.CapacityThresholdEvent
+	for _, threshold := range thresholds {
+		percent := threshold * 100
+		var direction string
+		switch {
+		case before < percent && after >= percent:
+			direction = ThresholdRising
+		case before >= percent && after < percent:
+			direction = ThresholdFalling
+		default:
+			continue
+		}
+		events = append(events, dto.CapacityThresholdEvent{
+			GardenID:            gardenID,
+			Threshold:           percent,
+			Direction:           direction,
+			Trigger:             trigger,
+			CropID:              cropID,
+			PreviousUtilization: before,
+			Utilization:         after,
+			OccurredAt:          s.clock.Now(),
+		})
+	}
+	return events
+}
+
+// fireCapacityWebhooks delivers threshold events in the background once the change that
+// caused them has been committed, so a slow endpoint does not hold up crop changes. It
+// is called by operations holding acquire, and Close waits for the delivery to finish.
+func (s *CropService) fireCapacityWebhooks(ctx context.Context, events []dto.CapacityThresholdEvent) {
+	if len(events) == 0 {
+		return
+	}
+	s.mu.RLock()
+	webhook, attempts, delay, clk := s.webhook, s.webhookTries, s.webhookDelay, s.clock
+	s.mu.RUnlock()
+	if webhook == nil {
+		return
+	}
+
+	// The delivery outlives the request that caused it
+	ctx = context.WithoutCancel(ctx)
+	s.inFlight.Add(1)
+	go func() {
+		defer s.inFlight.Done()
+		for _, event := range events {
+			s.deliverCapacityEvent(ctx, clk, webhook, event, attempts, delay)
+		}
+	}()
+}
+
+// deliverCapacityEvent delivers one event, retrying failures with exponential backoff
+// timed by clk. Retries are abandoned once Close starts. Events still undelivered are
+// logged rather than failing the crop change.
+func (s *CropService) deliverCapacityEvent(ctx context.Context, clk clock.Clock, webhook CapacityWebhook, event dto.CapacityThresholdEvent, attempts int, delay time.Duration) {
+	var err error
+	attempt := 1
+	for {
+		if err = webhook.Deliver(ctx, event); err == nil {
+			return
+		}
+		if attempt == attempts || !s.waitForRetry(ctx, clk, delay) {
+			break
+		}
+		attempt++
+		delay *= 2
+	}
+
+	s.logger.Warn("failed to deliver capacity webhook",
+		zap.String("gardenId", event.GardenID),
+		zap.Float64("threshold", event.Threshold),
+		zap.Int("attempts", attempt),
+		zap.Error(err))
+}
@@ -0,0 +1,128 @@
+// Package models provides database models for the Urban Gardening Assistant application
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Environmental factors schedule acceleration rules can test
+const (
+	FactorTemperature = "temperature" // Degrees Celsius
+	FactorHumidity    = "humidity"    // Relative humidity percentage
+	FactorLightLevel  = "lightLevel"  // Compared as low=1, medium=2, high=3
+)
+
+// How an acceleration rule compares a factor with its threshold
+const (
+	AccelerateAbove = "above"
+	AccelerateBelow = "below"
+)
+
+// DefaultMaxAcceleration bounds how far all matching rules together bring a task forward
+const DefaultMaxAcceleration = 12 * time.Hour
+
+// ErrInvalidAccelerationRule is returned for acceleration policies that cannot be applied
+var ErrInvalidAccelerationRule = errors.New("invalid schedule acceleration rule")
+
+// lightLevelScale orders the categorical light levels so rules can compare them
+var lightLevelScale = map[string]float64{"low": 1, "medium": 2, "high": 3}
+
+// AccelerationRule brings an AI-recommended task's next scheduled time forward by Advance
+// while an environmental factor is above or below a threshold
+type AccelerationRule struct {
+	TaskType   string
+	Factor     string
+	Comparison string
+	Threshold  float64
+	Advance    time.Duration
+}
+
+// AccelerationPolicy is the set of rules adjusting next scheduled times for environmental
+// factors. The advances of every matching rule add up, bounded by MaxAdvance and by half
+// the task's interval, and a task is never brought forward into the past.
+type AccelerationPolicy struct {
+	Rules      []AccelerationRule
+	MaxAdvance time.Duration
+}
+
+// DefaultAccelerationPolicy returns the built-in policy, which waters 6 hours earlier
+// above 30°C
+func DefaultAccelerationPolicy() *AccelerationPolicy {
+	return &AccelerationPolicy{
+		Rules: []AccelerationRule{
+			{TaskType: "Water", Factor: FactorTemperature, Comparison: AccelerateAbove, Threshold: 30, Advance: 6 * time.Hour},
+		},
+		MaxAdvance: DefaultMaxAcceleration,
+	}
+}
+
+// Validate checks that every rule names a known task type, factor, and comparison with a
+// positive advance, and that the policy's bound is positive
+func (p *AccelerationPolicy) Validate() error {
+	if p.MaxAdvance <= 0 {
+		return fmt.Errorf("%w: maximum advance must be positive", ErrInvalidAccelerationRule)
+	}
+	for _, rule := range p.Rules {
+		if TaskUnit(rule.TaskType) == "" {
+			return fmt.Errorf("%w: unknown task type %q", ErrInvalidAccelerationRule, rule.TaskType)
+		}
+		switch rule.Factor {
+		case FactorTemperature, FactorHumidity, FactorLightLevel:
+		default:
+			return fmt.Errorf("%w: unknown factor %q", ErrInvalidAccelerationRule, rule.Factor)
+		}
+		switch rule.Comparison {
+		case AccelerateAbove, AccelerateBelow:
+		default:
+			return fmt.Errorf("%w: comparison must be %q or %q", ErrInvalidAccelerationRule, AccelerateAbove, AccelerateBelow)
+		}
+		if rule.Advance <= 0 {
+			return fmt.Errorf("%w: advance for %s %s must be positive", ErrInvalidAccelerationRule, rule.TaskType, rule.Factor)
+		}
+	}
+	return nil
+}
+
+// advance returns how far a task of taskType with the given interval is brought forward
+// under factors
+func (p *AccelerationPolicy) advance(taskType string, interval time.Duration, factors map[string]interface{}) time.Duration {
+	var total time.Duration
+	for _, rule := range p.Rules {
+		if rule.TaskType != taskType {
+			continue
+		}
+		value, ok := factorValue(factors, rule.Factor)
+		if !ok {
+			continue
+		}
+		if (rule.Comparison == AccelerateAbove && value > rule.Threshold) ||
+			(rule.Comparison == AccelerateBelow && value < rule.Threshold) {
+			total += rule.Advance
+		}
+	}
+
+	if total > p.MaxAdvance {
+		total = p.MaxAdvance
+	}
+	// Never bring a task closer to its previous occurrence than to its next
+	if total > interval/2 {
+		total = interval / 2
+	}
+	return total
+}
+
+// factorValue returns a factor as a number, mapping categorical light levels onto their scale
+func factorValue(factors map[string]interface{}, factor string) (float64, bool) {
+	switch value := factors[factor].(type) {
+	case float64:
+		return value, true
+	case string:
+		if factor == FactorLightLevel {
+			level, ok := lightLevelScale[value]
+			return level, ok
+		}
+	}
+	return 0, false
+}
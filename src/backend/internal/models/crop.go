@@ -4,10 +4,13 @@ package models
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid" // v1.3.0
 	"gorm.io/gorm" // v1.25.0
+
+	"github.com/urban-gardening-assistant/backend/pkg/dto"
 )
 
 // Crop represents a crop in the Urban Gardening Assistant system
@@ -21,10 +24,133 @@ type Crop struct {
 	BagSize        string    `gorm:"type:varchar(10);not null"`
 	EstimatedYield float64   `gorm:"type:decimal(10,2)"`
 	SpaceRequired  float64   `gorm:"type:decimal(10,2)"`
+	Starred        bool      `gorm:"not null;default:false;index"`
+	Organic        bool      `gorm:"not null;default:false;index"` // Grown organically rather than conventionally
 	CreatedAt      time.Time `gorm:"not null"`
 	UpdatedAt      time.Time `gorm:"not null"`
 	DeletedAt      *time.Time
 	Garden         *Garden `gorm:"foreignKey:GardenID"`
+	// Conditions optionally adjusts the yield estimate by measured growing conditions
+	Conditions     *dto.GrowingConditions `gorm:"-"`
+	// Harvest goal, e.g. 2 kg per week; a zero TargetYield means no goal is set
+	TargetYield       float64 `gorm:"type:decimal(10,2);not null;default:0"`
+	TargetYieldUnit   string  `gorm:"type:varchar(5)"`  // g or kg
+	TargetYieldPeriod string  `gorm:"type:varchar(10)"` // week or month
+	// Maintenance task types turned off for the crop, comma-separated; empty means every
+	// task type is active, e.g. "Water" for cacti that should get no watering reminders
+	DisabledTaskTypes string `gorm:"type:varchar(100);not null;default:''"`
+
+	// soilEfficiency is the factor resolved for the garden's soil, including the fallback
+	// for unknown soil types; zero uses the garden's own factor when its soil is known
+	soilEfficiency float64
+}
+
+// Environmental yield adjustment bounds; the combined adjustment never exceeds the
+// 10% accuracy target so estimates stay comparable with unadjusted ones
+const (
+	maxEnvironmentAdjustment = 0.10
+	idealMinTemperatureC     = 18.0
+	idealMaxTemperatureC     = 27.0
+	stressMinTemperatureC    = 10.0
+	stressMaxTemperatureC    = 32.0
+	idealMinHumidityPct      = 40.0
+	idealMaxHumidityPct      = 70.0
+	stressMinHumidityPct     = 25.0
+	stressMaxHumidityPct     = 85.0
+)
+
+// cropBaseYields is the base yield per 10" bag in kg/day for each supported crop
+var cropBaseYields = map[string]float64{
+	dto.CropTomatoes: 0.225, // 200-250g per day
+	dto.CropSpinach:  0.125, // 100-150g per day
+	dto.CropLettuce:  0.175, // 150-200g per day
+	dto.CropPeppers:  0.125, // 100-150g per day
+	dto.CropEggplant: 0.225, // 200-250g per day
+}
+
+// DefaultCropBaseYield is the conservative base yield in kg/day for unlisted crops
+const DefaultCropBaseYield = 0.150
+
+// bagSizeMultipliers scales the base yield by grow bag size
+var bagSizeMultipliers = map[string]float64{
+	dto.BagSize8:  0.8,
+	dto.BagSize10: 1.0,
+	dto.BagSize12: 1.2,
+	dto.BagSize14: 1.4,
+}
+
+// cropMinBagSizes is the smallest grow bag each crop can grow to full size in; fruiting
+// crops need the root volume of a 10" bag, leafy greens and peppers manage in an 8" one
+var cropMinBagSizes = map[string]string{
+	dto.CropTomatoes: dto.BagSize10,
+	dto.CropEggplant: dto.BagSize10,
+	dto.CropPeppers:  dto.BagSize8,
+	dto.CropSpinach:  dto.BagSize8,
+	dto.CropLettuce:  dto.BagSize8,
+}
+
+// bagSizeDiameters is the diameter in inches of each supported grow bag size
+var bagSizeDiameters = map[string]float64{
+	dto.BagSize8:  8,
+	dto.BagSize10: 10,
+	dto.BagSize12: 12,
+	dto.BagSize14: 14,
+}
+
+// soilEfficiencyFactors scales yield and space estimates by garden soil type
+var soilEfficiencyFactors = map[string]float64{
+	"red_soil":   1.0,
+	"sandy_soil": 0.8,
+	"loamy_soil": 1.2,
+	"clay_soil":  0.9,
+	"black_soil": 1.1,
+}
+
+// CropBaseYields returns a copy of the base yield per 10" bag in kg/day by crop name
+func CropBaseYields() map[string]float64 {
+	return copyFactors(cropBaseYields)
+}
+
+// BagSizeMultipliers returns a copy of the yield multiplier for each supported bag size
+func BagSizeMultipliers() map[string]float64 {
+	return copyFactors(bagSizeMultipliers)
+}
+
+// SoilEfficiencyFactors returns a copy of the efficiency factor for each known soil type
+func SoilEfficiencyFactors() map[string]float64 {
+	return copyFactors(soilEfficiencyFactors)
+}
+
+// SoilEfficiency returns the efficiency factor for soilType and whether it is known
+func SoilEfficiency(soilType string) (float64, bool) {
+	factor, ok := soilEfficiencyFactors[soilType]
+	return factor, ok
+}
+
+// MinBagSize returns the smallest grow bag cropName grows to full size in and whether the
+// crop has a known minimum
+func MinBagSize(cropName string) (string, bool) {
+	size, ok := cropMinBagSizes[dto.NormalizeCropName(cropName)]
+	return size, ok
+}
+
+// copyFactors returns a copy of factors so callers cannot modify the shared tables
+func copyFactors(factors map[string]float64) map[string]float64 {
+	result := make(map[string]float64, len(factors))
+	for key, factor := range factors {
+		result[key] = factor
+	}
+	return result
+}
+
+// canopySpreadFactors scales a crop's bag footprint by the clearance its foliage needs;
+// sprawling crops need room beyond the bag, compact leafy crops do not
+var canopySpreadFactors = map[string]float64{
+	dto.CropTomatoes: 1.5,
+	dto.CropEggplant: 1.4,
+	dto.CropPeppers:  1.2,
+	dto.CropSpinach:  1.0,
+	dto.CropLettuce:  1.0,
 }
 
 // Custom validation errors
@@ -119,6 +245,13 @@ func (c *Crop) Validate(tx *gorm.DB) error {
 		return ErrInvalidBagSize
 	}
 
+	// Validate disabled task types
+	for _, taskType := range c.DisabledTaskTypeList() {
+		if TaskUnit(taskType) == "" {
+			return fmt.Errorf("%w: %s", ErrInvalidTaskType, taskType)
+		}
+	}
+
 	// Validate garden capacity
 	spaceRequired := c.calculateSpaceRequired()
 	gardenArea, err := garden.CalculateArea()
@@ -137,48 +270,88 @@ func (c *Crop) Validate(tx *gorm.DB) error {
 
 // CalculateYield implements sophisticated yield calculation with 10% accuracy
 func (c *Crop) CalculateYield() float64 {
-	// Base yield per bag in kg/day based on crop type
-	baseYield := map[string]float64{
-		"Tomatoes": 0.225,  // 200-250g per day
-		"Spinach":  0.125,  // 100-150g per day
-		"Lettuce":  0.175,  // 150-200g per day
-		"Peppers":  0.125,  // 100-150g per day
-		"Eggplant": 0.225,  // 200-250g per day
-	}
-
-	// Get base yield or use default
-	yield := baseYield[c.Name]
+	// Get base yield or use default, matching names case-insensitively and by synonym
+	yield := cropBaseYields[dto.NormalizeCropName(c.Name)]
 	if yield == 0 {
-		yield = 0.150 // Default conservative estimate
+		yield = DefaultCropBaseYield
 	}
 
-	// Apply bag size multiplier
-	sizeMultiplier := map[string]float64{
-		"8\"":  0.8,
-		"10\"": 1.0,
-		"12\"": 1.2,
-		"14\"": 1.4,
-	}[c.BagSize]
-
 	// Calculate total yield considering grow bags and size
-	totalYield := yield * float64(c.GrowBags) * sizeMultiplier
+	totalYield := yield * float64(c.GrowBags) * bagSizeMultipliers[c.BagSize]
+
+	// Plants in bags below the crop's minimum are stunted by the lack of root space
+	totalYield *= c.UndersizedBagFactor()
+
+	// Apply the resolved soil efficiency, else the garden's own factor when its soil is
+	// known; an unknown soil without a resolved factor leaves the yield unadjusted
+	if c.soilEfficiency > 0 {
+		totalYield *= c.soilEfficiency
+	} else if c.Garden != nil {
+		if soilEfficiency, known := c.Garden.SoilEfficiency(); known {
+			totalYield *= soilEfficiency
+		}
+	}
 
-	// Apply soil efficiency if garden is available
-	if c.Garden != nil {
-		soilEfficiency := map[string]float64{
-			"red_soil":    1.0,
-			"sandy_soil":  0.8,
-			"loamy_soil":  1.2,
-			"clay_soil":   0.9,
-			"black_soil":  1.1,
-		}[c.Garden.SoilType]
-		totalYield *= soilEfficiency
+	// Apply measured growing conditions when provided
+	if c.Conditions != nil {
+		totalYield *= 1 + environmentAdjustment(c.Conditions)
 	}
 
 	return totalYield
 }
 
-// calculateSpaceRequired calculates the total space required in square feet
+// UndersizedBagFactor returns the share of full yield a crop reaches in its grow bag:
+// 1 when the bag meets the crop's minimum size, otherwise the bag's soil area relative to
+// the minimum, e.g. 0.64 for tomatoes in an 8" bag
+func (c *Crop) UndersizedBagFactor() float64 {
+	minSize, ok := MinBagSize(c.Name)
+	if !ok {
+		return 1
+	}
+	diameter, minDiameter := bagSizeDiameters[c.BagSize], bagSizeDiameters[minSize]
+	if diameter == 0 || diameter >= minDiameter {
+		return 1
+	}
+	return (diameter * diameter) / (minDiameter * minDiameter)
+}
+
+// environmentAdjustment returns the fractional yield change implied by growing
+// conditions, clamped to the accuracy target
+func environmentAdjustment(conditions *dto.GrowingConditions) float64 {
+	adjustment := 0.0
+
+	switch {
+	case conditions.Temperature >= idealMinTemperatureC && conditions.Temperature <= idealMaxTemperatureC:
+		adjustment += 0.04
+	case conditions.Temperature < stressMinTemperatureC || conditions.Temperature > stressMaxTemperatureC:
+		adjustment -= 0.05
+	}
+
+	switch {
+	case conditions.Humidity >= idealMinHumidityPct && conditions.Humidity <= idealMaxHumidityPct:
+		adjustment += 0.03
+	case conditions.Humidity < stressMinHumidityPct || conditions.Humidity > stressMaxHumidityPct:
+		adjustment -= 0.03
+	}
+
+	switch conditions.LightLevel {
+	case "high":
+		adjustment += 0.03
+	case "low":
+		adjustment -= 0.05
+	}
+
+	if adjustment > maxEnvironmentAdjustment {
+		return maxEnvironmentAdjustment
+	}
+	if adjustment < -maxEnvironmentAdjustment {
+		return -maxEnvironmentAdjustment
+	}
+	return adjustment
+}
+
+// calculateSpaceRequired calculates the total space required in square feet, including
+// the clearance the crop's canopy needs around each bag
 func (c *Crop) calculateSpaceRequired() float64 {
 	// Extract bag size number
 	bagSize := 0
@@ -195,7 +368,38 @@ func (c *Crop) calculateSpaceRequired() float64 {
 
 	// Calculate space in square feet (bag size in inches converted to feet)
 	spacePerBag := float64(bagSize * bagSize) / 144.0
-	return spacePerBag * float64(c.GrowBags)
+	return spacePerBag * c.canopySpreadFactor() * float64(c.GrowBags)
+}
+
+// MaxGrowBagsFor returns how many of the crop's grow bags, with canopy clearance, fit in a
+// garden of the given area, capped at the global maximum
+func (c *Crop) MaxGrowBagsFor(gardenArea float64) int {
+	single := *c
+	single.GrowBags = 1
+	spacePerBag := single.calculateSpaceRequired()
+	if spacePerBag <= 0 {
+		return dto.MaxGrowBags
+	}
+
+	maxBags := int(gardenArea / spacePerBag)
+	if maxBags > dto.MaxGrowBags {
+		return dto.MaxGrowBags
+	}
+	return maxBags
+}
+
+// canopySpreadFactor returns the crop's footprint multiplier, 1.0 for unknown crops
+func (c *Crop) canopySpreadFactor() float64 {
+	if factor, ok := canopySpreadFactors[dto.NormalizeCropName(c.Name)]; ok {
+		return factor
+	}
+	return 1.0
+}
+
+// CalculateSpaceRequired returns the total space required in square feet for
+// the crop's grow bags and canopy
+func (c *Crop) CalculateSpaceRequired() float64 {
+	return c.calculateSpaceRequired()
 }
 
 // FromDTO updates the crop model from a CropRequest DTO
@@ -205,6 +409,8 @@ func (c *Crop) FromDTO(req *dto.CropRequest) error {
 	c.QuantityNeeded = req.QuantityNeeded
 	c.GrowBags = req.GrowBags
 	c.BagSize = req.BagSize
+	c.Organic = req.Organic
+	c.SetDisabledTaskTypes(req.DisabledTaskTypes)
 	
 	return nil
 }
@@ -219,9 +425,51 @@ func (c *Crop) ToResponse() *dto.CropResponse {
 		GrowBags:       c.GrowBags,
 		BagSize:        c.BagSize,
 		EstimatedYield: c.EstimatedYield,
+		Starred:        c.Starred,
+		Organic:        c.Organic,
 		CreatedAt:      c.CreatedAt,
 		UpdatedAt:      c.UpdatedAt,
+
+		DisabledTaskTypes: c.DisabledTaskTypeList(),
+	}
+}
+
+// DisabledTaskTypeList returns the maintenance task types turned off for the crop
+func (c *Crop) DisabledTaskTypeList() []string {
+	if c.DisabledTaskTypes == "" {
+		return []string{}
+	}
+	return strings.Split(c.DisabledTaskTypes, ",")
+}
+
+// SetSoilEfficiency sets the soil efficiency applied to the crop's yield, as resolved
+// for its garden with any configured fallback for unknown soil types
+func (c *Crop) SetSoilEfficiency(factor float64) {
+	c.soilEfficiency = factor
+}
+
+// SetDisabledTaskTypes stores taskTypes as the crop's disabled task types, dropping repeats
+func (c *Crop) SetDisabledTaskTypes(taskTypes []string) {
+	unique := make([]string, 0, len(taskTypes))
+	seen := make(map[string]bool, len(taskTypes))
+	for _, taskType := range taskTypes {
+		if taskType == "" || seen[taskType] {
+			continue
+		}
+		seen[taskType] = true
+		unique = append(unique, taskType)
+	}
+	c.DisabledTaskTypes = strings.Join(unique, ",")
+}
+
+// TaskTypeEnabled reports whether maintenance of taskType is active for the crop
+func (c *Crop) TaskTypeEnabled(taskType string) bool {
+	for _, disabled := range c.DisabledTaskTypeList() {
+		if disabled == taskType {
+			return false
+		}
 	}
+	return true
 }
 
 // TableName specifies the database table name for the Crop model
@@ -0,0 +1,35 @@
+// Package models provides database models for the Urban Gardening Assistant application
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid" // v1.3.0
+	"gorm.io/gorm" // v1.25.0
+)
+
+// EnvironmentReading represents a temperature, humidity and light reading pushed
+// from a gardener's own sensors for a garden
+type EnvironmentReading struct {
+	ID          string    `gorm:"type:uuid;primary_key"`
+	GardenID    string    `gorm:"type:uuid;not null;index"`
+	Temperature float64   `gorm:"type:decimal(5,2);not null"` // Celsius
+	Humidity    float64   `gorm:"type:decimal(5,2);not null"` // Relative humidity percentage
+	LightLevel  string    `gorm:"type:varchar(10);not null"`
+	RecordedAt  time.Time `gorm:"not null;index"`
+	CreatedAt   time.Time `gorm:"not null"`
+}
+
+// BeforeCreate implements GORM hook for ID and timestamp initialization
+func (e *EnvironmentReading) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	e.CreatedAt = now
+	if e.RecordedAt.IsZero() {
+		e.RecordedAt = now
+	}
+	return nil
+}
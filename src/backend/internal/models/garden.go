@@ -3,27 +3,39 @@ package models
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
 	"github.com/urban-gardening-assistant/backend/pkg/constants/garden"
+	"github.com/urban-gardening-assistant/backend/pkg/dto"
 	"github.com/urban-gardening-assistant/backend/pkg/types/common"
 )
 
 // Garden represents a garden space in the Urban Gardening Assistant system
 // Implements core space planning functionality with comprehensive validation
 type Garden struct {
-	ID        string     `gorm:"type:uuid;primary_key"`
-	UserID    string     `gorm:"type:uuid;not null;index"`
-	Length    float64    `gorm:"type:decimal(10,2);not null"`
-	Width     float64    `gorm:"type:decimal(10,2);not null"`
-	SoilType  string     `gorm:"type:varchar(50);not null"`
-	Sunlight  string     `gorm:"type:varchar(50);not null"`
-	CreatedAt time.Time  `gorm:"not null"`
-	UpdatedAt time.Time  `gorm:"not null"`
-	DeletedAt *time.Time `gorm:"index"`
+	ID          string     `gorm:"type:uuid;primary_key"`
+	UserID      string     `gorm:"type:uuid;not null;index"`
+	Length      float64    `gorm:"type:decimal(10,2);not null"`
+	Width       float64    `gorm:"type:decimal(10,2);not null"`
+	SoilType    string     `gorm:"type:varchar(50);not null"`
+	Sunlight    string     `gorm:"type:varchar(50);not null"`
+	Environment string     `gorm:"type:varchar(20);not null;default:'Outdoor'"` // Outdoor, Indoor, or Greenhouse; empty means Outdoor
+	CreatedAt   time.Time  `gorm:"not null"`
+	UpdatedAt   time.Time  `gorm:"not null"`
+	DeletedAt   *time.Time `gorm:"index"`
+
+	// SoilEfficiencyOverride replaces the soil type's efficiency factor in yield and space
+	// calculations when set, for gardens whose soil has been amended
+	SoilEfficiencyOverride *float64 `gorm:"type:decimal(4,2)"`
+
+	// Latitude and Longitude locate the garden in decimal degrees for weather and sun
+	// modeling; both are set or neither is
+	Latitude  *float64 `gorm:"type:decimal(9,6)"`
+	Longitude *float64 `gorm:"type:decimal(9,6)"`
 }
 
 // Custom validation errors
@@ -43,6 +55,10 @@ func (g *Garden) BeforeCreate(tx *gorm.DB) error {
 		return ErrInvalidUserID
 	}
 
+	if g.Environment == "" {
+		g.Environment = garden.EnvironmentOutdoor
+	}
+
 	// Perform comprehensive validation
 	return g.Validate()
 }
@@ -98,7 +114,50 @@ func (g *Garden) Validate() error {
 		}
 	}
 
-	return nil
+	// Validate growing environment; unset means Outdoor
+	if g.Environment != "" {
+		validEnvironment := false
+		for _, environment := range garden.ValidEnvironments() {
+			if g.Environment == environment {
+				validEnvironment = true
+				break
+			}
+		}
+		if !validEnvironment {
+			return &common.ValidationError{
+				Field:   "environment",
+				Message: "invalid growing environment",
+				Value:   g.Environment,
+			}
+		}
+	}
+
+	if g.SoilEfficiencyOverride != nil &&
+		(*g.SoilEfficiencyOverride < garden.MinSoilEfficiencyOverride || *g.SoilEfficiencyOverride > garden.MaxSoilEfficiencyOverride) {
+		return &common.ValidationError{
+			Field:   "soil_efficiency_override",
+			Message: fmt.Sprintf("soil efficiency override must be between %.1f and %.1f", garden.MinSoilEfficiencyOverride, garden.MaxSoilEfficiencyOverride),
+			Value:   fmt.Sprintf("%v", *g.SoilEfficiencyOverride),
+		}
+	}
+
+	return dto.ValidateCoordinates(g.Latitude, g.Longitude)
+}
+
+// SoilEfficiency returns the garden's soil efficiency factor: the override when set,
+// otherwise the factor for its soil type. The result is false for unknown soil types
+// without an override.
+func (g *Garden) SoilEfficiency() (float64, bool) {
+	if g.SoilEfficiencyOverride != nil {
+		return *g.SoilEfficiencyOverride, true
+	}
+	return SoilEfficiency(g.SoilType)
+}
+
+// IsSheltered reports whether the garden is grown indoors or in a greenhouse, away
+// from outdoor seasons
+func (g *Garden) IsSheltered() bool {
+	return g.Environment == garden.EnvironmentIndoor || g.Environment == garden.EnvironmentGreenhouse
 }
 
 // ToDimensions converts garden dimensions to common.Dimensions type
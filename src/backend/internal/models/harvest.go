@@ -0,0 +1,32 @@
+// Package models provides database models for the Urban Gardening Assistant application
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid" // v1.3.0
+	"gorm.io/gorm" // v1.25.0
+)
+
+// Harvest represents a quantity of produce picked from a crop
+type Harvest struct {
+	ID          string    `gorm:"type:uuid;primary_key"`
+	CropID      string    `gorm:"type:uuid;not null;index"`
+	Grams       float64   `gorm:"type:decimal(10,2);not null"`
+	HarvestedAt time.Time `gorm:"not null;index"`
+	CreatedAt   time.Time `gorm:"not null"`
+}
+
+// BeforeCreate implements GORM hook for ID and timestamp initialization
+func (h *Harvest) BeforeCreate(tx *gorm.DB) error {
+	if h.ID == "" {
+		h.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	h.CreatedAt = now
+	if h.HarvestedAt.IsZero() {
+		h.HarvestedAt = now
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+// Package models provides database models for the Urban Gardening Assistant application
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0
+	"gorm.io/gorm" // v1.25.0
+)
+
+// GardenLayout stores the most recently computed grow bag arrangement for a garden's crops
+type GardenLayout struct {
+	ID           string          `gorm:"type:uuid;primary_key"`
+	GardenID     string          `gorm:"type:uuid;not null;uniqueIndex"`
+	Placements   json.RawMessage `gorm:"type:jsonb;not null"`
+	RequiredBags int             `gorm:"not null"`
+	PlacedBags   int             `gorm:"not null"`
+	CreatedAt    time.Time       `gorm:"not null"`
+	UpdatedAt    time.Time       `gorm:"not null"`
+}
+
+// BeforeCreate implements GORM hook for ID and timestamp initialization
+func (l *GardenLayout) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == "" {
+		l.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	l.CreatedAt = now
+	l.UpdatedAt = now
+	return nil
+}
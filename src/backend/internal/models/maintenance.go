@@ -8,6 +8,9 @@ import (
 
 	"github.com/google/uuid" // v1.3.0
 	"gorm.io/gorm" // v1.25.0
+
+	"github.com/urban-gardening/backend/internal/utils/clock"
+	"github.com/urban-gardening/backend/pkg/dto"
 )
 
 // Custom validation errors
@@ -18,21 +21,33 @@ var (
 	ErrInvalidAmount        = errors.New("invalid amount")
 	ErrInvalidUnit          = errors.New("invalid unit")
 	ErrInvalidPreferredTime = errors.New("invalid preferred time")
+	ErrCompletionBeforeLast = errors.New("completion time is before the last recorded completion")
+	ErrCompletionInFuture   = errors.New("completion time cannot be in the future")
 )
 
 // Valid task types
-var validTaskTypes = []string{"Fertilizer", "Water", "Composting", "Pruning", "Pest Control"}
+var validTaskTypes = []string{"Fertilizer", "Water", "Composting", "Pruning", "Pest Control", "Re-pot"}
 
 // Valid frequencies
 var validFrequencies = []string{"Daily", "Twice-Daily", "Weekly", "Bi-weekly", "Monthly"}
 
-// Valid units based on task type
-var validUnits = map[string]string{
-	"Fertilizer":  "g",
-	"Water":       "ml",
-	"Composting":  "g",
-	"Pruning":     "n/a",
-	"Pest Control": "ml",
+// frequencyOnce marks a one-off task, such as a re-pot reminder. It is scheduled by the
+// system rather than requested, so it is not listed with the valid frequencies.
+const frequencyOnce = "Once"
+
+// ValidTaskTypes returns a copy of the supported maintenance task types
+func ValidTaskTypes() []string {
+	return append([]string(nil), validTaskTypes...)
+}
+
+// ValidFrequencies returns a copy of the supported maintenance frequencies
+func ValidFrequencies() []string {
+	return append([]string(nil), validFrequencies...)
+}
+
+// TaskUnit returns the unit amounts are measured in for taskType, or "" if unknown
+func TaskUnit(taskType string) string {
+	return dto.TaskUnit(taskType)
 }
 
 // Maintenance represents a maintenance task for a crop in the Urban Gardening Assistant system
@@ -45,10 +60,13 @@ type Maintenance struct {
 	Unit                string          `gorm:"type:varchar(10)"`
 	PreferredTime       string          `gorm:"type:varchar(5)"` // HH:MM format
 	AIRecommended       bool            `gorm:"default:false"`
+	AIRecommendedAt     *time.Time      // When the AI recommendation was made; nil for rule-based tasks
 	Active              bool            `gorm:"default:true"`
 	CompletionStreak    int            `gorm:"default:0"`
 	CompletionRate      float64         `gorm:"type:decimal(5,2);default:0"`
 	EnvironmentalFactors json.RawMessage `gorm:"type:jsonb"`
+	CurrentBagSize      string          `gorm:"type:varchar(10)"` // Re-pot tasks only: grow bag the crop is in
+	SuggestedBagSize    string          `gorm:"type:varchar(10)"` // Re-pot tasks only: grow bag to move the crop into
 	NextScheduledTime   time.Time       `gorm:"not null"`
 	LastCompletedTime   *time.Time
 	LastModifiedAt      time.Time       `gorm:"not null"`
@@ -56,6 +74,48 @@ type Maintenance struct {
 	UpdatedAt           time.Time       `gorm:"not null"`
 	DeletedAt           *time.Time      `gorm:"index"`
 	Crop               *Crop           `gorm:"foreignKey:CropID"`
+
+	// clock supplies the current time for scheduling math; nil uses the system clock
+	clock clock.Clock
+
+	// acceleration adjusts AI-recommended tasks for environmental factors; nil uses the default policy
+	acceleration *AccelerationPolicy
+
+	// allowPastSchedule keeps a next time computed in the past, e.g. from a completion long
+	// ago, instead of rolling it forward to the next future occurrence
+	allowPastSchedule bool
+
+	// intervalAdjuster rescales the interval after a completion, e.g. for sensor readings;
+	// nil keeps the frequency's calendar interval
+	intervalAdjuster func(time.Duration) time.Duration
+}
+
+// SetClock sets the time source used when scheduling and completing the task
+func (m *Maintenance) SetClock(c clock.Clock) {
+	m.clock = c
+}
+
+// SetAccelerationPolicy sets the rules bringing the task forward for environmental
+// factors; nil uses DefaultAccelerationPolicy
+func (m *Maintenance) SetAccelerationPolicy(policy *AccelerationPolicy) {
+	m.acceleration = policy
+}
+
+// SetAllowPastSchedule sets whether a next time computed in the past is kept as is. By
+// default it is rolled forward whole intervals to the next future occurrence.
+func (m *Maintenance) SetAllowPastSchedule(allow bool) {
+	m.allowPastSchedule = allow
+}
+
+// SetIntervalAdjuster sets the function rescaling the interval from the last completion
+// to the next occurrence; nil keeps the frequency's calendar interval
+func (m *Maintenance) SetIntervalAdjuster(adjust func(time.Duration) time.Duration) {
+	m.intervalAdjuster = adjust
+}
+
+// now returns the current time from the task's clock
+func (m *Maintenance) now() time.Time {
+	return clock.OrReal(m.clock).Now()
 }
 
 // BeforeCreate implements GORM hook for pre-creation validation and initialization
@@ -66,7 +126,7 @@ func (m *Maintenance) BeforeCreate(tx *gorm.DB) error {
 	}
 
 	// Set timestamps
-	now := time.Now()
+	now := m.now()
 	m.CreatedAt = now
 	m.UpdatedAt = now
 	m.LastModifiedAt = now
@@ -87,8 +147,9 @@ func (m *Maintenance) BeforeCreate(tx *gorm.DB) error {
 
 // BeforeUpdate implements GORM hook for pre-update validation
 func (m *Maintenance) BeforeUpdate(tx *gorm.DB) error {
-	m.UpdatedAt = time.Now()
-	m.LastModifiedAt = time.Now()
+	now := m.now()
+	m.UpdatedAt = now
+	m.LastModifiedAt = now
 
 	// Recalculate next scheduled time if frequency changed
 	nextTime, err := m.CalculateNextSchedule()
@@ -126,7 +187,7 @@ func (m *Maintenance) Validate(tx *gorm.DB) error {
 	}
 
 	// Validate Frequency
-	validFreq := false
+	validFreq := m.Frequency == frequencyOnce
 	for _, freq := range validFrequencies {
 		if m.Frequency == freq {
 			validFreq = true
@@ -159,8 +220,8 @@ func (m *Maintenance) Validate(tx *gorm.DB) error {
 
 // validateAmountAndUnit validates amount and unit based on task type
 func (m *Maintenance) validateAmountAndUnit() error {
-	expectedUnit, exists := validUnits[m.TaskType]
-	if !exists {
+	expectedUnit := TaskUnit(m.TaskType)
+	if expectedUnit == "" {
 		return ErrInvalidTaskType
 	}
 
@@ -170,21 +231,8 @@ func (m *Maintenance) validateAmountAndUnit() error {
 		}
 
 		// Validate amount ranges
-		switch m.TaskType {
-		case "Water":
-			if m.Amount < 50 || m.Amount > 2000 {
-				return ErrInvalidAmount
-			}
-		case "Fertilizer":
-			if m.Amount < 10 || m.Amount > 500 {
-				return ErrInvalidAmount
-			}
-		case "Composting":
-			if m.Amount < 50 || m.Amount > 1000 {
-				return ErrInvalidAmount
-			}
-		case "Pest Control":
-			if m.Amount < 10 || m.Amount > 200 {
+		if minAmount, maxAmount, bounded := dto.AmountBounds(m.TaskType); bounded {
+			if m.Amount < minAmount || m.Amount > maxAmount {
 				return ErrInvalidAmount
 			}
 		}
@@ -193,19 +241,12 @@ func (m *Maintenance) validateAmountAndUnit() error {
 	return nil
 }
 
-// validatePreferredTime validates time format and daylight hours
+// validatePreferredTime validates the time format. The allowed window depends on the
+// growing environment, so daylight hours are enforced on requests by dto.CheckPreferredTime.
 func (m *Maintenance) validatePreferredTime() error {
-	t, err := time.Parse("15:04", m.PreferredTime)
-	if err != nil {
-		return ErrInvalidPreferredTime
-	}
-
-	// Ensure preferred time is during daylight hours (6:00-18:00)
-	hour := t.Hour()
-	if hour < 6 || hour > 18 {
+	if _, err := time.Parse("15:04", m.PreferredTime); err != nil {
 		return ErrInvalidPreferredTime
 	}
-
 	return nil
 }
 
@@ -231,9 +272,11 @@ func (m *Maintenance) validateEnvironmentalFactors() error {
 	return nil
 }
 
-// CalculateNextSchedule calculates the next scheduled maintenance time
+// CalculateNextSchedule calculates the next scheduled maintenance time: the nearest
+// preferred time still ahead for a new task, or one interval after the last completion
 func (m *Maintenance) CalculateNextSchedule() (time.Time, error) {
-	baseTime := time.Now()
+	now := m.now()
+	baseTime := now
 	if m.LastCompletedTime != nil {
 		baseTime = *m.LastCompletedTime
 	}
@@ -255,21 +298,56 @@ func (m *Maintenance) CalculateNextSchedule() (time.Time, error) {
 		baseTime.Location(),
 	)
 
+	// A one-off task falls due once, at the time it was scheduled for or else the nearest
+	// upcoming preferred time, and is not rescheduled by completing it
+	if m.Frequency == frequencyOnce {
+		if !m.NextScheduledTime.IsZero() {
+			return m.NextScheduledTime, nil
+		}
+		if !baseTime.After(now) {
+			baseTime = baseTime.AddDate(0, 0, 1)
+		}
+		return baseTime, nil
+	}
+
 	// Calculate next schedule based on frequency
-	var nextTime time.Time
-	switch m.Frequency {
-	case "Daily":
-		nextTime = baseTime.AddDate(0, 0, 1)
-	case "Twice-Daily":
-		nextTime = baseTime.Add(12 * time.Hour)
-	case "Weekly":
-		nextTime = baseTime.AddDate(0, 0, 7)
-	case "Bi-weekly":
-		nextTime = baseTime.AddDate(0, 0, 14)
-	case "Monthly":
-		nextTime = baseTime.AddDate(0, 1, 0)
-	default:
-		return time.Time{}, ErrInvalidFrequency
+	nextTime, err := m.nextOccurrence(baseTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// Stretch or shrink the interval from the last completion, e.g. watering sooner in
+	// hot weather
+	if m.LastCompletedTime != nil && m.intervalAdjuster != nil {
+		nextTime = baseTime.Add(m.intervalAdjuster(nextTime.Sub(baseTime)))
+	}
+
+	// A task that has never been completed starts at the nearest upcoming preferred time,
+	// so a Daily task created before its time of day is first due today
+	if m.LastCompletedTime == nil {
+		switch m.Frequency {
+		case "Daily":
+			if baseTime.After(now) {
+				nextTime = baseTime
+			}
+		case "Twice-Daily":
+			for _, slot := range []time.Time{baseTime.Add(-12 * time.Hour), baseTime} {
+				if slot.After(now) {
+					nextTime = slot
+					break
+				}
+			}
+		}
+	}
+
+	// A task last completed more than an interval ago would fall due in the past and be
+	// notified at once; skip the missed occurrences so it keeps its cadence instead
+	if !m.allowPastSchedule {
+		for !nextTime.After(now) {
+			if nextTime, err = m.nextOccurrence(nextTime); err != nil {
+				return time.Time{}, err
+			}
+		}
 	}
 
 	// Adjust for environmental factors if AI recommended
@@ -279,42 +357,82 @@ func (m *Maintenance) CalculateNextSchedule() (time.Time, error) {
 			return time.Time{}, err
 		}
 
-		// Adjust schedule based on environmental factors
-		if temp, ok := factors["temperature"].(float64); ok {
-			if temp > 30 && m.TaskType == "Water" {
-				nextTime = nextTime.Add(-6 * time.Hour) // Schedule earlier for high temperatures
-			}
+		// Schedule earlier under the acceleration rules, but never in the past
+		policy := m.acceleration
+		if policy == nil {
+			policy = DefaultAccelerationPolicy()
+		}
+		advance := policy.advance(m.TaskType, m.getExpectedInterval(), factors)
+		if earlier := nextTime.Add(-advance); advance > 0 && earlier.After(now) {
+			nextTime = earlier
 		}
 	}
 
 	return nextTime, nil
 }
 
+// nextOccurrence returns the occurrence one interval of the task's frequency after t
+func (m *Maintenance) nextOccurrence(t time.Time) (time.Time, error) {
+	switch m.Frequency {
+	case "Daily":
+		return t.AddDate(0, 0, 1), nil
+	case "Twice-Daily":
+		return t.Add(12 * time.Hour), nil
+	case "Weekly":
+		return t.AddDate(0, 0, 7), nil
+	case "Bi-weekly":
+		return t.AddDate(0, 0, 14), nil
+	case "Monthly":
+		return t.AddDate(0, 1, 0), nil
+	}
+	return time.Time{}, ErrInvalidFrequency
+}
+
 // MarkComplete marks a maintenance task as completed and updates metrics
 func (m *Maintenance) MarkComplete() error {
-	now := time.Now()
-	m.LastCompletedTime = &now
-	m.LastModifiedAt = now
+	return m.MarkCompleteAt(m.now())
+}
 
-	// Update completion streak
+// MarkCompleteAt marks a maintenance task as completed at the given time, which
+// may be in the past but not before the last recorded completion
+func (m *Maintenance) MarkCompleteAt(completedAt time.Time) error {
+	now := m.now()
+	if completedAt.After(now) {
+		return ErrCompletionInFuture
+	}
+	if m.LastCompletedTime != nil && completedAt.Before(*m.LastCompletedTime) {
+		return ErrCompletionBeforeLast
+	}
+
+	// Update completion streak against the previous completion
 	if m.LastCompletedTime != nil {
 		expectedInterval := m.getExpectedInterval()
-		actualInterval := now.Sub(*m.LastCompletedTime)
-		
+		actualInterval := completedAt.Sub(*m.LastCompletedTime)
+
 		if actualInterval <= expectedInterval {
 			m.CompletionStreak++
 		} else {
 			m.CompletionStreak = 1
 		}
+	} else {
+		m.CompletionStreak = 1
 	}
 
-	// Calculate next scheduled time
+	m.LastCompletedTime = &completedAt
+	m.LastModifiedAt = now
+
+	// Calculate next scheduled time from the completion time
 	nextTime, err := m.CalculateNextSchedule()
 	if err != nil {
 		return err
 	}
 	m.NextScheduledTime = nextTime
 
+	// A one-off task is done once completed
+	if m.Frequency == frequencyOnce {
+		m.Active = false
+	}
+
 	// Update completion rate (based on last 30 days)
 	m.updateCompletionRate()
 
@@ -356,7 +474,7 @@ func (m *Maintenance) updateCompletionRate() {
 		expectedCount = 4
 	case "Bi-weekly":
 		expectedCount = 2
-	case "Monthly":
+	case "Monthly", frequencyOnce:
 		expectedCount = 1
 	}
 
@@ -0,0 +1,34 @@
+// Package models provides database models for the Urban Gardening Assistant application
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// NotificationPreference represents a user's chosen delivery channels and quiet hours.
+// A record with an empty GardenID holds the user's own preferences; a record for a garden
+// overrides them for that garden's notifications. A user without a record gets the
+// default preferences.
+type NotificationPreference struct {
+	UserID          string    `gorm:"type:uuid;primary_key"`
+	GardenID        string    `gorm:"type:varchar(36);primary_key;default:''"` // Empty for the user's own preferences
+	Channels        string    `gorm:"type:varchar(50);not null"`               // Comma-separated channel names
+	QuietHoursStart string    `gorm:"type:varchar(5)"`
+	QuietHoursEnd   string    `gorm:"type:varchar(5)"`
+	TimeZone        string    `gorm:"type:varchar(64)"`
+	UpdatedAt       time.Time `gorm:"not null"`
+}
+
+// ChannelList returns the preferred channels in the order they were saved
+func (p *NotificationPreference) ChannelList() []string {
+	if p.Channels == "" {
+		return []string{}
+	}
+	return strings.Split(p.Channels, ",")
+}
+
+// SetChannels stores channels as the preferred delivery channels
+func (p *NotificationPreference) SetChannels(channels []string) {
+	p.Channels = strings.Join(channels, ",")
+}
@@ -0,0 +1,50 @@
+// Package models provides database models for the Urban Gardening Assistant application
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid" // v1.3.0
+	"gorm.io/gorm" // v1.25.0
+)
+
+// PestIncident records a pest or disease problem seen on a crop and how it was treated,
+// so future plantings and pest control can be planned around it
+type PestIncident struct {
+	ID         string    `gorm:"type:uuid;primary_key"`
+	CropID     string    `gorm:"type:uuid;not null;index"`
+	Type       string    `gorm:"type:varchar(100);not null"` // e.g. aphids, powdery mildew
+	Severity   string    `gorm:"type:varchar(10);not null"`
+	OccurredAt time.Time `gorm:"not null;index"`
+	Notes      string    `gorm:"type:text"`
+	Treatment  string    `gorm:"type:varchar(255)"`
+
+	// RaisePestControl makes the incident raise the suggested pest control frequency
+	// for the crop while it is recent
+	RaisePestControl bool `gorm:"not null;default:false"`
+
+	CreatedAt time.Time      `gorm:"not null"`
+	UpdatedAt time.Time      `gorm:"not null"`
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// BeforeCreate implements GORM hook for ID and timestamp initialization
+func (p *PestIncident) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == "" {
+		p.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	p.CreatedAt = now
+	p.UpdatedAt = now
+	if p.OccurredAt.IsZero() {
+		p.OccurredAt = now
+	}
+	return nil
+}
+
+// BeforeUpdate implements GORM hook for timestamp updates
+func (p *PestIncident) BeforeUpdate(tx *gorm.DB) error {
+	p.UpdatedAt = time.Now()
+	return nil
+}
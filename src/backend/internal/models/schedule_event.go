@@ -0,0 +1,65 @@
+// Package models provides database models for the Urban Gardening Assistant application
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid" // v1.3.0
+	"gorm.io/gorm" // v1.25.0
+)
+
+// Schedule change event types
+const (
+	ScheduleEventCreated   = "created"
+	ScheduleEventUpdated   = "updated"
+	ScheduleEventDeleted   = "deleted"
+	ScheduleEventRestored  = "restored"
+	ScheduleEventCompleted = "completed"
+)
+
+// ScheduleChangeEvent records a snapshot of a maintenance schedule each time it is
+// created, changed, deleted, restored, or completed, giving gardeners a history of how a crop's
+// care evolved and when it was carried out
+type ScheduleChangeEvent struct {
+	ID            string    `gorm:"type:uuid;primary_key"`
+	MaintenanceID string    `gorm:"type:uuid;not null;index"`
+	CropID        string    `gorm:"type:uuid;not null;index"`
+	EventType     string    `gorm:"type:varchar(20);not null"`
+	TaskType      string    `gorm:"type:varchar(50);not null"`
+	Frequency     string    `gorm:"type:varchar(20);not null"`
+	Amount        float64   `gorm:"type:decimal(10,2)"`
+	Unit          string    `gorm:"type:varchar(10)"`
+	PreferredTime string    `gorm:"type:varchar(5)"`
+	CreatedAt     time.Time `gorm:"not null;index"`
+
+	// IdempotencyKey identifies the external event that reported a completion so that
+	// retried reports are recognised; empty for events raised by the API itself
+	IdempotencyKey string `gorm:"type:varchar(100);index"`
+
+	Crop *Crop `gorm:"foreignKey:CropID"`
+}
+
+// NewScheduleChangeEvent captures the current state of a maintenance schedule
+func NewScheduleChangeEvent(m *Maintenance, eventType string) *ScheduleChangeEvent {
+	return &ScheduleChangeEvent{
+		MaintenanceID: m.ID,
+		CropID:        m.CropID,
+		EventType:     eventType,
+		TaskType:      m.TaskType,
+		Frequency:     m.Frequency,
+		Amount:        m.Amount,
+		Unit:          m.Unit,
+		PreferredTime: m.PreferredTime,
+	}
+}
+
+// BeforeCreate implements GORM hook for ID and timestamp initialization
+func (e *ScheduleChangeEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+// Package models provides database models for the Urban Gardening Assistant application
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0
+	"gorm.io/gorm" // v1.25.0
+)
+
+// GardenSnapshot records a garden's crops and maintenance schedules at a point in time,
+// so gardeners experimenting with changes can restore the garden to it
+type GardenSnapshot struct {
+	ID            string          `gorm:"type:uuid;primary_key"`
+	GardenID      string          `gorm:"type:uuid;not null;index"`
+	Label         string          `gorm:"type:varchar(100)"`
+	Crops         json.RawMessage `gorm:"type:jsonb;not null"` // []Crop not deleted when taken
+	Schedules     json.RawMessage `gorm:"type:jsonb;not null"` // []Maintenance of those crops not deleted when taken
+	CropCount     int             `gorm:"not null"`
+	ScheduleCount int             `gorm:"not null"`
+	CreatedAt     time.Time       `gorm:"not null;index"`
+}
+
+// BeforeCreate implements GORM hook for ID and timestamp initialization
+func (s *GardenSnapshot) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+	if s.CreatedAt.IsZero() {
+		s.CreatedAt = time.Now()
+	}
+	return nil
+}
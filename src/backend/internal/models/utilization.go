@@ -0,0 +1,42 @@
+// Package models provides database models for the Urban Gardening Assistant application
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid" // v1.3.0
+	"gorm.io/gorm" // v1.25.0
+)
+
+// Utilization snapshot triggers
+const (
+	UtilizationTriggerCropCreated   = "crop_created"
+	UtilizationTriggerCropDeleted   = "crop_deleted"
+	UtilizationTriggerCropsImported = "crops_imported"
+	UtilizationTriggerRestored      = "snapshot_restored"
+)
+
+// UtilizationSnapshot records how full a garden was right after its crops changed, so
+// gardeners can see how its space filled up over time
+type UtilizationSnapshot struct {
+	ID          string    `gorm:"type:uuid;primary_key"`
+	GardenID    string    `gorm:"type:uuid;not null;index"`
+	CropID      string    `gorm:"type:uuid"` // Crop created or deleted; empty for imports and restores
+	Trigger     string    `gorm:"type:varchar(20);not null"`
+	CropCount   int       `gorm:"not null"`
+	UsedSpace   float64   `gorm:"type:decimal(10,2);not null"` // sq ft
+	TotalSpace  float64   `gorm:"type:decimal(10,2);not null"` // sq ft
+	Utilization float64   `gorm:"type:decimal(6,2);not null"`  // Percent, adjusted for soil efficiency
+	RecordedAt  time.Time `gorm:"not null;index"`
+}
+
+// BeforeCreate implements GORM hook for ID and timestamp initialization
+func (u *UtilizationSnapshot) BeforeCreate(tx *gorm.DB) error {
+	if u.ID == "" {
+		u.ID = uuid.New().String()
+	}
+	if u.RecordedAt.IsZero() {
+		u.RecordedAt = time.Now()
+	}
+	return nil
+}
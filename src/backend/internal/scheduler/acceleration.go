@@ -0,0 +1,50 @@
+// Package scheduler provides maintenance scheduling functionality for the Urban Gardening Assistant
+package scheduler
+
+import (
+	"fmt"
+
+	"github.com/urban-gardening/backend/internal/models"
+	"github.com/urban-gardening/backend/pkg/types"
+)
+
+// SetAccelerationPolicy replaces the rules that bring AI-recommended tasks forward for
+// heat, dry air, bright light, or other environmental factors
+func (s *MaintenanceScheduler) SetAccelerationPolicy(policy models.AccelerationPolicy) error {
+	policy.Rules = append([]models.AccelerationRule(nil), policy.Rules...)
+	if err := policy.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+
+	s.mutex.Lock()
+	s.acceleration = &policy
+	s.mutex.Unlock()
+	return nil
+}
+
+// accelerationPolicy builds the acceleration policy a scheduler configuration describes.
+// Configured rules replace the default rules, and a configured bound replaces the default
+// bound; ok is false when the configuration sets neither.
+func accelerationPolicy(cfg *types.SchedulerConfig) (policy models.AccelerationPolicy, ok bool) {
+	if cfg == nil || (len(cfg.AccelerationRules) == 0 && cfg.MaxScheduleAcceleration <= 0) {
+		return models.AccelerationPolicy{}, false
+	}
+
+	policy = *models.DefaultAccelerationPolicy()
+	if len(cfg.AccelerationRules) > 0 {
+		policy.Rules = make([]models.AccelerationRule, len(cfg.AccelerationRules))
+		for i, rule := range cfg.AccelerationRules {
+			policy.Rules[i] = models.AccelerationRule{
+				TaskType:   rule.TaskType,
+				Factor:     rule.Factor,
+				Comparison: rule.Comparison,
+				Threshold:  rule.Threshold,
+				Advance:    rule.Advance,
+			}
+		}
+	}
+	if cfg.MaxScheduleAcceleration > 0 {
+		policy.MaxAdvance = cfg.MaxScheduleAcceleration
+	}
+	return policy, true
+}
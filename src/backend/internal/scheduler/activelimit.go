@@ -0,0 +1,200 @@
+// Package scheduler provides notification management for garden maintenance tasks
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8" // v8.11.5
+)
+
+// Policies for a notification beyond a user's active notification limit
+const (
+	NotificationLimitReject = "reject" // Fail the scheduling
+	NotificationLimitEvict  = "evict"  // Replace the user's lowest-priority pending notification
+)
+
+// activeOwnersKey is the Redis hash of task ID to the slot its pending notifications hold
+const activeOwnersKey = "notifications_active_owners"
+
+// reserveSlotScript records a task in its owner's set of tasks with pending notifications
+// and returns 1, unless the task is new to a set already at the limit, when nothing is
+// changed and 0 is returned. Checking and recording in one script keeps concurrent
+// reservations from overshooting the limit.
+var reserveSlotScript = redis.NewScript(`
+if not redis.call("ZSCORE", KEYS[1], ARGV[1]) and redis.call("ZCARD", KEYS[1]) >= tonumber(ARGV[4]) then
+    return 0
+end
+redis.call("ZADD", KEYS[1], ARGV[2], ARGV[1])
+redis.call("HSET", KEYS[2], ARGV[1], ARGV[3])
+return 1
+`)
+
+// UserResolver looks up the user who owns a garden, so pending notifications can be
+// counted against that user's limit
+type UserResolver func(ctx context.Context, gardenID string) (string, error)
+
+// activeSlot records which user a task's pending notifications are counted against
+type activeSlot struct {
+	UserID   string `json:"userId"`
+	TaskType string `json:"taskType"`
+}
+
+// activeNotificationsKey returns the Redis sorted set of a user's tasks with pending
+// notifications, scored by notification priority
+func activeNotificationsKey(userID string) string {
+	return fmt.Sprintf("notifications_active:%s", userID)
+}
+
+// SetUserResolver configures how gardens are mapped to their owners when enforcing the
+// per-user active notification limit
+func (nm *NotificationManager) SetUserResolver(resolver UserResolver) {
+	nm.mu.Lock()
+	nm.resolveUser = resolver
+	nm.mu.Unlock()
+}
+
+// userForGarden returns the user who owns a garden, or "" if unknown
+func (nm *NotificationManager) userForGarden(ctx context.Context, gardenID string) string {
+	nm.mu.RLock()
+	resolver := nm.resolveUser
+	nm.mu.RUnlock()
+
+	if resolver == nil || gardenID == "" {
+		return ""
+	}
+	userID, err := resolver(ctx, gardenID)
+	if err != nil {
+		return ""
+	}
+	return userID
+}
+
+// reserveActiveSlot counts a task's pending notifications against its garden owner's
+// limit. A task already holding a slot keeps it. Once the user is at the limit the
+// notification is rejected with ErrNotificationLimit, or, when eviction is enabled, the
+// user's lowest-priority pending task is cancelled to make room if it has lower priority
+// than the new notification. Tasks whose owner is unknown are not limited.
+func (nm *NotificationManager) reserveActiveSlot(ctx context.Context, gardenID string, pending *notification) error {
+	if nm.maxActivePerUser <= 0 {
+		return nil
+	}
+	userID := nm.userForGarden(ctx, gardenID)
+	if userID == "" {
+		return nil
+	}
+
+	slotJSON, err := json.Marshal(activeSlot{UserID: userID, TaskType: pending.TaskType})
+	if err != nil {
+		return fmt.Errorf("failed to marshal active notification: %w", err)
+	}
+
+	reserve := func() (bool, error) {
+		reserved, err := reserveSlotScript.Run(ctx, nm.redisClient,
+			[]string{activeNotificationsKey(userID), activeOwnersKey},
+			pending.TaskID, pending.Priority, slotJSON, nm.maxActivePerUser).Int()
+		if err != nil {
+			return false, fmt.Errorf("failed to record active notification: %w", err)
+		}
+		return reserved == 1, nil
+	}
+
+	reserved, err := reserve()
+	if err != nil || reserved {
+		return err
+	}
+	if err := nm.evictLowestPriority(ctx, userID, pending.Priority); err != nil {
+		return err
+	}
+
+	// Another reservation may have taken the evicted slot first
+	reserved, err = reserve()
+	if err != nil {
+		return err
+	}
+	if !reserved {
+		return fmt.Errorf("%w: user %s already has %d tasks with pending notifications", ErrNotificationLimit, userID, nm.maxActivePerUser)
+	}
+	return nil
+}
+
+// evictLowestPriority makes room for a notification of the given priority in a user's
+// full set of active notifications, failing with ErrNotificationLimit unless eviction is
+// enabled and the user has a pending task of lower priority
+func (nm *NotificationManager) evictLowestPriority(ctx context.Context, userID string, priority int) error {
+	limitErr := fmt.Errorf("%w: user %s already has %d tasks with pending notifications", ErrNotificationLimit, userID, nm.maxActivePerUser)
+	if !nm.evictOnLimit {
+		return limitErr
+	}
+
+	lowest, err := nm.redisClient.ZRangeWithScores(ctx, activeNotificationsKey(userID), 0, 0).Result()
+	if err != nil {
+		return fmt.Errorf("failed to find lowest-priority notification: %w", err)
+	}
+	if len(lowest) == 0 || int(lowest[0].Score) >= priority {
+		return limitErr
+	}
+
+	taskID, _ := lowest[0].Member.(string)
+	entry, err := nm.redisClient.HGet(ctx, activeOwnersKey, taskID).Result()
+	if err != nil && err != redis.Nil {
+		return fmt.Errorf("failed to find lowest-priority notification: %w", err)
+	}
+	var slot activeSlot
+	if entry != "" {
+		if err := json.Unmarshal([]byte(entry), &slot); err != nil {
+			return fmt.Errorf("failed to decode active notification: %w", err)
+		}
+	}
+
+	if err := nm.CancelNotifications(ctx, taskID, slot.TaskType); err != nil {
+		return fmt.Errorf("failed to evict notification: %w", err)
+	}
+	// CancelNotifications releases the slot; remove it directly if the owner entry was lost
+	if err := nm.redisClient.ZRem(ctx, activeNotificationsKey(userID), taskID).Err(); err != nil {
+		return fmt.Errorf("failed to evict notification: %w", err)
+	}
+	nm.metrics.evictedCount++
+	return nil
+}
+
+// releaseActiveSlot stops counting a task against its owner's limit once it has no
+// pending notifications left
+func (nm *NotificationManager) releaseActiveSlot(ctx context.Context, taskID string) error {
+	entry, err := nm.redisClient.HGet(ctx, activeOwnersKey, taskID).Result()
+	if err == redis.Nil {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to release active notification: %w", err)
+	}
+
+	var slot activeSlot
+	if err := json.Unmarshal([]byte(entry), &slot); err == nil {
+		if err := nm.redisClient.ZRem(ctx, activeNotificationsKey(slot.UserID), taskID).Err(); err != nil {
+			return fmt.Errorf("failed to release active notification: %w", err)
+		}
+	}
+	if err := nm.redisClient.HDel(ctx, activeOwnersKey, taskID).Err(); err != nil {
+		return fmt.Errorf("failed to release active notification: %w", err)
+	}
+	return nil
+}
+
+// releaseDelivered releases the slots of a notification that has nothing left pending,
+// including every reminder gathered into a digest
+func (nm *NotificationManager) releaseDelivered(ctx context.Context, delivered *notification) {
+	taskIDs := []string{delivered.TaskID}
+	for _, reminder := range delivered.Tasks {
+		taskIDs = append(taskIDs, reminder.TaskID)
+	}
+
+	for _, taskID := range taskIDs {
+		if err := nm.releaseActiveSlot(ctx, taskID); err != nil {
+			nm.metrics.lastError = err
+			nm.metrics.lastErrorTime = time.Now()
+		}
+	}
+}
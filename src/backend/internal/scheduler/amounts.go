@@ -0,0 +1,116 @@
+// Package scheduler provides maintenance scheduling functionality for the Urban Gardening Assistant
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/urban-gardening/backend/internal/models"
+	"github.com/urban-gardening/backend/pkg/dto"
+)
+
+// Policies for AI-recommended amounts outside the bounds for their task type
+const (
+	AIAmountClamp   = "clamp"   // Apply the nearest bound instead
+	AIAmountDiscard = "discard" // Keep the requested amount instead
+)
+
+// aiAmountField is the schedule field holding the AI's recommended amount, in the task's unit
+const aiAmountField = "amount"
+
+// SetAIAmountPolicy sets how AI-recommended amounts outside the bounds for their task type
+// are handled. Either way the task is still created and the adjustment is reported;
+// out-of-bounds amounts are clamped by default.
+func (s *MaintenanceScheduler) SetAIAmountPolicy(policy string) error {
+	switch policy {
+	case AIAmountClamp, AIAmountDiscard:
+	default:
+		return fmt.Errorf("%w: unknown AI amount policy %q", ErrInvalidRequest, policy)
+	}
+
+	s.mutex.Lock()
+	s.aiAmountPolicy = policy
+	s.mutex.Unlock()
+	return nil
+}
+
+// applyAIAmount gives a task the amount its AI schedule recommends. An amount outside the
+// bounds for the task type is clamped or discarded according to the AI amount policy.
+// Whenever the recommendation leaves the task with a different amount than requested, or
+// was out of bounds, the adjustment is returned; nil means the requested amount stands
+// unchanged. Task types without bounds keep their requested amount.
+func (s *MaintenanceScheduler) applyAIAmount(maintenance *models.Maintenance, schedule map[string]interface{}) *dto.AmountAdjustment {
+	recommended, ok := scheduleAmount(schedule)
+	if !ok {
+		return nil
+	}
+	minAmount, maxAmount, bounded := dto.AmountBounds(maintenance.TaskType)
+	if !bounded {
+		return nil
+	}
+
+	adjustment := &dto.AmountAdjustment{
+		RequestedAmount:   maintenance.Amount,
+		RecommendedAmount: recommended,
+		MinAmount:         minAmount,
+		MaxAmount:         maxAmount,
+	}
+	if recommended >= minAmount && recommended <= maxAmount {
+		maintenance.Amount = recommended
+	} else {
+		s.mutex.RLock()
+		adjustment.Policy = s.aiAmountPolicy
+		s.mutex.RUnlock()
+
+		if adjustment.Policy == AIAmountClamp {
+			maintenance.Amount = math.Min(math.Max(recommended, minAmount), maxAmount)
+		}
+	}
+	adjustment.AppliedAmount = maintenance.Amount
+
+	if adjustment.Policy == "" && adjustment.AppliedAmount == adjustment.RequestedAmount {
+		return nil
+	}
+	return adjustment
+}
+
+// clampTaskAmount keeps amount within the bounds for taskType; task types without bounds
+// keep amount as is
+func clampTaskAmount(taskType string, amount float64) float64 {
+	if minAmount, maxAmount, bounded := dto.AmountBounds(taskType); bounded {
+		return math.Min(math.Max(amount, minAmount), maxAmount)
+	}
+	return amount
+}
+
+// scheduleAmount reads the AI's recommended amount from a schedule, accepting a JSON
+// number or a numeric string
+func scheduleAmount(schedule map[string]interface{}) (float64, bool) {
+	var amount float64
+	switch value := schedule[aiAmountField].(type) {
+	case float64:
+		amount = value
+	case int:
+		amount = float64(value)
+	case json.Number:
+		parsed, err := value.Float64()
+		if err != nil {
+			return 0, false
+		}
+		amount = parsed
+	case string:
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, false
+		}
+		amount = parsed
+	default:
+		return 0, false
+	}
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return 0, false
+	}
+	return amount, true
+}
@@ -0,0 +1,137 @@
+// Package scheduler provides maintenance scheduling functionality for the Urban Gardening Assistant
+package scheduler
+
+import (
+    "context"
+    "fmt"
+    "sort"
+    "time"
+
+    "github.com/urban-gardening/backend/internal/models"
+    "github.com/urban-gardening/backend/pkg/dto"
+)
+
+// checklistDays is the number of days covered by a weekly checklist, starting today
+const checklistDays = 7
+
+// WeeklyChecklist returns a garden's active maintenance tasks for the coming week grouped
+// by day, starting today. Recurring tasks appear on every day they fall due; tasks already
+// overdue are listed on the first day.
+func (s *SchedulerService) WeeklyChecklist(ctx context.Context, gardenID string) (*dto.WeeklyChecklistResponse, error) {
+    if gardenID == "" {
+        return nil, fmt.Errorf("%w: garden ID is required", ErrInvalidRequest)
+    }
+
+    tasks, err := s.scheduler.ListGardenActiveMaintenance(ctx, gardenID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to build weekly checklist: %w", err)
+    }
+
+    now := s.clock.Now()
+    start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+    end := start.AddDate(0, 0, checklistDays)
+
+    response := &dto.WeeklyChecklistResponse{
+        GardenID:  gardenID,
+        StartDate: start.Format("2006-01-02"),
+        EndDate:   end.AddDate(0, 0, -1).Format("2006-01-02"),
+        Days:      make([]dto.ChecklistDay, checklistDays),
+    }
+    for i := range response.Days {
+        day := start.AddDate(0, 0, i)
+        response.Days[i] = dto.ChecklistDay{
+            Date:    day.Format("2006-01-02"),
+            Weekday: day.Weekday().String(),
+            Items:   []dto.ChecklistItem{},
+        }
+    }
+
+    for i := range tasks {
+        task := &tasks[i]
+        for _, occurrence := range checklistOccurrences(task, start, end) {
+            // Overdue occurrences stay on the first day
+            dayIndex := 0
+            for dayIndex < checklistDays-1 && !occurrence.Before(start.AddDate(0, 0, dayIndex+1)) {
+                dayIndex++
+            }
+
+            cropName := ""
+            if task.Crop != nil {
+                cropName = task.Crop.Name
+            }
+
+            response.Days[dayIndex].Items = append(response.Days[dayIndex].Items, dto.ChecklistItem{
+                ScheduleID: task.ID,
+                CropID:     task.CropID,
+                CropName:   cropName,
+                TaskType:   task.TaskType,
+                Amount:     task.Amount,
+                Unit:       task.Unit,
+                Time:       occurrence.Format("15:04"),
+                Overdue:    occurrence.Before(start),
+            })
+        }
+    }
+
+    // List each day's tasks in the order they should be done
+    for i := range response.Days {
+        items := response.Days[i].Items
+        sort.SliceStable(items, func(a, b int) bool {
+            return items[a].Time < items[b].Time
+        })
+    }
+
+    return response, nil
+}
+
+// checklistOccurrences returns the times a task falls due before end. An overdue task
+// contributes its missed due time once, followed by its occurrences from start onwards;
+// a one-off task contributes only its single due time.
+func checklistOccurrences(task *models.Maintenance, start, end time.Time) []time.Time {
+    next := task.NextScheduledTime
+    if next.IsZero() {
+        return nil
+    }
+
+    var occurrences []time.Time
+    if next.Before(start) {
+        occurrences = append(occurrences, next)
+        for next.Before(start) {
+            var ok bool
+            if next, ok = advanceOccurrence(next, task.Frequency); !ok {
+                return occurrences
+            }
+        }
+    }
+
+    for next.Before(end) {
+        occurrences = append(occurrences, next)
+
+        var ok bool
+        if next, ok = advanceOccurrence(next, task.Frequency); !ok {
+            break
+        }
+    }
+
+    return occurrences
+}
+
+// advanceOccurrence returns the due time following t for a task frequency, matching the
+// intervals used when maintenance schedules are calculated, and false for one-off tasks,
+// which have no further occurrences
+func advanceOccurrence(t time.Time, frequency string) (time.Time, bool) {
+    switch frequency {
+    case dto.FrequencyOnce:
+        return time.Time{}, false
+    case dto.FrequencyTwiceDaily:
+        return t.Add(12 * time.Hour), true
+    case dto.FrequencyWeekly:
+        return t.AddDate(0, 0, 7), true
+    case dto.FrequencyBiWeekly:
+        return t.AddDate(0, 0, 14), true
+    case dto.FrequencyMonthly:
+        return t.AddDate(0, 1, 0), true
+    default:
+        return t.AddDate(0, 0, 1), true
+    }
+}
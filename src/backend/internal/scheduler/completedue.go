@@ -0,0 +1,97 @@
+// Package scheduler provides maintenance scheduling functionality for the Urban Gardening Assistant
+package scheduler
+
+import (
+    "context"
+    "fmt"
+    "sync"
+
+    "github.com/urban-gardening/backend/pkg/dto"
+)
+
+// CompleteDueTasks completes every active task in a garden whose next scheduled time has
+// passed, rescheduling each as CompleteTask does. Tasks are completed by a bounded worker
+// pool so that no more than the configured number run at once; the response reports how
+// far the run got, including tasks left unstarted when ctx is cancelled.
+func (s *SchedulerService) CompleteDueTasks(ctx context.Context, gardenID string) (*dto.CompleteDueResponse, error) {
+    if gardenID == "" {
+        return nil, fmt.Errorf("%w: garden ID is required", ErrInvalidRequest)
+    }
+
+    maintenances, err := s.scheduler.ListGardenActiveMaintenance(ctx, gardenID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list due tasks: %w", err)
+    }
+
+    // Tasks are listed soonest first, so the due ones lead the list
+    now := s.clock.Now()
+    var taskIDs []string
+    for _, maintenance := range maintenances {
+        if maintenance.NextScheduledTime.After(now) {
+            break
+        }
+        taskIDs = append(taskIDs, maintenance.ID)
+    }
+
+    response := &dto.CompleteDueResponse{
+        GardenID:       gardenID,
+        Total:          len(taskIDs),
+        MaxConcurrency: s.maxCompletions,
+        Results:        make([]dto.CompleteDueResult, len(taskIDs)),
+    }
+    if len(taskIDs) == 0 {
+        return response, nil
+    }
+
+    workers := s.maxCompletions
+    if workers > len(taskIDs) {
+        workers = len(taskIDs)
+    }
+
+    started := make([]bool, len(taskIDs))
+    jobs := make(chan int)
+
+    var wg sync.WaitGroup
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for i := range jobs {
+                result := dto.CompleteDueResult{TaskID: taskIDs[i]}
+                task, err := s.CompleteTask(ctx, taskIDs[i], nil, "")
+                if err != nil {
+                    result.Error = err.Error()
+                } else {
+                    result.Task = task
+                }
+                response.Results[i] = result
+            }
+        }()
+    }
+
+feed:
+    for i := range taskIDs {
+        select {
+        case jobs <- i:
+            started[i] = true
+        case <-ctx.Done():
+            break feed
+        }
+    }
+    close(jobs)
+    wg.Wait()
+
+    for i, result := range response.Results {
+        switch {
+        case !started[i]:
+            response.Results[i] = dto.CompleteDueResult{TaskID: taskIDs[i], Error: ctx.Err().Error()}
+            response.Remaining++
+        case result.Error != "":
+            response.Failed++
+        default:
+            response.Completed++
+        }
+    }
+
+    return response, nil
+}
@@ -0,0 +1,141 @@
+// Package scheduler provides notification management for garden maintenance tasks
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/urban-gardening/backend/pkg/dto"
+)
+
+// deadLetterKey is the Redis list holding notifications that exhausted their retries,
+// newest first
+const deadLetterKey = "notification_dead_letters"
+
+// maxDeadLetters bounds the dead-letter queue; the oldest entries are dropped first
+const maxDeadLetters = 1000
+
+// Reasons a notification is dead-lettered
+const (
+	DeadLetterReasonDeliveryFailed  = "delivery_failed"  // A channel sender returned an error
+	DeadLetterReasonNoSender        = "no_sender"        // No sender is registered for a recipient's channel
+	DeadLetterReasonProcessingError = "processing_error" // The notification could not be prepared, e.g. recipients failed to load
+)
+
+// deadLetterReasons lists the reasons accepted when filtering the dead-letter queue
+var deadLetterReasons = map[string]bool{
+	DeadLetterReasonDeliveryFailed:  true,
+	DeadLetterReasonNoSender:        true,
+	DeadLetterReasonProcessingError: true,
+}
+
+// deadLetter is a dead-lettered notification as stored in Redis. The notification is
+// kept whole so it can be inspected or redelivered later.
+type deadLetter struct {
+	dto.DeadLetter
+	Notification notification `json:"notification"`
+}
+
+// deadLetterReason classifies why a notification exhausted its retries
+func deadLetterReason(processErr error, outcome *deliveryOutcome) (string, error) {
+	if processErr != nil {
+		return DeadLetterReasonProcessingError, processErr
+	}
+	if errors.Is(outcome.lastErr, ErrNoSender) {
+		return DeadLetterReasonNoSender, outcome.lastErr
+	}
+	return DeadLetterReasonDeliveryFailed, outcome.lastErr
+}
+
+// addDeadLetter moves a notification that exhausted its retries to the dead-letter queue
+func (nm *NotificationManager) addDeadLetter(ctx context.Context, failed *notification, reason string, cause error) error {
+	nm.mu.RLock()
+	now := nm.clock.Now()
+	nm.mu.RUnlock()
+
+	gardenID, _ := failed.Metadata["gardenId"].(string)
+	entry := deadLetter{
+		DeadLetter: dto.DeadLetter{
+			ID:            fmt.Sprintf("%s_%d", failed.CorrelationID, now.UnixNano()),
+			TaskID:        failed.TaskID,
+			TaskType:      failed.TaskType,
+			GardenID:      gardenID,
+			Reason:        reason,
+			RecipientIDs:  failed.RecipientIDs,
+			RetryCount:    failed.RetryCount,
+			ScheduledTime: failed.ScheduledTime,
+			FailedAt:      now,
+		},
+		Notification: *failed,
+	}
+	if cause != nil {
+		entry.Error = cause.Error()
+	}
+
+	entryJSON, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dead letter: %w", err)
+	}
+
+	if err := nm.redisClient.LPush(ctx, deadLetterKey, entryJSON).Err(); err != nil {
+		return fmt.Errorf("failed to dead-letter notification: %w", err)
+	}
+	return nm.redisClient.LTrim(ctx, deadLetterKey, 0, maxDeadLetters-1).Err()
+}
+
+// ListDeadLetters returns a page of dead-lettered notifications, newest first, optionally
+// narrowed to one task type and failure reason. Out-of-range page sizes fall back to the
+// default used for maintenance task lists.
+func (nm *NotificationManager) ListDeadLetters(ctx context.Context, page, pageSize int, filter dto.DeadLetterFilter) (*dto.DeadLetterListResponse, error) {
+	if filter.Reason != "" && !deadLetterReasons[filter.Reason] {
+		return nil, fmt.Errorf("%w: unknown failure reason %q", ErrInvalidRequest, filter.Reason)
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > maxPageSize {
+		pageSize = defaultPageSize
+	}
+
+	entries, err := nm.redisClient.LRange(ctx, deadLetterKey, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+
+	matching := make([]dto.DeadLetter, 0, len(entries))
+	for _, entry := range entries {
+		var letter deadLetter
+		if err := json.Unmarshal([]byte(entry), &letter); err != nil {
+			continue
+		}
+		if filter.TaskType != "" && letter.TaskType != filter.TaskType {
+			continue
+		}
+		if filter.Reason != "" && letter.Reason != filter.Reason {
+			continue
+		}
+		matching = append(matching, letter.DeadLetter)
+	}
+
+	total := len(matching)
+	start := (page - 1) * pageSize
+	if start > total {
+		start = total
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+
+	return &dto.DeadLetterListResponse{
+		DeadLetters: matching[start:end],
+		Total:       total,
+		Page:        page,
+		PageSize:    pageSize,
+		TotalPages:  (total + pageSize - 1) / pageSize,
+		HasNext:     end < total,
+		HasPrevious: page > 1,
+	}, nil
+}
@@ -0,0 +1,95 @@
+// Package scheduler provides notification management for garden maintenance tasks
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/urban-gardening/backend/pkg/dto"
+)
+
+// maxDeliveryRecords bounds each garden's delivery log; the oldest entries are dropped first
+const maxDeliveryRecords = 500
+
+// deliveriesKey returns the Redis list logging a garden's delivered notifications, newest first
+func deliveriesKey(gardenID string) string {
+	return fmt.Sprintf("notification_deliveries:%s", gardenID)
+}
+
+// deliver sends a message to a recipient over each of their channels in priority order
+// until one succeeds. It returns the channel that delivered the message and the channels
+// that failed before it; when every channel fails, the error is the last channel's.
+func (nm *NotificationManager) deliver(ctx context.Context, recipient dto.NotificationRecipient, message NotificationMessage) (string, []string, error) {
+	var failed []string
+	var lastErr error
+	for _, channel := range recipient.ChannelPriority() {
+		nm.mu.RLock()
+		sender, ok := nm.senders[channel.Channel]
+		nm.mu.RUnlock()
+
+		if !ok {
+			failed = append(failed, channel.Channel)
+			lastErr = fmt.Errorf("%w: %s", ErrNoSender, channel.Channel)
+			continue
+		}
+
+		// Senders see the channel being tried as the recipient's channel
+		attempt := recipient
+		attempt.Channel = channel.Channel
+		attempt.Address = channel.Address
+		if err := sender.Send(ctx, attempt, message); err != nil {
+			failed = append(failed, channel.Channel)
+			lastErr = err
+			continue
+		}
+		return channel.Channel, failed, nil
+	}
+	return "", failed, lastErr
+}
+
+// recordDelivery logs the channel that delivered a message to a recipient
+func (nm *NotificationManager) recordDelivery(ctx context.Context, recipient dto.NotificationRecipient, message NotificationMessage, channel string, failed []string) error {
+	nm.mu.RLock()
+	now := nm.clock.Now()
+	nm.mu.RUnlock()
+
+	delivery := dto.NotificationDelivery{
+		RecipientID:    recipient.ID,
+		TaskID:         message.TaskID,
+		TaskType:       message.TaskType,
+		CorrelationID:  message.CorrelationID,
+		Channel:        channel,
+		FailedChannels: failed,
+		DeliveredAt:    now,
+	}
+	deliveryJSON, err := json.Marshal(delivery)
+	if err != nil {
+		return fmt.Errorf("failed to marshal delivery: %w", err)
+	}
+
+	key := deliveriesKey(message.GardenID)
+	if err := nm.redisClient.LPush(ctx, key, deliveryJSON).Err(); err != nil {
+		return fmt.Errorf("failed to record delivery: %w", err)
+	}
+	return nm.redisClient.LTrim(ctx, key, 0, maxDeliveryRecords-1).Err()
+}
+
+// ListDeliveries returns a garden's delivered notifications, newest first, with the
+// channel that delivered each
+func (nm *NotificationManager) ListDeliveries(ctx context.Context, gardenID string) ([]dto.NotificationDelivery, error) {
+	entries, err := nm.redisClient.LRange(ctx, deliveriesKey(gardenID), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deliveries: %w", err)
+	}
+
+	deliveries := make([]dto.NotificationDelivery, 0, len(entries))
+	for _, entry := range entries {
+		var delivery dto.NotificationDelivery
+		if err := json.Unmarshal([]byte(entry), &delivery); err != nil {
+			continue
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,203 @@
+// Package scheduler provides notification management for garden maintenance tasks
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-redis/redis/v8" // v8.11.5
+	"github.com/urban-gardening/backend/pkg/dto"
+)
+
+// digestTaskType is the task type under which daily digests are queued alongside the
+// individual reminders for each task type
+const digestTaskType = "Digest"
+
+// Redis keys for digest mode
+const (
+	digestSettingsKey = "notification_digest_settings" // Hash of garden ID to digest settings
+	digestIndexKey    = "notification_digest_index"    // Hash of task ID to the digest holding it
+)
+
+// digestTasksKey returns the Redis hash gathering a garden's reminders for one day
+func digestTasksKey(gardenID, date string) string {
+	return fmt.Sprintf("notification_digest:%s:%s", gardenID, date)
+}
+
+// SetDigestSettings enables or disables a garden's daily digest. Reminders already
+// gathered into a digest are still delivered with it after the digest is disabled.
+func (nm *NotificationManager) SetDigestSettings(ctx context.Context, gardenID string, settings *dto.DigestSettings) error {
+	if gardenID == "" || settings == nil {
+		return fmt.Errorf("%w: garden ID and settings are required", ErrInvalidDigestSettings)
+	}
+	if err := settings.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidDigestSettings, err)
+	}
+
+	settingsJSON, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal digest settings: %w", err)
+	}
+
+	if err := nm.redisClient.HSet(ctx, digestSettingsKey, gardenID, settingsJSON).Err(); err != nil {
+		return fmt.Errorf("failed to save digest settings: %w", err)
+	}
+	return nil
+}
+
+// GetDigestSettings returns a garden's digest settings; digests are off until enabled
+func (nm *NotificationManager) GetDigestSettings(ctx context.Context, gardenID string) (*dto.DigestSettings, error) {
+	entry, err := nm.redisClient.HGet(ctx, digestSettingsKey, gardenID).Result()
+	if err == redis.Nil {
+		return &dto.DigestSettings{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get digest settings: %w", err)
+	}
+
+	var settings dto.DigestSettings
+	if err := json.Unmarshal([]byte(entry), &settings); err != nil {
+		return nil, fmt.Errorf("failed to decode digest settings: %w", err)
+	}
+	return &settings, nil
+}
+
+// addToDigest gathers a task's reminder into the digest for the day the task is due
+// instead of queueing it on its own. The day's digest is queued for the configured
+// delivery time, or for the next run if that time has already passed. Rescheduling a
+// task moves its reminder rather than adding a second one.
+func (nm *NotificationManager) addToDigest(ctx context.Context, gardenID string, settings *dto.DigestSettings, reminder *notification, dueTime time.Time) error {
+	loc := time.UTC
+	if settings.TimeZone != "" {
+		if zone, err := time.LoadLocation(settings.TimeZone); err == nil {
+			loc = zone
+		}
+	}
+	deliveryClock, err := time.Parse("15:04", settings.DeliveryTime)
+	if err != nil {
+		return fmt.Errorf("invalid digest delivery time: %w", err)
+	}
+
+	due := dueTime.In(loc)
+	date := due.Format("2006-01-02")
+	deliverAt := time.Date(due.Year(), due.Month(), due.Day(), deliveryClock.Hour(), deliveryClock.Minute(), 0, 0, loc)
+	tasksKey := digestTasksKey(gardenID, date)
+
+	reminder.ScheduledTime = dueTime
+	reminderJSON, err := json.Marshal(reminder)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	previous, err := nm.redisClient.HGet(ctx, digestIndexKey, reminder.TaskID).Result()
+	if err != nil && err != redis.Nil {
+		return fmt.Errorf("failed to look up digest: %w", err)
+	}
+	if previous != "" && previous != tasksKey {
+		if err := nm.redisClient.HDel(ctx, previous, reminder.TaskID).Err(); err != nil {
+			return fmt.Errorf("failed to move task between digests: %w", err)
+		}
+	}
+
+	if err := nm.redisClient.HSet(ctx, tasksKey, reminder.TaskID, reminderJSON).Err(); err != nil {
+		return fmt.Errorf("failed to add task to digest: %w", err)
+	}
+	if err := nm.redisClient.HSet(ctx, digestIndexKey, reminder.TaskID, tasksKey).Err(); err != nil {
+		return fmt.Errorf("failed to index digest task: %w", err)
+	}
+
+	// The entry is identical for every task of the day, so the digest is queued once
+	digest := &notification{
+		TaskType:      digestTaskType,
+		ScheduledTime: deliverAt,
+		CorrelationID: fmt.Sprintf("digest_%s_%s", gardenID, date),
+		Metadata: map[string]interface{}{
+			"gardenId":  gardenID,
+			"digestKey": tasksKey,
+		},
+	}
+	digestJSON, err := json.Marshal(digest)
+	if err != nil {
+		return fmt.Errorf("failed to marshal digest: %w", err)
+	}
+
+	nm.mu.RLock()
+	now := nm.clock.Now()
+	nm.mu.RUnlock()
+	score := deliverAt
+	if score.Before(now) {
+		score = now
+	}
+
+	if err := nm.redisClient.ZAddNX(ctx, fmt.Sprintf("notifications:%s", digestTaskType), &redis.Z{
+		Score:  float64(score.Unix()),
+		Member: digestJSON,
+	}).Err(); err != nil {
+		return fmt.Errorf("failed to schedule digest: %w", err)
+	}
+
+	nm.metrics.scheduledCount++
+	return nil
+}
+
+// collectDigest moves the reminders gathered for a due digest into it, ordered by due
+// time. Retries and deferred copies already carry their reminders and are returned as is.
+func (nm *NotificationManager) collectDigest(ctx context.Context, digest *notification) error {
+	if len(digest.Tasks) > 0 {
+		return nil
+	}
+
+	tasksKey, _ := digest.Metadata["digestKey"].(string)
+	if tasksKey == "" {
+		return nil
+	}
+
+	entries, err := nm.redisClient.HGetAll(ctx, tasksKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to collect digest: %w", err)
+	}
+
+	for taskID, entry := range entries {
+		var reminder notification
+		if err := json.Unmarshal([]byte(entry), &reminder); err != nil {
+			continue
+		}
+		digest.Tasks = append(digest.Tasks, reminder)
+		nm.redisClient.HDel(ctx, digestIndexKey, taskID)
+	}
+	if err := nm.redisClient.Del(ctx, tasksKey).Err(); err != nil {
+		return fmt.Errorf("failed to clear digest: %w", err)
+	}
+
+	sort.Slice(digest.Tasks, func(i, j int) bool {
+		if !digest.Tasks[i].ScheduledTime.Equal(digest.Tasks[j].ScheduledTime) {
+			return digest.Tasks[i].ScheduledTime.Before(digest.Tasks[j].ScheduledTime)
+		}
+		return digest.Tasks[i].TaskID < digest.Tasks[j].TaskID
+	})
+	for _, reminder := range digest.Tasks {
+		if reminder.Priority > digest.Priority {
+			digest.Priority = reminder.Priority
+		}
+	}
+	return nil
+}
+
+// removeFromDigest drops a task's reminder from the digest gathering it, if any
+func (nm *NotificationManager) removeFromDigest(ctx context.Context, taskID string) error {
+	tasksKey, err := nm.redisClient.HGet(ctx, digestIndexKey, taskID).Result()
+	if err == redis.Nil {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up digest: %w", err)
+	}
+
+	if err := nm.redisClient.HDel(ctx, tasksKey, taskID).Err(); err != nil {
+		return fmt.Errorf("failed to remove task from digest: %w", err)
+	}
+	return nm.redisClient.HDel(ctx, digestIndexKey, taskID).Err()
+}
@@ -0,0 +1,146 @@
+// Package scheduler provides maintenance scheduling functionality for the Urban Gardening Assistant
+package scheduler
+
+import (
+    "context"
+    "fmt"
+    "strings"
+    "time"
+
+    "github.com/urban-gardening/backend/internal/models"
+    "github.com/urban-gardening/backend/pkg/dto"
+)
+
+// stageBoundary marks the first day since planting at which a growth stage begins
+type stageBoundary struct {
+    stage    string
+    startDay int
+}
+
+// fertilizerDose is the per-grow-bag fertilizer schedule for a growth stage
+type fertilizerDose struct {
+    frequency    string
+    gramsPerBag  float64
+    rationale    string
+    organic      string // Fertilizer suggested for organically grown crops
+    conventional string // Fertilizer suggested for conventionally grown crops
+}
+
+// fruitingStages describes crops grown for their fruit, which flower and set fruit
+var fruitingStages = []stageBoundary{
+    {stage: dto.GrowthStageSeedling, startDay: 0},
+    {stage: dto.GrowthStageVegetative, startDay: 21},
+    {stage: dto.GrowthStageFlowering, startDay: 42},
+    {stage: dto.GrowthStageFruiting, startDay: 70},
+}
+
+// leafyStages describes crops harvested for their leaves before they flower
+var leafyStages = []stageBoundary{
+    {stage: dto.GrowthStageSeedling, startDay: 0},
+    {stage: dto.GrowthStageVegetative, startDay: 14},
+}
+
+// leafyCrops lists crops harvested for their leaves
+var leafyCrops = map[string]bool{
+    "spinach": true,
+    "lettuce": true,
+    "kale":    true,
+}
+
+// stageDoses ramps feeding up through vegetative growth, flowering, and fruiting
+var stageDoses = map[string]fertilizerDose{
+    dto.GrowthStageSeedling: {
+        frequency:    dto.FrequencyMonthly,
+        gramsPerBag:  5,
+        rationale:    "seedlings need light feeding to avoid root burn",
+        organic:      "worm castings",
+        conventional: "diluted balanced 10-10-10 fertilizer",
+    },
+    dto.GrowthStageVegetative: {
+        frequency:    dto.FrequencyBiWeekly,
+        gramsPerBag:  10,
+        rationale:    "nitrogen supports rapid leaf and stem growth",
+        organic:      "fish emulsion or blood meal",
+        conventional: "high-nitrogen fertilizer",
+    },
+    dto.GrowthStageFlowering: {
+        frequency:    dto.FrequencyWeekly,
+        gramsPerBag:  15,
+        rationale:    "phosphorus and potassium demand peaks while flowering",
+        organic:      "bone meal",
+        conventional: "high-phosphorus bloom fertilizer",
+    },
+    dto.GrowthStageFruiting: {
+        frequency:    dto.FrequencyWeekly,
+        gramsPerBag:  20,
+        rationale:    "developing fruit draws heavily on soil nutrients",
+        organic:      "compost and kelp meal",
+        conventional: "high-potassium fertilizer",
+    },
+}
+
+// RecommendFertilizerSchedule suggests a fertilizer frequency and amount for a crop based
+// on its type and the growth stage implied by how long ago it was planted, suggesting an
+// organic fertilizer for organically grown crops. The result can be applied to a
+// maintenance request and created through CreateSchedule.
+func (s *SchedulerService) RecommendFertilizerSchedule(ctx context.Context, cropID string) (*dto.FertilizerRecommendation, error) {
+    if cropID == "" {
+        return nil, fmt.Errorf("%w: crop ID is required", ErrInvalidRequest)
+    }
+
+    crop, err := s.scheduler.GetCrop(ctx, cropID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to recommend fertilizer schedule: %w", err)
+    }
+
+    return fertilizerRecommendationFor(crop, s.clock.Now()), nil
+}
+
+// fertilizerRecommendationFor computes the growth-stage fertilizer schedule for a crop as of now
+func fertilizerRecommendationFor(crop *models.Crop, now time.Time) *dto.FertilizerRecommendation {
+    daysSincePlanting := int(now.Sub(crop.CreatedAt).Hours() / 24)
+    if daysSincePlanting < 0 {
+        daysSincePlanting = 0
+    }
+
+    stages := fruitingStages
+    if leafyCrops[strings.ToLower(strings.TrimSpace(crop.Name))] {
+        stages = leafyStages
+    }
+    stage := growthStageFor(stages, daysSincePlanting)
+    dose := stageDoses[stage]
+
+    growBags := crop.GrowBags
+    if growBags < 1 {
+        growBags = 1
+    }
+
+    method, fertilizer := dto.GrowingMethodConventional, dose.conventional
+    if crop.Organic {
+        method, fertilizer = dto.GrowingMethodOrganic, dose.organic
+    }
+
+    return &dto.FertilizerRecommendation{
+        CropID:            crop.ID,
+        CropName:          crop.Name,
+        GrowthStage:       stage,
+        DaysSincePlanting: daysSincePlanting,
+        Frequency:         dose.frequency,
+        Amount:            clampTaskAmount(dto.TaskTypeFertilizer, dose.gramsPerBag*float64(growBags)),
+        Unit:              "g",
+        Rationale:         dose.rationale,
+        GrowingMethod:     method,
+        Fertilizer:        fertilizer,
+    }
+}
+
+// growthStageFor returns the latest stage whose start day has been reached
+func growthStageFor(stages []stageBoundary, daysSincePlanting int) string {
+    stage := stages[0].stage
+    for _, boundary := range stages {
+        if daysSincePlanting >= boundary.startDay {
+            stage = boundary.stage
+        }
+    }
+    return stage
+}
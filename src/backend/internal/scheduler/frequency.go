@@ -0,0 +1,148 @@
+// Package scheduler provides maintenance scheduling functionality for the Urban Gardening Assistant
+package scheduler
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "sort"
+    "time"
+
+    "gorm.io/gorm"
+
+    "github.com/urban-gardening/backend/pkg/dto"
+)
+
+// Completion history thresholds for frequency suggestions
+const (
+    frequencyHistoryLimit   = 10  // Most recent completions analyzed
+    minFrequencyCompletions = 4   // Fewer completions are too little history to judge
+    lateCompletionRatio     = 1.5 // Median interval this far over schedule suggests less often
+    earlyCompletionRatio    = 0.5 // Median interval this far under schedule suggests more often
+)
+
+// frequencyLadder lists the supported frequencies from most to least frequent
+var frequencyLadder = []struct {
+    frequency string
+    interval  time.Duration
+}{
+    {dto.FrequencyTwiceDaily, 12 * time.Hour},
+    {dto.FrequencyDaily, 24 * time.Hour},
+    {dto.FrequencyWeekly, 7 * 24 * time.Hour},
+    {dto.FrequencyBiWeekly, 14 * 24 * time.Hour},
+    {dto.FrequencyMonthly, 30 * 24 * time.Hour},
+}
+
+// SuggestFrequencyAdjustment compares how often a task has actually been completed with
+// its scheduled frequency. A task consistently completed well after it falls due is
+// suggested the supported frequency closest to its real cadence, as is one consistently
+// completed well before; otherwise the current frequency is kept.
+func (s *SchedulerService) SuggestFrequencyAdjustment(ctx context.Context, taskID string) (*dto.FrequencySuggestionResponse, error) {
+    if taskID == "" {
+        return nil, fmt.Errorf("%w: task ID is required", ErrInvalidRequest)
+    }
+
+    task, err := s.scheduler.GetMaintenanceTask(ctx, taskID)
+    if err != nil {
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return nil, ErrScheduleNotFound
+        }
+        return nil, fmt.Errorf("failed to get maintenance task: %w", err)
+    }
+
+    completions, err := s.scheduler.ListCompletionTimes(ctx, taskID, frequencyHistoryLimit)
+    if err != nil {
+        return nil, err
+    }
+
+    scheduled := frequencyInterval(task.Frequency)
+    response := &dto.FrequencySuggestionResponse{
+        TaskID:                 task.ID,
+        TaskType:               task.TaskType,
+        CurrentFrequency:       task.Frequency,
+        SuggestedFrequency:     task.Frequency,
+        Change:                 dto.FrequencyChangeNone,
+        CompletionsAnalyzed:    len(completions),
+        ScheduledIntervalHours: scheduled.Hours(),
+    }
+
+    if len(completions) < minFrequencyCompletions {
+        response.Reason = fmt.Sprintf("at least %d completions are needed to suggest a change; %d recorded",
+            minFrequencyCompletions, len(completions))
+        return response, nil
+    }
+
+    median := medianInterval(completions)
+    response.MedianIntervalHours = median.Hours()
+
+    // Frequencies at either end of the ladder have nothing further to step to
+    ratio := median.Hours() / scheduled.Hours()
+    if ratio >= lateCompletionRatio || ratio <= earlyCompletionRatio {
+        if suggested := closestFrequency(median, scheduled, ratio > 1); suggested != "" {
+            response.SuggestedFrequency = suggested
+        }
+    }
+
+    switch {
+    case response.SuggestedFrequency == task.Frequency:
+        response.Reason = fmt.Sprintf("completions every %.0f hours on average track the %s schedule",
+            median.Hours(), task.Frequency)
+    case ratio > 1:
+        response.Change = dto.FrequencyChangeLessFrequent
+        response.Reason = fmt.Sprintf("task is typically completed every %.0f hours, later than its %s schedule",
+            median.Hours(), task.Frequency)
+    default:
+        response.Change = dto.FrequencyChangeMoreFrequent
+        response.Reason = fmt.Sprintf("task is typically completed every %.0f hours, sooner than its %s schedule",
+            median.Hours(), task.Frequency)
+    }
+
+    return response, nil
+}
+
+// frequencyInterval returns the scheduled interval of a supported frequency, defaulting
+// to daily like the model's expected interval
+func frequencyInterval(frequency string) time.Duration {
+    for _, step := range frequencyLadder {
+        if step.frequency == frequency {
+            return step.interval
+        }
+    }
+    return 24 * time.Hour
+}
+
+// medianInterval returns the median gap between consecutive completion times
+func medianInterval(completions []time.Time) time.Duration {
+    gaps := make([]time.Duration, 0, len(completions)-1)
+    for i := 1; i < len(completions); i++ {
+        gaps = append(gaps, completions[i].Sub(completions[i-1]))
+    }
+    sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+
+    mid := len(gaps) / 2
+    if len(gaps)%2 == 0 {
+        return (gaps[mid-1] + gaps[mid]) / 2
+    }
+    return gaps[mid]
+}
+
+// closestFrequency returns the frequency whose interval is nearest the observed one,
+// considering only frequencies less frequent than scheduled when longer is set and only
+// more frequent ones otherwise. It returns "" when there is no such frequency.
+func closestFrequency(observed, scheduled time.Duration, longer bool) string {
+    best := ""
+    var bestDiff time.Duration
+    for _, step := range frequencyLadder {
+        if (longer && step.interval <= scheduled) || (!longer && step.interval >= scheduled) {
+            continue
+        }
+        diff := step.interval - observed
+        if diff < 0 {
+            diff = -diff
+        }
+        if best == "" || diff < bestDiff {
+            best, bestDiff = step.frequency, diff
+        }
+    }
+    return best
+}
@@ -0,0 +1,80 @@
+// Package scheduler provides maintenance scheduling functionality for the Urban Gardening Assistant
+package scheduler
+
+import (
+    "bytes"
+    "context"
+    "encoding/csv"
+    "fmt"
+    "strconv"
+    "time"
+)
+
+// historyCSVHeader is the header row of a maintenance history export
+var historyCSVHeader = []string{
+    "occurred_at",
+    "event",
+    "schedule_id",
+    "crop_id",
+    "crop_name",
+    "task_type",
+    "frequency",
+    "amount",
+    "unit",
+    "preferred_time",
+}
+
+// ExportHistoryCSV renders a garden's maintenance history between from and to as CSV for
+// analysis in a spreadsheet. Each row is a schedule being created, updated, deleted,
+// restored, or completed, oldest first, with the task as it stood at that moment.
+// A zero from or to leaves that end of the range open.
+func (s *SchedulerService) ExportHistoryCSV(ctx context.Context, gardenID string, from, to time.Time) ([]byte, error) {
+    if gardenID == "" {
+        return nil, fmt.Errorf("%w: garden ID is required", ErrInvalidRequest)
+    }
+    if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+        return nil, fmt.Errorf("%w: from must be before to", ErrInvalidRequest)
+    }
+
+    events, err := s.scheduler.ListGardenScheduleEvents(ctx, gardenID, from, to)
+    if err != nil {
+        return nil, fmt.Errorf("failed to export maintenance history: %w", err)
+    }
+
+    var buf bytes.Buffer
+    writer := csv.NewWriter(&buf)
+    if err := writer.Write(historyCSVHeader); err != nil {
+        return nil, fmt.Errorf("failed to write history header: %w", err)
+    }
+
+    for i := range events {
+        event := &events[i]
+        cropName := ""
+        if event.Crop != nil {
+            cropName = event.Crop.Name
+        }
+
+        record := []string{
+            event.CreatedAt.UTC().Format(time.RFC3339),
+            event.EventType,
+            event.MaintenanceID,
+            event.CropID,
+            cropName,
+            event.TaskType,
+            event.Frequency,
+            strconv.FormatFloat(event.Amount, 'f', -1, 64),
+            event.Unit,
+            event.PreferredTime,
+        }
+        if err := writer.Write(record); err != nil {
+            return nil, fmt.Errorf("failed to write history row: %w", err)
+        }
+    }
+
+    writer.Flush()
+    if err := writer.Error(); err != nil {
+        return nil, fmt.Errorf("failed to export maintenance history: %w", err)
+    }
+
+    return buf.Bytes(), nil
+}
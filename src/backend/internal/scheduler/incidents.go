@@ -0,0 +1,127 @@
+// Package scheduler provides maintenance scheduling functionality for the Urban Gardening Assistant
+package scheduler
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/urban-gardening/backend/internal/models"
+    "github.com/urban-gardening/backend/pkg/dto"
+)
+
+// recentIncidentWindow is how long a pest incident keeps raising pest control suggestions
+const recentIncidentWindow = 30 * 24 * time.Hour
+
+// pestControlPerBagML is the pest control treatment per grow bag
+const pestControlPerBagML = 50.0
+
+// suggestedPestControlTime is early, before pollinators are active
+const suggestedPestControlTime = "07:30"
+
+// pestControlFrequencies is the suggested pest control frequency by the severity of the
+// worst recent incident
+var pestControlFrequencies = map[string]string{
+    dto.IncidentSeverityLow:    dto.FrequencyBiWeekly,
+    dto.IncidentSeverityMedium: dto.FrequencyWeekly,
+    dto.IncidentSeverityHigh:   dto.FrequencyDaily,
+}
+
+// incidentSeverityRank orders severities so the worst recent incident can be found
+var incidentSeverityRank = map[string]int{
+    dto.IncidentSeverityLow:    1,
+    dto.IncidentSeverityMedium: 2,
+    dto.IncidentSeverityHigh:   3,
+}
+
+// RecordPestIncident records a pest or disease incident on a crop
+func (s *SchedulerService) RecordPestIncident(ctx context.Context, cropID string, request *dto.PestIncidentRequest) (*dto.PestIncidentResponse, error) {
+    if cropID == "" {
+        return nil, fmt.Errorf("%w: crop ID is required", ErrInvalidRequest)
+    }
+    if err := request.Validate(); err != nil {
+        return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+    }
+
+    if _, err := s.scheduler.GetCrop(ctx, cropID); err != nil {
+        return nil, fmt.Errorf("failed to record pest incident: %w", err)
+    }
+
+    incident, err := s.scheduler.SavePestIncident(ctx, cropID, request)
+    if err != nil {
+        return nil, fmt.Errorf("failed to record pest incident: %w", err)
+    }
+    return incident, nil
+}
+
+// GetPestIncident retrieves a pest incident by ID
+func (s *SchedulerService) GetPestIncident(ctx context.Context, incidentID string) (*dto.PestIncidentResponse, error) {
+    if incidentID == "" {
+        return nil, fmt.Errorf("%w: incident ID is required", ErrInvalidRequest)
+    }
+    return s.scheduler.GetPestIncident(ctx, incidentID)
+}
+
+// ListPestIncidents retrieves every pest incident recorded for a crop, most recent first
+func (s *SchedulerService) ListPestIncidents(ctx context.Context, cropID string) ([]*dto.PestIncidentResponse, error) {
+    if cropID == "" {
+        return nil, fmt.Errorf("%w: crop ID is required", ErrInvalidRequest)
+    }
+    return s.scheduler.ListPestIncidents(ctx, cropID, time.Time{})
+}
+
+// UpdatePestIncident replaces the details of a pest incident
+func (s *SchedulerService) UpdatePestIncident(ctx context.Context, incidentID string, request *dto.PestIncidentRequest) (*dto.PestIncidentResponse, error) {
+    if incidentID == "" {
+        return nil, fmt.Errorf("%w: incident ID is required", ErrInvalidRequest)
+    }
+    if err := request.Validate(); err != nil {
+        return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+    }
+    return s.scheduler.UpdatePestIncident(ctx, incidentID, request)
+}
+
+// DeletePestIncident deletes a pest incident
+func (s *SchedulerService) DeletePestIncident(ctx context.Context, incidentID string) error {
+    if incidentID == "" {
+        return fmt.Errorf("%w: incident ID is required", ErrInvalidRequest)
+    }
+    return s.scheduler.DeletePestIncident(ctx, incidentID)
+}
+
+// pestControlSuggestion suggests a Pest Control schedule when the crop has incidents in the
+// recent window flagged to raise pest control, more often the more severe the worst of
+// them. It returns nil when there are none.
+func (s *SchedulerService) pestControlSuggestion(ctx context.Context, crop *models.Crop) (*dto.ScheduleSuggestion, error) {
+    incidents, err := s.scheduler.ListPestIncidents(ctx, crop.ID, s.clock.Now().Add(-recentIncidentWindow))
+    if err != nil {
+        return nil, err
+    }
+
+    var worst *dto.PestIncidentResponse
+    for _, incident := range incidents {
+        if !incident.RaisePestControl {
+            continue
+        }
+        if worst == nil || incidentSeverityRank[incident.Severity] > incidentSeverityRank[worst.Severity] {
+            worst = incident
+        }
+    }
+    if worst == nil {
+        return nil, nil
+    }
+
+    growBags := crop.GrowBags
+    if growBags < 1 {
+        growBags = 1
+    }
+
+    return &dto.ScheduleSuggestion{
+        TaskType:      dto.TaskTypePestControl,
+        Frequency:     pestControlFrequencies[worst.Severity],
+        Amount:        clampTaskAmount(dto.TaskTypePestControl, pestControlPerBagML*float64(growBags)),
+        Unit:          "ml",
+        PreferredTime: suggestedPestControlTime,
+        Rationale:     fmt.Sprintf("a %s %s incident on %s calls for closer pest control", worst.Severity, worst.Type, worst.OccurredAt.Format("2006-01-02")),
+    }, nil
+}
@@ -3,16 +3,20 @@ package scheduler
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/urban-gardening/backend/internal/ai"
 	"github.com/urban-gardening/backend/internal/models"
+	"github.com/urban-gardening/backend/internal/utils/clock"
 	"github.com/urban-gardening/backend/pkg/dto"
 )
 
@@ -24,19 +28,20 @@ const (
 	maxRetries      = 3
 )
 
-// Prometheus metrics
+// Prometheus metrics, registered once at package load so any number of schedulers can
+// be constructed in one process
 var (
-	maintenanceTasksCreated = prometheus.NewCounter(prometheus.CounterOpts{
+	maintenanceTasksCreated = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "maintenance_tasks_created_total",
 		Help: "Total number of maintenance tasks created",
 	})
 
-	maintenanceTasksCompleted = prometheus.NewCounter(prometheus.CounterOpts{
+	maintenanceTasksCompleted = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "maintenance_tasks_completed_total",
 		Help: "Total number of maintenance tasks completed",
 	})
 
-	aiRecommendationLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+	aiRecommendationLatency = promauto.NewHistogram(prometheus.HistogramOpts{
 		Name:    "ai_recommendation_latency_seconds",
 		Help:    "Latency of AI recommendation generation",
 		Buckets: prometheus.LinearBuckets(0, 0.1, 10),
@@ -45,9 +50,13 @@ var (
 
 // MaintenanceScheduler handles maintenance task scheduling and management
 type MaintenanceScheduler struct {
-	db        *gorm.DB
-	aiService *ai.RecommendationService
-	mutex     *sync.RWMutex
+	db             *gorm.DB
+	aiService      *ai.RecommendationService
+	mutex          *sync.RWMutex
+	clock          clock.Clock
+	aiAmountPolicy string // How out-of-bounds AI-recommended amounts are handled
+	acceleration   *models.AccelerationPolicy // Rules bringing tasks forward for environmental factors
+	allowPast      bool                       // Keep next times computed in the past rather than rolling them forward
 }
 
 // NewMaintenanceScheduler creates a new MaintenanceScheduler instance
@@ -59,45 +68,52 @@ func NewMaintenanceScheduler(db *gorm.DB, aiService *ai.RecommendationService) (
 		return nil, errors.New("AI service is required")
 	}
 
-	prometheus.MustRegister(maintenanceTasksCreated)
-	prometheus.MustRegister(maintenanceTasksCompleted)
-	prometheus.MustRegister(aiRecommendationLatency)
-
 	return &MaintenanceScheduler{
-		db:        db,
-		aiService: aiService,
-		mutex:     &sync.RWMutex{},
+		db:             db,
+		aiService:      aiService,
+		mutex:          &sync.RWMutex{},
+		clock:          clock.Real(),
+		aiAmountPolicy: AIAmountClamp,
+		acceleration:   models.DefaultAccelerationPolicy(),
 	}, nil
 }
 
+// SetClock replaces the time source used for scheduling math, primarily so tests
+// can pin the current time
+func (s *MaintenanceScheduler) SetClock(c clock.Clock) {
+	s.mutex.Lock()
+	s.clock = clock.OrReal(c)
+	s.mutex.Unlock()
+}
+
+// SetAllowPastSchedules sets whether tasks keep a next time computed in the past, e.g.
+// after a completion backdated by more than an interval. By default such next times are
+// rolled forward to the next future occurrence.
+func (s *MaintenanceScheduler) SetAllowPastSchedules(allow bool) {
+	s.mutex.Lock()
+	s.allowPast = allow
+	s.mutex.Unlock()
+}
+
+// prepareTask gives a task built or loaded by the scheduler its clock, acceleration
+// policy, and past schedule check. Callers hold the scheduler mutex.
+func (s *MaintenanceScheduler) prepareTask(maintenance *models.Maintenance) {
+	maintenance.SetClock(s.clock)
+	maintenance.SetAccelerationPolicy(s.acceleration)
+	maintenance.SetAllowPastSchedule(s.allowPast)
+}
+
 // CreateMaintenanceTask creates a new maintenance task with AI recommendations
 func (s *MaintenanceScheduler) CreateMaintenanceTask(ctx context.Context, request *dto.MaintenanceRequest) (*dto.MaintenanceResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid maintenance request: %w", err)
 	}
 
-	// Start AI recommendation timing
-	start := time.Now()
-
-	// Generate AI recommendations with retry mechanism
-	schedule, err := s.generateMaintenanceSchedule(ctx, request)
+	maintenance, _, adjustment, err := s.newMaintenance(ctx, request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate maintenance schedule: %w", err)
-	}
-
-	// Record AI recommendation latency
-	aiRecommendationLatency.Observe(time.Since(start).Seconds())
-
-	// Create maintenance model
-	maintenance := &models.Maintenance{}
-	if err := maintenance.FromDTO(request); err != nil {
-		return nil, fmt.Errorf("failed to create maintenance model: %w", err)
+		return nil, err
 	}
 
-	// Apply AI recommendations
-	maintenance.AIRecommended = true
-	maintenance.EnvironmentalFactors = schedule
-
 	// Begin transaction
 	tx := s.db.Begin()
 	if tx.Error != nil {
@@ -110,6 +126,12 @@ func (s *MaintenanceScheduler) CreateMaintenanceTask(ctx context.Context, reques
 		return nil, fmt.Errorf("failed to create maintenance task: %w", err)
 	}
 
+	// Record creation in the crop's schedule history
+	if err := tx.Create(models.NewScheduleChangeEvent(maintenance, models.ScheduleEventCreated)).Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to record schedule change: %w", err)
+	}
+
 	// Commit transaction
 	if err := tx.Commit().Error; err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
@@ -118,7 +140,77 @@ func (s *MaintenanceScheduler) CreateMaintenanceTask(ctx context.Context, reques
 	// Increment metrics
 	maintenanceTasksCreated.Inc()
 
-	return maintenance.ToResponse(), nil
+	response := maintenance.ToResponse()
+	response.AmountAdjustment = adjustment
+	return response, nil
+}
+
+// PreviewMaintenanceTask computes when the task a request describes would first fall due,
+// along with any AI schedule behind it, without saving anything. It builds the task
+// exactly as CreateMaintenanceTask does, so the preview matches what creation would store.
+func (s *MaintenanceScheduler) PreviewMaintenanceTask(ctx context.Context, request *dto.MaintenanceRequest) (*dto.SchedulePreviewResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid maintenance request: %w", err)
+	}
+
+	maintenance, schedule, adjustment, err := s.newMaintenance(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	nextTime, err := maintenance.CalculateNextSchedule()
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate next schedule: %w", err)
+	}
+
+	return &dto.SchedulePreviewResponse{
+		CropID:            request.CropID,
+		TaskType:          maintenance.TaskType,
+		Frequency:         maintenance.Frequency,
+		PreferredTime:     maintenance.PreferredTime,
+		NextScheduledTime: nextTime,
+		AIRecommended:     maintenance.AIRecommended,
+		AISuggestions:     schedule,
+		Amount:            maintenance.Amount,
+		AmountAdjustment:  adjustment,
+	}, nil
+}
+
+// newMaintenance builds an unsaved maintenance task from a request with the AI schedule
+// applied, returning the schedule too, and any adjustment made to an out-of-bounds AI
+// amount. Once the AI budget is spent the task is built from the request's rule-based
+// values instead and the schedule is nil.
+func (s *MaintenanceScheduler) newMaintenance(ctx context.Context, request *dto.MaintenanceRequest) (*models.Maintenance, map[string]interface{}, *dto.AmountAdjustment, error) {
+	// Start AI recommendation timing
+	start := time.Now()
+
+	// Generate AI recommendations with retry mechanism
+	schedule, err := s.generateMaintenanceSchedule(ctx, request)
+	if err != nil && !errors.Is(err, ai.ErrBudgetExceeded) {
+		return nil, nil, nil, fmt.Errorf("failed to generate maintenance schedule: %w", err)
+	}
+
+	// Record AI recommendation latency
+	aiRecommendationLatency.Observe(time.Since(start).Seconds())
+
+	// Create maintenance model
+	maintenance := &models.Maintenance{}
+	s.prepareTask(maintenance)
+	if err := maintenance.FromDTO(request); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create maintenance model: %w", err)
+	}
+
+	// Apply AI recommendations
+	maintenance.AIRecommended = schedule != nil
+	var adjustment *dto.AmountAdjustment
+	if schedule != nil {
+		recommendedAt := s.clock.Now()
+		maintenance.AIRecommendedAt = &recommendedAt
+		maintenance.EnvironmentalFactors = schedule
+		adjustment = s.applyAIAmount(maintenance, schedule)
+	}
+
+	return maintenance, schedule, adjustment, nil
 }
 
 // UpdateMaintenanceTask updates an existing maintenance task
@@ -130,19 +222,40 @@ func (s *MaintenanceScheduler) UpdateMaintenanceTask(ctx context.Context, id str
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	// A deleted task is only brought back through RestoreMaintenanceTask
 	var maintenance models.Maintenance
-	if err := s.db.First(&maintenance, "id = ?", id).Error; err != nil {
-		return nil, fmt.Errorf("maintenance task not found: %w", err)
+	if err := s.db.WithContext(ctx).First(&maintenance, "id = ? AND deleted_at IS NULL", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrScheduleNotFound
+		}
+		return nil, fmt.Errorf("failed to get maintenance task: %w", err)
 	}
+	s.prepareTask(&maintenance)
 
 	if err := maintenance.FromDTO(request); err != nil {
 		return nil, fmt.Errorf("failed to update maintenance model: %w", err)
 	}
 
-	if err := s.db.Save(&maintenance).Error; err != nil {
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+
+	if err := tx.Save(&maintenance).Error; err != nil {
+		tx.Rollback()
 		return nil, fmt.Errorf("failed to update maintenance task: %w", err)
 	}
 
+	// Record the update in the crop's schedule history
+	if err := tx.Create(models.NewScheduleChangeEvent(&maintenance, models.ScheduleEventUpdated)).Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to record schedule change: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return maintenance.ToResponse(), nil
 }
 
@@ -152,13 +265,32 @@ func (s *MaintenanceScheduler) GetMaintenanceTask(ctx context.Context, id string
 	defer s.mutex.RUnlock()
 
 	var maintenance models.Maintenance
-	if err := s.db.First(&maintenance, "id = ?", id).Error; err != nil {
+	if err := s.db.First(&maintenance, "id = ? AND deleted_at IS NULL", id).Error; err != nil {
 		return nil, fmt.Errorf("maintenance task not found: %w", err)
 	}
 
 	return maintenance.ToResponse(), nil
 }
 
+// GetMaintenanceTasks retrieves the maintenance tasks with the given IDs in one query.
+// IDs with no matching task are left out of the result.
+func (s *MaintenanceScheduler) GetMaintenanceTasks(ctx context.Context, ids []string) ([]*dto.MaintenanceResponse, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var maintenances []models.Maintenance
+	if err := s.db.WithContext(ctx).Where("id IN ? AND deleted_at IS NULL", ids).Find(&maintenances).Error; err != nil {
+		return nil, fmt.Errorf("failed to get maintenance tasks: %w", err)
+	}
+
+	tasks := make([]*dto.MaintenanceResponse, len(maintenances))
+	for i, maintenance := range maintenances {
+		tasks[i] = maintenance.ToResponse()
+	}
+
+	return tasks, nil
+}
+
 // ListMaintenanceTasks retrieves a paginated list of maintenance tasks
 func (s *MaintenanceScheduler) ListMaintenanceTasks(ctx context.Context, page, pageSize int) (*dto.MaintenanceListResponse, error) {
 	if page < 1 {
@@ -172,12 +304,12 @@ func (s *MaintenanceScheduler) ListMaintenanceTasks(ctx context.Context, page, p
 	defer s.mutex.RUnlock()
 
 	var total int64
-	if err := s.db.Model(&models.Maintenance{}).Count(&total).Error; err != nil {
+	if err := s.db.Model(&models.Maintenance{}).Where("deleted_at IS NULL").Count(&total).Error; err != nil {
 		return nil, fmt.Errorf("failed to count maintenance tasks: %w", err)
 	}
 
 	var maintenances []models.Maintenance
-	if err := s.db.Offset((page - 1) * pageSize).Limit(pageSize).Find(&maintenances).Error; err != nil {
+	if err := s.db.Where("deleted_at IS NULL").Offset((page - 1) * pageSize).Limit(pageSize).Find(&maintenances).Error; err != nil {
 		return nil, fmt.Errorf("failed to list maintenance tasks: %w", err)
 	}
 
@@ -198,26 +330,1034 @@ func (s *MaintenanceScheduler) ListMaintenanceTasks(ctx context.Context, page, p
 	return response, nil
 }
 
-// CompleteMaintenanceTask marks a maintenance task as completed
-func (s *MaintenanceScheduler) CompleteMaintenanceTask(ctx context.Context, id string) error {
+// ListCropMaintenanceTasks retrieves the active maintenance tasks for a crop ordered by
+// next scheduled time
+func (s *MaintenanceScheduler) ListCropMaintenanceTasks(ctx context.Context, cropID string) ([]*dto.MaintenanceResponse, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var maintenances []models.Maintenance
+	if err := s.db.WithContext(ctx).
+		Where("crop_id = ? AND active = ? AND deleted_at IS NULL", cropID, true).
+		Order("next_scheduled_time ASC").
+		Find(&maintenances).Error; err != nil {
+		return nil, fmt.Errorf("failed to list crop maintenance tasks: %w", err)
+	}
+
+	tasks := make([]*dto.MaintenanceResponse, len(maintenances))
+	for i, maintenance := range maintenances {
+		tasks[i] = maintenance.ToResponse()
+	}
+
+	return tasks, nil
+}
+
+// GetCrop retrieves the crop a maintenance schedule would be attached to
+func (s *MaintenanceScheduler) GetCrop(ctx context.Context, cropID string) (*models.Crop, error) {
+	var crop models.Crop
+	err := s.db.WithContext(ctx).
+		Where("id = ? AND deleted_at IS NULL", cropID).
+		First(&crop).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCropNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get crop: %w", err)
+	}
+
+	return &crop, nil
+}
+
+// GetGardenOwner retrieves the ID of the user who owns a garden
+func (s *MaintenanceScheduler) GetGardenOwner(ctx context.Context, gardenID string) (string, error) {
+	var garden models.Garden
+	err := s.db.WithContext(ctx).
+		Select("user_id").
+		Where("id = ? AND deleted_at IS NULL", gardenID).
+		First(&garden).Error
+	if err != nil {
+		return "", fmt.Errorf("failed to get garden owner: %w", err)
+	}
+
+	return garden.UserID, nil
+}
+
+// GetGardenEnvironment retrieves a garden's growing environment, Outdoor when unset
+func (s *MaintenanceScheduler) GetGardenEnvironment(ctx context.Context, gardenID string) (string, error) {
+	var garden models.Garden
+	err := s.db.WithContext(ctx).
+		Select("environment").
+		Where("id = ? AND deleted_at IS NULL", gardenID).
+		First(&garden).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", ErrGardenNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get garden environment: %w", err)
+	}
+
+	if garden.Environment == "" {
+		return dto.EnvironmentOutdoor, nil
+	}
+	return garden.Environment, nil
+}
+
+// ListScheduleChangeEvents retrieves the schedule history for a crop in chronological order
+func (s *MaintenanceScheduler) ListScheduleChangeEvents(ctx context.Context, cropID string) ([]*dto.ScheduleChangeEvent, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var events []models.ScheduleChangeEvent
+	if err := s.db.WithContext(ctx).
+		Where("crop_id = ?", cropID).
+		Order("created_at ASC").
+		Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to list schedule changes: %w", err)
+	}
+
+	history := make([]*dto.ScheduleChangeEvent, len(events))
+	for i, event := range events {
+		history[i] = &dto.ScheduleChangeEvent{
+			ID:            event.ID,
+			ScheduleID:    event.MaintenanceID,
+			CropID:        event.CropID,
+			EventType:     event.EventType,
+			TaskType:      event.TaskType,
+			Frequency:     event.Frequency,
+			Amount:        event.Amount,
+			Unit:          event.Unit,
+			PreferredTime: event.PreferredTime,
+			OccurredAt:    event.CreatedAt,
+		}
+	}
+
+	return history, nil
+}
+
+// ListCompletionTimes retrieves when a maintenance task was most recently completed, up
+// to limit completions, in chronological order
+func (s *MaintenanceScheduler) ListCompletionTimes(ctx context.Context, maintenanceID string, limit int) ([]time.Time, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var events []models.ScheduleChangeEvent
+	if err := s.db.WithContext(ctx).
+		Where("maintenance_id = ? AND event_type = ?", maintenanceID, models.ScheduleEventCompleted).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to list completions: %w", err)
+	}
+
+	times := make([]time.Time, len(events))
+	for i, event := range events {
+		times[len(events)-1-i] = event.CreatedAt
+	}
+
+	return times, nil
+}
+
+// PurgeCompletionEvents deletes up to limit completion events recorded before cutoff,
+// returning how many were removed; callers repeat it until fewer than limit are removed.
+// Each task's latest completion is kept whatever its age, as are its streak and last
+// completion time, which live on the task itself. Only events no task reads are touched,
+// so the scheduler lock is not taken and scheduling carries on while history is purged.
+func (s *MaintenanceScheduler) PurgeCompletionEvents(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	latest := s.db.WithContext(ctx).
+		Model(&models.ScheduleChangeEvent{}).
+		Select("DISTINCT ON (maintenance_id) id").
+		Where("event_type = ?", models.ScheduleEventCompleted).
+		Order("maintenance_id, created_at DESC")
+
+	batch := s.db.WithContext(ctx).
+		Model(&models.ScheduleChangeEvent{}).
+		Select("id").
+		Where("event_type = ? AND created_at < ? AND id NOT IN (?)", models.ScheduleEventCompleted, cutoff, latest).
+		Limit(limit)
+
+	result := s.db.WithContext(ctx).
+		Where("id IN (?)", batch).
+		Delete(&models.ScheduleChangeEvent{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge completion history: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// ListGardenScheduleEvents retrieves the schedule history of every crop in a garden with
+// the crops loaded, in chronological order. Events before from or at or after to are
+// excluded; a zero bound leaves that end of the range open. Events for crops removed
+// since are kept so the history stays complete.
+func (s *MaintenanceScheduler) ListGardenScheduleEvents(ctx context.Context, gardenID string, from, to time.Time) ([]models.ScheduleChangeEvent, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	query := s.db.WithContext(ctx).
+		Preload("Crop", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Joins("JOIN crops ON crops.id = schedule_change_events.crop_id").
+		Where("crops.garden_id = ?", gardenID)
+	if !from.IsZero() {
+		query = query.Where("schedule_change_events.created_at >= ?", from)
+	}
+	if !to.IsZero() {
+		query = query.Where("schedule_change_events.created_at < ?", to)
+	}
+
+	var events []models.ScheduleChangeEvent
+	if err := query.Order("schedule_change_events.created_at ASC").Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to list garden schedule history: %w", err)
+	}
+
+	return events, nil
+}
+
+// SaveEnvironmentReading stores a sensor reading pushed for a garden
+func (s *MaintenanceScheduler) SaveEnvironmentReading(ctx context.Context, gardenID string, request *dto.EnvironmentReadingRequest) (*dto.EnvironmentReadingResponse, error) {
+	reading := &models.EnvironmentReading{
+		GardenID:    gardenID,
+		Temperature: *request.Temperature,
+		Humidity:    *request.Humidity,
+		LightLevel:  request.LightLevel,
+	}
+	if request.RecordedAt != nil {
+		reading.RecordedAt = *request.RecordedAt
+	}
+
+	if err := s.db.WithContext(ctx).Create(reading).Error; err != nil {
+		return nil, fmt.Errorf("failed to save environment reading: %w", err)
+	}
+
+	return toEnvironmentReadingResponse(reading), nil
+}
+
+// GetLatestEnvironmentReading retrieves the most recent sensor reading for the garden
+// a crop belongs to. It returns nil without error when no readings have been pushed.
+func (s *MaintenanceScheduler) GetLatestEnvironmentReading(ctx context.Context, cropID string) (*dto.EnvironmentReadingResponse, error) {
+	reading, err := latestCropEnvironmentReading(s.db.WithContext(ctx), cropID)
+	if err != nil || reading == nil {
+		return nil, err
+	}
+
+	return toEnvironmentReadingResponse(reading), nil
+}
+
+// latestCropEnvironmentReading loads the most recent sensor reading for the garden a
+// crop belongs to through db, which may be an open transaction. It returns nil without
+// error when no readings have been pushed.
+func latestCropEnvironmentReading(db *gorm.DB, cropID string) (*models.EnvironmentReading, error) {
+	var reading models.EnvironmentReading
+	err := db.
+		Joins("JOIN crops ON crops.garden_id = environment_readings.garden_id").
+		Where("crops.id = ?", cropID).
+		Order("environment_readings.recorded_at DESC").
+		First(&reading).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get environment reading: %w", err)
+	}
+
+	return &reading, nil
+}
+
+// GetLatestGardenEnvironmentReading retrieves the most recent sensor reading for a garden.
+// It returns nil without error when no readings have been pushed.
+func (s *MaintenanceScheduler) GetLatestGardenEnvironmentReading(ctx context.Context, gardenID string) (*dto.EnvironmentReadingResponse, error) {
+	var reading models.EnvironmentReading
+	err := s.db.WithContext(ctx).
+		Where("garden_id = ?", gardenID).
+		Order("recorded_at DESC").
+		First(&reading).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get environment reading: %w", err)
+	}
+
+	return toEnvironmentReadingResponse(&reading), nil
+}
+
+// ListGardenAIMaintenanceTasks retrieves the active AI-recommended maintenance tasks for
+// every crop in a garden, oldest recommendation first
+func (s *MaintenanceScheduler) ListGardenAIMaintenanceTasks(ctx context.Context, gardenID string) ([]models.Maintenance, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var maintenances []models.Maintenance
+	if err := s.db.WithContext(ctx).
+		Joins("JOIN crops ON crops.id = maintenances.crop_id").
+		Where("crops.garden_id = ? AND maintenances.ai_recommended = ? AND maintenances.active = ? AND maintenances.deleted_at IS NULL", gardenID, true, true).
+		Order("COALESCE(maintenances.ai_recommended_at, maintenances.created_at) ASC").
+		Find(&maintenances).Error; err != nil {
+		return nil, fmt.Errorf("failed to list garden maintenance tasks: %w", err)
+	}
+
+	return maintenances, nil
+}
+
+// ListGardenActiveMaintenance retrieves the active maintenance tasks for every crop in a
+// garden with their crops loaded, ordered by next scheduled time
+func (s *MaintenanceScheduler) ListGardenActiveMaintenance(ctx context.Context, gardenID string) ([]models.Maintenance, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var maintenances []models.Maintenance
+	if err := s.db.WithContext(ctx).
+		Preload("Crop").
+		Joins("JOIN crops ON crops.id = maintenances.crop_id").
+		Where("crops.garden_id = ? AND crops.deleted_at IS NULL AND maintenances.active = ? AND maintenances.deleted_at IS NULL", gardenID, true).
+		Order("maintenances.next_scheduled_time ASC").
+		Find(&maintenances).Error; err != nil {
+		return nil, fmt.Errorf("failed to list garden maintenance tasks: %w", err)
+	}
+
+	return maintenances, nil
+}
+
+// ListUserActiveMaintenance retrieves the active maintenance tasks for every crop in the
+// gardens a user owns, with their crops loaded, ordered by next scheduled time
+func (s *MaintenanceScheduler) ListUserActiveMaintenance(ctx context.Context, userID string) ([]models.Maintenance, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var maintenances []models.Maintenance
+	if err := s.db.WithContext(ctx).
+		Preload("Crop").
+		Joins("JOIN crops ON crops.id = maintenances.crop_id").
+		Joins("JOIN gardens ON gardens.id = crops.garden_id").
+		Where("gardens.user_id = ? AND gardens.deleted_at IS NULL AND crops.deleted_at IS NULL AND maintenances.active = ? AND maintenances.deleted_at IS NULL", userID, true).
+		Order("maintenances.next_scheduled_time ASC").
+		Find(&maintenances).Error; err != nil {
+		return nil, fmt.Errorf("failed to list user maintenance tasks: %w", err)
+	}
+
+	return maintenances, nil
+}
+
+// ShiftGardenPreferredTimes moves every active task in a garden whose preferred time falls
+// within from to toTime, recomputing each task's next scheduled time. All tasks are
+// updated in one transaction; the tasks as committed are returned.
+func (s *MaintenanceScheduler) ShiftGardenPreferredTimes(ctx context.Context, gardenID string, from dto.TimeRange, toTime string) ([]*dto.MaintenanceResponse, error) {
+	start, err := clockMinutes(from.Start)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid range start %q", ErrInvalidRequest, from.Start)
+	}
+	end, err := clockMinutes(from.End)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid range end %q", ErrInvalidRequest, from.End)
+	}
+	if start >= end {
+		return nil, fmt.Errorf("%w: range start must be before its end", ErrInvalidRequest)
+	}
+
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	tx := s.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer tx.Rollback()
+
+	var maintenances []models.Maintenance
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Joins("JOIN crops ON crops.id = maintenances.crop_id").
+		Where("crops.garden_id = ? AND crops.deleted_at IS NULL AND maintenances.active = ? AND maintenances.deleted_at IS NULL", gardenID, true).
+		Find(&maintenances).Error; err != nil {
+		return nil, fmt.Errorf("failed to list garden maintenance tasks: %w", err)
+	}
+
+	shifted := make([]*dto.MaintenanceResponse, 0, len(maintenances))
+	for i := range maintenances {
+		maintenance := &maintenances[i]
+		s.prepareTask(maintenance)
+		preferred, err := clockMinutes(maintenance.PreferredTime)
+		if err != nil || preferred < start || preferred >= end {
+			continue
+		}
+
+		maintenance.PreferredTime = toTime
+		nextTime, err := maintenance.CalculateNextSchedule()
+		if err != nil {
+			return nil, fmt.Errorf("failed to recompute next schedule for task %s: %w", maintenance.ID, err)
+		}
+		maintenance.NextScheduledTime = nextTime
+
+		if err := tx.Save(maintenance).Error; err != nil {
+			return nil, fmt.Errorf("failed to update maintenance task %s: %w", maintenance.ID, err)
+		}
+
+		if err := tx.Create(models.NewScheduleChangeEvent(maintenance, models.ScheduleEventUpdated)).Error; err != nil {
+			return nil, fmt.Errorf("failed to record schedule change: %w", err)
+		}
+
+		shifted = append(shifted, maintenance.ToResponse())
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return shifted, nil
+}
+
+// clockMinutes converts an HH:MM clock time to minutes past midnight
+func clockMinutes(clock string) (int, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// toEnvironmentReadingResponse converts a stored reading to its DTO
+func toEnvironmentReadingResponse(reading *models.EnvironmentReading) *dto.EnvironmentReadingResponse {
+	return &dto.EnvironmentReadingResponse{
+		ID:          reading.ID,
+		GardenID:    reading.GardenID,
+		Temperature: reading.Temperature,
+		Humidity:    reading.Humidity,
+		LightLevel:  reading.LightLevel,
+		RecordedAt:  reading.RecordedAt,
+	}
+}
+
+// SavePestIncident stores a pest or disease incident recorded for a crop
+func (s *MaintenanceScheduler) SavePestIncident(ctx context.Context, cropID string, request *dto.PestIncidentRequest) (*dto.PestIncidentResponse, error) {
+	incident := &models.PestIncident{CropID: cropID}
+	applyPestIncidentRequest(incident, request)
+
+	if err := s.db.WithContext(ctx).Create(incident).Error; err != nil {
+		return nil, fmt.Errorf("failed to save pest incident: %w", err)
+	}
+
+	return toPestIncidentResponse(incident), nil
+}
+
+// GetPestIncident retrieves a pest incident by ID
+func (s *MaintenanceScheduler) GetPestIncident(ctx context.Context, id string) (*dto.PestIncidentResponse, error) {
+	var incident models.PestIncident
+	err := s.db.WithContext(ctx).Where("id = ?", id).First(&incident).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrIncidentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pest incident: %w", err)
+	}
+
+	return toPestIncidentResponse(&incident), nil
+}
+
+// ListPestIncidents retrieves a crop's pest incidents, most recent first. Incidents before
+// since are excluded; a zero since returns them all.
+func (s *MaintenanceScheduler) ListPestIncidents(ctx context.Context, cropID string, since time.Time) ([]*dto.PestIncidentResponse, error) {
+	query := s.db.WithContext(ctx).Where("crop_id = ?", cropID)
+	if !since.IsZero() {
+		query = query.Where("occurred_at >= ?", since)
+	}
+
+	var incidents []models.PestIncident
+	if err := query.Order("occurred_at DESC").Find(&incidents).Error; err != nil {
+		return nil, fmt.Errorf("failed to list pest incidents: %w", err)
+	}
+
+	responses := make([]*dto.PestIncidentResponse, len(incidents))
+	for i := range incidents {
+		responses[i] = toPestIncidentResponse(&incidents[i])
+	}
+	return responses, nil
+}
+
+// UpdatePestIncident replaces the details of a pest incident
+func (s *MaintenanceScheduler) UpdatePestIncident(ctx context.Context, id string, request *dto.PestIncidentRequest) (*dto.PestIncidentResponse, error) {
+	var incident models.PestIncident
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", id).
+			First(&incident).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrIncidentNotFound
+			}
+			return fmt.Errorf("failed to get pest incident: %w", err)
+		}
+
+		applyPestIncidentRequest(&incident, request)
+		if err := tx.Save(&incident).Error; err != nil {
+			return fmt.Errorf("failed to update pest incident: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toPestIncidentResponse(&incident), nil
+}
+
+// DeletePestIncident soft-deletes a pest incident
+func (s *MaintenanceScheduler) DeletePestIncident(ctx context.Context, id string) error {
+	result := s.db.WithContext(ctx).Where("id = ?", id).Delete(&models.PestIncident{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete pest incident: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrIncidentNotFound
+	}
+	return nil
+}
+
+// applyPestIncidentRequest copies a request's details onto a stored incident
+func applyPestIncidentRequest(incident *models.PestIncident, request *dto.PestIncidentRequest) {
+	incident.Type = request.Type
+	incident.Severity = request.Severity
+	incident.Notes = request.Notes
+	incident.Treatment = request.Treatment
+	incident.RaisePestControl = request.RaisePestControl
+	if request.OccurredAt != nil {
+		incident.OccurredAt = *request.OccurredAt
+	}
+}
+
+// toPestIncidentResponse converts a stored incident to its DTO
+func toPestIncidentResponse(incident *models.PestIncident) *dto.PestIncidentResponse {
+	return &dto.PestIncidentResponse{
+		ID:               incident.ID,
+		CropID:           incident.CropID,
+		Type:             incident.Type,
+		Severity:         incident.Severity,
+		OccurredAt:       incident.OccurredAt,
+		Notes:            incident.Notes,
+		Treatment:        incident.Treatment,
+		RaisePestControl: incident.RaisePestControl,
+		CreatedAt:        incident.CreatedAt,
+		UpdatedAt:        incident.UpdatedAt,
+	}
+}
+
+// GetNotificationPreference retrieves a user's saved notification preferences. It returns
+// nil without error when the user has not saved any.
+func (s *MaintenanceScheduler) GetNotificationPreference(ctx context.Context, userID string) (*dto.NotificationPreferences, error) {
+	var preference models.NotificationPreference
+	err := s.db.WithContext(ctx).First(&preference, "user_id = ? AND garden_id = ''", userID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	return toNotificationPreferences(&preference), nil
+}
+
+// GetGardenNotificationPreference retrieves the preferences a user saved for one of their
+// gardens. It returns nil without error when the garden has none of its own.
+func (s *MaintenanceScheduler) GetGardenNotificationPreference(ctx context.Context, userID, gardenID string) (*dto.NotificationPreferences, error) {
+	var preference models.NotificationPreference
+	err := s.db.WithContext(ctx).First(&preference, "user_id = ? AND garden_id = ?", userID, gardenID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get garden notification preferences: %w", err)
+	}
+
+	return toNotificationPreferences(&preference), nil
+}
+
+// ApplyNotificationPreferenceToAll saves preferences for every garden the user owns in a
+// single transaction, replacing each garden's previous preferences. Gardens created later
+// use the user's own preferences until set.
+func (s *MaintenanceScheduler) ApplyNotificationPreferenceToAll(ctx context.Context, userID string, preferences *dto.NotificationPreferences) ([]dto.GardenNotificationPreferences, error) {
+	updatedAt := s.clock.Now()
+	applied := make([]dto.GardenNotificationPreferences, 0)
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var gardenIDs []string
+		if err := tx.Model(&models.Garden{}).
+			Where("user_id = ? AND deleted_at IS NULL", userID).
+			Order("created_at").
+			Pluck("id", &gardenIDs).Error; err != nil {
+			return fmt.Errorf("failed to list gardens: %w", err)
+		}
+		if len(gardenIDs) == 0 {
+			return nil
+		}
+
+		records := make([]models.NotificationPreference, len(gardenIDs))
+		for i, gardenID := range gardenIDs {
+			records[i] = models.NotificationPreference{
+				UserID:          userID,
+				GardenID:        gardenID,
+				QuietHoursStart: preferences.QuietHoursStart,
+				QuietHoursEnd:   preferences.QuietHoursEnd,
+				TimeZone:        preferences.TimeZone,
+				UpdatedAt:       updatedAt,
+			}
+			records[i].SetChannels(preferences.Channels)
+		}
+
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&records).Error; err != nil {
+			return fmt.Errorf("failed to save garden notification preferences: %w", err)
+		}
+
+		for i := range records {
+			applied = append(applied, dto.GardenNotificationPreferences{
+				GardenID:                records[i].GardenID,
+				NotificationPreferences: *toNotificationPreferences(&records[i]),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return applied, nil
+}
+
+// SaveNotificationPreference creates or replaces a user's notification preferences
+func (s *MaintenanceScheduler) SaveNotificationPreference(ctx context.Context, userID string, preferences *dto.NotificationPreferences) (*dto.NotificationPreferences, error) {
+	preference := &models.NotificationPreference{
+		UserID:          userID,
+		QuietHoursStart: preferences.QuietHoursStart,
+		QuietHoursEnd:   preferences.QuietHoursEnd,
+		TimeZone:        preferences.TimeZone,
+		UpdatedAt:       s.clock.Now(),
+	}
+	preference.SetChannels(preferences.Channels)
+
+	if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(preference).Error; err != nil {
+		return nil, fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+
+	return toNotificationPreferences(preference), nil
+}
+
+// DeleteNotificationPreference removes a user's saved preferences so the defaults apply again
+func (s *MaintenanceScheduler) DeleteNotificationPreference(ctx context.Context, userID string) error {
+	if err := s.db.WithContext(ctx).Delete(&models.NotificationPreference{}, "user_id = ? AND garden_id = ''", userID).Error; err != nil {
+		return fmt.Errorf("failed to delete notification preferences: %w", err)
+	}
+	return nil
+}
+
+// toNotificationPreferences converts a stored preference record to its DTO
+func toNotificationPreferences(preference *models.NotificationPreference) *dto.NotificationPreferences {
+	updatedAt := preference.UpdatedAt
+	return &dto.NotificationPreferences{
+		Channels:        preference.ChannelList(),
+		QuietHoursStart: preference.QuietHoursStart,
+		QuietHoursEnd:   preference.QuietHoursEnd,
+		TimeZone:        preference.TimeZone,
+		UpdatedAt:       &updatedAt,
+	}
+}
+
+// CompleteMaintenanceTask marks a maintenance task as completed and returns the task
+// as committed. A nil completedAt records the completion as happening now. The task
+// row is locked for the duration of the transaction so concurrent completions cannot
+// lose streak increments. A non-empty idempotencyKey is stored with the completion;
+// completing the task again with the same key leaves it unchanged and reports the
+// completion as replayed.
+func (s *MaintenanceScheduler) CompleteMaintenanceTask(ctx context.Context, id string, completedAt *time.Time, idempotencyKey string) (*dto.MaintenanceResponse, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tx := s.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, false, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer tx.Rollback()
+
 	var maintenance models.Maintenance
-	if err := s.db.First(&maintenance, "id = ?", id).Error; err != nil {
-		return fmt.Errorf("maintenance task not found: %w", err)
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&maintenance, "id = ? AND deleted_at IS NULL", id).Error; err != nil {
+		return nil, false, fmt.Errorf("maintenance task not found: %w", err)
 	}
 
-	if err := maintenance.MarkComplete(); err != nil {
-		return fmt.Errorf("failed to mark task as complete: %w", err)
+	// The key is checked under the row lock so a retry racing the original report
+	// waits for it and then sees its completion
+	if idempotencyKey != "" {
+		var replays int64
+		if err := tx.Model(&models.ScheduleChangeEvent{}).
+			Where("maintenance_id = ? AND event_type = ? AND idempotency_key = ?", id, models.ScheduleEventCompleted, idempotencyKey).
+			Count(&replays).Error; err != nil {
+			return nil, false, fmt.Errorf("failed to check completion key: %w", err)
+		}
+		if replays > 0 {
+			return maintenance.ToResponse(), true, nil
+		}
 	}
 
-	if err := s.db.Save(&maintenance).Error; err != nil {
-		return fmt.Errorf("failed to save completion status: %w", err)
+	// Resolve the default only once the row lock is held so that completions are
+	// recorded in the order they acquire the lock
+	s.prepareTask(&maintenance)
+	when := s.clock.Now()
+	if completedAt != nil {
+		when = *completedAt
+	}
+
+	// The next time is computed once, here, from the completion streak and the garden's
+	// latest sensor reading, so the stored time is the one the completion is notified for
+	reading, err := latestCropEnvironmentReading(tx, maintenance.CropID)
+	if err != nil {
+		return nil, false, err
+	}
+	maintenance.SetIntervalAdjuster(func(interval time.Duration) time.Duration {
+		return adjustIntervalForEnvironment(interval, &maintenance, reading)
+	})
+
+	if err := maintenance.MarkCompleteAt(when); err != nil {
+		if errors.Is(err, models.ErrCompletionBeforeLast) || errors.Is(err, models.ErrCompletionInFuture) {
+			return nil, false, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+		}
+		return nil, false, fmt.Errorf("failed to mark task as complete: %w", err)
+	}
+
+	if err := tx.Save(&maintenance).Error; err != nil {
+		return nil, false, fmt.Errorf("failed to save completion status: %w", err)
+	}
+
+	completion := models.NewScheduleChangeEvent(&maintenance, models.ScheduleEventCompleted)
+	completion.CreatedAt = when
+	completion.IdempotencyKey = idempotencyKey
+	if err := tx.Create(completion).Error; err != nil {
+		return nil, false, fmt.Errorf("failed to record completion: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, false, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	maintenanceTasksCompleted.Inc()
-	return nil
+	return maintenance.ToResponse(), false, nil
+}
+
+// adjustIntervalForEnvironment shortens the interval after a run of on-time completions
+// and, for watering, scales it for the temperature and humidity of the latest reading
+func adjustIntervalForEnvironment(interval time.Duration, maintenance *models.Maintenance, reading *models.EnvironmentReading) time.Duration {
+	if maintenance.CompletionStreak > 5 {
+		interval = interval * 9 / 10 // Reduce interval by 10% for consistent completion
+	}
+
+	// Sensor readings only affect how quickly the soil dries out
+	if reading == nil || maintenance.TaskType != dto.TaskTypeWater {
+		return interval
+	}
+
+	switch {
+	case reading.Temperature >= hotTemperatureC:
+		interval = interval * 8 / 10 // Water 20% sooner in hot weather
+	case reading.Temperature <= coolTemperatureC:
+		interval = interval * 12 / 10 // Water 20% later in cool weather
+	}
+
+	switch {
+	case reading.Humidity <= lowHumidityPct:
+		interval = interval * 9 / 10
+	case reading.Humidity >= highHumidityPct:
+		interval = interval * 11 / 10
+	}
+
+	return interval
+}
+
+// DeleteMaintenanceTask soft-deletes a maintenance task, deactivating it so it is no
+// longer listed or scheduled while keeping it available for RestoreMaintenanceTask
+func (s *MaintenanceScheduler) DeleteMaintenanceTask(ctx context.Context, id string) (*dto.MaintenanceResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tx := s.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer tx.Rollback()
+
+	var maintenance models.Maintenance
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&maintenance, "id = ? AND deleted_at IS NULL", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrScheduleNotFound
+		}
+		return nil, fmt.Errorf("failed to get maintenance task: %w", err)
+	}
+
+	now := s.clock.Now()
+	maintenance.DeletedAt = &now
+	maintenance.Active = false
+
+	// UpdateColumns skips the update hooks so the schedule is preserved as it was
+	if err := tx.Model(&maintenance).UpdateColumns(map[string]interface{}{
+		"deleted_at": maintenance.DeletedAt,
+		"active":     false,
+	}).Error; err != nil {
+		return nil, fmt.Errorf("failed to delete maintenance task: %w", err)
+	}
+
+	if err := tx.Create(models.NewScheduleChangeEvent(&maintenance, models.ScheduleEventDeleted)).Error; err != nil {
+		return nil, fmt.Errorf("failed to record schedule change: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return maintenance.ToResponse(), nil
+}
+
+// RestoreMaintenanceTask reactivates a soft-deleted maintenance task. Saving the task
+// recomputes its next scheduled time from the current time.
+func (s *MaintenanceScheduler) RestoreMaintenanceTask(ctx context.Context, id string) (*dto.MaintenanceResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tx := s.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer tx.Rollback()
+
+	var maintenance models.Maintenance
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&maintenance, "id = ? AND deleted_at IS NOT NULL", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrScheduleNotFound
+		}
+		return nil, fmt.Errorf("failed to get maintenance task: %w", err)
+	}
+
+	s.prepareTask(&maintenance)
+	maintenance.DeletedAt = nil
+	maintenance.Active = true
+
+	if err := tx.Save(&maintenance).Error; err != nil {
+		return nil, fmt.Errorf("failed to restore maintenance task: %w", err)
+	}
+
+	if err := tx.Create(models.NewScheduleChangeEvent(&maintenance, models.ScheduleEventRestored)).Error; err != nil {
+		return nil, fmt.Errorf("failed to record schedule change: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return maintenance.ToResponse(), nil
+}
+
+// CreateGardenSnapshot records a garden's crops and their maintenance schedules as they
+// stand, leaving out deleted ones
+func (s *MaintenanceScheduler) CreateGardenSnapshot(ctx context.Context, gardenID, label string) (*models.GardenSnapshot, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	db := s.db.WithContext(ctx)
+	var garden models.Garden
+	if err := db.Where("id = ? AND deleted_at IS NULL", gardenID).First(&garden).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGardenNotFound
+		}
+		return nil, fmt.Errorf("failed to get garden: %w", err)
+	}
+
+	var crops []models.Crop
+	if err := db.Where("garden_id = ? AND deleted_at IS NULL", gardenID).
+		Order("created_at ASC").
+		Find(&crops).Error; err != nil {
+		return nil, fmt.Errorf("failed to list garden crops: %w", err)
+	}
+
+	var maintenances []models.Maintenance
+	if err := db.Joins("JOIN crops ON crops.id = maintenances.crop_id").
+		Where("crops.garden_id = ? AND crops.deleted_at IS NULL AND maintenances.deleted_at IS NULL", gardenID).
+		Order("maintenances.created_at ASC").
+		Find(&maintenances).Error; err != nil {
+		return nil, fmt.Errorf("failed to list garden maintenance tasks: %w", err)
+	}
+
+	cropsJSON, err := json.Marshal(crops)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal crops: %w", err)
+	}
+	maintenancesJSON, err := json.Marshal(maintenances)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal maintenance tasks: %w", err)
+	}
+
+	snapshot := &models.GardenSnapshot{
+		GardenID:      gardenID,
+		Label:         label,
+		Crops:         cropsJSON,
+		Schedules:     maintenancesJSON,
+		CropCount:     len(crops),
+		ScheduleCount: len(maintenances),
+		CreatedAt:     s.clock.Now(),
+	}
+	if err := db.Create(snapshot).Error; err != nil {
+		return nil, fmt.Errorf("failed to save garden snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// ListGardenSnapshots retrieves a garden's snapshots, newest first
+func (s *MaintenanceScheduler) ListGardenSnapshots(ctx context.Context, gardenID string) ([]models.GardenSnapshot, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var snapshots []models.GardenSnapshot
+	if err := s.db.WithContext(ctx).
+		Where("garden_id = ?", gardenID).
+		Order("created_at DESC").
+		Find(&snapshots).Error; err != nil {
+		return nil, fmt.Errorf("failed to list garden snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// GardenRestore is what RestoreGardenSnapshot changed in a garden
+type GardenRestore struct {
+	Snapshot *models.GardenSnapshot
+	Restored []models.Maintenance // Tasks as restored
+	Removed  []models.Maintenance // Tasks created since the snapshot, now deleted
+	CropIDs  []string             // Crops saved from the snapshot or removed
+}
+
+// RestoreGardenSnapshot returns a garden's crops and maintenance tasks to a snapshot in
+// one transaction. Crops and tasks created since the snapshot are soft-deleted, and each
+// crop and task in the snapshot is saved as it was, reactivating any deleted since.
+// Saving a task recomputes its next scheduled time from the current time.
+func (s *MaintenanceScheduler) RestoreGardenSnapshot(ctx context.Context, gardenID, snapshotID string) (*GardenRestore, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tx := s.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer tx.Rollback()
+
+	var snapshot models.GardenSnapshot
+	if err := tx.First(&snapshot, "id = ? AND garden_id = ?", snapshotID, gardenID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSnapshotNotFound
+		}
+		return nil, fmt.Errorf("failed to get garden snapshot: %w", err)
+	}
+
+	var crops []models.Crop
+	if err := json.Unmarshal(snapshot.Crops, &crops); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot crops: %w", err)
+	}
+	var restored []models.Maintenance
+	if err := json.Unmarshal(snapshot.Schedules, &restored); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot maintenance tasks: %w", err)
+	}
+
+	cropIDs := make([]string, len(crops))
+	for i := range crops {
+		cropIDs[i] = crops[i].ID
+	}
+	kept := make(map[string]bool, len(restored))
+	for i := range restored {
+		kept[restored[i].ID] = true
+	}
+
+	now := s.clock.Now()
+
+	// Remove tasks created since the snapshot, including those of crops added since
+	var current []models.Maintenance
+	if err := tx.Joins("JOIN crops ON crops.id = maintenances.crop_id").
+		Where("crops.garden_id = ? AND maintenances.deleted_at IS NULL", gardenID).
+		Find(&current).Error; err != nil {
+		return nil, fmt.Errorf("failed to list garden maintenance tasks: %w", err)
+	}
+	var removed []models.Maintenance
+	for i := range current {
+		maintenance := current[i]
+		if kept[maintenance.ID] {
+			continue
+		}
+		maintenance.DeletedAt = &now
+		maintenance.Active = false
+
+		// UpdateColumns skips the update hooks so the schedule is preserved as it was
+		if err := tx.Model(&maintenance).UpdateColumns(map[string]interface{}{
+			"deleted_at": maintenance.DeletedAt,
+			"active":     false,
+		}).Error; err != nil {
+			return nil, fmt.Errorf("failed to delete maintenance task: %w", err)
+		}
+		if err := tx.Create(models.NewScheduleChangeEvent(&maintenance, models.ScheduleEventDeleted)).Error; err != nil {
+			return nil, fmt.Errorf("failed to record schedule change: %w", err)
+		}
+		removed = append(removed, maintenance)
+	}
+
+	// Remove crops added since the snapshot
+	query := tx.Model(&models.Crop{}).Where("garden_id = ? AND deleted_at IS NULL", gardenID)
+	if len(cropIDs) > 0 {
+		query = query.Where("id NOT IN ?", cropIDs)
+	}
+	var removedCropIDs []string
+	if err := query.Pluck("id", &removedCropIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to list crops added since snapshot: %w", err)
+	}
+	if len(removedCropIDs) > 0 {
+		if err := tx.Model(&models.Crop{}).Where("id IN ?", removedCropIDs).UpdateColumn("deleted_at", now).Error; err != nil {
+			return nil, fmt.Errorf("failed to delete crops: %w", err)
+		}
+	}
+
+	for i := range crops {
+		crops[i].DeletedAt = nil
+		if err := tx.Save(&crops[i]).Error; err != nil {
+			return nil, fmt.Errorf("failed to restore crop: %w", err)
+		}
+	}
+
+	for i := range restored {
+		s.prepareTask(&restored[i])
+		restored[i].DeletedAt = nil
+		if err := tx.Save(&restored[i]).Error; err != nil {
+			return nil, fmt.Errorf("failed to restore maintenance task: %w", err)
+		}
+		if err := tx.Create(models.NewScheduleChangeEvent(&restored[i], models.ScheduleEventRestored)).Error; err != nil {
+			return nil, fmt.Errorf("failed to record schedule change: %w", err)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &GardenRestore{
+		Snapshot: &snapshot,
+		Restored: restored,
+		Removed:  removed,
+		CropIDs:  append(cropIDs, removedCropIDs...),
+	}, nil
+}
+
+// scheduleConditions returns the garden conditions sent to the AI for a maintenance
+// request, including the crop's growing method so organic crops are given organic
+// fertilizers. Crops that cannot be found are treated as conventionally grown.
+func (s *MaintenanceScheduler) scheduleConditions(ctx context.Context, request *dto.MaintenanceRequest) map[string]string {
+	method := dto.GrowingMethodConventional
+	if crop, err := s.GetCrop(ctx, request.CropID); err == nil && crop.Organic {
+		method = dto.GrowingMethodOrganic
+	}
+
+	return map[string]string{
+		"soilType":           request.SoilType,
+		"growBagSize":        request.GrowBagSize,
+		"growingEnvironment": request.GrowingEnvironment,
+		"growingMethod":      method,
+	}
 }
 
 // generateMaintenanceSchedule generates AI-powered maintenance schedule with retries
@@ -229,15 +1369,14 @@ func (s *MaintenanceScheduler) generateMaintenanceSchedule(ctx context.Context,
 		scheduleCtx, cancel := context.WithTimeout(ctx, aiTimeout)
 		defer cancel()
 
-		schedule, err = s.aiService.GenerateMaintenanceSchedule(scheduleCtx, []string{request.TaskType}, map[string]string{
-			"soilType":           request.SoilType,
-			"growBagSize":        request.GrowBagSize,
-			"growingEnvironment": request.GrowingEnvironment,
-		})
+		schedule, err = s.aiService.GenerateMaintenanceSchedule(scheduleCtx, []string{request.TaskType}, s.scheduleConditions(ctx, request))
 
 		if err == nil {
 			return schedule, nil
 		}
+		if errors.Is(err, ai.ErrBudgetExceeded) {
+			return nil, err
+		}
 
 		if attempt < maxRetries-1 {
 			time.Sleep(time.Duration(attempt+1) * 500 * time.Millisecond)
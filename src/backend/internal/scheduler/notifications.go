@@ -6,11 +6,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/go-redis/redis/v8" // v8.11.5
+	"github.com/google/uuid"       // v1.3.0
 	"github.com/urban-gardening-assistant/backend/internal/models"
+	"github.com/urban-gardening/backend/internal/utils/clock"
+	"github.com/urban-gardening/backend/pkg/dto"
 )
 
 // Custom errors for notification management
@@ -19,6 +23,38 @@ var (
 	ErrRedisConnection      = errors.New("redis connection error")
 	ErrNotificationSchedule = errors.New("failed to schedule notification")
 	ErrRateLimit           = errors.New("rate limit exceeded")
+	ErrInvalidRecipient     = errors.New("invalid notification recipient")
+	ErrNoSender             = errors.New("no sender registered for channel")
+	ErrInvalidDigestSettings = errors.New("invalid digest settings")
+	ErrNotificationLimit    = errors.New("active notification limit reached")
+)
+
+// NotificationSender delivers a notification to a single recipient over one channel
+type NotificationSender interface {
+	Send(ctx context.Context, recipient dto.NotificationRecipient, message NotificationMessage) error
+}
+
+// NotificationMessage is the content handed to a sender for one maintenance reminder,
+// or for a garden's daily digest when Digest is set
+type NotificationMessage struct {
+	TaskID        string
+	TaskType      string
+	CropID        string
+	GardenID      string
+	ScheduledTime time.Time
+	Priority      int
+	CorrelationID string
+	Digest        []NotificationMessage // Reminders covered by a digest, in due order
+}
+
+// GardenResolver looks up the garden a crop belongs to, so notifications can be routed
+// to that garden's recipients
+type GardenResolver func(ctx context.Context, cropID string) (string, error)
+
+// Sources of the current time that decides which notifications are due
+const (
+	TimeSourceLocal = "local" // The replica's clock, held within the skew tolerance of Redis TIME when one is set
+	TimeSourceRedis = "redis" // Redis TIME, shared by every replica
 )
 
 // NotificationConfig holds configuration for the notification manager
@@ -29,6 +65,19 @@ type NotificationConfig struct {
 	ProcessorCount     int
 	RateLimitPerHour   map[string]int
 	ShutdownTimeout    time.Duration
+	MinGap             time.Duration // Minimum time between notifications for the same task; zero disables coalescing
+	MaxActivePerUser   int           // Maximum tasks with pending notifications per user; zero is unlimited
+	EvictOnLimit       bool          // Evict a lower-priority pending task instead of rejecting at the limit
+	TaskTypePriorities map[string]int // Base notification priority by task type, overriding the defaults
+	TimeSource         string         // Where processing takes the current time from; empty is TimeSourceLocal
+	ClockSkewTolerance time.Duration  // How far a local processing time may drift from Redis TIME; zero skips the check
+}
+
+// defaultTaskTypePriorities are the base notification priorities of task types; other
+// task types have a base priority of 1
+var defaultTaskTypePriorities = map[string]int{
+	"Water":      3,
+	"Fertilizer": 2,
 }
 
 // NotificationManager handles scheduling and delivery of maintenance task notifications
@@ -38,11 +87,21 @@ type NotificationManager struct {
 	maxRetries         int
 	retryDelay         time.Duration
 	processorCount     int
+	minGap             time.Duration
+	maxActivePerUser   int
+	evictOnLimit       bool
+	taskTypePriorities map[string]int
+	timeSource         string
+	skewTolerance      time.Duration
 	notificationRateLimit map[string]int
 	shutdownChan      chan struct{}
 	wg                sync.WaitGroup
 	mu                sync.RWMutex
 	metrics           *notificationMetrics
+	senders           map[string]NotificationSender // Keyed by recipient channel
+	resolveGarden     GardenResolver
+	resolveUser       UserResolver
+	clock             clock.Clock
 }
 
 // notificationMetrics tracks notification system performance
@@ -51,6 +110,10 @@ type notificationMetrics struct {
 	deliveredCount   int64
 	failedCount      int64
 	retryCount       int64
+	coalescedCount   int64
+	fallbackCount    int64 // Deliveries that succeeded only on a fallback channel
+	evictedCount     int64 // Pending tasks cancelled to stay within a user's limit
+	skewCorrectedCount int64 // Processing runs whose time was pulled within the skew tolerance
 	lastError        error
 	lastErrorTime    time.Time
 }
@@ -64,6 +127,8 @@ type notification struct {
 	RetryCount        int                    `json:"retryCount"`
 	Metadata          map[string]interface{} `json:"metadata,omitempty"`
 	CorrelationID     string                 `json:"correlationId"`
+	RecipientIDs      []string               `json:"recipientIds,omitempty"` // Limits a retry or deferred delivery to these recipients
+	Tasks             []notification         `json:"tasks,omitempty"`        // Reminders gathered into a digest
 }
 
 // NewNotificationManager creates a new notification manager instance
@@ -88,6 +153,26 @@ func NewNotificationManager(redisClient *redis.Client, config NotificationConfig
 	if config.ShutdownTimeout == 0 {
 		config.ShutdownTimeout = 30 * time.Second
 	}
+	if config.MinGap < 0 {
+		return nil, errors.New("minimum notification gap cannot be negative")
+	}
+	if config.TimeSource == "" {
+		config.TimeSource = TimeSourceLocal
+	}
+	if config.TimeSource != TimeSourceLocal && config.TimeSource != TimeSourceRedis {
+		return nil, fmt.Errorf("unknown notification time source %q", config.TimeSource)
+	}
+	if config.ClockSkewTolerance < 0 {
+		return nil, errors.New("clock skew tolerance cannot be negative")
+	}
+
+	priorities := make(map[string]int, len(defaultTaskTypePriorities)+len(config.TaskTypePriorities))
+	for taskType, priority := range defaultTaskTypePriorities {
+		priorities[taskType] = priority
+	}
+	for taskType, priority := range config.TaskTypePriorities {
+		priorities[taskType] = priority
+	}
 
 	nm := &NotificationManager{
 		redisClient:        redisClient,
@@ -95,9 +180,17 @@ func NewNotificationManager(redisClient *redis.Client, config NotificationConfig
 		maxRetries:         config.MaxRetries,
 		retryDelay:        config.RetryDelay,
 		processorCount:     config.ProcessorCount,
+		minGap:             config.MinGap,
+		maxActivePerUser:   config.MaxActivePerUser,
+		evictOnLimit:       config.EvictOnLimit,
+		taskTypePriorities: priorities,
+		timeSource:         config.TimeSource,
+		skewTolerance:      config.ClockSkewTolerance,
 		notificationRateLimit: config.RateLimitPerHour,
 		shutdownChan:      make(chan struct{}),
 		metrics:           &notificationMetrics{},
+		senders:           make(map[string]NotificationSender),
+		clock:             clock.Real(),
 	}
 
 	// Start background processors
@@ -121,9 +214,22 @@ func (nm *NotificationManager) ScheduleNotification(ctx context.Context, task *m
 	}
 
 	// Calculate notification time with lead time
+	nm.mu.RLock()
+	now := nm.clock.Now()
+	nm.mu.RUnlock()
 	notifyTime := task.NextScheduledTime.Add(-nm.defaultLeadTime)
-	if notifyTime.Before(time.Now()) {
-		notifyTime = time.Now().Add(5 * time.Minute)
+	if notifyTime.Before(now) {
+		notifyTime = now.Add(5 * time.Minute)
+	}
+
+	metadata := map[string]interface{}{
+		"cropId":        task.CropID,
+		"frequency":     task.Frequency,
+		"preferredTime": task.PreferredTime,
+	}
+	gardenID := nm.gardenForTask(ctx, task)
+	if gardenID != "" {
+		metadata["gardenId"] = gardenID
 	}
 
 	// Create notification payload
@@ -131,14 +237,26 @@ func (nm *NotificationManager) ScheduleNotification(ctx context.Context, task *m
 		TaskID:        task.ID,
 		TaskType:      task.TaskType,
 		ScheduledTime: notifyTime,
-		Priority:      calculatePriority(task),
+		Priority:      nm.calculatePriority(task),
 		RetryCount:    0,
 		CorrelationID: generateCorrelationID(),
-		Metadata: map[string]interface{}{
-			"cropId":        task.CropID,
-			"frequency":     task.Frequency,
-			"preferredTime": task.PreferredTime,
-		},
+		Metadata:      metadata,
+	}
+
+	// Count the task against its owner's active notification limit
+	if err := nm.reserveActiveSlot(ctx, gardenID, notification); err != nil {
+		return err
+	}
+
+	// Gardens in digest mode get the reminder in their daily digest instead
+	if gardenID != "" {
+		settings, err := nm.GetDigestSettings(ctx, gardenID)
+		if err != nil {
+			return err
+		}
+		if settings.Enabled {
+			return nm.addToDigest(ctx, gardenID, settings, notification, task.NextScheduledTime)
+		}
 	}
 
 	// Serialize notification
@@ -150,6 +268,11 @@ func (nm *NotificationManager) ScheduleNotification(ctx context.Context, task *m
 	// Add to Redis sorted set with score as Unix timestamp
 	key := fmt.Sprintf("notifications:%s", task.TaskType)
 	score := float64(notification.ScheduledTime.Unix())
+
+	// Replace pending notifications for the task that are too close to this one
+	if err := nm.coalescePending(ctx, key, task.ID, notifyTime); err != nil {
+		return fmt.Errorf("failed to coalesce notifications: %w", err)
+	}
 	
 	if err := nm.redisClient.ZAdd(ctx, key, &redis.Z{
 		Score:  score,
@@ -166,6 +289,62 @@ func (nm *NotificationManager) ScheduleNotification(ctx context.Context, task *m
 	return nil
 }
 
+// CancelNotifications removes every pending notification for a maintenance task,
+// including its reminder in a pending digest
+func (nm *NotificationManager) CancelNotifications(ctx context.Context, taskID, taskType string) error {
+	if err := nm.removeFromDigest(ctx, taskID); err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("notifications:%s", taskType)
+
+	members, err := nm.redisClient.ZRange(ctx, key, 0, -1).Result()
+	if err != nil {
+		return fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	for _, member := range members {
+		var pending notification
+		if err := json.Unmarshal([]byte(member), &pending); err != nil || pending.TaskID != taskID {
+			continue
+		}
+		if err := nm.redisClient.ZRem(ctx, key, member).Err(); err != nil {
+			return fmt.Errorf("failed to cancel notification: %w", err)
+		}
+	}
+
+	return nm.releaseActiveSlot(ctx, taskID)
+}
+
+// coalescePending removes the task's pending notifications scheduled within the minimum
+// gap of notifyTime, so the notification about to be added supersedes them
+func (nm *NotificationManager) coalescePending(ctx context.Context, key, taskID string, notifyTime time.Time) error {
+	if nm.minGap <= 0 {
+		return nil
+	}
+
+	members, err := nm.redisClient.ZRangeByScore(ctx, key, &redis.ZRangeBy{
+		Min: fmt.Sprintf("(%d", notifyTime.Add(-nm.minGap).Unix()),
+		Max: fmt.Sprintf("(%d", notifyTime.Add(nm.minGap).Unix()),
+	}).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		var pending notification
+		if err := json.Unmarshal([]byte(member), &pending); err != nil || pending.TaskID != taskID {
+			continue
+		}
+		if err := nm.redisClient.ZRem(ctx, key, member).Err(); err != nil {
+			return err
+		}
+		nm.metrics.coalescedCount++
+	}
+
+	return nil
+}
+
 // startProcessor starts a background processor for handling due notifications
 func (nm *NotificationManager) startProcessor(id int) {
 	defer nm.wg.Done()
@@ -178,7 +357,7 @@ func (nm *NotificationManager) startProcessor(id int) {
 		case <-nm.shutdownChan:
 			return
 		case <-ticker.C:
-			if err := nm.processDueNotifications(context.Background()); err != nil {
+			if err := nm.ProcessDueNotifications(context.Background(), time.Now()); err != nil {
 				nm.metrics.lastError = err
 				nm.metrics.lastErrorTime = time.Now()
 			}
@@ -186,12 +365,39 @@ func (nm *NotificationManager) startProcessor(id int) {
 	}
 }
 
-// processDueNotifications processes notifications that are due
-func (nm *NotificationManager) processDueNotifications(ctx context.Context) error {
-	now := time.Now()
-	
-	// Get due notifications from all task types
+// ProcessDueNotifications delivers every notification due at or before now, including
+// daily digests, to all of its garden's recipients, highest priority first and then in
+// due order. Recipients inside their quiet hours are sent a deferred copy when their
+// quiet hours end; recipients whose delivery failed are retried with backoff, and the
+// notification is dead-lettered once retries run out. Under the Redis time source, or
+// with a clock skew tolerance, now is first checked against Redis TIME; see dueCutoff.
+func (nm *NotificationManager) ProcessDueNotifications(ctx context.Context, now time.Time) error {
+	now, err := nm.dueCutoff(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	taskTypes := make([]string, 0, len(nm.notificationRateLimit)+len(nm.taskTypePriorities)+1)
 	for taskType := range nm.notificationRateLimit {
+		taskTypes = append(taskTypes, taskType)
+	}
+	for taskType := range nm.taskTypePriorities {
+		if _, ok := nm.notificationRateLimit[taskType]; !ok {
+			taskTypes = append(taskTypes, taskType)
+		}
+	}
+	taskTypes = append(taskTypes, digestTaskType)
+
+	// dueNotification is a due notification and the queue entry it was read from
+	type dueNotification struct {
+		key          string
+		member       string
+		notification notification
+	}
+
+	// Get due notifications from all task types
+	var due []dueNotification
+	for _, taskType := range taskTypes {
 		key := fmt.Sprintf("notifications:%s", taskType)
 		
 		// Get notifications due up to now
@@ -204,41 +410,357 @@ func (nm *NotificationManager) processDueNotifications(ctx context.Context) erro
 			return fmt.Errorf("failed to get due notifications: %w", err)
 		}
 
-		// Process notifications
 		for _, notificationStr := range notifications {
-			var notification notification
-			if err := json.Unmarshal([]byte(notificationStr), &notification); err != nil {
+			var pending notification
+			if err := json.Unmarshal([]byte(notificationStr), &pending); err != nil {
 				continue
 			}
+			due = append(due, dueNotification{key: key, member: notificationStr, notification: pending})
+		}
+	}
+
+	sort.SliceStable(due, func(i, j int) bool {
+		if due[i].notification.Priority != due[j].notification.Priority {
+			return due[i].notification.Priority > due[j].notification.Priority
+		}
+		return due[i].notification.ScheduledTime.Before(due[j].notification.ScheduledTime)
+	})
+
+	// Process notifications
+	for i := range due {
+		key, notificationStr, notification := due[i].key, due[i].member, due[i].notification
+
+		// Claim the entry by removing it before fanning out, so follow-up copies are the
+		// only pending entries; another instance that removed it first owns its delivery
+		claimed, err := nm.redisClient.ZRem(ctx, key, notificationStr).Result()
+		if err != nil {
+			return fmt.Errorf("failed to claim due notification: %w", err)
+		}
+		if claimed != 1 {
+			continue
+		}
+
+		if notification.TaskType == digestTaskType {
+			if err := nm.collectDigest(ctx, &notification); err != nil {
+				// Leave the digest queued for the next run
+				nm.rescheduleNotification(ctx, &notification)
+				return err
+			}
+			// Every reminder in the digest was cancelled or moved to another day
+			if len(notification.Tasks) == 0 {
+				continue
+			}
+		}
+
+		outcome, err := nm.processNotification(ctx, &notification, now)
+		if err != nil {
+			outcome = &deliveryOutcome{failed: notification.RecipientIDs}
+		}
+
+		for resumeAt, recipientIDs := range outcome.deferred {
+			deferred := notification
+			deferred.RecipientIDs = recipientIDs
+			deferred.ScheduledTime = time.Unix(resumeAt, 0)
+			nm.rescheduleNotification(ctx, &deferred)
+		}
+
+		if err == nil && len(outcome.failed) == 0 {
+			nm.metrics.deliveredCount++
+			if len(outcome.deferred) == 0 {
+				nm.releaseDelivered(ctx, &notification)
+			}
+			continue
+		}
+
+		if notification.RetryCount < nm.maxRetries {
+			// Reschedule with backoff, only for the recipients that failed
+			retry := notification
+			retry.RecipientIDs = outcome.failed
+			retry.RetryCount++
+			retry.ScheduledTime = now.Add(nm.retryDelay * time.Duration(retry.RetryCount))
+			nm.rescheduleNotification(ctx, &retry)
+			nm.metrics.retryCount++
+		} else {
+			nm.metrics.failedCount++
+			reason, cause := deadLetterReason(err, outcome)
+			if err := nm.addDeadLetter(ctx, &notification, reason, cause); err != nil {
+				nm.metrics.lastError = err
+				nm.metrics.lastErrorTime = time.Now()
+			}
+			if len(outcome.deferred) == 0 {
+				nm.releaseDelivered(ctx, &notification)
+			}
+		}
+	}
+
+	return nil
+}
+
+// dueCutoff returns the time a processing run started at now treats as current. Under the
+// Redis time source it is the Redis server's time. Under the local source with a skew
+// tolerance, a now further than the tolerance from Redis TIME is pulled back to the
+// tolerance, so a replica with a skewed clock processes notifications neither earlier nor
+// later than the tolerance allows.
+func (nm *NotificationManager) dueCutoff(ctx context.Context, now time.Time) (time.Time, error) {
+	if nm.timeSource == TimeSourceLocal && nm.skewTolerance <= 0 {
+		return now, nil
+	}
+
+	reference, err := nm.redisClient.Time(ctx).Result()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read Redis time: %w", err)
+	}
+	if nm.timeSource == TimeSourceRedis {
+		return reference, nil
+	}
+
+	earliest, latest := reference.Add(-nm.skewTolerance), reference.Add(nm.skewTolerance)
+	switch {
+	case now.After(latest):
+		nm.metrics.skewCorrectedCount++
+		return latest, nil
+	case now.Before(earliest):
+		nm.metrics.skewCorrectedCount++
+		return earliest, nil
+	}
+	return now, nil
+}
+
+// deliveryOutcome records which recipients still need a notification after a delivery attempt
+type deliveryOutcome struct {
+	failed   []string           // Recipient IDs whose delivery failed
+	deferred map[int64][]string // Recipient IDs in quiet hours, keyed by the Unix time their quiet hours end
+	lastErr  error              // Error from the last failed delivery
+}
+
+// processNotification fans a notification out to its garden's recipients, holding back
+// those in quiet hours. Each recipient's channels are tried in priority order and the
+// channel that delivered is recorded. A notification for a garden without recipients is
+// a no-op.
+func (nm *NotificationManager) processNotification(ctx context.Context, notification *notification, now time.Time) (*deliveryOutcome, error) {
+	outcome := &deliveryOutcome{deferred: make(map[int64][]string)}
+
+	gardenID, _ := notification.Metadata["gardenId"].(string)
+	if gardenID == "" {
+		return outcome, nil
+	}
+
+	recipients, err := nm.ListRecipients(ctx, gardenID)
+	if err != nil {
+		return nil, err
+	}
 
-			if err := nm.processNotification(ctx, &notification); err != nil {
-				if notification.RetryCount < nm.maxRetries {
-					// Reschedule with backoff
-					notification.RetryCount++
-					notification.ScheduledTime = now.Add(nm.retryDelay * time.Duration(notification.RetryCount))
-					nm.rescheduleNotification(ctx, &notification)
-					nm.metrics.retryCount++
-				} else {
-					nm.metrics.failedCount++
-				}
-			} else {
-				nm.metrics.deliveredCount++
-				// Remove processed notification
-				nm.redisClient.ZRem(ctx, key, notificationStr)
+	// Retries and deferred copies only go to the recipients they were created for
+	if len(notification.RecipientIDs) > 0 {
+		wanted := make(map[string]bool, len(notification.RecipientIDs))
+		for _, id := range notification.RecipientIDs {
+			wanted[id] = true
+		}
+		filtered := recipients[:0]
+		for _, recipient := range recipients {
+			if wanted[recipient.ID] {
+				filtered = append(filtered, recipient)
 			}
 		}
+		recipients = filtered
+	}
+
+	cropID, _ := notification.Metadata["cropId"].(string)
+	message := NotificationMessage{
+		TaskID:        notification.TaskID,
+		TaskType:      notification.TaskType,
+		CropID:        cropID,
+		GardenID:      gardenID,
+		ScheduledTime: notification.ScheduledTime,
+		Priority:      notification.Priority,
+		CorrelationID: notification.CorrelationID,
+	}
+	for _, reminder := range notification.Tasks {
+		reminderCropID, _ := reminder.Metadata["cropId"].(string)
+		message.Digest = append(message.Digest, NotificationMessage{
+			TaskID:        reminder.TaskID,
+			TaskType:      reminder.TaskType,
+			CropID:        reminderCropID,
+			GardenID:      gardenID,
+			ScheduledTime: reminder.ScheduledTime,
+			Priority:      reminder.Priority,
+			CorrelationID: reminder.CorrelationID,
+		})
+	}
+
+	for _, recipient := range recipients {
+		if resumeAt, quiet := quietHoursEnd(recipient, now); quiet {
+			outcome.deferred[resumeAt.Unix()] = append(outcome.deferred[resumeAt.Unix()], recipient.ID)
+			continue
+		}
+
+		channel, failedChannels, err := nm.deliver(ctx, recipient, message)
+		if err != nil {
+			nm.metrics.lastError = fmt.Errorf("failed to notify recipient %s: %w", recipient.ID, err)
+			nm.metrics.lastErrorTime = time.Now()
+			outcome.lastErr = nm.metrics.lastError
+			outcome.failed = append(outcome.failed, recipient.ID)
+			continue
+		}
+
+		if len(failedChannels) > 0 {
+			nm.metrics.fallbackCount++
+		}
+		if err := nm.recordDelivery(ctx, recipient, message, channel, failedChannels); err != nil {
+			nm.metrics.lastError = err
+			nm.metrics.lastErrorTime = time.Now()
+		}
+	}
+
+	return outcome, nil
+}
+
+// quietHoursEnd reports whether now falls inside the recipient's quiet hours and, if so,
+// when they end. Quiet hours may wrap past midnight, e.g. 22:00 to 07:00.
+func quietHoursEnd(recipient dto.NotificationRecipient, now time.Time) (time.Time, bool) {
+	if recipient.QuietHoursStart == "" || recipient.QuietHoursEnd == "" {
+		return time.Time{}, false
+	}
+
+	loc := time.UTC
+	if recipient.TimeZone != "" {
+		if zone, err := time.LoadLocation(recipient.TimeZone); err == nil {
+			loc = zone
+		}
+	}
+
+	startClock, err := time.Parse("15:04", recipient.QuietHoursStart)
+	if err != nil {
+		return time.Time{}, false
+	}
+	endClock, err := time.Parse("15:04", recipient.QuietHoursEnd)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	local := now.In(loc)
+	at := func(clock time.Time, days int) time.Time {
+		return time.Date(local.Year(), local.Month(), local.Day()+days, clock.Hour(), clock.Minute(), 0, 0, loc)
+	}
+	start, end := at(startClock, 0), at(endClock, 0)
+
+	switch {
+	case start.Equal(end):
+		return time.Time{}, false
+	case start.Before(end):
+		return end, !local.Before(start) && local.Before(end)
+	case !local.Before(start):
+		// Inside quiet hours that run past midnight
+		return at(endClock, 1), true
+	default:
+		return end, local.Before(end)
+	}
+}
+
+// SetSender registers the sender used to deliver notifications over a channel
+func (nm *NotificationManager) SetSender(channel string, sender NotificationSender) {
+	nm.mu.Lock()
+	nm.senders[channel] = sender
+	nm.mu.Unlock()
+}
+
+// SetClock replaces the time source used to pick notification times
+func (nm *NotificationManager) SetClock(c clock.Clock) {
+	nm.mu.Lock()
+	nm.clock = clock.OrReal(c)
+	nm.mu.Unlock()
+}
+
+// SetGardenResolver configures how crops are mapped to gardens when a notification is
+// scheduled for a task whose crop is not loaded
+func (nm *NotificationManager) SetGardenResolver(resolver GardenResolver) {
+	nm.mu.Lock()
+	nm.resolveGarden = resolver
+	nm.mu.Unlock()
+}
+
+// gardenForTask returns the garden a task's notifications should go to, or "" if unknown
+func (nm *NotificationManager) gardenForTask(ctx context.Context, task *models.Maintenance) string {
+	if task.Crop != nil && task.Crop.GardenID != "" {
+		return task.Crop.GardenID
+	}
+
+	nm.mu.RLock()
+	resolver := nm.resolveGarden
+	nm.mu.RUnlock()
+
+	if resolver == nil {
+		return ""
+	}
+	gardenID, err := resolver(ctx, task.CropID)
+	if err != nil {
+		return ""
+	}
+	return gardenID
+}
+
+// recipientsKey returns the Redis hash holding a garden's notification recipients
+func recipientsKey(gardenID string) string {
+	return fmt.Sprintf("notification_recipients:%s", gardenID)
+}
+
+// RegisterRecipient adds or replaces a notification recipient for a garden. A recipient
+// without an ID is assigned one.
+func (nm *NotificationManager) RegisterRecipient(ctx context.Context, gardenID string, recipient *dto.NotificationRecipient) error {
+	if gardenID == "" || recipient == nil {
+		return fmt.Errorf("%w: garden ID and recipient are required", ErrInvalidRecipient)
+	}
+	if err := recipient.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRecipient, err)
+	}
+	if recipient.ID == "" {
+		recipient.ID = uuid.New().String()
+	}
+
+	recipientJSON, err := json.Marshal(recipient)
+	if err != nil {
+		return fmt.Errorf("failed to marshal recipient: %w", err)
 	}
 
+	if err := nm.redisClient.HSet(ctx, recipientsKey(gardenID), recipient.ID, recipientJSON).Err(); err != nil {
+		return fmt.Errorf("failed to register recipient: %w", err)
+	}
 	return nil
 }
 
-// processNotification handles the actual notification delivery
-func (nm *NotificationManager) processNotification(ctx context.Context, notification *notification) error {
-	// Implementation would include actual notification delivery logic
-	// This could involve sending to a notification service, email, SMS, etc.
+// RemoveRecipient stops notifications for a garden going to a recipient
+func (nm *NotificationManager) RemoveRecipient(ctx context.Context, gardenID, recipientID string) error {
+	if err := nm.redisClient.HDel(ctx, recipientsKey(gardenID), recipientID).Err(); err != nil {
+		return fmt.Errorf("failed to remove recipient: %w", err)
+	}
 	return nil
 }
 
+// ListRecipients returns a garden's notification recipients ordered by name
+func (nm *NotificationManager) ListRecipients(ctx context.Context, gardenID string) ([]dto.NotificationRecipient, error) {
+	entries, err := nm.redisClient.HGetAll(ctx, recipientsKey(gardenID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recipients: %w", err)
+	}
+
+	recipients := make([]dto.NotificationRecipient, 0, len(entries))
+	for _, entry := range entries {
+		var recipient dto.NotificationRecipient
+		if err := json.Unmarshal([]byte(entry), &recipient); err != nil {
+			continue
+		}
+		recipients = append(recipients, recipient)
+	}
+
+	sort.Slice(recipients, func(i, j int) bool {
+		if recipients[i].Name != recipients[j].Name {
+			return recipients[i].Name < recipients[j].Name
+		}
+		return recipients[i].ID < recipients[j].ID
+	})
+	return recipients, nil
+}
+
 // rescheduleNotification reschedules a failed notification with backoff
 func (nm *NotificationManager) rescheduleNotification(ctx context.Context, notification *notification) error {
 	notificationJSON, err := json.Marshal(notification)
@@ -276,15 +798,11 @@ func (nm *NotificationManager) checkRateLimit(ctx context.Context, taskType stri
 }
 
 // calculatePriority determines notification priority based on task type and frequency
-func calculatePriority(task *models.Maintenance) int {
-	priority := 1
-	
-	// Higher priority for water and fertilizer tasks
-	switch task.TaskType {
-	case "Water":
-		priority = 3
-	case "Fertilizer":
-		priority = 2
+func (nm *NotificationManager) calculatePriority(task *models.Maintenance) int {
+	// Base priority by task type, water and fertilizer highest unless configured otherwise
+	priority, ok := nm.taskTypePriorities[task.TaskType]
+	if !ok {
+		priority = 1
 	}
 
 	// Adjust priority based on frequency
@@ -330,6 +848,10 @@ func (nm *NotificationManager) GetMetrics() map[string]interface{} {
 		"deliveredCount": nm.metrics.deliveredCount,
 		"failedCount":    nm.metrics.failedCount,
 		"retryCount":     nm.metrics.retryCount,
+		"coalescedCount": nm.metrics.coalescedCount,
+		"fallbackCount":  nm.metrics.fallbackCount,
+		"evictedCount":   nm.metrics.evictedCount,
+		"skewCorrectedCount": nm.metrics.skewCorrectedCount,
 		"lastError":      nm.metrics.lastError,
 		"lastErrorTime":  nm.metrics.lastErrorTime,
 	}
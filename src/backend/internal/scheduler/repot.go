@@ -0,0 +1,185 @@
+// Package scheduler provides maintenance scheduling functionality for the Urban Gardening Assistant
+package scheduler
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "strings"
+    "time"
+
+    "github.com/urban-gardening/backend/internal/models"
+    "github.com/urban-gardening/backend/pkg/dto"
+)
+
+// suggestedRepotTime is the preferred time for re-pot reminders, before the heat of the day
+const suggestedRepotTime = "09:00"
+
+// defaultRepotDays is how many days after planting a crop of each growth rate outgrows
+// its grow bag
+var defaultRepotDays = map[string]int{
+    dto.GrowthRateFast:   21,
+    dto.GrowthRateMedium: 35,
+    dto.GrowthRateSlow:   56,
+}
+
+// cropGrowthRates is how quickly each crop fills its grow bag with roots; crops not
+// listed grow at a medium rate
+var cropGrowthRates = map[string]string{
+    "tomatoes": dto.GrowthRateFast,
+    "eggplant": dto.GrowthRateMedium,
+    "peppers":  dto.GrowthRateMedium,
+    "kale":     dto.GrowthRateMedium,
+    "spinach":  dto.GrowthRateSlow,
+    "lettuce":  dto.GrowthRateSlow,
+}
+
+// bagSizeOrder lists the grow bag sizes from smallest to largest
+var bagSizeOrder = []string{dto.BagSize8, dto.BagSize10, dto.BagSize12, dto.BagSize14}
+
+// ScheduleRepotReminder schedules a one-off Re-pot task for a crop, due the configured
+// number of days after planting for the crop's growth rate, and suggests the next larger
+// grow bag. A reminder whose lead time has already passed falls due at the next preferred
+// time. Crops already in the largest bag, with re-pot reminders disabled, or with a
+// pending re-pot reminder are rejected with ErrInvalidRequest.
+func (s *SchedulerService) ScheduleRepotReminder(ctx context.Context, cropID string) (*dto.RepotReminder, error) {
+    if cropID == "" {
+        return nil, fmt.Errorf("%w: crop ID is required", ErrInvalidRequest)
+    }
+
+    crop, err := s.scheduler.GetCrop(ctx, cropID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to schedule re-pot reminder: %w", err)
+    }
+    if !crop.TaskTypeEnabled(dto.TaskTypeRepot) {
+        return nil, fmt.Errorf("%w: re-pot reminders are disabled for crop %s", ErrInvalidRequest, cropID)
+    }
+
+    suggested, ok := nextBagSize(crop.BagSize)
+    if !ok {
+        return nil, fmt.Errorf("%w: crop %s is already in the largest grow bag", ErrInvalidRequest, cropID)
+    }
+
+    rate := growthRateFor(crop.Name)
+    days := s.repotDays[rate]
+    dueAt, err := repotDueTime(crop.CreatedAt, days, s.clock.Now())
+    if err != nil {
+        return nil, fmt.Errorf("failed to schedule re-pot reminder: %w", err)
+    }
+
+    task, err := s.scheduler.CreateRepotTask(ctx, crop, dueAt, suggested)
+    if err != nil {
+        return nil, fmt.Errorf("failed to schedule re-pot reminder: %w", err)
+    }
+
+    // Schedule the reminder's notification; a reminder the user has no room to be
+    // notified of is not kept
+    if err := s.notificationMgr.ScheduleNotification(ctx, task); err != nil {
+        if errors.Is(err, ErrNotificationLimit) {
+            if _, deleteErr := s.scheduler.DeleteMaintenanceTask(ctx, task.ID); deleteErr != nil {
+                return nil, fmt.Errorf("failed to remove re-pot reminder over notification limit: %w", deleteErr)
+            }
+        }
+        return nil, fmt.Errorf("failed to schedule notifications: %w", err)
+    }
+    s.invalidateCache(ctx, task.ID)
+
+    return &dto.RepotReminder{
+        Task:              task,
+        CropID:            crop.ID,
+        CropName:          crop.Name,
+        GrowthRate:        rate,
+        DaysAfterPlanting: days,
+        CurrentBagSize:    crop.BagSize,
+        SuggestedBagSize:  suggested,
+    }, nil
+}
+
+// CreateRepotTask saves a one-off Re-pot task for a crop due at dueAt, recording the
+// suggested bag size with it. A crop can have only one pending re-pot task.
+func (s *MaintenanceScheduler) CreateRepotTask(ctx context.Context, crop *models.Crop, dueAt time.Time, suggestedBagSize string) (*dto.MaintenanceResponse, error) {
+    s.mutex.Lock()
+    defer s.mutex.Unlock()
+
+    tx := s.db.WithContext(ctx).Begin()
+    if tx.Error != nil {
+        return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+    }
+    defer tx.Rollback()
+
+    var pending int64
+    if err := tx.Model(&models.Maintenance{}).
+        Where("crop_id = ? AND task_type = ? AND active = ? AND deleted_at IS NULL", crop.ID, dto.TaskTypeRepot, true).
+        Count(&pending).Error; err != nil {
+        return nil, fmt.Errorf("failed to check pending re-pot tasks: %w", err)
+    }
+    if pending > 0 {
+        return nil, fmt.Errorf("%w: crop %s already has a pending re-pot reminder", ErrInvalidRequest, crop.ID)
+    }
+
+    maintenance := &models.Maintenance{
+        CropID:            crop.ID,
+        TaskType:          dto.TaskTypeRepot,
+        Frequency:         dto.FrequencyOnce,
+        Unit:              "n/a",
+        PreferredTime:     dueAt.Format("15:04"),
+        Active:            true,
+        CurrentBagSize:    crop.BagSize,
+        SuggestedBagSize:  suggestedBagSize,
+        NextScheduledTime: dueAt,
+    }
+    s.prepareTask(maintenance)
+
+    if err := tx.Create(maintenance).Error; err != nil {
+        return nil, fmt.Errorf("failed to create re-pot task: %w", err)
+    }
+    if err := tx.Create(models.NewScheduleChangeEvent(maintenance, models.ScheduleEventCreated)).Error; err != nil {
+        return nil, fmt.Errorf("failed to record schedule change: %w", err)
+    }
+    if err := tx.Commit().Error; err != nil {
+        return nil, fmt.Errorf("failed to commit transaction: %w", err)
+    }
+
+    maintenanceTasksCreated.Inc()
+    return maintenance.ToResponse(), nil
+}
+
+// growthRateFor returns the growth rate of a crop by name
+func growthRateFor(cropName string) string {
+    if rate, ok := cropGrowthRates[strings.ToLower(strings.TrimSpace(cropName))]; ok {
+        return rate
+    }
+    return dto.GrowthRateMedium
+}
+
+// nextBagSize returns the grow bag size one step larger than size, and false when size
+// is already the largest or unknown
+func nextBagSize(size string) (string, bool) {
+    for i, candidate := range bagSizeOrder[:len(bagSizeOrder)-1] {
+        if candidate == size {
+            return bagSizeOrder[i+1], true
+        }
+    }
+    return "", false
+}
+
+// repotDueTime returns the suggested re-pot time on the day days after plantedAt, or the
+// next suggested re-pot time after now when that has already passed
+func repotDueTime(plantedAt time.Time, days int, now time.Time) (time.Time, error) {
+    preferred, err := time.Parse("15:04", suggestedRepotTime)
+    if err != nil {
+        return time.Time{}, err
+    }
+
+    day := plantedAt.AddDate(0, 0, days)
+    dueAt := time.Date(day.Year(), day.Month(), day.Day(), preferred.Hour(), preferred.Minute(), 0, 0, day.Location())
+    if dueAt.After(now) {
+        return dueAt, nil
+    }
+
+    dueAt = time.Date(now.Year(), now.Month(), now.Day(), preferred.Hour(), preferred.Minute(), 0, 0, now.Location())
+    if !dueAt.After(now) {
+        dueAt = dueAt.AddDate(0, 0, 1)
+    }
+    return dueAt, nil
+}
@@ -0,0 +1,85 @@
+// Package scheduler provides maintenance scheduling functionality for the Urban Gardening Assistant
+package scheduler
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "time"
+
+    "github.com/go-redis/redis/v8" // v8.11.5
+    "github.com/google/uuid"       // v1.3.0
+)
+
+// historyPurgeLockKey is the Redis key held by the replica purging completion history
+const historyPurgeLockKey = "locks:completion-history-purge"
+
+// historyPurgeLockTTL bounds how long a crashed replica can hold the purge lock
+const historyPurgeLockTTL = 10 * time.Minute
+
+// defaultHistoryPurgeBatchSize bounds the completion events deleted per batch when no
+// scheduler config is provided
+const defaultHistoryPurgeBatchSize = 1000
+
+// ErrPurgeInProgress is returned when another replica holds the completion history purge lock
+var ErrPurgeInProgress = errors.New("completion history purge already in progress")
+
+// releaseLockScript deletes a lock only while it still holds the caller's token, so a
+// purge that outlived its lock cannot release another replica's
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+    return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// renewLockScript extends a lock's TTL only while it still holds the caller's token
+var renewLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+    return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// PurgeCompletionHistory deletes completion events older than the configured retention
+// window, measured back from now, and returns how many were removed. Streaks and each
+// task's latest completion are kept. Events are deleted in batches, renewing the purge
+// lock after each, so a long purge neither blocks scheduling nor outlives its lock. Only
+// one replica purges at a time: the others get ErrPurgeInProgress. Nothing is purged
+// when no retention window is configured.
+func (s *SchedulerService) PurgeCompletionHistory(ctx context.Context, now time.Time) (int64, error) {
+    if s.historyRetention <= 0 {
+        return 0, nil
+    }
+
+    token := uuid.New().String()
+    acquired, err := s.cache.SetNX(ctx, historyPurgeLockKey, token, historyPurgeLockTTL).Result()
+    if err != nil {
+        return 0, fmt.Errorf("failed to acquire purge lock: %w", err)
+    }
+    if !acquired {
+        return 0, ErrPurgeInProgress
+    }
+    defer releaseLockScript.Run(context.Background(), s.cache, []string{historyPurgeLockKey}, token)
+
+    cutoff := now.Add(-s.historyRetention)
+    var purged int64
+    for {
+        removed, err := s.scheduler.PurgeCompletionEvents(ctx, cutoff, s.purgeBatchSize)
+        purged += removed
+        if err != nil {
+            return purged, err
+        }
+        if removed < int64(s.purgeBatchSize) {
+            return purged, nil
+        }
+
+        held, err := renewLockScript.Run(ctx, s.cache, []string{historyPurgeLockKey}, token, historyPurgeLockTTL.Milliseconds()).Int()
+        if err != nil {
+            return purged, fmt.Errorf("failed to renew purge lock: %w", err)
+        }
+        if held == 0 {
+            return purged, ErrPurgeInProgress
+        }
+    }
+}
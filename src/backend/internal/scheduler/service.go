@@ -3,35 +3,41 @@ package scheduler
 
 import (
     "context"
+    "encoding/json"
     "errors"
     "fmt"
+    "sort"
     "sync"
     "time"
 
     "github.com/go-redis/redis/v8" // v8.11.5
     "gorm.io/gorm" // v1.25.0
     "github.com/prometheus/client_golang/prometheus"
+    "github.com/prometheus/client_golang/prometheus/promauto"
 
     "github.com/urban-gardening/backend/internal/ai"
+    "github.com/urban-gardening/backend/internal/utils/cache"
+    "github.com/urban-gardening/backend/internal/utils/clock"
     "github.com/urban-gardening/backend/pkg/dto"
     "github.com/urban-gardening/backend/pkg/types"
 )
 
-// Prometheus metrics
+// Prometheus metrics, registered once at package load so any number of services can be
+// constructed in one process
 var (
-    scheduleCreationLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+    scheduleCreationLatency = promauto.NewHistogram(prometheus.HistogramOpts{
         Name:    "schedule_creation_latency_seconds",
         Help:    "Latency of maintenance schedule creation",
         Buckets: prometheus.LinearBuckets(0, 0.5, 6),
     })
 
-    scheduleUpdateLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+    scheduleUpdateLatency = promauto.NewHistogram(prometheus.HistogramOpts{
         Name:    "schedule_update_latency_seconds",
         Help:    "Latency of maintenance schedule updates",
         Buckets: prometheus.LinearBuckets(0, 0.5, 6),
     })
 
-    aiRecommendationErrors = prometheus.NewCounter(prometheus.CounterOpts{
+    aiRecommendationErrors = promauto.NewCounter(prometheus.CounterOpts{
         Name: "ai_recommendation_errors_total",
         Help: "Total number of AI recommendation generation errors",
     })
@@ -43,6 +49,52 @@ var (
     ErrScheduleNotFound = errors.New("maintenance schedule not found")
     ErrAIServiceFailure = errors.New("AI service failure")
     ErrCacheFailure = errors.New("cache operation failed")
+    ErrCropNotFound = errors.New("crop not found")
+    ErrIncidentNotFound = errors.New("pest incident not found")
+    ErrGardenNotFound = errors.New("garden not found")
+    ErrSnapshotNotFound = errors.New("garden snapshot not found")
+)
+
+// Sensor thresholds used to adjust watering intervals
+const (
+    hotTemperatureC  = 30.0
+    coolTemperatureC = 15.0
+    lowHumidityPct   = 40.0
+    highHumidityPct  = 80.0
+)
+
+// defaultStaleThreshold is how far an AI recommendation may predate the latest
+// environment reading before FindStaleSchedules flags it
+const defaultStaleThreshold = 24 * time.Hour
+
+// maxScheduleFetch bounds the number of schedules fetched by ID in one call
+const maxScheduleFetch = 100
+
+// maxIdempotencyKeyLength matches the column storing completion idempotency keys
+const maxIdempotencyKeyLength = 100
+
+// defaultAIWorkerPoolSize bounds concurrent AI calls when no scheduler config is provided
+const defaultAIWorkerPoolSize = 5
+
+// defaultMaxBatchSize bounds the schedules created by one batch request when no
+// scheduler config is provided
+const defaultMaxBatchSize = 50
+
+// defaultMinNotificationGap is the window within which a task's notifications are
+// coalesced when no scheduler config is provided
+const defaultMinNotificationGap = 30 * time.Minute
+
+// defaultMaxConcurrentCompletions bounds concurrent due-task completions when no scheduler
+// config is provided
+const defaultMaxConcurrentCompletions = 5
+
+// scheduleCacheTTL is the lifetime of cached schedule recommendations before jitter
+const scheduleCacheTTL = 1 * time.Hour
+
+// Stale read defaults used when no scheduler config is provided
+const (
+    defaultServeStaleReads = true
+    defaultStaleReadTTL    = 24 * time.Hour
 )
 
 // SchedulerService coordinates maintenance scheduling, notifications, and AI recommendations
@@ -53,6 +105,18 @@ type SchedulerService struct {
     cache              *redis.Client
     db                 *gorm.DB
     config             *types.ServiceConfig
+    aiWorkerPoolSize   int
+    maxBatchSize       int                 // Schedules created at most by one CreateSchedules call
+    maxCompletions     int                 // Due tasks completed at once by CompleteDueTasks
+    serveStaleReads    bool                // Serve last-known schedules when the database fails on reads
+    staleReadTTL       time.Duration       // Lifetime of last-known schedule copies
+    cacheTTLJitter     float64             // Percentage by which cached schedule TTLs are spread either way
+    clock              clock.Clock         // Source of the current time for scheduling math
+    defaultFrequencies map[string]string   // Frequency by task type for requests that omit one
+    historyRetention   time.Duration       // Age past which completion events are purged; 0 keeps them
+    purgeBatchSize     int                 // Completion events deleted per batch when purging history
+    repotDays          map[string]int      // Days after planting re-pot reminders fall due, by growth rate
+    cropObserver       CropRestoreObserver // Told of crops written by snapshot restores
     mu                 sync.RWMutex
 }
 
@@ -67,6 +131,18 @@ func NewSchedulerService(db *gorm.DB, redisClient *redis.Client, aiService *ai.R
     if err != nil {
         return nil, fmt.Errorf("failed to initialize maintenance scheduler: %w", err)
     }
+    if config.Scheduler != nil && config.Scheduler.AIAmountPolicy != "" {
+        if err := scheduler.SetAIAmountPolicy(config.Scheduler.AIAmountPolicy); err != nil {
+            return nil, err
+        }
+    }
+    allowPast := config.Scheduler != nil && config.Scheduler.AllowPastSchedules
+    scheduler.SetAllowPastSchedules(allowPast)
+    if policy, ok := accelerationPolicy(config.Scheduler); ok {
+        if err := scheduler.SetAccelerationPolicy(policy); err != nil {
+            return nil, err
+        }
+    }
 
     // Initialize notification manager
     notifConfig := NotificationConfig{
@@ -82,23 +158,119 @@ func NewSchedulerService(db *gorm.DB, redisClient *redis.Client, aiService *ai.R
         ShutdownTimeout:   30 * time.Second,
     }
 
+    // A configured gap of zero disables coalescing
+    notifConfig.MinGap = defaultMinNotificationGap
+    if config.Scheduler != nil {
+        notifConfig.MinGap = config.Scheduler.MinNotificationGap
+        notifConfig.MaxActivePerUser = config.Scheduler.MaxActiveNotificationsPerUser
+        notifConfig.EvictOnLimit = config.Scheduler.NotificationLimitPolicy == NotificationLimitEvict
+        notifConfig.TaskTypePriorities = config.Scheduler.TaskTypePriorities
+        notifConfig.TimeSource = config.Scheduler.NotificationTimeSource
+        notifConfig.ClockSkewTolerance = config.Scheduler.ClockSkewTolerance
+    }
+
     notificationMgr, err := NewNotificationManager(redisClient, notifConfig)
     if err != nil {
         return nil, fmt.Errorf("failed to initialize notification manager: %w", err)
     }
 
-    // Register metrics
-    prometheus.MustRegister(scheduleCreationLatency)
-    prometheus.MustRegister(scheduleUpdateLatency)
-    prometheus.MustRegister(aiRecommendationErrors)
+    // Route each task's notifications to the recipients of its crop's garden
+    notificationMgr.SetGardenResolver(func(ctx context.Context, cropID string) (string, error) {
+        crop, err := scheduler.GetCrop(ctx, cropID)
+        if err != nil {
+            return "", err
+        }
+        return crop.GardenID, nil
+    })
+
+    // Count pending notifications against the owner of each garden
+    notificationMgr.SetUserResolver(scheduler.GetGardenOwner)
+
+    // Bound concurrent AI calls for batch operations
+    poolSize := defaultAIWorkerPoolSize
+    if config.Scheduler != nil && config.Scheduler.AIWorkerPoolSize > 0 {
+        poolSize = config.Scheduler.AIWorkerPoolSize
+    }
+    maxBatchSize := defaultMaxBatchSize
+    if config.Scheduler != nil && config.Scheduler.MaxBatchSize > 0 {
+        maxBatchSize = config.Scheduler.MaxBatchSize
+    }
+
+    // Bound concurrent completions when completing every due task in a garden
+    maxCompletions := defaultMaxConcurrentCompletions
+    if config.Scheduler != nil && config.Scheduler.MaxConcurrentCompletions > 0 {
+        maxCompletions = config.Scheduler.MaxConcurrentCompletions
+    }
+
+    serveStale, staleTTL := defaultServeStaleReads, defaultStaleReadTTL
+    if config.Scheduler != nil {
+        serveStale = config.Scheduler.ServeStaleReads
+        if config.Scheduler.StaleReadTTL > 0 {
+            staleTTL = config.Scheduler.StaleReadTTL
+        }
+    }
+
+    // Spread cache expirations so schedules cached together do not expire together
+    var ttlJitter float64
+    if config.Redis != nil {
+        ttlJitter = config.Redis.TTLJitterPercent
+    }
+
+    // Configured frequencies override the built-in defaults task type by task type
+    frequencies := dto.DefaultTaskFrequencies()
+    if config.Scheduler != nil {
+        for taskType, frequency := range config.Scheduler.DefaultFrequencies {
+            if _, known := frequencies[taskType]; !known {
+                return nil, fmt.Errorf("invalid default frequency: unknown task type %q", taskType)
+            }
+            if !isValidFrequency(frequency) {
+                return nil, fmt.Errorf("invalid default frequency %q for task type %q", frequency, taskType)
+            }
+            frequencies[taskType] = frequency
+        }
+    }
+
+    var historyRetention time.Duration
+    purgeBatchSize := defaultHistoryPurgeBatchSize
+    if config.Scheduler != nil {
+        historyRetention = config.Scheduler.CompletionHistoryRetention
+        if config.Scheduler.HistoryPurgeBatchSize > 0 {
+            purgeBatchSize = config.Scheduler.HistoryPurgeBatchSize
+        }
+    }
+
+    // Configured re-pot lead times override the built-in ones growth rate by growth rate
+    repotDays := make(map[string]int, len(defaultRepotDays))
+    for rate, days := range defaultRepotDays {
+        repotDays[rate] = days
+    }
+    if config.Scheduler != nil {
+        for rate, days := range config.Scheduler.RepotDays {
+            if _, known := repotDays[rate]; !known || days < 1 {
+                return nil, fmt.Errorf("invalid re-pot days %d for growth rate %q", days, rate)
+            }
+            repotDays[rate] = days
+        }
+    }
 
     return &SchedulerService{
-        scheduler:       scheduler,
-        notificationMgr: notificationMgr,
-        aiService:      aiService,
-        cache:          redisClient,
-        db:            db,
-        config:        config,
+        scheduler:          scheduler,
+        notificationMgr:    notificationMgr,
+        aiService:          aiService,
+        cache:              redisClient,
+        db:                 db,
+        config:             config,
+        aiWorkerPoolSize:   poolSize,
+        maxBatchSize:       maxBatchSize,
+        maxCompletions:     maxCompletions,
+        serveStaleReads:    serveStale,
+        staleReadTTL:       staleTTL,
+        cacheTTLJitter:     ttlJitter,
+        clock:              clock.Real(),
+        defaultFrequencies: frequencies,
+        historyRetention:   historyRetention,
+        purgeBatchSize:     purgeBatchSize,
+        repotDays:          repotDays,
     }, nil
 }
 
@@ -107,19 +279,31 @@ func (s *SchedulerService) CreateSchedule(ctx context.Context, request *dto.Main
     start := time.Now()
     defer scheduleCreationLatency.Observe(time.Since(start).Seconds())
 
+    request = s.withDefaultFrequency(request)
     if err := request.Validate(); err != nil {
         return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
     }
 
-    // Check cache for similar recommendations
+    if err := s.checkTaskTypeEnabled(ctx, request); err != nil {
+        return nil, err
+    }
+
+    // Prefer the garden's pushed sensor readings over client-supplied factors
+    request = s.applySensorReadings(ctx, request)
+
+    // Check cache for similar recommendations unless a fresh AI answer was requested;
+    // the new result is cached either way
     cacheKey := fmt.Sprintf("schedule:%s:%s:%s", request.TaskType, request.SoilType, request.GrowingEnvironment)
-    if cached, err := s.getFromCache(ctx, cacheKey); err == nil && cached != nil {
-        return cached, nil
+    if !ai.RefreshRequested(ctx) {
+        if cached, err := s.getFromCache(ctx, cacheKey); err == nil && cached != nil {
+            return cached, nil
+        }
     }
 
-    // Generate AI recommendations with retry mechanism
+    // Generate AI recommendations with retry mechanism; a spent AI budget falls back
+    // to the rule-based schedule rather than failing
     schedule, err := s.generateScheduleWithRetry(ctx, request)
-    if err != nil {
+    if err != nil && !errors.Is(err, ai.ErrBudgetExceeded) {
         aiRecommendationErrors.Inc()
         return nil, fmt.Errorf("failed to generate AI recommendations: %w", err)
     }
@@ -130,8 +314,13 @@ func (s *SchedulerService) CreateSchedule(ctx context.Context, request *dto.Main
         return nil, fmt.Errorf("failed to create maintenance task: %w", err)
     }
 
-    // Schedule notifications
+    // Schedule notifications; a task the user has no room to be reminded of is not kept
     if err := s.notificationMgr.ScheduleNotification(ctx, task); err != nil {
+        if errors.Is(err, ErrNotificationLimit) {
+            if _, deleteErr := s.scheduler.DeleteMaintenanceTask(ctx, task.ID); deleteErr != nil {
+                return nil, fmt.Errorf("failed to remove task over notification limit: %w", deleteErr)
+            }
+        }
         return nil, fmt.Errorf("failed to schedule notifications: %w", err)
     }
 
@@ -141,6 +330,102 @@ func (s *SchedulerService) CreateSchedule(ctx context.Context, request *dto.Main
     return task, nil
 }
 
+// PreviewNextSchedule validates a proposed schedule and returns when it would first fire,
+// with any AI suggestions behind it, without creating the task or its notifications.
+// Defaults and sensor readings are applied as CreateSchedule applies them.
+func (s *SchedulerService) PreviewNextSchedule(ctx context.Context, request *dto.MaintenanceRequest) (*dto.SchedulePreviewResponse, error) {
+    request = s.withDefaultFrequency(request)
+    if err := request.Validate(); err != nil {
+        return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+    }
+
+    if err := s.checkTaskTypeEnabled(ctx, request); err != nil {
+        return nil, err
+    }
+
+    // Prefer the garden's pushed sensor readings over client-supplied factors
+    request = s.applySensorReadings(ctx, request)
+
+    preview, err := s.scheduler.PreviewMaintenanceTask(ctx, request)
+    if err != nil {
+        return nil, fmt.Errorf("failed to preview maintenance schedule: %w", err)
+    }
+    return preview, nil
+}
+
+// checkTaskTypeEnabled rejects requests for a task type the crop has switched off, e.g.
+// no watering for cacti. Crops that cannot be found are left to the later steps.
+func (s *SchedulerService) checkTaskTypeEnabled(ctx context.Context, request *dto.MaintenanceRequest) error {
+    if crop, err := s.scheduler.GetCrop(ctx, request.CropID); err == nil && !crop.TaskTypeEnabled(request.TaskType) {
+        return fmt.Errorf("%w: %s tasks are disabled for crop %s", ErrInvalidRequest, request.TaskType, request.CropID)
+    }
+    return nil
+}
+
+// CreateSchedules creates several maintenance schedules using a bounded worker pool so
+// that no more than the configured number of AI calls run at once; the rest are queued.
+// Batches larger than the configured maximum are rejected with ErrInvalidRequest.
+func (s *SchedulerService) CreateSchedules(ctx context.Context, requests []*dto.MaintenanceRequest) (*dto.MaintenanceBatchResponse, error) {
+    if len(requests) == 0 {
+        return nil, fmt.Errorf("%w: at least one request is required", ErrInvalidRequest)
+    }
+    if len(requests) > s.maxBatchSize {
+        return nil, fmt.Errorf("%w: at most %d schedules can be created in one batch", ErrInvalidRequest, s.maxBatchSize)
+    }
+
+    results := make([]dto.MaintenanceBatchResult, len(requests))
+    jobs := make(chan int)
+
+    workers := s.aiWorkerPoolSize
+    if workers > len(requests) {
+        workers = len(requests)
+    }
+
+    var wg sync.WaitGroup
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for i := range jobs {
+                result := dto.MaintenanceBatchResult{Index: i}
+                schedule, err := s.CreateSchedule(ctx, requests[i])
+                if err != nil {
+                    result.Error = err.Error()
+                } else {
+                    result.Schedule = schedule
+                }
+                results[i] = result
+            }
+        }()
+    }
+
+feed:
+    for i := range requests {
+        select {
+        case jobs <- i:
+        case <-ctx.Done():
+            // Requests that never reached a worker are reported as cancelled
+            for j := i; j < len(requests); j++ {
+                results[j] = dto.MaintenanceBatchResult{Index: j, Error: ctx.Err().Error()}
+            }
+            break feed
+        }
+    }
+    close(jobs)
+    wg.Wait()
+
+    response := &dto.MaintenanceBatchResponse{Results: results}
+    for _, result := range results {
+        if result.Error != "" {
+            response.Failed++
+        } else {
+            response.Succeeded++
+        }
+    }
+
+    return response, nil
+}
+
 // UpdateSchedule updates an existing maintenance schedule
 func (s *SchedulerService) UpdateSchedule(ctx context.Context, scheduleID string, request *dto.MaintenanceRequest) (*dto.MaintenanceResponse, error) {
     start := time.Now()
@@ -150,10 +435,14 @@ func (s *SchedulerService) UpdateSchedule(ctx context.Context, scheduleID string
     defer s.mu.Unlock()
 
     // Validate request
+    request = s.withDefaultFrequency(request)
     if err := request.Validate(); err != nil {
         return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
     }
 
+    // Prefer the garden's pushed sensor readings over client-supplied factors
+    request = s.applySensorReadings(ctx, request)
+
     // Update maintenance task
     task, err := s.scheduler.UpdateMaintenanceTask(ctx, scheduleID, request)
     if err != nil {
@@ -171,35 +460,91 @@ func (s *SchedulerService) UpdateSchedule(ctx context.Context, scheduleID string
     return task, nil
 }
 
-// CompleteTask marks a maintenance task as completed
-func (s *SchedulerService) CompleteTask(ctx context.Context, taskID string) (*dto.MaintenanceResponse, error) {
-    if err := s.scheduler.CompleteMaintenanceTask(ctx, taskID); err != nil {
-        return nil, fmt.Errorf("failed to complete task: %w", err)
+// CompleteTask marks a maintenance task as completed. completedAt is optional and
+// allows backdating a completion; it must not precede the last recorded completion.
+// Integrations that retry their reports pass an idempotencyKey: a completion repeating
+// an earlier key returns the task as it stands without bumping the streak or
+// rescheduling it again.
+func (s *SchedulerService) CompleteTask(ctx context.Context, taskID string, completedAt *time.Time, idempotencyKey string) (*dto.MaintenanceResponse, error) {
+    if len(idempotencyKey) > maxIdempotencyKeyLength {
+        return nil, fmt.Errorf("%w: idempotency key must be at most %d characters", ErrInvalidRequest, maxIdempotencyKeyLength)
     }
 
-    // Get updated task
-    task, err := s.scheduler.GetMaintenanceTask(ctx, taskID)
+    // Use the task state committed by this completion rather than re-reading it,
+    // which could observe a concurrent completion
+    task, replayed, err := s.scheduler.CompleteMaintenanceTask(ctx, taskID, completedAt, idempotencyKey)
     if err != nil {
-        return nil, fmt.Errorf("failed to get updated task: %w", err)
+        return nil, fmt.Errorf("failed to complete task: %w", err)
+    }
+
+    // Invalidate cache
+    s.invalidateCache(ctx, taskID)
+
+    // A replayed completion was already rescheduled when it was first reported, and a
+    // completed one-off task is not rescheduled at all
+    if replayed || task.Frequency == dto.FrequencyOnce {
+        return task, nil
     }
 
-    // Update next schedule
-    nextSchedule, err := s.calculateNextOptimalSchedule(ctx, task)
+    // Notify for the next time persisted with the completion
+    if err := s.notificationMgr.ScheduleNotification(ctx, task); err != nil {
+        return nil, fmt.Errorf("failed to schedule next notification: %w", err)
+    }
+
+    return task, nil
+}
+
+// DeleteSchedule soft-deletes a maintenance schedule and removes its pending
+// notifications. The schedule can be brought back with RestoreSchedule.
+func (s *SchedulerService) DeleteSchedule(ctx context.Context, scheduleID string) error {
+    if scheduleID == "" {
+        return fmt.Errorf("%w: schedule ID is required", ErrInvalidRequest)
+    }
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    task, err := s.scheduler.DeleteMaintenanceTask(ctx, scheduleID)
     if err != nil {
-        return nil, fmt.Errorf("failed to calculate next schedule: %w", err)
+        return fmt.Errorf("failed to delete maintenance task: %w", err)
     }
 
-    task.NextScheduledTime = nextSchedule
+    if err := s.notificationMgr.CancelNotifications(ctx, task.ID, task.TaskType); err != nil {
+        return fmt.Errorf("failed to remove notifications: %w", err)
+    }
+
+    s.invalidateCache(ctx, scheduleID)
+
+    return nil
+}
+
+// RestoreSchedule reactivates a soft-deleted maintenance schedule, recomputing its next
+// scheduled time and scheduling notifications again
+func (s *SchedulerService) RestoreSchedule(ctx context.Context, scheduleID string) (*dto.MaintenanceResponse, error) {
+    if scheduleID == "" {
+        return nil, fmt.Errorf("%w: schedule ID is required", ErrInvalidRequest)
+    }
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    task, err := s.scheduler.RestoreMaintenanceTask(ctx, scheduleID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to restore maintenance task: %w", err)
+    }
 
-    // Update notifications for next task
     if err := s.notificationMgr.ScheduleNotification(ctx, task); err != nil {
-        return nil, fmt.Errorf("failed to schedule next notification: %w", err)
+        return nil, fmt.Errorf("failed to schedule notifications: %w", err)
     }
 
+    s.invalidateCache(ctx, scheduleID)
+
     return task, nil
 }
 
-// GetSchedule retrieves a maintenance schedule
+// GetSchedule retrieves a maintenance schedule. When stale reads are enabled and the
+// database fails for a reason other than a missing record, the last-known copy of the
+// schedule is returned with Stale set instead of an error.
 func (s *SchedulerService) GetSchedule(ctx context.Context, scheduleID string) (*dto.MaintenanceResponse, error) {
     // Check cache first
     cacheKey := fmt.Sprintf("schedule:%s", scheduleID)
@@ -210,6 +555,12 @@ func (s *SchedulerService) GetSchedule(ctx context.Context, scheduleID string) (
     // Get from database
     task, err := s.scheduler.GetMaintenanceTask(ctx, scheduleID)
     if err != nil {
+        if s.serveStaleReads && !errors.Is(err, gorm.ErrRecordNotFound) {
+            if stale, staleErr := s.getFromCache(ctx, staleScheduleKey(scheduleID)); staleErr == nil && stale != nil {
+                stale.Stale = true
+                return stale, nil
+            }
+        }
         return nil, fmt.Errorf("failed to get maintenance task: %w", err)
     }
 
@@ -219,60 +570,480 @@ func (s *SchedulerService) GetSchedule(ctx context.Context, scheduleID string) (
     return task, nil
 }
 
-// Helper functions
+// GetSchedules retrieves several maintenance schedules by ID, serving what it can from
+// the cache and loading the rest in a single query. Schedules are returned in the order
+// requested; IDs with no matching schedule are listed as missing rather than failing.
+func (s *SchedulerService) GetSchedules(ctx context.Context, ids []string) (*dto.MaintenanceMultiResponse, error) {
+    // Drop blanks and duplicates while keeping the requested order
+    seen := make(map[string]bool, len(ids))
+    unique := make([]string, 0, len(ids))
+    for _, id := range ids {
+        if id == "" || seen[id] {
+            continue
+        }
+        seen[id] = true
+        unique = append(unique, id)
+    }
+    if len(unique) == 0 {
+        return nil, fmt.Errorf("%w: at least one schedule ID is required", ErrInvalidRequest)
+    }
+    if len(unique) > maxScheduleFetch {
+        return nil, fmt.Errorf("%w: at most %d schedule IDs may be fetched at once", ErrInvalidRequest, maxScheduleFetch)
+    }
 
-func (s *SchedulerService) generateScheduleWithRetry(ctx context.Context, request *dto.MaintenanceRequest) (map[string]interface{}, error) {
-    var schedule map[string]interface{}
-    var err error
+    found := s.getManyFromCache(ctx, unique)
 
-    for attempt := 0; attempt < 3; attempt++ {
-        schedule, err = s.aiService.GenerateMaintenanceSchedule(ctx, []string{request.TaskType}, map[string]string{
-            "soilType":           request.SoilType,
-            "growBagSize":        request.GrowBagSize,
-            "growingEnvironment": request.GrowingEnvironment,
-        })
+    uncached := make([]string, 0, len(unique))
+    for _, id := range unique {
+        if _, ok := found[id]; !ok {
+            uncached = append(uncached, id)
+        }
+    }
+    if len(uncached) > 0 {
+        tasks, err := s.scheduler.GetMaintenanceTasks(ctx, uncached)
+        if err != nil {
+            return nil, fmt.Errorf("failed to get schedules: %w", err)
+        }
+        for _, task := range tasks {
+            found[task.ID] = task
+            s.cacheSchedule(ctx, fmt.Sprintf("schedule:%s", task.ID), task)
+        }
+    }
 
-        if err == nil {
-            return schedule, nil
+    response := &dto.MaintenanceMultiResponse{
+        Schedules: make([]*dto.MaintenanceResponse, 0, len(unique)),
+        Missing:   []string{},
+    }
+    for _, id := range unique {
+        if task, ok := found[id]; ok {
+            response.Schedules = append(response.Schedules, task)
+        } else {
+            response.Missing = append(response.Missing, id)
         }
+    }
 
-        time.Sleep(time.Duration(attempt+1) * 500 * time.Millisecond)
+    return response, nil
+}
+
+// GetCropSchedules retrieves the active maintenance schedules attached to a crop, soonest
+// first; paused schedules are left out
+func (s *SchedulerService) GetCropSchedules(ctx context.Context, cropID string) ([]*dto.MaintenanceResponse, error) {
+    if cropID == "" {
+        return nil, fmt.Errorf("%w: crop ID is required", ErrInvalidRequest)
     }
 
-    return nil, err
+    tasks, err := s.scheduler.ListCropMaintenanceTasks(ctx, cropID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get crop schedules: %w", err)
+    }
+
+    return tasks, nil
+}
+
+// GetScheduleChangeLog retrieves the chronological history of schedule creations and
+// updates for a crop
+func (s *SchedulerService) GetScheduleChangeLog(ctx context.Context, cropID string) ([]*dto.ScheduleChangeEvent, error) {
+    if cropID == "" {
+        return nil, fmt.Errorf("%w: crop ID is required", ErrInvalidRequest)
+    }
+
+    history, err := s.scheduler.ListScheduleChangeEvents(ctx, cropID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get schedule history: %w", err)
+    }
+
+    return history, nil
+}
+
+// RecordEnvironmentReading stores current sensor readings for a garden. The latest
+// reading becomes the environmental factor source for subsequent schedule calculations.
+func (s *SchedulerService) RecordEnvironmentReading(ctx context.Context, gardenID string, request *dto.EnvironmentReadingRequest) (*dto.EnvironmentReadingResponse, error) {
+    if gardenID == "" {
+        return nil, fmt.Errorf("%w: garden ID is required", ErrInvalidRequest)
+    }
+    if err := request.Validate(); err != nil {
+        return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+    }
+
+    reading, err := s.scheduler.SaveEnvironmentReading(ctx, gardenID, request)
+    if err != nil {
+        return nil, fmt.Errorf("failed to record environment reading: %w", err)
+    }
+
+    return reading, nil
+}
+
+// FindStaleSchedules returns the AI-recommended schedules in a garden whose recommendation
+// predates the garden's latest environment reading by more than the threshold, oldest
+// first, so they can be refreshed in bulk. A zero threshold uses the default.
+func (s *SchedulerService) FindStaleSchedules(ctx context.Context, gardenID string, factors dto.StaleScheduleFactors) ([]*dto.MaintenanceResponse, error) {
+    if gardenID == "" {
+        return nil, fmt.Errorf("%w: garden ID is required", ErrInvalidRequest)
+    }
+    if factors.Threshold < 0 {
+        return nil, fmt.Errorf("%w: staleness threshold cannot be negative", ErrInvalidRequest)
+    }
+
+    threshold := factors.Threshold
+    if threshold == 0 {
+        threshold = defaultStaleThreshold
+    }
+
+    reading, err := s.scheduler.GetLatestGardenEnvironmentReading(ctx, gardenID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to find stale schedules: %w", err)
+    }
+    // Without a reading there is no environment change to be stale against
+    if reading == nil {
+        return []*dto.MaintenanceResponse{}, nil
+    }
+
+    tasks, err := s.scheduler.ListGardenAIMaintenanceTasks(ctx, gardenID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to find stale schedules: %w", err)
+    }
+
+    taskTypes := make(map[string]bool, len(factors.TaskTypes))
+    for _, taskType := range factors.TaskTypes {
+        taskTypes[taskType] = true
+    }
+
+    stale := make([]*dto.MaintenanceResponse, 0, len(tasks))
+    for i := range tasks {
+        task := &tasks[i]
+        if len(taskTypes) > 0 && !taskTypes[task.TaskType] {
+            continue
+        }
+        // Completing or editing a task keeps its recommendation; tasks saved before
+        // recommendation times were recorded were recommended when created
+        recommendedAt := task.CreatedAt
+        if task.AIRecommendedAt != nil {
+            recommendedAt = *task.AIRecommendedAt
+        }
+        if reading.RecordedAt.Sub(recommendedAt) > threshold {
+            stale = append(stale, task.ToResponse())
+        }
+    }
+
+    return stale, nil
+}
+
+// ListTasksByCompletionRate returns a garden's active tasks whose completion rate is below
+// belowPct, lowest rate first, to surface the tasks most often being missed. The threshold
+// is a percentage from 0 to 100.
+func (s *SchedulerService) ListTasksByCompletionRate(ctx context.Context, gardenID string, belowPct float64) ([]*dto.MaintenanceResponse, error) {
+    if gardenID == "" {
+        return nil, fmt.Errorf("%w: garden ID is required", ErrInvalidRequest)
+    }
+    if belowPct < 0 || belowPct > 100 {
+        return nil, fmt.Errorf("%w: completion rate threshold must be between 0 and 100", ErrInvalidRequest)
+    }
+
+    maintenances, err := s.scheduler.ListGardenActiveMaintenance(ctx, gardenID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list tasks by completion rate: %w", err)
+    }
+
+    tasks := make([]*dto.MaintenanceResponse, 0, len(maintenances))
+    for _, maintenance := range maintenances {
+        if maintenance.CompletionRate < belowPct {
+            tasks = append(tasks, maintenance.ToResponse())
+        }
+    }
+
+    sort.SliceStable(tasks, func(i, j int) bool {
+        return tasks[i].CompletionRate < tasks[j].CompletionRate
+    })
+
+    return tasks, nil
+}
+
+// ShiftPreferredTimes moves a garden's active tasks whose preferred time falls within
+// fromRange to toTime, e.g. every morning task to the evening. The tasks are updated in
+// one transaction, then their notifications are rescheduled for the new times.
+func (s *SchedulerService) ShiftPreferredTimes(ctx context.Context, gardenID string, fromRange dto.TimeRange, toTime string) (*dto.ShiftPreferredTimesResponse, error) {
+    if gardenID == "" {
+        return nil, fmt.Errorf("%w: garden ID is required", ErrInvalidRequest)
+    }
+
+    // Hold the target to the window newly created schedules get in the garden's environment
+    environment, err := s.scheduler.GetGardenEnvironment(ctx, gardenID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to shift preferred times: %w", err)
+    }
+    check, err := dto.CheckPreferredTime(environment, toTime)
+    if err != nil {
+        return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+    }
+    if !check.Allowed {
+        return nil, fmt.Errorf("%w: target time %q: %s", ErrInvalidRequest, toTime, check.Reason)
+    }
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    shifted, err := s.scheduler.ShiftGardenPreferredTimes(ctx, gardenID, fromRange, toTime)
+    if err != nil {
+        return nil, err
+    }
+
+    for _, task := range shifted {
+        // Drop notifications queued for the old time before queueing the new one
+        if err := s.notificationMgr.CancelNotifications(ctx, task.ID, task.TaskType); err != nil {
+            return nil, fmt.Errorf("failed to cancel notifications: %w", err)
+        }
+        if err := s.notificationMgr.ScheduleNotification(ctx, task); err != nil {
+            return nil, fmt.Errorf("failed to reschedule notifications: %w", err)
+        }
+        s.invalidateCache(ctx, task.ID)
+    }
+
+    return &dto.ShiftPreferredTimesResponse{
+        GardenID: gardenID,
+        ToTime:   toTime,
+        Shifted:  shifted,
+    }, nil
+}
+
+// ValidatePreferredTime reports whether a preferred time is allowed for a growing
+// environment, and why not, so clients can check it before submitting a schedule
+func (s *SchedulerService) ValidatePreferredTime(request *dto.PreferredTimeValidationRequest) (*dto.PreferredTimeValidationResponse, error) {
+    if request == nil {
+        return nil, fmt.Errorf("%w: request is required", ErrInvalidRequest)
+    }
+
+    result, err := dto.CheckPreferredTime(request.Environment, request.PreferredTime)
+    if err != nil {
+        return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+    }
+    return result, nil
+}
+
+// ValidateMaintenanceRequest checks a maintenance request as CreateSchedule would, with the
+// frequency defaulted by task type, and reports every failing field without creating
+// anything. Cross-field rules cover the unit and amount for the task type, the daylight
+// window for the growing environment, and the environmental factor ranges.
+func (s *SchedulerService) ValidateMaintenanceRequest(request *dto.MaintenanceRequest) (*dto.MaintenanceValidationResponse, error) {
+    if request == nil {
+        return nil, fmt.Errorf("%w: request is required", ErrInvalidRequest)
+    }
+
+    err := s.withDefaultFrequency(request).ValidateAll()
+    if err == nil {
+        return &dto.MaintenanceValidationResponse{Valid: true}, nil
+    }
+
+    var fieldErrs types.ValidationErrors
+    if !errors.As(err, &fieldErrs) {
+        return nil, fmt.Errorf("failed to validate maintenance request: %w", err)
+    }
+
+    response := &dto.MaintenanceValidationResponse{Errors: make([]dto.FieldError, len(fieldErrs))}
+    for i, fieldErr := range fieldErrs {
+        response.Errors[i] = dto.FieldError{
+            Field:   fieldErr.Field,
+            Message: fieldErr.Message,
+            Value:   fieldErr.Value,
+        }
+    }
+    return response, nil
+}
+
+// RegisterNotificationRecipient adds a person to be notified of a garden's maintenance tasks
+func (s *SchedulerService) RegisterNotificationRecipient(ctx context.Context, gardenID string, recipient *dto.NotificationRecipient) (*dto.NotificationRecipient, error) {
+    if err := s.notificationMgr.RegisterRecipient(ctx, gardenID, recipient); err != nil {
+        return nil, err
+    }
+    return recipient, nil
+}
+
+// ListNotificationRecipients retrieves everyone notified of a garden's maintenance tasks
+func (s *SchedulerService) ListNotificationRecipients(ctx context.Context, gardenID string) ([]dto.NotificationRecipient, error) {
+    return s.notificationMgr.ListRecipients(ctx, gardenID)
+}
+
+// RemoveNotificationRecipient stops notifying a recipient of a garden's maintenance tasks
+func (s *SchedulerService) RemoveNotificationRecipient(ctx context.Context, gardenID, recipientID string) error {
+    return s.notificationMgr.RemoveRecipient(ctx, gardenID, recipientID)
+}
+
+// ListNotificationDeliveries retrieves a garden's delivered notifications, newest first,
+// recording which of each recipient's channels delivered them
+func (s *SchedulerService) ListNotificationDeliveries(ctx context.Context, gardenID string) ([]dto.NotificationDelivery, error) {
+    return s.notificationMgr.ListDeliveries(ctx, gardenID)
+}
+
+// SetNotificationDigest enables or disables a garden's daily digest, which replaces the
+// garden's individual reminders with one notification per day at the delivery time
+func (s *SchedulerService) SetNotificationDigest(ctx context.Context, gardenID string, settings *dto.DigestSettings) (*dto.DigestSettings, error) {
+    if err := s.notificationMgr.SetDigestSettings(ctx, gardenID, settings); err != nil {
+        return nil, err
+    }
+    return settings, nil
 }
 
-func (s *SchedulerService) calculateNextOptimalSchedule(ctx context.Context, task *dto.MaintenanceResponse) (time.Time, error) {
-    // Consider environmental factors and completion streak
-    baseInterval := s.getBaseInterval(task.Frequency)
-    adjustedInterval := s.adjustIntervalForEnvironment(baseInterval, task)
-    
-    return time.Now().Add(adjustedInterval), nil
+// GetNotificationDigest retrieves a garden's daily digest settings
+func (s *SchedulerService) GetNotificationDigest(ctx context.Context, gardenID string) (*dto.DigestSettings, error) {
+    return s.notificationMgr.GetDigestSettings(ctx, gardenID)
 }
 
-func (s *SchedulerService) getBaseInterval(frequency string) time.Duration {
+// ListDeadLetters returns a page of notifications that exhausted their delivery retries,
+// newest first, optionally filtered by task type and failure reason
+func (s *SchedulerService) ListDeadLetters(ctx context.Context, page, pageSize int, filter dto.DeadLetterFilter) (*dto.DeadLetterListResponse, error) {
+    return s.notificationMgr.ListDeadLetters(ctx, page, pageSize, filter)
+}
+
+// GetNotificationPreferences returns a user's notification preferences, or the defaults
+// when the user has not saved any
+func (s *SchedulerService) GetNotificationPreferences(ctx context.Context, userID string) (*dto.NotificationPreferences, error) {
+    if userID == "" {
+        return nil, fmt.Errorf("%w: user ID is required", ErrInvalidRequest)
+    }
+
+    preferences, err := s.scheduler.GetNotificationPreference(ctx, userID)
+    if err != nil {
+        return nil, err
+    }
+    if preferences == nil {
+        return dto.DefaultNotificationPreferences(), nil
+    }
+    return preferences, nil
+}
+
+// SetNotificationPreferences validates and saves a user's notification preferences,
+// replacing any saved before
+func (s *SchedulerService) SetNotificationPreferences(ctx context.Context, userID string, preferences *dto.NotificationPreferences) (*dto.NotificationPreferences, error) {
+    if userID == "" || preferences == nil {
+        return nil, fmt.Errorf("%w: user ID and preferences are required", ErrInvalidRequest)
+    }
+    if err := preferences.Validate(); err != nil {
+        return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+    }
+
+    return s.scheduler.SaveNotificationPreference(ctx, userID, preferences)
+}
+
+// ApplyNotificationPreferenceToAll validates preferences and applies them to every garden
+// the user owns in one transaction, returning the preferences saved for each garden
+func (s *SchedulerService) ApplyNotificationPreferenceToAll(ctx context.Context, userID string, preferences *dto.NotificationPreferences) ([]dto.GardenNotificationPreferences, error) {
+    if userID == "" || preferences == nil {
+        return nil, fmt.Errorf("%w: user ID and preferences are required", ErrInvalidRequest)
+    }
+    if err := preferences.Validate(); err != nil {
+        return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+    }
+
+    return s.scheduler.ApplyNotificationPreferenceToAll(ctx, userID, preferences)
+}
+
+// GetGardenNotificationPreferences returns the preferences that apply to one of a user's
+// gardens: the garden's own, else the user's, else the defaults
+func (s *SchedulerService) GetGardenNotificationPreferences(ctx context.Context, userID, gardenID string) (*dto.NotificationPreferences, error) {
+    if userID == "" || gardenID == "" {
+        return nil, fmt.Errorf("%w: user ID and garden ID are required", ErrInvalidRequest)
+    }
+
+    preferences, err := s.scheduler.GetGardenNotificationPreference(ctx, userID, gardenID)
+    if err != nil {
+        return nil, err
+    }
+    if preferences != nil {
+        return preferences, nil
+    }
+    return s.GetNotificationPreferences(ctx, userID)
+}
+
+// ResetNotificationPreferences clears a user's saved preferences and returns the defaults
+// that apply from now on
+func (s *SchedulerService) ResetNotificationPreferences(ctx context.Context, userID string) (*dto.NotificationPreferences, error) {
+    if userID == "" {
+        return nil, fmt.Errorf("%w: user ID is required", ErrInvalidRequest)
+    }
+
+    if err := s.scheduler.DeleteNotificationPreference(ctx, userID); err != nil {
+        return nil, err
+    }
+    return dto.DefaultNotificationPreferences(), nil
+}
+
+// SetNotificationSender registers how notifications are delivered over a channel
+func (s *SchedulerService) SetNotificationSender(channel string, sender NotificationSender) {
+    s.notificationMgr.SetSender(channel, sender)
+}
+
+// SetClock replaces the time source used by the service, its maintenance scheduler, and
+// its notification manager. It must be called before the service handles requests;
+// tests use it to pin the current time.
+func (s *SchedulerService) SetClock(c clock.Clock) {
+    c = clock.OrReal(c)
+    s.clock = c
+    s.scheduler.SetClock(c)
+    s.notificationMgr.SetClock(c)
+}
+
+// DeliverDueNotifications fans out every notification due at or before now
+func (s *SchedulerService) DeliverDueNotifications(ctx context.Context, now time.Time) error {
+    return s.notificationMgr.ProcessDueNotifications(ctx, now)
+}
+
+// Helper functions
+
+// applySensorReadings returns a copy of the request whose environmental factors come from
+// the latest sensor reading for the crop's garden, or the request unchanged if there is none
+func (s *SchedulerService) applySensorReadings(ctx context.Context, request *dto.MaintenanceRequest) *dto.MaintenanceRequest {
+    reading, err := s.scheduler.GetLatestEnvironmentReading(ctx, request.CropID)
+    if err != nil || reading == nil {
+        return request
+    }
+
+    withSensors := *request
+    withSensors.EnvironmentalFactors = reading.EnvironmentalFactors()
+    return &withSensors
+}
+
+// withDefaultFrequency returns request with its frequency defaulted by task type when
+// omitted; requests with a frequency, or of an unknown task type, are returned as is
+func (s *SchedulerService) withDefaultFrequency(request *dto.MaintenanceRequest) *dto.MaintenanceRequest {
+    if request == nil || request.Frequency != "" {
+        return request
+    }
+    frequency, ok := s.defaultFrequencies[request.TaskType]
+    if !ok {
+        return request
+    }
+
+    defaulted := *request
+    defaulted.Frequency = frequency
+    return &defaulted
+}
+
+// isValidFrequency reports whether frequency is a supported maintenance frequency
+func isValidFrequency(frequency string) bool {
     switch frequency {
-    case "Daily":
-        return 24 * time.Hour
-    case "Twice-Daily":
-        return 12 * time.Hour
-    case "Weekly":
-        return 7 * 24 * time.Hour
-    case "Bi-weekly":
-        return 14 * 24 * time.Hour
-    default:
-        return 24 * time.Hour
+    case dto.FrequencyDaily, dto.FrequencyTwiceDaily, dto.FrequencyWeekly, dto.FrequencyBiWeekly, dto.FrequencyMonthly:
+        return true
     }
+    return false
 }
 
-func (s *SchedulerService) adjustIntervalForEnvironment(baseInterval time.Duration, task *dto.MaintenanceResponse) time.Duration {
-    // Adjust based on completion streak and environmental factors
-    if task.CompletionStreak > 5 {
-        baseInterval = baseInterval * 9 / 10 // Reduce interval by 10% for consistent completion
+func (s *SchedulerService) generateScheduleWithRetry(ctx context.Context, request *dto.MaintenanceRequest) (map[string]interface{}, error) {
+    var schedule map[string]interface{}
+    var err error
+
+    for attempt := 0; attempt < 3; attempt++ {
+        schedule, err = s.aiService.GenerateMaintenanceSchedule(ctx, []string{request.TaskType}, s.scheduler.scheduleConditions(ctx, request))
+
+        if err == nil {
+            return schedule, nil
+        }
+        if errors.Is(err, ai.ErrBudgetExceeded) {
+            return nil, err
+        }
+
+        time.Sleep(time.Duration(attempt+1) * 500 * time.Millisecond)
     }
 
-    // Additional environmental adjustments could be made here
-    return baseInterval
+    return nil, err
 }
 
 // Cache operations
@@ -294,15 +1065,55 @@ func (s *SchedulerService) getFromCache(ctx context.Context, key string) (*dto.M
     return &response, nil
 }
 
+// getManyFromCache looks up the cached schedules for ids in one round trip, keyed by ID.
+// Cache failures and undecodable entries are treated as misses.
+func (s *SchedulerService) getManyFromCache(ctx context.Context, ids []string) map[string]*dto.MaintenanceResponse {
+    found := make(map[string]*dto.MaintenanceResponse, len(ids))
+
+    keys := make([]string, len(ids))
+    for i, id := range ids {
+        keys[i] = fmt.Sprintf("schedule:%s", id)
+    }
+
+    values, err := s.cache.MGet(ctx, keys...).Result()
+    if err != nil {
+        return found
+    }
+
+    for i, value := range values {
+        raw, ok := value.(string)
+        if !ok {
+            continue
+        }
+        var response dto.MaintenanceResponse
+        if err := json.Unmarshal([]byte(raw), &response); err != nil {
+            continue
+        }
+        found[ids[i]] = &response
+    }
+
+    return found
+}
+
 func (s *SchedulerService) cacheSchedule(ctx context.Context, key string, response *dto.MaintenanceResponse) {
     data, err := json.Marshal(response)
     if err != nil {
         return
     }
 
-    s.cache.Set(ctx, key, data, 1*time.Hour)
+    s.cache.Set(ctx, key, data, cache.JitterTTL(scheduleCacheTTL, s.cacheTTLJitter))
+
+    // Keep a longer-lived copy to fall back on while the database is unavailable
+    if s.serveStaleReads {
+        s.cache.Set(ctx, staleScheduleKey(response.ID), data, cache.JitterTTL(s.staleReadTTL, s.cacheTTLJitter))
+    }
+}
+
+// staleScheduleKey returns the cache key of a schedule's last-known copy
+func staleScheduleKey(scheduleID string) string {
+    return fmt.Sprintf("schedule:stale:%s", scheduleID)
 }
 
 func (s *SchedulerService) invalidateCache(ctx context.Context, scheduleID string) {
-    s.cache.Del(ctx, fmt.Sprintf("schedule:%s", scheduleID))
+    s.cache.Del(ctx, fmt.Sprintf("schedule:%s", scheduleID), staleScheduleKey(scheduleID))
 }
\ No newline at end of file
@@ -0,0 +1,138 @@
+// Package scheduler provides maintenance scheduling functionality for the Urban Gardening Assistant
+package scheduler
+
+import (
+    "context"
+    "errors"
+    "fmt"
+
+    "github.com/urban-gardening/backend/internal/models"
+    "github.com/urban-gardening/backend/pkg/dto"
+)
+
+// maxSnapshotLabelLength bounds the label gardeners give a snapshot
+const maxSnapshotLabelLength = 100
+
+// CropRestoreObserver is told which of a garden's crops a snapshot restore wrote, so the
+// crop service can drop its cached copies, record the garden's utilization, and fire its
+// capacity webhooks as it does for its own crop changes
+type CropRestoreObserver interface {
+    CropsRestored(ctx context.Context, gardenID string, cropIDs []string)
+}
+
+// SetCropRestoreObserver configures who is told of crops changed by RestoreSnapshot.
+// Without one, crop caches and utilization history are left to catch up on their own.
+func (s *SchedulerService) SetCropRestoreObserver(observer CropRestoreObserver) {
+    s.mu.Lock()
+    s.cropObserver = observer
+    s.mu.Unlock()
+}
+
+// SnapshotGarden saves a garden's current crops and maintenance schedules so the garden
+// can be returned to this state with RestoreSnapshot
+func (s *SchedulerService) SnapshotGarden(ctx context.Context, gardenID, label string) (*dto.GardenSnapshotResponse, error) {
+    if gardenID == "" {
+        return nil, fmt.Errorf("%w: garden ID is required", ErrInvalidRequest)
+    }
+    if len(label) > maxSnapshotLabelLength {
+        return nil, fmt.Errorf("%w: snapshot label must be at most %d characters", ErrInvalidRequest, maxSnapshotLabelLength)
+    }
+
+    snapshot, err := s.scheduler.CreateGardenSnapshot(ctx, gardenID, label)
+    if err != nil {
+        return nil, fmt.Errorf("failed to snapshot garden: %w", err)
+    }
+
+    return toGardenSnapshotResponse(snapshot), nil
+}
+
+// ListGardenSnapshots returns a garden's snapshots, newest first
+func (s *SchedulerService) ListGardenSnapshots(ctx context.Context, gardenID string) ([]*dto.GardenSnapshotResponse, error) {
+    if gardenID == "" {
+        return nil, fmt.Errorf("%w: garden ID is required", ErrInvalidRequest)
+    }
+
+    snapshots, err := s.scheduler.ListGardenSnapshots(ctx, gardenID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list garden snapshots: %w", err)
+    }
+
+    responses := make([]*dto.GardenSnapshotResponse, len(snapshots))
+    for i := range snapshots {
+        responses[i] = toGardenSnapshotResponse(&snapshots[i])
+    }
+    return responses, nil
+}
+
+// RestoreSnapshot returns a garden's crops and maintenance schedules to a snapshot.
+// Schedules created since the snapshot are deleted and their pending notifications
+// removed; the snapshot's schedules are saved as they were, with next scheduled times
+// recomputed from now and notifications scheduled again for the active ones. The restore
+// is kept when a schedule is over the notification limit; such schedules are reported
+// in UnnotifiedScheduleIDs instead.
+func (s *SchedulerService) RestoreSnapshot(ctx context.Context, gardenID, snapshotID string) (*dto.SnapshotRestoreResponse, error) {
+    if gardenID == "" || snapshotID == "" {
+        return nil, fmt.Errorf("%w: garden ID and snapshot ID are required", ErrInvalidRequest)
+    }
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    restore, err := s.scheduler.RestoreGardenSnapshot(ctx, gardenID, snapshotID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to restore garden snapshot: %w", err)
+    }
+
+    if s.cropObserver != nil {
+        s.cropObserver.CropsRestored(ctx, gardenID, restore.CropIDs)
+    }
+
+    response := &dto.SnapshotRestoreResponse{
+        Snapshot:              *toGardenSnapshotResponse(restore.Snapshot),
+        Schedules:             make([]*dto.MaintenanceResponse, 0, len(restore.Restored)),
+        RemovedScheduleIDs:    make([]string, 0, len(restore.Removed)),
+        UnnotifiedScheduleIDs: []string{},
+    }
+
+    for i := range restore.Removed {
+        task := &restore.Removed[i]
+        if err := s.notificationMgr.CancelNotifications(ctx, task.ID, task.TaskType); err != nil {
+            return nil, fmt.Errorf("failed to remove notifications: %w", err)
+        }
+        s.invalidateCache(ctx, task.ID)
+        response.RemovedScheduleIDs = append(response.RemovedScheduleIDs, task.ID)
+    }
+
+    for i := range restore.Restored {
+        task := &restore.Restored[i]
+        // Replace notifications made for the task since the snapshot
+        if err := s.notificationMgr.CancelNotifications(ctx, task.ID, task.TaskType); err != nil {
+            return nil, fmt.Errorf("failed to remove notifications: %w", err)
+        }
+        if task.Active {
+            // The restore has committed; a schedule over the limit is kept and reported
+            if err := s.notificationMgr.ScheduleNotification(ctx, task); err != nil {
+                if !errors.Is(err, ErrNotificationLimit) {
+                    return nil, fmt.Errorf("failed to schedule notifications: %w", err)
+                }
+                response.UnnotifiedScheduleIDs = append(response.UnnotifiedScheduleIDs, task.ID)
+            }
+        }
+        s.invalidateCache(ctx, task.ID)
+        response.Schedules = append(response.Schedules, task.ToResponse())
+    }
+
+    return response, nil
+}
+
+// toGardenSnapshotResponse converts a stored snapshot to its DTO
+func toGardenSnapshotResponse(snapshot *models.GardenSnapshot) *dto.GardenSnapshotResponse {
+    return &dto.GardenSnapshotResponse{
+        ID:            snapshot.ID,
+        GardenID:      snapshot.GardenID,
+        Label:         snapshot.Label,
+        CropCount:     snapshot.CropCount,
+        ScheduleCount: snapshot.ScheduleCount,
+        CreatedAt:     snapshot.CreatedAt,
+    }
+}
@@ -0,0 +1,183 @@
+// Package scheduler provides maintenance scheduling functionality for the Urban Gardening Assistant
+package scheduler
+
+import (
+    "context"
+    "fmt"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/urban-gardening/backend/internal/models"
+    "github.com/urban-gardening/backend/pkg/dto"
+)
+
+// defaultWaterPerBagML is the daily water per grow bag for crops without a specific entry
+const defaultWaterPerBagML = 600.0
+
+// waterPerBagML is the daily water each grow bag of a crop needs; fruiting crops are thirstier
+var waterPerBagML = map[string]float64{
+    "tomatoes": 1000,
+    "eggplant": 900,
+    "peppers":  800,
+    "spinach":  500,
+    "lettuce":  400,
+    "kale":     500,
+}
+
+// Suggested preferred times: water before the heat of the day, prune once leaves are dry
+const (
+    suggestedWaterTime      = "07:00"
+    suggestedFertilizerTime = "08:00"
+    suggestedPruningTime    = "10:00"
+)
+
+// SuggestSchedulesForCrop suggests Water, Fertilizer, and Pruning schedules for a crop
+// without creating them, so the user can confirm each one through CreateSchedule. Rule-based
+// defaults are derived from the crop type and size; when the AI returns a usable frequency
+// for a task it replaces the rule-based one. A Pest Control schedule is also suggested
+// while the crop has recent pest incidents flagged to raise pest control. Task types the
+// crop has disabled, e.g. watering for cacti, are never suggested.
+func (s *SchedulerService) SuggestSchedulesForCrop(ctx context.Context, cropID string) (*dto.SuggestedSchedulesResponse, error) {
+    if cropID == "" {
+        return nil, fmt.Errorf("%w: crop ID is required", ErrInvalidRequest)
+    }
+
+    crop, err := s.scheduler.GetCrop(ctx, cropID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to suggest schedules: %w", err)
+    }
+
+    suggestions := enabledSuggestions(crop, ruleBasedSuggestions(crop, s.clock.Now()))
+    source := dto.RecommendationSourceRules
+    if s.applyAIFrequencies(ctx, crop, suggestions) {
+        source = dto.RecommendationSourceAI
+    }
+
+    // Recent pest problems call for pest control regardless of what the AI suggests
+    pestControl, err := s.pestControlSuggestion(ctx, crop)
+    if err != nil {
+        return nil, fmt.Errorf("failed to suggest schedules: %w", err)
+    }
+    if pestControl != nil && crop.TaskTypeEnabled(pestControl.TaskType) {
+        suggestions = append(suggestions, *pestControl)
+    }
+
+    return &dto.SuggestedSchedulesResponse{
+        CropID:      crop.ID,
+        CropName:    crop.Name,
+        Source:      source,
+        Suggestions: validSuggestions(suggestions),
+    }, nil
+}
+
+// ruleBasedSuggestions derives Water, Fertilizer, and Pruning schedules from the crop's
+// type, number of grow bags, and growth stage
+func ruleBasedSuggestions(crop *models.Crop, now time.Time) []dto.ScheduleSuggestion {
+    name := strings.ToLower(strings.TrimSpace(crop.Name))
+    leafy := leafyCrops[name]
+
+    growBags := crop.GrowBags
+    if growBags < 1 {
+        growBags = 1
+    }
+
+    perBag, ok := waterPerBagML[name]
+    if !ok {
+        perBag = defaultWaterPerBagML
+    }
+
+    fertilizer := fertilizerRecommendationFor(crop, now)
+
+    pruning := dto.ScheduleSuggestion{
+        TaskType:      dto.TaskTypePruning,
+        Frequency:     dto.FrequencyWeekly,
+        Unit:          "n/a",
+        PreferredTime: suggestedPruningTime,
+        Rationale:     "removing suckers and lower leaves keeps fruiting plants productive and airy",
+    }
+    if leafy {
+        pruning.Frequency = dto.FrequencyBiWeekly
+        pruning.Rationale = "harvesting outer leaves regularly encourages new growth"
+    }
+
+    return []dto.ScheduleSuggestion{
+        {
+            TaskType:      dto.TaskTypeWater,
+            Frequency:     dto.FrequencyDaily,
+            Amount:        clampTaskAmount(dto.TaskTypeWater, perBag*float64(growBags)),
+            Unit:          "ml",
+            PreferredTime: suggestedWaterTime,
+            Rationale:     fmt.Sprintf("about %.0f ml per grow bag keeps the soil evenly moist", perBag),
+        },
+        {
+            TaskType:      dto.TaskTypeFertilizer,
+            Frequency:     fertilizer.Frequency,
+            Amount:        fertilizer.Amount,
+            Unit:          fertilizer.Unit,
+            PreferredTime: suggestedFertilizerTime,
+            Rationale:     fertilizer.Rationale,
+        },
+        pruning,
+    }
+}
+
+// enabledSuggestions drops the suggestions whose task type the crop has disabled
+func enabledSuggestions(crop *models.Crop, suggestions []dto.ScheduleSuggestion) []dto.ScheduleSuggestion {
+    enabled := suggestions[:0]
+    for _, suggestion := range suggestions {
+        if crop.TaskTypeEnabled(suggestion.TaskType) {
+            enabled = append(enabled, suggestion)
+        }
+    }
+    return enabled
+}
+
+// validSuggestions drops the suggestions that would fail validation if accepted
+func validSuggestions(suggestions []dto.ScheduleSuggestion) []dto.ScheduleSuggestion {
+    valid := suggestions[:0]
+    for _, suggestion := range suggestions {
+        if suggestion.Validate() == nil {
+            valid = append(valid, suggestion)
+        }
+    }
+    return valid
+}
+
+// applyAIFrequencies asks the AI for per-task frequencies and applies any valid ones to
+// the suggestions, reporting whether the AI changed anything. AI failures, including a
+// spent AI budget, leave the rule-based suggestions untouched.
+func (s *SchedulerService) applyAIFrequencies(ctx context.Context, crop *models.Crop, suggestions []dto.ScheduleSuggestion) bool {
+    schedule, err := s.aiService.GenerateMaintenanceSchedule(ctx, []string{crop.Name}, map[string]string{
+        "growBags": strconv.Itoa(crop.GrowBags),
+        "bagSize":  crop.BagSize,
+    })
+    if err != nil {
+        return false
+    }
+
+    frequencies, ok := schedule["frequency"].(map[string]interface{})
+    if !ok {
+        return false
+    }
+
+    applied := false
+    for i := range suggestions {
+        frequency, ok := frequencies[suggestions[i].TaskType].(string)
+        if !ok || !validSuggestionFrequency(frequency) {
+            continue
+        }
+        suggestions[i].Frequency = frequency
+        applied = true
+    }
+    return applied
+}
+
+// validSuggestionFrequency reports whether frequency is one a maintenance request accepts
+func validSuggestionFrequency(frequency string) bool {
+    switch frequency {
+    case dto.FrequencyDaily, dto.FrequencyTwiceDaily, dto.FrequencyWeekly, dto.FrequencyBiWeekly, dto.FrequencyMonthly:
+        return true
+    }
+    return false
+}
@@ -0,0 +1,83 @@
+// Package scheduler provides maintenance scheduling functionality for the Urban Gardening Assistant
+package scheduler
+
+import (
+    "context"
+    "fmt"
+    "sort"
+    "time"
+
+    "github.com/urban-gardening/backend/pkg/dto"
+)
+
+// TodayView returns everything due today across all of a user's gardens, grouped by
+// garden. Tasks already overdue are included once and flagged; recurring tasks appear
+// for each time they fall due before midnight. Gardens with nothing due are omitted, and
+// gardens are listed in the order their first task falls due.
+func (s *SchedulerService) TodayView(ctx context.Context, userID string) (*dto.TodayViewResponse, error) {
+    if userID == "" {
+        return nil, fmt.Errorf("%w: user ID is required", ErrInvalidRequest)
+    }
+
+    tasks, err := s.scheduler.ListUserActiveMaintenance(ctx, userID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to build today view: %w", err)
+    }
+
+    now := s.clock.Now()
+    start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+    end := start.AddDate(0, 0, 1)
+
+    response := &dto.TodayViewResponse{
+        UserID:  userID,
+        Date:    start.Format("2006-01-02"),
+        Gardens: []dto.TodayGarden{},
+    }
+    gardenIndex := make(map[string]int)
+
+    for i := range tasks {
+        task := &tasks[i]
+        if task.Crop == nil {
+            continue
+        }
+
+        for _, occurrence := range checklistOccurrences(task, start, end) {
+            index, ok := gardenIndex[task.Crop.GardenID]
+            if !ok {
+                index = len(response.Gardens)
+                gardenIndex[task.Crop.GardenID] = index
+                response.Gardens = append(response.Gardens, dto.TodayGarden{GardenID: task.Crop.GardenID})
+            }
+
+            item := dto.ChecklistItem{
+                ScheduleID: task.ID,
+                CropID:     task.CropID,
+                CropName:   task.Crop.Name,
+                TaskType:   task.TaskType,
+                Amount:     task.Amount,
+                Unit:       task.Unit,
+                Time:       occurrence.Format("15:04"),
+                Overdue:    occurrence.Before(start),
+            }
+            response.Gardens[index].Items = append(response.Gardens[index].Items, item)
+
+            response.TotalCount++
+            if item.Overdue {
+                response.OverdueCount++
+            }
+        }
+    }
+
+    // List each garden's tasks in the order they should be done, overdue tasks first
+    for i := range response.Gardens {
+        items := response.Gardens[i].Items
+        sort.SliceStable(items, func(a, b int) bool {
+            if items[a].Overdue != items[b].Overdue {
+                return items[a].Overdue
+            }
+            return items[a].Time < items[b].Time
+        })
+    }
+
+    return response, nil
+}
@@ -57,11 +57,16 @@ func GenerateToken(user *dto.UserResponseDTO, config *types.ServiceConfig) (stri
 	}
 	jti := base64.URLEncoding.EncodeToString(jtiBytes)
 
+	role := user.Role
+	if role == "" {
+		role = dto.RoleUser // Default role
+	}
+
 	// Create claims with enhanced security
 	claims := &Claims{
 		UserID:            user.ID,
 		Email:             user.Email,
-		Role:             "user", // Default role
+		Role:             role,
 		JTI:              jti,
 		Environment:      config.Environment,
 		DeviceFingerprint: generateDeviceFingerprint(),
@@ -175,6 +180,7 @@ func ExtractUserFromToken(token *jwt.Token) (*dto.UserResponseDTO, error) {
 	return &dto.UserResponseDTO{
 		ID:    claims.UserID,
 		Email: claims.Email,
+		Role:  claims.Role,
 	}, nil
 }
 
@@ -0,0 +1,65 @@
+// Package breaker keeps a central registry of the circuit breakers guarding external
+// dependencies so operators can see which of them are open
+package breaker
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/sony/gobreaker" // v2.0.0
+
+	"github.com/urban-gardening/backend/pkg/dto"
+)
+
+// Registry tracks circuit breakers by name
+type Registry struct {
+	mu       sync.RWMutex
+	breakers map[string]*gobreaker.CircuitBreaker
+}
+
+// defaultRegistry holds the breakers created with New
+var defaultRegistry = NewRegistry()
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{breakers: make(map[string]*gobreaker.CircuitBreaker)}
+}
+
+// Default returns the registry every dependency breaker created with New is added to
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// New creates a circuit breaker with settings and registers it in the default registry
+func New(settings gobreaker.Settings) *gobreaker.CircuitBreaker {
+	cb := gobreaker.NewCircuitBreaker(settings)
+	defaultRegistry.Register(cb)
+	return cb
+}
+
+// Register adds cb to the registry, replacing any breaker registered under its name
+func (r *Registry) Register(cb *gobreaker.CircuitBreaker) {
+	r.mu.Lock()
+	r.breakers[cb.Name()] = cb
+	r.mu.Unlock()
+}
+
+// States returns the current state of every registered breaker ordered by name
+func (r *Registry) States() []dto.BreakerState {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	states := make([]dto.BreakerState, 0, len(r.breakers))
+	for name, cb := range r.breakers {
+		counts := cb.Counts()
+		states = append(states, dto.BreakerState{
+			Name:                name,
+			State:               cb.State().String(),
+			Requests:            counts.Requests,
+			ConsecutiveFailures: counts.ConsecutiveFailures,
+		})
+	}
+
+	sort.Slice(states, func(i, j int) bool { return states[i].Name < states[j].Name })
+	return states
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/rand"
 	"sync"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 
 	"github.com/urban-gardening-assistant/backend/pkg/types/config"
 	"github.com/urban-gardening-assistant/backend/internal/utils/errors"
+	"github.com/urban-gardening/backend/internal/utils/breaker"
 )
 
 // Default configuration values
@@ -43,6 +45,7 @@ type RedisClient struct {
 	breaker    *gobreaker.CircuitBreaker
 	compressor *s2.Writer
 	metrics    *cacheMetrics
+	ttlJitter  float64 // Percentage by which Set spreads expirations either way
 	mu         sync.RWMutex
 }
 
@@ -178,9 +181,10 @@ func NewRedisClient(cfg *config.RedisConfig) (*RedisClient, error) {
 	// Initialize client instance
 	rc := &RedisClient{
 		client:     client,
-		breaker:    gobreaker.NewCircuitBreaker(breakerSettings),
+		breaker:    breaker.New(breakerSettings),
 		compressor: s2.NewWriter(nil),
 		metrics:    initMetrics(),
+		ttlJitter:  cfg.TTLJitterPercent,
 	}
 
 	return rc, nil
@@ -197,6 +201,9 @@ func (rc *RedisClient) Set(ctx context.Context, key string, value interface{}, e
 		rc.metrics.operationDuration.WithLabelValues("set").Observe(time.Since(start).Seconds())
 	}()
 
+	// Spread expirations so entries cached together do not expire together
+	expiration = JitterTTL(expiration, rc.ttlJitter)
+
 	// Execute through circuit breaker
 	_, err := rc.breaker.Execute(func() (interface{}, error) {
 		// Convert value to JSON
@@ -304,4 +311,18 @@ func shouldRetry(err error) bool {
 
 func retryDelay(attempt int) time.Duration {
 	return time.Duration(attempt*100) * time.Millisecond
-}
\ No newline at end of file
+}
+
+// JitterTTL randomly adjusts ttl by up to percent of its length in either direction.
+// Non-positive TTLs (no expiry) and a zero percentage are returned unchanged.
+func JitterTTL(ttl time.Duration, percent float64) time.Duration {
+	if ttl <= 0 || percent <= 0 {
+		return ttl
+	}
+	spread := float64(ttl) * percent / 100
+	jittered := ttl + time.Duration((rand.Float64()*2-1)*spread)
+	if jittered < time.Second {
+		return time.Second
+	}
+	return jittered
+}
@@ -0,0 +1,122 @@
+// Package clock provides an injectable time source so that scheduling logic can be
+// driven by a fixed time in tests instead of the system clock
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock reports the current time
+type Clock interface {
+	Now() time.Time
+}
+
+// Timer is implemented by clocks that can also wait for time to pass on them
+type Timer interface {
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock reads the system clock
+type realClock struct{}
+
+// Now returns the current system time
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// After waits for d to pass on the system clock
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Real returns a Clock backed by the system clock
+func Real() Clock {
+	return realClock{}
+}
+
+// OrReal returns c, or the system clock when c is nil
+func OrReal(c Clock) Clock {
+	if c == nil {
+		return realClock{}
+	}
+	return c
+}
+
+// After returns a channel receiving the time once d has passed on c, falling back to the
+// system clock for clocks that cannot wait
+func After(c Clock, d time.Duration) <-chan time.Time {
+	if timer, ok := c.(Timer); ok {
+		return timer.After(d)
+	}
+	return time.After(d)
+}
+
+// Fake is a Clock that only moves when set or advanced, for deterministic tests
+type Fake struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+// fakeWaiter is a pending Fake.After call
+type fakeWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// NewFake creates a Fake clock fixed at now
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now returns the fake clock's current time
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// After returns a channel receiving the fake time once the clock is set or advanced at
+// least d past the current time
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- f.now
+		return ch
+	}
+	f.waiters = append(f.waiters, fakeWaiter{at: f.now.Add(d), ch: ch})
+	return ch
+}
+
+// Set moves the fake clock to t
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	f.now = t
+	f.fireWaiters()
+	f.mu.Unlock()
+}
+
+// Advance moves the fake clock forward by d
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	f.now = f.now.Add(d)
+	f.fireWaiters()
+	f.mu.Unlock()
+}
+
+// fireWaiters releases the After calls whose time has come. Callers hold f.mu.
+func (f *Fake) fireWaiters() {
+	pending := f.waiters[:0]
+	for _, waiter := range f.waiters {
+		if waiter.at.After(f.now) {
+			pending = append(pending, waiter)
+			continue
+		}
+		waiter.ch <- f.now
+	}
+	f.waiters = pending
+}
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus" // v1.15.0
+	"gorm.io/gorm"
+)
+
+// Database operations recorded in query metrics
+const (
+	OperationCreate = "create"
+	OperationQuery  = "query"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+)
+
+// queryStartKey is the statement instance key holding when an operation started
+const queryStartKey = "query_metrics:start"
+
+// QueryMetrics is a GORM plugin recording the latency of each create, query, update and
+// delete, labelled by operation and model table. Its collectors are registered once by
+// NewQueryMetrics, and the plugin can then be used by any number of connections.
+type QueryMetrics struct {
+	duration *prometheus.HistogramVec
+}
+
+// NewQueryMetrics creates the query latency metrics and registers them with registerer
+func NewQueryMetrics(registerer prometheus.Registerer) (*QueryMetrics, error) {
+	m := &QueryMetrics{
+		duration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "urban_gardening",
+				Subsystem: "database",
+				Name:      "query_duration_seconds",
+				Help:      "Duration of database operations in seconds by operation and model",
+				Buckets:   prometheus.DefBuckets,
+			},
+			[]string{"operation", "model"},
+		),
+	}
+
+	if err := registerer.Register(m.duration); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Name implements gorm.Plugin
+func (m *QueryMetrics) Name() string {
+	return "query_metrics"
+}
+
+// Initialize implements gorm.Plugin, timing each operation around GORM's own callback
+func (m *QueryMetrics) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	registrations := []error{
+		callbacks.Create().Before("gorm:create").Register("query_metrics:before_create", startQueryTimer),
+		callbacks.Create().After("gorm:create").Register("query_metrics:after_create", m.observeQuery(OperationCreate)),
+		callbacks.Query().Before("gorm:query").Register("query_metrics:before_query", startQueryTimer),
+		callbacks.Query().After("gorm:query").Register("query_metrics:after_query", m.observeQuery(OperationQuery)),
+		callbacks.Update().Before("gorm:update").Register("query_metrics:before_update", startQueryTimer),
+		callbacks.Update().After("gorm:update").Register("query_metrics:after_update", m.observeQuery(OperationUpdate)),
+		callbacks.Delete().Before("gorm:delete").Register("query_metrics:before_delete", startQueryTimer),
+		callbacks.Delete().After("gorm:delete").Register("query_metrics:after_delete", m.observeQuery(OperationDelete)),
+	}
+	for _, err := range registrations {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startQueryTimer records when an operation's statement started executing
+func startQueryTimer(db *gorm.DB) {
+	db.InstanceSet(queryStartKey, time.Now())
+}
+
+// observeQuery returns a callback recording the latency of operation since its start
+func (m *QueryMetrics) observeQuery(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(queryStartKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+		m.duration.WithLabelValues(operation, queryModel(db)).Observe(time.Since(start).Seconds())
+	}
+}
+
+// queryModel returns the table of the model an operation ran against, or "unknown" for
+// raw statements without one
+func queryModel(db *gorm.DB) string {
+	if db.Statement == nil {
+		return "unknown"
+	}
+	if db.Statement.Schema != nil {
+		return db.Statement.Schema.Table
+	}
+	if db.Statement.Table != "" {
+		return db.Statement.Table
+	}
+	return "unknown"
+}
@@ -8,12 +8,14 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/sony/gobreaker" // v2.0.0
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
 	"github.com/urban-gardening-assistant/backend/internal/utils/errors"
 	"github.com/urban-gardening-assistant/backend/pkg/types/config"
+	"github.com/urban-gardening/backend/internal/utils/breaker"
 )
 
 // Global variables for database management
@@ -28,6 +30,15 @@ var (
 	// Retry configuration
 	maxRetryAttempts = 3
 	retryBaseDelay   = time.Second
+
+	// pingBreaker stops health pings from piling up on an unreachable database
+	pingBreaker = breaker.New(gobreaker.Settings{
+		Name:    "database",
+		Timeout: 30 * time.Second,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= 3
+		},
+	})
 )
 
 // NewConnection establishes a new PostgreSQL database connection with enhanced
@@ -159,11 +170,14 @@ func Ping() error {
 			fmt.Sprintf("failed to get database instance: %v", err))
 	}
 
-	// Execute ping with timeout context
+	// Execute ping with timeout context, failing fast while the breaker is open
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := sqlDB.PingContext(ctx); err != nil {
+	_, err = pingBreaker.Execute(func() (interface{}, error) {
+		return nil, sqlDB.PingContext(ctx)
+	})
+	if err != nil {
 		return errors.NewError(ErrDBOperationFailed, 
 			fmt.Sprintf("failed to ping database: %v", err))
 	}
@@ -13,6 +13,27 @@ const (
 	MaxDimension = 100.0
 )
 
+// Soil efficiency override bounds for gardens whose amended soil performs differently
+// from its soil type
+const (
+	// MinSoilEfficiencyOverride defines the lowest efficiency factor a garden may set directly
+	MinSoilEfficiencyOverride = 0.5
+	// MaxSoilEfficiencyOverride defines the highest efficiency factor a garden may set directly
+	MaxSoilEfficiencyOverride = 1.5
+)
+
+// Coordinate bounds for a garden's GPS location, in decimal degrees
+const (
+	// MinLatitude defines the southernmost valid latitude
+	MinLatitude = -90.0
+	// MaxLatitude defines the northernmost valid latitude
+	MaxLatitude = 90.0
+	// MinLongitude defines the westernmost valid longitude
+	MinLongitude = -180.0
+	// MaxLongitude defines the easternmost valid longitude
+	MaxLongitude = 180.0
+)
+
 // Soil type constants define the available soil options for gardens
 const (
 	// SoilTypeRedSoil represents iron-rich soil best suited for root vegetables
@@ -37,6 +58,16 @@ const (
 	SunlightShade = "full_shade"
 )
 
+// Growing environment constants describe how exposed a garden is to outdoor seasons
+const (
+	// EnvironmentOutdoor represents a garden exposed to outdoor weather, the default
+	EnvironmentOutdoor = "Outdoor"
+	// EnvironmentIndoor represents a garden grown inside a home
+	EnvironmentIndoor = "Indoor"
+	// EnvironmentGreenhouse represents a garden sheltered in a greenhouse
+	EnvironmentGreenhouse = "Greenhouse"
+)
+
 // ValidSoilTypes returns a slice of all valid soil type constants
 // Used for validation and form population throughout the application
 func ValidSoilTypes() []string {
@@ -57,4 +88,14 @@ func ValidSunlightConditions() []string {
 		SunlightPartial,
 		SunlightShade,
 	}
-}
\ No newline at end of file
+}
+
+// ValidEnvironments returns a slice of all valid growing environment constants
+// Used for validation and form population throughout the application
+func ValidEnvironments() []string {
+	return []string{
+		EnvironmentOutdoor,
+		EnvironmentIndoor,
+		EnvironmentGreenhouse,
+	}
+}
@@ -0,0 +1,56 @@
+package dto
+
+import "time"
+
+// AIHealthResponse represents the DTO for a runtime AI configuration and connectivity check
+type AIHealthResponse struct {
+	Model     string    `json:"model"`
+	LatencyMs int64     `json:"latencyMs"`
+	Success   bool      `json:"success"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// BreakerState represents the DTO for the current state of one dependency's circuit breaker
+type BreakerState struct {
+	Name                string `json:"name"`
+	State               string `json:"state"` // "closed", "half-open", or "open"
+	Requests            uint32 `json:"requests"`
+	ConsecutiveFailures uint32 `json:"consecutiveFailures"`
+}
+
+// BreakerStatesResponse represents the DTO listing every registered circuit breaker
+type BreakerStatesResponse struct {
+	Breakers []BreakerState `json:"breakers"`
+}
+
+// DeadLetter represents the DTO for a notification that exhausted its delivery retries
+type DeadLetter struct {
+	ID            string    `json:"id"`
+	TaskID        string    `json:"taskId"`
+	TaskType      string    `json:"taskType"`
+	GardenID      string    `json:"gardenId,omitempty"`
+	Reason        string    `json:"reason"` // "delivery_failed", "no_sender", or "processing_error"
+	Error         string    `json:"error,omitempty"`
+	RecipientIDs  []string  `json:"recipientIds,omitempty"` // Recipients still owed the notification, if limited
+	RetryCount    int       `json:"retryCount"`
+	ScheduledTime time.Time `json:"scheduledTime"`
+	FailedAt      time.Time `json:"failedAt"`
+}
+
+// DeadLetterFilter narrows a dead-letter listing; empty fields match everything
+type DeadLetterFilter struct {
+	TaskType string
+	Reason   string
+}
+
+// DeadLetterListResponse represents the DTO for a page of dead-lettered notifications
+type DeadLetterListResponse struct {
+	DeadLetters []DeadLetter `json:"deadLetters"`
+	Total       int          `json:"total"`
+	Page        int          `json:"page"`
+	PageSize    int          `json:"pageSize"`
+	TotalPages  int          `json:"totalPages"`
+	HasNext     bool         `json:"hasNext"`
+	HasPrevious bool         `json:"hasPrevious"`
+}
@@ -2,9 +2,11 @@ package dto
 
 import (
     "fmt"
+    "reflect"
+    "strings"
     "time"
     "github.com/go-playground/validator/v10" // v10.11.0
-    "github.com/yourusername/urbangardening/pkg/types/common"
+    "github.com/urban-gardening-assistant/backend/pkg/types/common"
 )
 
 // Supported grow bag sizes in inches
@@ -24,13 +26,49 @@ const (
     YieldAccuracy    = 0.10 // 10% accuracy requirement
 )
 
+// Canonical crop names used as keys in yield tables
+const (
+    CropTomatoes = "Tomatoes"
+    CropSpinach  = "Spinach"
+    CropLettuce  = "Lettuce"
+    CropPeppers  = "Peppers"
+    CropEggplant = "Eggplant"
+)
+
+// cropNameAliases maps lower-case singular names and synonyms to canonical crop names
+var cropNameAliases = map[string]string{
+    "tomato":      CropTomatoes,
+    "spinach":     CropSpinach,
+    "lettuce":     CropLettuce,
+    "pepper":      CropPeppers,
+    "bell pepper": CropPeppers,
+    "capsicum":    CropPeppers,
+    "eggplant":    CropEggplant,
+    "aubergine":   CropEggplant,
+    "brinjal":     CropEggplant,
+}
+
 // CropRequest represents the request payload for crop creation and updates
 type CropRequest struct {
-    GardenID       string `json:"gardenId" validate:"required,uuid"`
-    Name           string `json:"name" validate:"required,min=2,max=50"`
-    QuantityNeeded int    `json:"quantityNeeded" validate:"required,min=1,max=1000"`
-    GrowBags       int    `json:"growBags" validate:"required,min=1,max=100"`
-    BagSize        string `json:"bagSize" validate:"required,oneof=8\" 10\" 12\" 14\""`
+    GardenID       string             `json:"gardenId" validate:"required,uuid"`
+    Name           string             `json:"name" validate:"required,min=2,max=50"`
+    QuantityNeeded int                `json:"quantityNeeded" validate:"required,min=1,max=1000"`
+    GrowBags       int                `json:"growBags" validate:"required,min=1,max=100"`
+    BagSize        string             `json:"bagSize" validate:"required,oneof=8\" 10\" 12\" 14\""`
+    Conditions     *GrowingConditions `json:"conditions,omitempty" validate:"omitempty"`
+    Organic        bool               `json:"organic"` // Grown organically; conventional by default
+
+    // DisabledTaskTypes lists maintenance task types to turn off for the crop, e.g. Water
+    // for cacti; every task type is active by default
+    DisabledTaskTypes []string `json:"disabledTaskTypes,omitempty" validate:"omitempty,dive,oneof=Fertilizer Water Composting Pruning 'Pest Control'"`
+}
+
+// GrowingConditions represents measured environmental conditions that can refine yield
+// estimates when environment-driven yield adjustment is enabled
+type GrowingConditions struct {
+    Temperature float64 `json:"temperature" validate:"min=-20,max=60"` // Celsius
+    Humidity    float64 `json:"humidity" validate:"min=0,max=100"`     // Percent
+    LightLevel  string  `json:"lightLevel" validate:"required,oneof=low medium high"`
 }
 
 // CropResponse represents the response payload for crop operations
@@ -42,8 +80,54 @@ type CropResponse struct {
     GrowBags       int       `json:"growBags"`
     BagSize        string    `json:"bagSize"`
     EstimatedYield float64   `json:"estimatedYield"`
+    Starred        bool      `json:"starred"`
+    Organic        bool      `json:"organic"`
     CreatedAt      time.Time `json:"createdAt"`
     UpdatedAt      time.Time `json:"updatedAt"`
+    Stale          bool      `json:"stale,omitempty"` // Served from cache because the database was unavailable
+
+    // RequestedGrowBags is set when the requested count exceeded the crop's maximum for the
+    // garden and GrowBags was reduced to that maximum
+    RequestedGrowBags int `json:"requestedGrowBags,omitempty"`
+
+    // BagSizeWarning is set when the bag is below the crop's minimum size and the
+    // estimated yield was reduced accordingly
+    BagSizeWarning string `json:"bagSizeWarning,omitempty"`
+
+    // CapacityWarning is set when the garden's space utilization, including this crop,
+    // has reached the approaching-capacity threshold
+    CapacityWarning string `json:"capacityWarning,omitempty"`
+
+    // OverCapacity is set when the crop was created although it exceeds the garden's
+    // capacity, which happens only when over-capacity crops are allowed
+    OverCapacity bool `json:"overCapacity,omitempty"`
+
+    // SeasonalWarning is set when an outdoor crop is planted outside its recommended
+    // planting window
+    SeasonalWarning string `json:"seasonalWarning,omitempty"`
+
+    // TargetYield reports progress toward the crop's harvest goal, when one is set
+    TargetYield *TargetYieldProgress `json:"targetYield,omitempty"`
+
+    // DisabledTaskTypes lists the maintenance task types turned off for the crop
+    DisabledTaskTypes []string `json:"disabledTaskTypes,omitempty"`
+}
+
+// TaskTypeToggleRequest turns one maintenance task type on or off for a crop
+type TaskTypeToggleRequest struct {
+    TaskType string `json:"taskType" validate:"required,oneof=Fertilizer Water Composting Pruning 'Pest Control'"`
+    Enabled  bool   `json:"enabled"`
+}
+
+// PaginationParams represents the paging, sorting, and filtering options for listing crops
+type PaginationParams struct {
+    Page     int
+    PerPage  int
+    GardenID string
+    SortBy   string // name, createdAt, or estimatedYield
+    SortDir  string // asc or desc
+    Starred  *bool  // Filters by starred flag when set
+    Organic  *bool  // Filters by growing method when set
 }
 
 // CropListResponse represents a paginated list of crops
@@ -54,7 +138,238 @@ type CropListResponse struct {
     PerPage  int           `json:"perPage"`
 }
 
-// ValidateCropRequest performs comprehensive validation of the crop request
+// YieldPlanRequest represents the request payload for planning towards a weekly yield goal
+type YieldPlanRequest struct {
+    CropName     string  `json:"cropName" validate:"required,min=2,max=50"`
+    WeeklyTarget float64 `json:"weeklyTarget" validate:"required,gt=0"` // kg per week
+}
+
+// YieldPlanOption describes how many grow bags of one size are needed to reach a yield goal
+type YieldPlanOption struct {
+    BagSize       string  `json:"bagSize"`
+    GrowBags      int     `json:"growBags"`
+    SpaceRequired float64 `json:"spaceRequired"` // sq ft
+    WeeklyYield   float64 `json:"weeklyYield"`   // kg per week
+    Fits          bool    `json:"fits"`
+}
+
+// YieldPlanResponse represents the result of planning towards a weekly yield goal
+type YieldPlanResponse struct {
+    GardenID       string            `json:"gardenId"`
+    CropName       string            `json:"cropName"`
+    WeeklyTarget   float64           `json:"weeklyTarget"`
+    Feasible       bool              `json:"feasible"`
+    AvailableSpace float64           `json:"availableSpace"`
+    Recommended    *YieldPlanOption  `json:"recommended,omitempty"`
+    Options        []YieldPlanOption `json:"options"`
+    Message        string            `json:"message,omitempty"`
+}
+
+// Harvest goal units and periods
+const (
+    YieldUnitGrams     = "g"
+    YieldUnitKilograms = "kg"
+    YieldPeriodWeek    = "week"
+    YieldPeriodMonth   = "month"
+)
+
+// TargetYieldRequest represents the request payload for setting a crop's harvest goal
+type TargetYieldRequest struct {
+    Quantity float64 `json:"quantity" validate:"required,gt=0"`
+    Unit     string  `json:"unit" validate:"required,oneof=g kg"`
+    Period   string  `json:"period" validate:"required,oneof=week month"`
+}
+
+// HarvestRequest represents the request payload for logging a harvest from a crop
+type HarvestRequest struct {
+    Quantity    float64    `json:"quantity" validate:"required,gt=0"`
+    Unit        string     `json:"unit" validate:"required,oneof=g kg"`
+    HarvestedAt *time.Time `json:"harvestedAt,omitempty"` // Defaults to now
+}
+
+// TargetYieldProgress reports harvests logged over the goal's most recent period
+type TargetYieldProgress struct {
+    Quantity      float64   `json:"quantity"`
+    Unit          string    `json:"unit"`
+    Period        string    `json:"period"`
+    PeriodStart   time.Time `json:"periodStart"`
+    Harvested     float64   `json:"harvested"`     // In the goal's unit
+    PercentToGoal float64   `json:"percentToGoal"` // May exceed 100 when the goal is beaten
+}
+
+// LayoutPlacement represents the position of one grow bag in a garden layout, in feet
+// from the garden's corner
+type LayoutPlacement struct {
+    CropID   string  `json:"cropId"`
+    CropName string  `json:"cropName"`
+    BagSize  string  `json:"bagSize"`
+    X        float64 `json:"x"`
+    Y        float64 `json:"y"`
+}
+
+// LayoutShortfall represents grow bags of a crop that did not fit in a garden layout
+type LayoutShortfall struct {
+    CropID       string `json:"cropId"`
+    CropName     string `json:"cropName"`
+    BagSize      string `json:"bagSize"`
+    UnplacedBags int    `json:"unplacedBags"`
+}
+
+// GardenLayoutResponse represents the computed grow bag layout for a garden's crops
+type GardenLayoutResponse struct {
+    GardenID         string            `json:"gardenId"`
+    RequiredBags     int               `json:"requiredBags"`
+    PlacedBags       int               `json:"placedBags"`
+    Complete         bool              `json:"complete"`
+    SpaceUtilization float64           `json:"spaceUtilization"`
+    Placements       []LayoutPlacement `json:"placements"`
+    Shortfall        []LayoutShortfall `json:"shortfall,omitempty"`
+    ComputedAt       time.Time         `json:"computedAt"`
+}
+
+// LayoutEfficiencyBreakdown represents the parts of a layout efficiency score, each 0-100
+type LayoutEfficiencyBreakdown struct {
+    SpaceUtilization float64 `json:"spaceUtilization"` // Bag coverage relative to the densest layout
+    Accessibility    float64 `json:"accessibility"`    // Share of bags placed facing a maintenance path
+    Compatibility    float64 `json:"compatibility"`    // Sunlight suitability adjusted for companion crops
+}
+
+// LayoutEfficiencyResponse represents a 0-100 score of how good a garden's layout is,
+// with the parts behind it
+type LayoutEfficiencyResponse struct {
+    GardenID   string                    `json:"gardenId"`
+    Score      float64                   `json:"score"`
+    Breakdown  LayoutEfficiencyBreakdown `json:"breakdown"`
+    ComputedAt time.Time                 `json:"computedAt"`
+}
+
+// Crop recommendation sources
+const (
+    RecommendationSourceAI    = "ai"
+    RecommendationSourceRules = "rules"
+)
+
+// CropRecommendation describes a single crop suggested for a garden
+type CropRecommendation struct {
+    Rank                int      `json:"rank"`
+    Name                string   `json:"name"`
+    Score               float64  `json:"score"` // 0-1 suitability for the garden's conditions
+    BagSize             string   `json:"bagSize"`
+    SuggestedGrowBags   int      `json:"suggestedGrowBags"`
+    ExpectedWeeklyYield float64  `json:"expectedWeeklyYield"` // kg per week
+    Reasons             []string `json:"reasons,omitempty"`
+}
+
+// CropRecommendationResponse represents a ranked list of crops suited to a garden
+type CropRecommendationResponse struct {
+    GardenID        string               `json:"gardenId"`
+    Source          string               `json:"source"` // "ai" or "rules"
+    Recommendations []CropRecommendation `json:"recommendations"`
+}
+
+// GardenRecommendationsResponse represents one consolidated set of tips covering all of
+// a garden's crops
+type GardenRecommendationsResponse struct {
+    GardenID        string   `json:"gardenId"`
+    Source          string   `json:"source"` // "ai" or "rules"
+    Crops           []string `json:"crops"`
+    Recommendations []string `json:"recommendations"`
+}
+
+// RemainingSpaceSuggestion is a crop, bag size, and number of bags that fits a garden's
+// free space
+type RemainingSpaceSuggestion struct {
+    Rank                int     `json:"rank"`
+    Name                string  `json:"name"`
+    BagSize             string  `json:"bagSize"`
+    GrowBags            int     `json:"growBags"`
+    SpaceRequired       float64 `json:"spaceRequired"`       // sq ft
+    ExpectedWeeklyYield float64 `json:"expectedWeeklyYield"` // kg per week
+}
+
+// RemainingSpaceSuggestionResponse ranks crops by the yield they would add in a garden's
+// free space
+type RemainingSpaceSuggestionResponse struct {
+    GardenID       string                     `json:"gardenId"`
+    AvailableSpace float64                    `json:"availableSpace"` // sq ft
+    Suggestions    []RemainingSpaceSuggestion `json:"suggestions"`
+    Message        string                     `json:"message,omitempty"`
+}
+
+// CropRemovalPreviewResponse describes what removing a crop would free up and cost,
+// without removing it
+type CropRemovalPreviewResponse struct {
+    CropID               string  `json:"cropId"`
+    GardenID             string  `json:"gardenId"`
+    CropName             string  `json:"cropName"`
+    FreedSpace           float64 `json:"freedSpace"`           // sq ft
+    CurrentUtilization   float64 `json:"currentUtilization"`   // percent
+    ResultingUtilization float64 `json:"resultingUtilization"` // percent
+    LostDailyYield       float64 `json:"lostDailyYield"`       // kg/day
+}
+
+// UtilizationSnapshot describes how full a garden was right after its crops changed
+type UtilizationSnapshot struct {
+    Trigger     string    `json:"trigger"`          // "crop_created", "crop_deleted", or "crops_imported"
+    CropID      string    `json:"cropId,omitempty"` // Empty for imports
+    CropCount   int       `json:"cropCount"`
+    UsedSpace   float64   `json:"usedSpace"`   // sq ft
+    TotalSpace  float64   `json:"totalSpace"`  // sq ft
+    Utilization float64   `json:"utilization"` // percent, adjusted for soil efficiency
+    RecordedAt  time.Time `json:"recordedAt"`
+}
+
+// CapacityThresholdEvent is the webhook payload sent when creating or deleting crops moves
+// a garden's space utilization across a configured threshold
+type CapacityThresholdEvent struct {
+    GardenID            string    `json:"gardenId"`
+    Threshold           float64   `json:"threshold"`           // percent
+    Direction           string    `json:"direction"`           // "rising" or "falling"
+    Trigger             string    `json:"trigger"`             // "crop_created", "crop_deleted", "crops_imported", or "snapshot_restored"
+    CropID              string    `json:"cropId,omitempty"`    // Empty for imports and restores
+    PreviousUtilization float64   `json:"previousUtilization"` // percent
+    Utilization         float64   `json:"utilization"`         // percent
+    OccurredAt          time.Time `json:"occurredAt"`
+}
+
+// UtilizationHistoryResponse lists a garden's utilization snapshots in chronological order
+type UtilizationHistoryResponse struct {
+    GardenID  string                `json:"gardenId"`
+    From      *time.Time            `json:"from,omitempty"`
+    To        *time.Time            `json:"to,omitempty"`
+    Snapshots []UtilizationSnapshot `json:"snapshots"`
+}
+
+// Crop import row statuses
+const (
+    ImportRowCreated = "created" // The row's crop was created
+    ImportRowValid   = "valid"   // The row passed validation but the import was rejected as a whole
+    ImportRowInvalid = "invalid" // The row failed validation
+)
+
+// CropImportRowResult reports the outcome of one CSV row of a crop import
+type CropImportRowResult struct {
+    Row    int           `json:"row"` // Line number in the CSV, the header being line 1
+    Name   string        `json:"name,omitempty"`
+    Status string        `json:"status"`
+    Crop   *CropResponse `json:"crop,omitempty"`
+    Errors []string      `json:"errors,omitempty"`
+}
+
+// CropImportResponse represents the result of bulk-importing crops from CSV. Imports
+// are all-or-nothing: any invalid row or a space shortfall rejects every row.
+type CropImportResponse struct {
+    GardenID        string                   `json:"gardenId"`
+    Imported        bool                     `json:"imported"`
+    CreatedCount    int                      `json:"createdCount"`
+    InvalidCount    int                      `json:"invalidCount"`
+    SpaceValidation *SpaceValidationResponse `json:"spaceValidation,omitempty"`
+    Message         string                   `json:"message,omitempty"`
+    Rows            []CropImportRowResult    `json:"rows"`
+}
+
+// ValidateCropRequest performs comprehensive validation of the crop request,
+// reporting every failing field as common.ValidationErrors
 func ValidateCropRequest(req *CropRequest) error {
     if req == nil {
         return &common.ValidationError{
@@ -63,38 +378,50 @@ func ValidateCropRequest(req *CropRequest) error {
         }
     }
 
-    // Initialize validator
+    // Initialize validator reporting JSON field names
     validate := validator.New()
+    validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+        name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+        if name == "-" {
+            return ""
+        }
+        return name
+    })
+
+    var errs common.ValidationErrors
 
     // Perform struct validation
     if err := validate.Struct(req); err != nil {
-        if validationErrors, ok := err.(validator.ValidationErrors); ok {
-            return &common.ValidationError{
-                Field:   validationErrors[0].Field(),
-                Message: fmt.Sprintf("validation failed for field %s", validationErrors[0].Field()),
-                Value:   validationErrors[0].Value().(string),
-                Err:     err,
-            }
+        validationErrors, ok := err.(validator.ValidationErrors)
+        if !ok {
+            return err
+        }
+        for _, fieldErr := range validationErrors {
+            errs = append(errs, &common.ValidationError{
+                Field:   fieldErr.Field(),
+                Message: fmt.Sprintf("failed %q validation", fieldErr.Tag()),
+                Value:   fmt.Sprintf("%v", fieldErr.Value()),
+                Err:     fieldErr,
+            })
         }
-        return err
     }
 
     // Validate quantity needed
-    if req.QuantityNeeded < MinQuantityNeeded || req.QuantityNeeded > MaxQuantityNeeded {
-        return &common.ValidationError{
+    if !errs.HasField("quantityNeeded") && (req.QuantityNeeded < MinQuantityNeeded || req.QuantityNeeded > MaxQuantityNeeded) {
+        errs = append(errs, &common.ValidationError{
             Field:   "quantityNeeded",
             Message: fmt.Sprintf("quantity must be between %d and %d", MinQuantityNeeded, MaxQuantityNeeded),
             Value:   fmt.Sprintf("%d", req.QuantityNeeded),
-        }
+        })
     }
 
     // Validate grow bags
-    if req.GrowBags < MinGrowBags || req.GrowBags > MaxGrowBags {
-        return &common.ValidationError{
+    if !errs.HasField("growBags") && (req.GrowBags < MinGrowBags || req.GrowBags > MaxGrowBags) {
+        errs = append(errs, &common.ValidationError{
             Field:   "growBags",
             Message: fmt.Sprintf("grow bags must be between %d and %d", MinGrowBags, MaxGrowBags),
             Value:   fmt.Sprintf("%d", req.GrowBags),
-        }
+        })
     }
 
     // Validate bag size
@@ -105,12 +432,16 @@ func ValidateCropRequest(req *CropRequest) error {
             break
         }
     }
-    if !validBagSize {
-        return &common.ValidationError{
+    if !validBagSize && !errs.HasField("bagSize") {
+        errs = append(errs, &common.ValidationError{
             Field:   "bagSize",
             Message: "invalid bag size",
             Value:   req.BagSize,
-        }
+        })
+    }
+
+    if len(errs) > 0 {
+        return errs
     }
 
     // Calculate space requirements based on bag size and count
@@ -141,20 +472,43 @@ func ValidateCropRequest(req *CropRequest) error {
     return nil
 }
 
+// NormalizeCropName resolves a crop name to its canonical yield table key, ignoring case,
+// surrounding whitespace, plurals, and common synonyms such as "aubergine". Names that
+// match no known crop are returned trimmed but otherwise unchanged.
+func NormalizeCropName(name string) string {
+    trimmed := strings.Join(strings.Fields(name), " ")
+    key := strings.ToLower(trimmed)
+
+    if canonical, ok := cropNameAliases[key]; ok {
+        return canonical
+    }
+    // Try singular forms, e.g. "tomatoes" -> "tomato" and "peppers" -> "pepper"
+    for _, suffix := range []string{"es", "s"} {
+        if !strings.HasSuffix(key, suffix) {
+            continue
+        }
+        if canonical, ok := cropNameAliases[strings.TrimSuffix(key, suffix)]; ok {
+            return canonical
+        }
+    }
+
+    return trimmed
+}
+
 // calculateEstimatedYield calculates the estimated yield for a crop
 func calculateEstimatedYield(name string, growBags int, bagSize string) float64 {
     // Yield calculations based on the grow bag size reference table from technical specifications
     baseYield := 0.0
-    switch name {
-    case "Tomatoes":
+    switch NormalizeCropName(name) {
+    case CropTomatoes:
         baseYield = 0.225 // 200-250g per day average
-    case "Spinach":
+    case CropSpinach:
         baseYield = 0.125 // 100-150g per day average
-    case "Lettuce":
+    case CropLettuce:
         baseYield = 0.175 // 150-200g per day average
-    case "Peppers":
+    case CropPeppers:
         baseYield = 0.125 // 100-150g per day average
-    case "Eggplant":
+    case CropEggplant:
         baseYield = 0.225 // 200-250g per day average
     default:
         baseYield = 0.150 // Default conservative estimate
@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10" // v10.11.0
+	"github.com/urban-gardening/backend/pkg/types"
+)
+
+// Light level constants
+const (
+	LightLevelLow    = "low"
+	LightLevelMedium = "medium"
+	LightLevelHigh   = "high"
+)
+
+// EnvironmentReadingRequest represents the DTO for pushing sensor readings for a garden
+type EnvironmentReadingRequest struct {
+	Temperature *float64   `json:"temperature" validate:"required,gte=-30,lte=60"` // Celsius
+	Humidity    *float64   `json:"humidity" validate:"required,gte=0,lte=100"`     // Relative humidity percentage
+	LightLevel  string     `json:"lightLevel" validate:"required,oneof=low medium high"`
+	RecordedAt  *time.Time `json:"recordedAt,omitempty"` // Defaults to the time the reading is received
+}
+
+// EnvironmentReadingResponse represents the DTO for a stored garden sensor reading
+type EnvironmentReadingResponse struct {
+	ID          string    `json:"id"`
+	GardenID    string    `json:"gardenId"`
+	Temperature float64   `json:"temperature"`
+	Humidity    float64   `json:"humidity"`
+	LightLevel  string    `json:"lightLevel"`
+	RecordedAt  time.Time `json:"recordedAt"`
+}
+
+// Validate performs validation of the environment reading request
+func (r *EnvironmentReadingRequest) Validate() error {
+	if err := validator.New().Struct(r); err != nil {
+		return &types.ValidationError{
+			Field:   "request",
+			Message: "invalid environment reading",
+			Err:     err,
+		}
+	}
+
+	if r.RecordedAt != nil && r.RecordedAt.After(time.Now()) {
+		return &types.ValidationError{
+			Field:   "recordedAt",
+			Message: "reading time cannot be in the future",
+			Value:   r.RecordedAt.Format(time.RFC3339),
+		}
+	}
+
+	return nil
+}
+
+// EnvironmentalFactors converts the reading into the factor map used by schedule calculations
+func (r *EnvironmentReadingResponse) EnvironmentalFactors() map[string]interface{} {
+	return map[string]interface{}{
+		"temperature": r.Temperature,
+		"humidity":    r.Humidity,
+		"lightLevel":  r.LightLevel,
+		"source":      "sensor",
+		"recordedAt":  r.RecordedAt,
+	}
+}
@@ -0,0 +1,17 @@
+// Package dto provides Data Transfer Objects for the Urban Gardening Assistant application
+package dto
+
+// ErrorResponse represents the standard error payload returned by API handlers
+type ErrorResponse struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Error   string       `json:"error,omitempty"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+// FieldError describes a single failing field within a validation error response
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	Value   string `json:"value,omitempty"`
+}
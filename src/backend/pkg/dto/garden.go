@@ -2,6 +2,7 @@
 package dto
 
 import (
+	"fmt"
 	"github.com/go-playground/validator/v10" // v10.11.0
 	"time"
 
@@ -14,6 +15,15 @@ type CreateGardenRequest struct {
 	Dimensions common.Dimensions `json:"dimensions" validate:"required"`
 	SoilType   string          `json:"soil_type" validate:"required"`
 	Sunlight   string          `json:"sunlight" validate:"required"`
+	Environment string         `json:"environment,omitempty"` // Outdoor, Indoor, or Greenhouse; defaults to Outdoor
+
+	// SoilEfficiencyOverride sets the soil efficiency factor directly instead of deriving it
+	// from the soil type
+	SoilEfficiencyOverride *float64 `json:"soil_efficiency_override,omitempty"`
+
+	// Latitude and Longitude locate the garden in decimal degrees; give both or neither
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
 }
 
 // Validate performs comprehensive validation of the garden creation request
@@ -75,7 +85,21 @@ func (r *CreateGardenRequest) Validate() error {
 		}
 	}
 
-	return nil
+
+	// Validate growing environment if provided
+	if r.Environment != "" && !isValidEnvironment(r.Environment) {
+		return &common.ValidationError{
+			Field:   "environment",
+			Message: "invalid growing environment",
+			Value:   r.Environment,
+		}
+	}
+
+	if err := validateSoilEfficiencyOverride(r.SoilEfficiencyOverride); err != nil {
+		return err
+	}
+
+	return ValidateCoordinates(r.Latitude, r.Longitude)
 }
 
 // UpdateGardenRequest represents the DTO for garden updates with optional fields
@@ -83,6 +107,15 @@ type UpdateGardenRequest struct {
 	Dimensions *common.Dimensions `json:"dimensions,omitempty"`
 	SoilType   *string          `json:"soil_type,omitempty"`
 	Sunlight   *string          `json:"sunlight,omitempty"`
+	Environment *string         `json:"environment,omitempty"`
+
+	// SoilEfficiencyOverride sets the soil efficiency factor directly instead of deriving it
+	// from the soil type
+	SoilEfficiencyOverride *float64 `json:"soil_efficiency_override,omitempty"`
+
+	// Latitude and Longitude locate the garden in decimal degrees; give both or neither
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
 }
 
 // Validate performs validation of the garden update request
@@ -150,9 +183,77 @@ func (r *UpdateGardenRequest) Validate() error {
 		}
 	}
 
+
+	// Validate growing environment if provided
+	if r.Environment != nil && !isValidEnvironment(*r.Environment) {
+		return &common.ValidationError{
+			Field:   "environment",
+			Message: "invalid growing environment",
+			Value:   *r.Environment,
+		}
+	}
+
+	if err := validateSoilEfficiencyOverride(r.SoilEfficiencyOverride); err != nil {
+		return err
+	}
+
+	return ValidateCoordinates(r.Latitude, r.Longitude)
+}
+
+// validateSoilEfficiencyOverride checks an optional soil efficiency override is within bounds
+func validateSoilEfficiencyOverride(override *float64) error {
+	if override == nil {
+		return nil
+	}
+	if *override < garden.MinSoilEfficiencyOverride || *override > garden.MaxSoilEfficiencyOverride {
+		return &common.ValidationError{
+			Field:   "soil_efficiency_override",
+			Message: fmt.Sprintf("soil efficiency override must be between %.1f and %.1f", garden.MinSoilEfficiencyOverride, garden.MaxSoilEfficiencyOverride),
+			Value:   fmt.Sprintf("%v", *override),
+		}
+	}
+	return nil
+}
+
+// ValidateCoordinates checks that an optional latitude and longitude are given together
+// and lie within their valid ranges
+func ValidateCoordinates(latitude, longitude *float64) error {
+	if (latitude == nil) != (longitude == nil) {
+		return &common.ValidationError{
+			Field:   "coordinates",
+			Message: "latitude and longitude must be given together",
+		}
+	}
+	if latitude == nil {
+		return nil
+	}
+	if *latitude < garden.MinLatitude || *latitude > garden.MaxLatitude {
+		return &common.ValidationError{
+			Field:   "latitude",
+			Message: fmt.Sprintf("latitude must be between %.0f and %.0f", garden.MinLatitude, garden.MaxLatitude),
+			Value:   fmt.Sprintf("%v", *latitude),
+		}
+	}
+	if *longitude < garden.MinLongitude || *longitude > garden.MaxLongitude {
+		return &common.ValidationError{
+			Field:   "longitude",
+			Message: fmt.Sprintf("longitude must be between %.0f and %.0f", garden.MinLongitude, garden.MaxLongitude),
+			Value:   fmt.Sprintf("%v", *longitude),
+		}
+	}
 	return nil
 }
 
+// isValidEnvironment reports whether environment is a supported growing environment
+func isValidEnvironment(environment string) bool {
+	for _, valid := range garden.ValidEnvironments() {
+		if environment == valid {
+			return true
+		}
+	}
+	return false
+}
+
 // GardenResponse represents the DTO for garden API responses
 type GardenResponse struct {
 	ID        string           `json:"id"`
@@ -160,6 +261,45 @@ type GardenResponse struct {
 	Dimensions common.Dimensions `json:"dimensions"`
 	SoilType   string          `json:"soil_type"`
 	Sunlight   string          `json:"sunlight"`
+	Environment string         `json:"environment"`
+	SoilEfficiencyOverride *float64 `json:"soil_efficiency_override,omitempty"`
+	Latitude   *float64        `json:"latitude,omitempty"`
+	Longitude  *float64        `json:"longitude,omitempty"`
 	CreatedAt  time.Time       `json:"created_at"`
 	UpdatedAt  time.Time       `json:"updated_at"`
-}
\ No newline at end of file
+}
+
+// PathAreaRequest represents the DTO for calculating the space taken by maintenance paths
+type PathAreaRequest struct {
+	Dimensions          common.Dimensions `json:"dimensions" validate:"required"`
+	IncludeCornerSpaces bool              `json:"include_corner_spaces"` // Reserve turning space in each corner
+}
+
+// PathAreaResponse represents the DTO for path area results, in square feet
+type PathAreaResponse struct {
+	TotalArea  float64 `json:"total_area"`
+	PathArea   float64 `json:"path_area"`
+	UsableArea float64 `json:"usable_area"`
+	PathRatio  float64 `json:"path_ratio"` // Fraction of the total area taken by paths
+}
+
+// CompareLayoutsRequest represents the DTO for comparing grow bag layouts across bag sizes
+type CompareLayoutsRequest struct {
+	Dimensions common.Dimensions `json:"dimensions" validate:"required"`
+	BagSizes   []float64         `json:"bag_sizes" validate:"required,min=1"` // Bag diameters in inches
+}
+
+// LayoutComparisonResponse represents the best layout for one bag size
+type LayoutComparisonResponse struct {
+	BagSize            float64 `json:"bag_size"` // Bag diameter in inches
+	Viable             bool    `json:"viable"`   // False when no layout meets the accessibility minimum
+	Capacity           int     `json:"capacity"`
+	SpaceUtilization   float64 `json:"space_utilization"`
+	AccessibilityScore float64 `json:"accessibility_score"`
+	ViabilityWarning   string  `json:"viability_warning,omitempty"` // Set when a non-viable layout is still reported
+}
+
+// CompareLayoutsResponse represents the DTO for layout comparison results, in request order
+type CompareLayoutsResponse struct {
+	Layouts []LayoutComparisonResponse `json:"layouts"`
+}
@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10" // v10.11.0
+	"github.com/urban-gardening/backend/pkg/types"
+)
+
+// Incident severity constants
+const (
+	IncidentSeverityLow    = "low"
+	IncidentSeverityMedium = "medium"
+	IncidentSeverityHigh   = "high"
+)
+
+// PestIncidentRequest represents the DTO for recording or updating a pest or disease
+// incident on a crop
+type PestIncidentRequest struct {
+	Type             string     `json:"type" validate:"required,max=100"` // e.g. aphids, powdery mildew
+	Severity         string     `json:"severity" validate:"required,oneof=low medium high"`
+	OccurredAt       *time.Time `json:"occurredAt,omitempty"` // Defaults to the time the incident is recorded
+	Notes            string     `json:"notes,omitempty" validate:"max=2000"`
+	Treatment        string     `json:"treatment,omitempty" validate:"max=255"`
+	RaisePestControl bool       `json:"raisePestControl"` // Raise the crop's suggested pest control frequency while the incident is recent
+}
+
+// PestIncidentResponse represents the DTO for a stored pest or disease incident
+type PestIncidentResponse struct {
+	ID               string    `json:"id"`
+	CropID           string    `json:"cropId"`
+	Type             string    `json:"type"`
+	Severity         string    `json:"severity"`
+	OccurredAt       time.Time `json:"occurredAt"`
+	Notes            string    `json:"notes,omitempty"`
+	Treatment        string    `json:"treatment,omitempty"`
+	RaisePestControl bool      `json:"raisePestControl"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+// Validate performs validation of the pest incident request
+func (r *PestIncidentRequest) Validate() error {
+	if err := validator.New().Struct(r); err != nil {
+		return &types.ValidationError{
+			Field:   "request",
+			Message: "invalid pest incident",
+			Err:     err,
+		}
+	}
+
+	if r.OccurredAt != nil && r.OccurredAt.After(time.Now()) {
+		return &types.ValidationError{
+			Field:   "occurredAt",
+			Message: "incident time cannot be in the future",
+			Value:   r.OccurredAt.Format(time.RFC3339),
+		}
+	}
+
+	return nil
+}
@@ -1,7 +1,10 @@
 package dto
 
 import (
+	"fmt"
+	"reflect"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10" // v10.11.0
@@ -15,6 +18,7 @@ const (
 	TaskTypeComposting  = "Composting"
 	TaskTypePruning     = "Pruning"
 	TaskTypePestControl = "Pest Control"
+	TaskTypeRepot       = "Re-pot" // One-off reminder to move a crop into a larger grow bag
 )
 
 // Frequency constants
@@ -24,8 +28,21 @@ const (
 	FrequencyWeekly     = "Weekly"
 	FrequencyBiWeekly   = "Bi-weekly"
 	FrequencyMonthly    = "Monthly"
+	FrequencyOnce       = "Once" // One-off tasks, which are not rescheduled once completed
 )
 
+// DefaultTaskFrequencies returns how often each task type is scheduled when a request
+// omits its frequency
+func DefaultTaskFrequencies() map[string]string {
+	return map[string]string{
+		TaskTypeWater:       FrequencyDaily,
+		TaskTypeFertilizer:  FrequencyWeekly,
+		TaskTypeComposting:  FrequencyMonthly,
+		TaskTypePruning:     FrequencyBiWeekly,
+		TaskTypePestControl: FrequencyWeekly,
+	}
+}
+
 // Environment constants
 const (
 	EnvironmentIndoor     = "Indoor"
@@ -33,21 +50,75 @@ const (
 	EnvironmentGreenhouse = "Greenhouse"
 )
 
-// Validation constants
+// Growing method constants, sent to the AI as the growingMethod garden condition
+const (
+	GrowingMethodOrganic      = "organic"
+	GrowingMethodConventional = "conventional"
+)
+
+// Growth stage constants
+const (
+	GrowthStageSeedling   = "Seedling"
+	GrowthStageVegetative = "Vegetative"
+	GrowthStageFlowering  = "Flowering"
+	GrowthStageFruiting   = "Fruiting"
+)
+
+// Growth rate constants, which set how soon a crop outgrows its grow bag
 const (
-	minAmount     = 0.1
-	maxWaterML    = 2000.0
-	maxFertilizeG = 500.0
-	maxCompostG   = 1000.0
+	GrowthRateFast   = "fast"
+	GrowthRateMedium = "medium"
+	GrowthRateSlow   = "slow"
 )
 
+// Amount limits per task type, in the task type's unit. Stored maintenance tasks are
+// held to the same limits, so requests and models share them through AmountBounds.
+const (
+	minWaterML       = 50.0
+	maxWaterML       = 2000.0
+	minFertilizeG    = 10.0
+	maxFertilizeG    = 500.0
+	minCompostG      = 50.0
+	maxCompostG      = 1000.0
+	minPestControlML = 10.0
+	maxPestControlML = 200.0
+)
+
+// AmountBounds returns the smallest and largest amount accepted for a task type, and
+// whether the task type's amount is bounded
+func AmountBounds(taskType string) (float64, float64, bool) {
+	switch taskType {
+	case TaskTypeWater:
+		return minWaterML, maxWaterML, true
+	case TaskTypeFertilizer:
+		return minFertilizeG, maxFertilizeG, true
+	case TaskTypeComposting:
+		return minCompostG, maxCompostG, true
+	case TaskTypePestControl:
+		return minPestControlML, maxPestControlML, true
+	}
+	return 0, 0, false
+}
+
+// AmountAdjustment reports a task whose amount differs from the one requested because
+// of an AI recommendation: either the recommendation was applied, or it fell outside the
+// bounds for its task type and Policy records what was applied instead
+type AmountAdjustment struct {
+	RequestedAmount   float64 `json:"requestedAmount"`
+	RecommendedAmount float64 `json:"recommendedAmount"`
+	AppliedAmount     float64 `json:"appliedAmount"`
+	MinAmount         float64 `json:"minAmount"`
+	MaxAmount         float64 `json:"maxAmount"`
+	Policy            string  `json:"policy,omitempty"` // clamp or discard; set only for out-of-bounds recommendations
+}
+
 // MaintenanceRequest represents the DTO for creating or updating maintenance tasks
 type MaintenanceRequest struct {
 	CropID              string                 `json:"cropId" validate:"required,uuid"`
-	TaskType            string                 `json:"taskType" validate:"required,oneof=Fertilizer Water Composting Pruning 'Pest Control'"`
-	Frequency           string                 `json:"frequency" validate:"required,oneof=Daily Twice-Daily Weekly Bi-weekly Monthly"`
-	Amount              float64                `json:"amount" validate:"required,gt=0"`
-	Unit                string                 `json:"unit" validate:"required,oneof=ml g"`
+	TaskType            string                 `json:"taskType" validate:"required,oneof=Fertilizer Water Composting Pruning 'Pest Control' Re-pot"`
+	Frequency           string                 `json:"frequency" validate:"omitempty,oneof=Daily Twice-Daily Weekly Bi-weekly Monthly Once"` // Defaults by task type when omitted
+	Amount              float64                `json:"amount" validate:"gte=0"` // Bounded per task type; see AmountBounds
+	Unit                string                 `json:"unit" validate:"required,oneof=ml g n/a"`
 	PreferredTime       string                 `json:"preferredTime" validate:"required,datetime=15:04"`
 	AIRecommended       bool                   `json:"aiRecommended"`
 	SoilType           string                 `json:"soilType" validate:"required,oneof=Red Sandy Loamy Clay Black"`
@@ -75,6 +146,434 @@ type MaintenanceResponse struct {
 	CreatedAt             time.Time              `json:"createdAt"`
 	UpdatedAt             time.Time              `json:"updatedAt"`
 	LastModifiedAt        time.Time              `json:"lastModifiedAt"`
+	CurrentBagSize        string                 `json:"currentBagSize,omitempty"`   // Re-pot tasks only
+	SuggestedBagSize      string                 `json:"suggestedBagSize,omitempty"` // Re-pot tasks only
+	Stale                 bool                   `json:"stale,omitempty"` // Served from cache because the database was unavailable
+	AmountAdjustment      *AmountAdjustment      `json:"amountAdjustment,omitempty"` // Set when the AI's amount was out of bounds at creation
+}
+
+// SchedulePreviewResponse represents the DTO for when a proposed maintenance schedule
+// would first fire, computed without creating the task
+type SchedulePreviewResponse struct {
+	CropID            string                 `json:"cropId"`
+	TaskType          string                 `json:"taskType"`
+	Frequency         string                 `json:"frequency"`
+	PreferredTime     string                 `json:"preferredTime"`
+	NextScheduledTime time.Time              `json:"nextScheduledTime"`
+	AIRecommended     bool                   `json:"aiRecommended"`
+	AISuggestions     map[string]interface{} `json:"aiSuggestions,omitempty"` // The AI schedule applied, when one was available
+	Amount            float64                `json:"amount"`
+	AmountAdjustment  *AmountAdjustment      `json:"amountAdjustment,omitempty"` // Set when the AI's amount was out of bounds
+}
+
+// MaintenanceBatchRequest represents the DTO for creating several maintenance tasks at once
+type MaintenanceBatchRequest struct {
+	Requests []*MaintenanceRequest `json:"requests" validate:"required,min=1"`
+}
+
+// MaintenanceBatchResult represents the outcome of a single request within a batch
+type MaintenanceBatchResult struct {
+	Index    int                  `json:"index"`
+	Schedule *MaintenanceResponse `json:"schedule,omitempty"`
+	Error    string               `json:"error,omitempty"`
+}
+
+// MaintenanceBatchResponse represents the DTO for batch maintenance task creation results
+type MaintenanceBatchResponse struct {
+	Results   []MaintenanceBatchResult `json:"results"`
+	Succeeded int                      `json:"succeeded"`
+	Failed    int                      `json:"failed"`
+}
+
+// CompleteDueResult represents the outcome of completing a single due task
+type CompleteDueResult struct {
+	TaskID string               `json:"taskId"`
+	Task   *MaintenanceResponse `json:"task,omitempty"`
+	Error  string               `json:"error,omitempty"`
+}
+
+// CompleteDueResponse represents the DTO reporting progress through a garden's due tasks.
+// Tasks not started before the request was cancelled count as remaining.
+type CompleteDueResponse struct {
+	GardenID       string              `json:"gardenId"`
+	Total          int                 `json:"total"`
+	Completed      int                 `json:"completed"`
+	Failed         int                 `json:"failed"`
+	Remaining      int                 `json:"remaining"`
+	MaxConcurrency int                 `json:"maxConcurrency"` // Configured limit on tasks completed at once
+	Results        []CompleteDueResult `json:"results"`
+}
+
+// GardenSnapshotRequest represents the optional payload for taking a garden snapshot
+type GardenSnapshotRequest struct {
+	Label string `json:"label,omitempty" validate:"omitempty,max=100"` // e.g. "before trying peppers"
+}
+
+// GardenSnapshotResponse represents the DTO for a saved snapshot of a garden's crops and schedules
+type GardenSnapshotResponse struct {
+	ID            string    `json:"id"`
+	GardenID      string    `json:"gardenId"`
+	Label         string    `json:"label,omitempty"`
+	CropCount     int       `json:"cropCount"`
+	ScheduleCount int       `json:"scheduleCount"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// SnapshotRestoreResponse represents the DTO reporting a garden restored to a snapshot
+type SnapshotRestoreResponse struct {
+	Snapshot              GardenSnapshotResponse `json:"snapshot"`
+	Schedules             []*MaintenanceResponse `json:"schedules"`             // The garden's schedules as restored
+	RemovedScheduleIDs    []string               `json:"removedScheduleIds"`    // Schedules created after the snapshot, now deleted
+	UnnotifiedScheduleIDs []string               `json:"unnotifiedScheduleIds"` // Restored schedules over the notification limit
+}
+
+// MaintenanceMultiResponse represents the DTO for fetching several maintenance tasks by ID
+type MaintenanceMultiResponse struct {
+	Schedules []*MaintenanceResponse `json:"schedules"` // Found tasks, in the order requested
+	Missing   []string               `json:"missing"`   // Requested IDs with no matching task
+}
+
+// CompleteTaskRequest represents the optional payload for completing a maintenance task
+type CompleteTaskRequest struct {
+	CompletedAt    *time.Time `json:"completedAt,omitempty"`    // Defaults to now; may be backdated
+	IdempotencyKey string     `json:"idempotencyKey,omitempty"` // Repeats of a key complete the task once
+}
+
+// ScheduleChangeEvent represents the DTO for a single entry in a crop's schedule history
+type ScheduleChangeEvent struct {
+	ID            string    `json:"id"`
+	ScheduleID    string    `json:"scheduleId"`
+	CropID        string    `json:"cropId"`
+	EventType     string    `json:"eventType"` // "created", "updated", "deleted", "restored", or "completed"
+	TaskType      string    `json:"taskType"`
+	Frequency     string    `json:"frequency"`
+	Amount        float64   `json:"amount"`
+	Unit          string    `json:"unit"`
+	PreferredTime string    `json:"preferredTime"`
+	OccurredAt    time.Time `json:"occurredAt"`
+}
+
+// Frequency change directions reported by a frequency suggestion
+const (
+	FrequencyChangeNone         = "none"
+	FrequencyChangeLessFrequent = "less_frequent"
+	FrequencyChangeMoreFrequent = "more_frequent"
+)
+
+// FrequencySuggestionResponse represents the DTO for a frequency change recommended from
+// how a task has actually been completed
+type FrequencySuggestionResponse struct {
+	TaskID                 string  `json:"taskId"`
+	TaskType               string  `json:"taskType"`
+	CurrentFrequency       string  `json:"currentFrequency"`
+	SuggestedFrequency     string  `json:"suggestedFrequency"` // Equal to the current frequency when no change is suggested
+	Change                 string  `json:"change"`             // "none", "less_frequent", or "more_frequent"
+	CompletionsAnalyzed    int     `json:"completionsAnalyzed"`
+	MedianIntervalHours    float64 `json:"medianIntervalHours"` // Typical time between completions; 0 without enough history
+	ScheduledIntervalHours float64 `json:"scheduledIntervalHours"`
+	Reason                 string  `json:"reason"`
+}
+
+// StaleScheduleFactors represents the criteria for flagging AI-recommended schedules as
+// out of date relative to a garden's latest environment reading
+type StaleScheduleFactors struct {
+	Threshold time.Duration `json:"threshold"`           // Minimum age of the recommendation relative to the reading
+	TaskTypes []string      `json:"taskTypes,omitempty"` // Limits the check to these task types; empty checks all
+}
+
+// FertilizerRecommendation represents the DTO for a suggested fertilizer schedule
+// matched to a crop's current growth stage
+type FertilizerRecommendation struct {
+	CropID            string  `json:"cropId"`
+	CropName          string  `json:"cropName"`
+	GrowthStage       string  `json:"growthStage"`
+	DaysSincePlanting int     `json:"daysSincePlanting"`
+	Frequency         string  `json:"frequency"`
+	Amount            float64 `json:"amount"`
+	Unit              string  `json:"unit"`
+	Rationale         string  `json:"rationale"`
+	GrowingMethod     string  `json:"growingMethod"` // organic or conventional
+	Fertilizer        string  `json:"fertilizer"`    // Suggested fertilizer suited to the stage and growing method
+}
+
+// ApplyTo sets the fertilizer task fields of a maintenance request from the
+// recommendation, so it can be created through the normal schedule flow
+func (r *FertilizerRecommendation) ApplyTo(req *MaintenanceRequest) {
+	req.CropID = r.CropID
+	req.TaskType = TaskTypeFertilizer
+	req.Frequency = r.Frequency
+	req.Amount = r.Amount
+	req.Unit = r.Unit
+}
+
+// RepotReminder represents the DTO for a scheduled re-pot reminder, with the grow bag
+// the crop should be moved into
+type RepotReminder struct {
+	Task              *MaintenanceResponse `json:"task"`
+	CropID            string               `json:"cropId"`
+	CropName          string               `json:"cropName"`
+	GrowthRate        string               `json:"growthRate"`        // fast, medium, or slow
+	DaysAfterPlanting int                  `json:"daysAfterPlanting"` // Lead time the reminder was scheduled with
+	CurrentBagSize    string               `json:"currentBagSize"`
+	SuggestedBagSize  string               `json:"suggestedBagSize"`
+}
+
+// ScheduleSuggestion represents a suggested maintenance schedule that has not been
+// created yet, so the user can review it before accepting
+type ScheduleSuggestion struct {
+	TaskType      string  `json:"taskType"`
+	Frequency     string  `json:"frequency"`
+	Amount        float64 `json:"amount"`
+	Unit          string  `json:"unit"`
+	PreferredTime string  `json:"preferredTime"`
+	Rationale     string  `json:"rationale"`
+}
+
+// ApplyTo sets the task fields of a maintenance request from the suggestion, so an
+// accepted suggestion can be created through the normal schedule flow
+func (s *ScheduleSuggestion) ApplyTo(req *MaintenanceRequest) {
+	req.TaskType = s.TaskType
+	req.Frequency = s.Frequency
+	req.Amount = s.Amount
+	req.Unit = s.Unit
+	req.PreferredTime = s.PreferredTime
+}
+
+// Validate checks the suggestion's task fields against the rules a maintenance request
+// built from it must pass, so only suggestions that can be accepted are offered
+func (s *ScheduleSuggestion) Validate() error {
+	req := &MaintenanceRequest{}
+	s.ApplyTo(req)
+
+	err := req.ValidateAll()
+	errs, ok := err.(types.ValidationErrors)
+	if !ok {
+		return err
+	}
+	for _, fieldErr := range errs {
+		switch fieldErr.Field {
+		case "taskType", "frequency", "amount", "unit", "preferredTime":
+			return fieldErr
+		}
+	}
+	return nil
+}
+
+// SuggestedSchedulesResponse represents the suggested maintenance schedules for a crop
+type SuggestedSchedulesResponse struct {
+	CropID      string               `json:"cropId"`
+	CropName    string               `json:"cropName"`
+	Source      string               `json:"source"` // "ai" or "rules"
+	Suggestions []ScheduleSuggestion `json:"suggestions"`
+}
+
+// ChecklistItem represents a single task occurrence on a weekly care checklist
+type ChecklistItem struct {
+	ScheduleID string  `json:"scheduleId"`
+	CropID     string  `json:"cropId"`
+	CropName   string  `json:"cropName"`
+	TaskType   string  `json:"taskType"`
+	Amount     float64 `json:"amount"`
+	Unit       string  `json:"unit"`
+	Time       string  `json:"time"`    // HH:MM
+	Overdue    bool    `json:"overdue"` // Was due before the checklist started
+}
+
+// ChecklistDay represents the tasks due on one day of a weekly care checklist
+type ChecklistDay struct {
+	Date    string          `json:"date"` // YYYY-MM-DD
+	Weekday string          `json:"weekday"`
+	Items   []ChecklistItem `json:"items"`
+}
+
+// WeeklyChecklistResponse represents the DTO for a garden's care tasks over the coming week,
+// grouped by day for printing
+type WeeklyChecklistResponse struct {
+	GardenID  string         `json:"gardenId"`
+	StartDate string         `json:"startDate"`
+	EndDate   string         `json:"endDate"`
+	Days      []ChecklistDay `json:"days"`
+}
+
+// TodayGarden represents the tasks due today in one of a user's gardens
+type TodayGarden struct {
+	GardenID string          `json:"gardenId"`
+	Items    []ChecklistItem `json:"items"`
+}
+
+// TodayViewResponse represents the DTO for everything due or overdue today across a
+// user's gardens, grouped by garden
+type TodayViewResponse struct {
+	UserID       string        `json:"userId"`
+	Date         string        `json:"date"` // YYYY-MM-DD
+	TotalCount   int           `json:"totalCount"`
+	OverdueCount int           `json:"overdueCount"`
+	Gardens      []TodayGarden `json:"gardens"`
+}
+
+// TimeRange represents a span of clock times within a day, from Start inclusive to End exclusive
+type TimeRange struct {
+	Start string `json:"start" validate:"required"` // HH:MM
+	End   string `json:"end" validate:"required"`   // HH:MM
+}
+
+// ShiftPreferredTimesRequest represents the DTO for moving a garden's tasks from one part of
+// the day to another, e.g. all morning tasks to the evening
+type ShiftPreferredTimesRequest struct {
+	From   TimeRange `json:"from" validate:"required"`
+	ToTime string    `json:"toTime" validate:"required"` // HH:MM
+}
+
+// ShiftPreferredTimesResponse represents the DTO for the tasks moved by a preferred time shift
+type ShiftPreferredTimesResponse struct {
+	GardenID string                 `json:"gardenId"`
+	ToTime   string                 `json:"toTime"`
+	Shifted  []*MaintenanceResponse `json:"shifted"`
+}
+
+// Daylight hours that outdoor and greenhouse tasks are held to; a preferred time is
+// within them when its hour falls between the two, inclusive
+const (
+	daylightStartHour = 6
+	daylightEndHour   = 18
+)
+
+// PreferredTimeValidationRequest represents the DTO for checking a preferred time before
+// submitting a schedule
+type PreferredTimeValidationRequest struct {
+	Environment   string `json:"environment"`   // Indoor, Outdoor, or Greenhouse; defaults to Outdoor
+	PreferredTime string `json:"preferredTime"` // HH:MM
+}
+
+// PreferredTimeValidationResponse represents the DTO reporting whether a preferred time is
+// allowed for an environment, and the window it must fall within
+type PreferredTimeValidationResponse struct {
+	Environment   string `json:"environment"`
+	PreferredTime string `json:"preferredTime"`
+	Allowed       bool   `json:"allowed"`
+	Reason        string `json:"reason,omitempty"` // Why the time is not allowed
+	WindowStart   string `json:"windowStart"`
+	WindowEnd     string `json:"windowEnd"`
+}
+
+// MaintenanceValidationResponse represents the DTO reporting whether a maintenance request
+// would be accepted, with every failing field when it would not
+type MaintenanceValidationResponse struct {
+	Valid  bool         `json:"valid"`
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// CheckPreferredTime reports whether preferredTime is allowed for a growing environment.
+// Indoor gardens run on artificial light and accept any time of day; outdoor and
+// greenhouse tasks must fall within daylight hours. An empty environment means Outdoor.
+// An unknown environment or malformed time is returned as an error.
+func CheckPreferredTime(environment, preferredTime string) (*PreferredTimeValidationResponse, error) {
+	if environment == "" {
+		environment = EnvironmentOutdoor
+	}
+
+	result := &PreferredTimeValidationResponse{
+		Environment:   environment,
+		PreferredTime: preferredTime,
+	}
+	switch environment {
+	case EnvironmentIndoor:
+		result.WindowStart, result.WindowEnd = "00:00", "23:59"
+	case EnvironmentOutdoor, EnvironmentGreenhouse:
+		result.WindowStart = fmt.Sprintf("%02d:00", daylightStartHour)
+		result.WindowEnd = fmt.Sprintf("%02d:59", daylightEndHour)
+	default:
+		return nil, fmt.Errorf("unknown growing environment %q", environment)
+	}
+
+	t, err := time.Parse("15:04", preferredTime)
+	if err != nil {
+		return nil, fmt.Errorf("preferred time %q must be in HH:MM format", preferredTime)
+	}
+
+	result.Allowed = true
+	if environment != EnvironmentIndoor && (t.Hour() < daylightStartHour || t.Hour() > daylightEndHour) {
+		result.Allowed = false
+		result.Reason = fmt.Sprintf("%s tasks must be scheduled during daylight, between %s and %s",
+			strings.ToLower(environment), result.WindowStart, result.WindowEnd)
+	}
+	return result, nil
+}
+
+// Notification channel constants
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+	ChannelPush  = "push"
+)
+
+// NotificationRecipient represents a person who receives a garden's maintenance notifications
+type NotificationRecipient struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Channel         string `json:"channel" validate:"required,oneof=email sms push"`
+	Address         string `json:"address" validate:"required"` // Email address, phone number, or device token
+	QuietHoursStart string `json:"quietHoursStart,omitempty"`   // HH:MM; notifications are held from this time
+	QuietHoursEnd   string `json:"quietHoursEnd,omitempty"`     // HH:MM; held notifications are sent at this time
+	TimeZone        string `json:"timeZone,omitempty"`          // IANA zone for quiet hours; defaults to UTC
+
+	// Fallbacks are tried in order when delivery over the primary channel fails
+	Fallbacks []NotificationChannel `json:"fallbacks,omitempty" validate:"omitempty,dive"`
+}
+
+// NotificationChannel represents one channel and address a recipient can be reached at
+type NotificationChannel struct {
+	Channel string `json:"channel" validate:"required,oneof=email sms push"`
+	Address string `json:"address" validate:"required"`
+}
+
+// ChannelPriority returns the recipient's channels in the order delivery tries them: the
+// primary channel, then each fallback
+func (r *NotificationRecipient) ChannelPriority() []NotificationChannel {
+	channels := make([]NotificationChannel, 0, len(r.Fallbacks)+1)
+	channels = append(channels, NotificationChannel{Channel: r.Channel, Address: r.Address})
+	return append(channels, r.Fallbacks...)
+}
+
+// NotificationDelivery represents a notification delivered to one recipient, recording
+// the channel that delivered it after any that failed
+type NotificationDelivery struct {
+	RecipientID    string    `json:"recipientId"`
+	TaskID         string    `json:"taskId,omitempty"` // Empty for daily digests
+	TaskType       string    `json:"taskType"`
+	CorrelationID  string    `json:"correlationId"`
+	Channel        string    `json:"channel"`
+	FailedChannels []string  `json:"failedChannels,omitempty"` // Channels tried first, in order
+	DeliveredAt    time.Time `json:"deliveredAt"`
+}
+
+// DigestSettings represents a garden's daily digest mode. While enabled, the garden's
+// reminders are gathered into one notification per day instead of being sent one by one.
+type DigestSettings struct {
+	Enabled      bool   `json:"enabled"`
+	DeliveryTime string `json:"deliveryTime,omitempty"` // HH:MM; when each day's digest is sent
+	TimeZone     string `json:"timeZone,omitempty"`     // IANA zone for the delivery time; defaults to UTC
+}
+
+// NotificationPreferences represents a user's own notification delivery settings
+type NotificationPreferences struct {
+	Channels        []string   `json:"channels" validate:"required,min=1,unique,dive,oneof=email sms push"`
+	QuietHoursStart string     `json:"quietHoursStart,omitempty"` // HH:MM; notifications are held from this time
+	QuietHoursEnd   string     `json:"quietHoursEnd,omitempty"`   // HH:MM; held notifications are sent at this time
+	TimeZone        string     `json:"timeZone,omitempty"`        // IANA zone for quiet hours; defaults to UTC
+	UpdatedAt       *time.Time `json:"updatedAt,omitempty"`       // Unset while the defaults apply
+}
+
+// GardenNotificationPreferences represents the notification preferences applied to one
+// of a user's gardens
+type GardenNotificationPreferences struct {
+	GardenID string `json:"gardenId"`
+	NotificationPreferences
+}
+
+// DefaultNotificationPreferences returns the preferences used until a user saves their own
+func DefaultNotificationPreferences() *NotificationPreferences {
+	return &NotificationPreferences{Channels: []string{ChannelPush}}
 }
 
 // MaintenanceListResponse represents the DTO for paginated maintenance task lists
@@ -90,11 +589,53 @@ type MaintenanceListResponse struct {
 	SortMetadata    map[string]interface{}  `json:"sortMetadata,omitempty"`
 }
 
-// Validate performs comprehensive validation of the maintenance request
+// Validate performs comprehensive validation of the maintenance request, returning the
+// first problem ValidateAll reports so both share one set of rules
 func (r *MaintenanceRequest) Validate() error {
-	validate := validator.New()
+	err := r.ValidateAll()
+	if errs, ok := err.(types.ValidationErrors); ok {
+		return errs[0]
+	}
+	return err
+}
+
+// Ranges accepted for numeric environmental factors, matching environment readings
+const (
+	minFactorTemperature = -30.0 // Celsius
+	maxFactorTemperature = 60.0
+	minFactorHumidity    = 0.0 // Percent
+	maxFactorHumidity    = 100.0
+)
+
+// taskUnits maps each task type to the unit its amount is measured in; task types
+// without a measured amount use "n/a"
+var taskUnits = map[string]string{
+	TaskTypeWater:       "ml",
+	TaskTypeFertilizer:  "g",
+	TaskTypeComposting:  "g",
+	TaskTypePruning:     "n/a",
+	TaskTypePestControl: "ml",
+	TaskTypeRepot:       "n/a",
+}
+
+// TaskUnit returns the unit amounts are measured in for taskType, or "" if unknown
+func TaskUnit(taskType string) string {
+	return taskUnits[taskType]
+}
 
-	// Register custom validation for time format
+// ValidateAll checks the request and reports every failing field, as
+// types.ValidationErrors. Amounts are held to the bounds stored tasks accept. Cross-field
+// rules are checked only when the fields they combine are individually valid, so each
+// problem is reported once.
+func (r *MaintenanceRequest) ValidateAll() error {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
 	if err := validate.RegisterValidation("datetime", validateTimeFormat); err != nil {
 		return &types.ValidationError{
 			Field:   "validator",
@@ -103,127 +644,219 @@ func (r *MaintenanceRequest) Validate() error {
 		}
 	}
 
-	// Perform struct validation
+	var errs types.ValidationErrors
 	if err := validate.Struct(r); err != nil {
-		return &types.ValidationError{
-			Field:   "request",
-			Message: "invalid request structure",
-			Err:     err,
+		fieldErrs, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return err
+		}
+		for _, fieldErr := range fieldErrs {
+			errs = append(errs, &types.ValidationError{
+				Field:   fieldErr.Field(),
+				Message: fmt.Sprintf("failed %q validation", fieldErr.Tag()),
+				Value:   fmt.Sprintf("%v", fieldErr.Value()),
+				Err:     fieldErr,
+			})
 		}
 	}
 
-	// Task-specific validation
-	if err := r.validateTaskSpecifics(); err != nil {
-		return err
+	// Unit and amount must suit the task type
+	if !errs.HasField("taskType") {
+		if unit, ok := taskUnits[r.TaskType]; ok && !errs.HasField("unit") && r.Unit != unit {
+			errs = append(errs, &types.ValidationError{
+				Field:   "unit",
+				Message: fmt.Sprintf("%s tasks must use %s as unit", strings.ToLower(r.TaskType), unit),
+				Value:   r.Unit,
+			})
+		}
+		if min, max, ok := AmountBounds(r.TaskType); ok && !errs.HasField("amount") && (r.Amount < min || r.Amount > max) {
+			errs = append(errs, &types.ValidationError{
+				Field:   "amount",
+				Message: fmt.Sprintf("%s amount must be between %g and %g", strings.ToLower(r.TaskType), min, max),
+				Value:   fmt.Sprintf("%g", r.Amount),
+			})
+		}
 	}
 
-	// Validate preferred time is within daylight hours (6:00-18:00)
-	if err := r.validatePreferredTime(); err != nil {
-		return err
+	// Preferred time must fall within the growing environment's window
+	if !errs.HasField("preferredTime") && !errs.HasField("growingEnvironment") {
+		if check, err := CheckPreferredTime(r.GrowingEnvironment, r.PreferredTime); err != nil {
+			errs = append(errs, &types.ValidationError{
+				Field:   "preferredTime",
+				Message: "invalid time format",
+				Value:   r.PreferredTime,
+				Err:     err,
+			})
+		} else if !check.Allowed {
+			errs = append(errs, &types.ValidationError{
+				Field:   "preferredTime",
+				Message: check.Reason,
+				Value:   r.PreferredTime,
+			})
+		}
 	}
 
-	// Validate environmental factors
-	if err := r.validateEnvironmentalFactors(); err != nil {
-		return err
+	if !errs.HasField("environmentalFactors") {
+		errs = append(errs, r.environmentalFactorErrors()...)
 	}
 
+	if len(errs) > 0 {
+		return errs
+	}
 	return nil
 }
 
-// validateTaskSpecifics performs task-specific validation rules
-func (r *MaintenanceRequest) validateTaskSpecifics() error {
-	switch r.TaskType {
-	case TaskTypeWater:
-		if r.Unit != "ml" {
-			return &types.ValidationError{
-				Field:   "unit",
-				Message: "water tasks must use ml as unit",
-				Value:   r.Unit,
-			}
+// environmentalFactorErrors reports each required environmental factor that is missing
+// or outside the range an environment reading accepts
+func (r *MaintenanceRequest) environmentalFactorErrors() types.ValidationErrors {
+	var errs types.ValidationErrors
+	numeric := []struct {
+		name     string
+		min, max float64
+	}{
+		{"temperature", minFactorTemperature, maxFactorTemperature},
+		{"humidity", minFactorHumidity, maxFactorHumidity},
+	}
+	for _, factor := range numeric {
+		field := "environmentalFactors." + factor.name
+		value, exists := r.EnvironmentalFactors[factor.name]
+		if !exists {
+			errs = append(errs, &types.ValidationError{
+				Field:   field,
+				Message: "missing required environmental factor: " + factor.name,
+			})
+			continue
 		}
-		if r.Amount > maxWaterML {
-			return &types.ValidationError{
-				Field:   "amount",
-				Message: "water amount exceeds maximum allowed",
-				Value:   string(r.Amount),
-			}
+		number, ok := value.(float64)
+		if !ok || number < factor.min || number > factor.max {
+			errs = append(errs, &types.ValidationError{
+				Field:   field,
+				Message: fmt.Sprintf("%s must be a number between %g and %g", factor.name, factor.min, factor.max),
+				Value:   fmt.Sprintf("%v", value),
+			})
 		}
-	case TaskTypeFertilizer:
-		if r.Unit != "g" {
-			return &types.ValidationError{
-				Field:   "unit",
-				Message: "fertilizer tasks must use g as unit",
-				Value:   r.Unit,
-			}
+	}
+
+	value, exists := r.EnvironmentalFactors["lightLevel"]
+	if !exists {
+		errs = append(errs, &types.ValidationError{
+			Field:   "environmentalFactors.lightLevel",
+			Message: "missing required environmental factor: lightLevel",
+		})
+	} else if level, _ := value.(string); level != "low" && level != "medium" && level != "high" {
+		errs = append(errs, &types.ValidationError{
+			Field:   "environmentalFactors.lightLevel",
+			Message: "lightLevel must be one of low, medium, or high",
+			Value:   fmt.Sprintf("%v", value),
+		})
+	}
+
+	return errs
+}
+
+// validateTimeFormat validates time string format (HH:MM)
+func validateTimeFormat(fl validator.FieldLevel) bool {
+	timeStr := fl.Field().String()
+	matched, _ := regexp.MatchString(`^([01]?[0-9]|2[0-3]):[0-5][0-9]$`, timeStr)
+	return matched
+}
+
+// Validate checks the recipient's channel, address, and quiet hours
+func (r *NotificationRecipient) Validate() error {
+	if err := validator.New().Struct(r); err != nil {
+		return &types.ValidationError{
+			Field:   "recipient",
+			Message: "invalid recipient structure",
+			Err:     err,
 		}
-		if r.Amount > maxFertilizeG {
-			return &types.ValidationError{
-				Field:   "amount",
-				Message: "fertilizer amount exceeds maximum allowed",
-				Value:   string(r.Amount),
-			}
+	}
+
+	return validateQuietHours(r.QuietHoursStart, r.QuietHoursEnd, r.TimeZone)
+}
+
+// Validate checks that an enabled digest has an HH:MM delivery time in a known time zone
+func (d *DigestSettings) Validate() error {
+	if d.Enabled && d.DeliveryTime == "" {
+		return &types.ValidationError{
+			Field:   "deliveryTime",
+			Message: "a delivery time is required when the digest is enabled",
 		}
-	case TaskTypeComposting:
-		if r.Unit != "g" {
+	}
+	if d.DeliveryTime != "" {
+		if _, err := time.Parse("15:04", d.DeliveryTime); err != nil {
 			return &types.ValidationError{
-				Field:   "unit",
-				Message: "composting tasks must use g as unit",
-				Value:   r.Unit,
+				Field:   "deliveryTime",
+				Message: "invalid time format",
+				Value:   d.DeliveryTime,
+				Err:     err,
 			}
 		}
-		if r.Amount > maxCompostG {
+	}
+	if d.TimeZone != "" {
+		if _, err := time.LoadLocation(d.TimeZone); err != nil {
 			return &types.ValidationError{
-				Field:   "amount",
-				Message: "compost amount exceeds maximum allowed",
-				Value:   string(r.Amount),
+				Field:   "timeZone",
+				Message: "unknown time zone",
+				Value:   d.TimeZone,
+				Err:     err,
 			}
 		}
 	}
 	return nil
 }
 
-// validateTimeFormat validates time string format (HH:MM)
-func validateTimeFormat(fl validator.FieldLevel) bool {
-	timeStr := fl.Field().String()
-	matched, _ := regexp.MatchString(`^([01]?[0-9]|2[0-3]):[0-5][0-9]$`, timeStr)
-	return matched
-}
-
-// validatePreferredTime ensures preferred time is within daylight hours
-func (r *MaintenanceRequest) validatePreferredTime() error {
-	t, err := time.Parse("15:04", r.PreferredTime)
-	if err != nil {
+// Validate checks the preferred channels and quiet hours
+func (p *NotificationPreferences) Validate() error {
+	if err := validator.New().Struct(p); err != nil {
 		return &types.ValidationError{
-			Field:   "preferredTime",
-			Message: "invalid time format",
-			Value:   r.PreferredTime,
+			Field:   "channels",
+			Message: "at least one distinct channel of email, sms, or push is required",
 			Err:     err,
 		}
 	}
 
-	hour := t.Hour()
-	if hour < 6 || hour > 18 {
+	if p.QuietHoursStart != "" && p.QuietHoursStart == p.QuietHoursEnd {
 		return &types.ValidationError{
-			Field:   "preferredTime",
-			Message: "preferred time must be between 06:00 and 18:00",
-			Value:   r.PreferredTime,
+			Field:   "quietHours",
+			Message: "quiet hours must not start and end at the same time",
+			Value:   p.QuietHoursStart,
 		}
 	}
-	return nil
+	return validateQuietHours(p.QuietHoursStart, p.QuietHoursEnd, p.TimeZone)
 }
 
-// validateEnvironmentalFactors ensures required environmental factors are present
-func (r *MaintenanceRequest) validateEnvironmentalFactors() error {
-	requiredFactors := []string{"temperature", "humidity", "lightLevel"}
-	
-	for _, factor := range requiredFactors {
-		if _, exists := r.EnvironmentalFactors[factor]; !exists {
+// validateQuietHours checks that quiet hours are either unset or a pair of HH:MM times,
+// and that the time zone they are read in exists
+func validateQuietHours(start, end, timeZone string) error {
+	if (start == "") != (end == "") {
+		return &types.ValidationError{
+			Field:   "quietHours",
+			Message: "quiet hours need both a start and an end",
+		}
+	}
+	for field, value := range map[string]string{"quietHoursStart": start, "quietHoursEnd": end} {
+		if value == "" {
+			continue
+		}
+		if _, err := time.Parse("15:04", value); err != nil {
 			return &types.ValidationError{
-				Field:   "environmentalFactors",
-				Message: "missing required environmental factor: " + factor,
-				Value:   factor,
+				Field:   field,
+				Message: "invalid time format",
+				Value:   value,
+				Err:     err,
+			}
+		}
+	}
+
+	if timeZone != "" {
+		if _, err := time.LoadLocation(timeZone); err != nil {
+			return &types.ValidationError{
+				Field:   "timeZone",
+				Message: "unknown time zone",
+				Value:   timeZone,
+				Err:     err,
 			}
 		}
 	}
 	return nil
-}
\ No newline at end of file
+}
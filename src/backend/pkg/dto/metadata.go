@@ -0,0 +1,48 @@
+// Package dto provides Data Transfer Objects for the Urban Gardening Assistant API
+package dto
+
+// MetadataResponse lists the values the server accepts for crops, grow bags, gardens,
+// and maintenance tasks so clients need not hardcode them
+type MetadataResponse struct {
+	Crops              []CropMetadata     `json:"crops"`
+	BagSizes           []BagSizeMetadata  `json:"bagSizes"`
+	SoilTypes          []SoilTypeMetadata `json:"soilTypes"`
+	SunlightConditions []string           `json:"sunlightConditions"`
+	Environments       []string           `json:"environments"`
+	Frequencies        []string           `json:"frequencies"`
+	TaskTypes          []TaskTypeMetadata `json:"taskTypes"`
+	YieldProfile       YieldProfile       `json:"yieldProfile"`
+}
+
+// CropMetadata describes a supported crop, its base yield, and its minimum bag size
+type CropMetadata struct {
+	Name            string  `json:"name"`
+	BaseYieldPerBag float64 `json:"baseYieldPerBag"`      // kg/day from one 10" bag
+	MinBagSize      string  `json:"minBagSize,omitempty"` // Smaller bags reduce yield
+}
+
+// BagSizeMetadata describes a supported grow bag size
+type BagSizeMetadata struct {
+	Size            string  `json:"size"`
+	YieldMultiplier float64 `json:"yieldMultiplier"` // Relative to a 10" bag
+}
+
+// SoilTypeMetadata describes a supported soil type
+type SoilTypeMetadata struct {
+	Name       string  `json:"name"`
+	Efficiency float64 `json:"efficiency"`
+}
+
+// TaskTypeMetadata describes a maintenance task type and the unit of its amount
+type TaskTypeMetadata struct {
+	Name string `json:"name"`
+	Unit string `json:"unit"`
+}
+
+// YieldProfile reports how the server adjusts yield estimates
+type YieldProfile struct {
+	DefaultBaseYieldPerBag float64 `json:"defaultBaseYieldPerBag"` // kg/day for crops not listed
+	AdjustForEnvironment   bool    `json:"adjustForEnvironment"`   // Growing conditions refine estimates
+	UnknownSoilFactor      float64 `json:"unknownSoilFactor"`      // Efficiency for unlisted soil types
+	RejectUnknownSoil      bool    `json:"rejectUnknownSoil"`      // Unlisted soil types are rejected
+}
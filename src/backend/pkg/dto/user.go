@@ -5,6 +5,12 @@ import (
 	"time"
 )
 
+// User role constants
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 // CreateUserDTO represents the data transfer object for user registration
 // with comprehensive input validation for security
 type CreateUserDTO struct {
@@ -29,6 +35,7 @@ type UserResponseDTO struct {
 	Email     string    `json:"email"`
 	FirstName string    `json:"firstName"`
 	LastName  string    `json:"lastName"`
+	Role      string    `json:"role,omitempty"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
@@ -3,6 +3,7 @@ package types
 import (
     "errors"
     "fmt"
+    "strings"
 )
 
 // Constants for dimension validation
@@ -43,6 +44,28 @@ func (ve *ValidationError) Error() string {
         ve.Field, ve.Message, ve.Value)
 }
 
+// ValidationErrors aggregates every field validation failure for a single request
+type ValidationErrors []*ValidationError
+
+// Error implements the error interface listing all failing fields
+func (ve ValidationErrors) Error() string {
+    messages := make([]string, len(ve))
+    for i, err := range ve {
+        messages[i] = err.Error()
+    }
+    return strings.Join(messages, "; ")
+}
+
+// HasField reports whether a failure has already been recorded for the field
+func (ve ValidationErrors) HasField(field string) bool {
+    for _, err := range ve {
+        if err.Field == field {
+            return true
+        }
+    }
+    return false
+}
+
 // IsValid performs comprehensive validation of dimensions
 func (d *Dimensions) IsValid() (bool, error) {
     if d.Length <= 0 || d.Width <= 0 {
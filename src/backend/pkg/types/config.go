@@ -25,6 +25,18 @@ type ServiceConfig struct {
 	// API holds the API server configuration
 	API *APIConfig `json:"api" yaml:"api"`
 
+	// Scheduler holds the maintenance scheduler configuration
+	Scheduler *SchedulerConfig `json:"scheduler" yaml:"scheduler"`
+
+	// CropManager holds the crop management configuration
+	CropManager *CropManagerConfig `json:"cropManager" yaml:"cropManager"`
+
+	// Calculator holds the garden space calculator configuration
+	Calculator *CalculatorConfig `json:"calculator" yaml:"calculator"`
+
+	// AI holds the AI client token budget configuration
+	AI *AIConfig `json:"ai" yaml:"ai"`
+
 	// Debug enables debug mode for additional logging and diagnostics
 	Debug bool `json:"debug" yaml:"debug"`
 
@@ -119,6 +131,10 @@ type RedisConfig struct {
 
 	// EnableTLS enables TLS encryption for Redis connections
 	EnableTLS bool `json:"enableTLS" yaml:"enableTLS"`
+
+	// TTLJitterPercent randomly spreads cache entry TTLs by up to this percentage either way
+	// so entries cached together do not all expire together; zero disables jitter
+	TTLJitterPercent float64 `json:"ttlJitterPercent" yaml:"ttlJitterPercent"`
 }
 
 // APIConfig represents API server configuration with comprehensive security,
@@ -175,9 +191,248 @@ type APIConfig struct {
 	// EnableMetrics enables Prometheus metrics collection
 	EnableMetrics bool `json:"enableMetrics" yaml:"enableMetrics"`
 
-	// RateLimit specifies the maximum number of requests per RateLimitWindow
+	// EnableCompression enables gzip compression of responses
+	EnableCompression bool `json:"enableCompression" yaml:"enableCompression"`
+
+	// CompressionLevel specifies the gzip compression level (1 fastest to 9 smallest)
+	CompressionLevel int `json:"compressionLevel" yaml:"compressionLevel"`
+
+	// CompressionMinSize specifies the minimum response size in bytes before compression is applied
+	CompressionMinSize int `json:"compressionMinSize" yaml:"compressionMinSize"`
+
+	// RequestIDHeader specifies the header carrying the request ID inbound and outbound, e.g. X-Correlation-ID
+	RequestIDHeader string `json:"requestIdHeader" yaml:"requestIdHeader"`
+
+	// RateLimit specifies the maximum number of requests per RateLimitWindow from a single IP
 	RateLimit int `json:"rateLimit" yaml:"rateLimit"`
 
+	// UserRateLimit specifies the maximum number of requests per RateLimitWindow from a single authenticated user
+	UserRateLimit int `json:"userRateLimit" yaml:"userRateLimit"`
+
 	// RateLimitWindow specifies the duration for rate limiting
 	RateLimitWindow time.Duration `json:"rateLimitWindow" yaml:"rateLimitWindow"`
-}
\ No newline at end of file
+}
+
+// SchedulerConfig represents maintenance scheduler configuration controlling how
+// schedule generation consumes shared resources such as the AI service.
+type SchedulerConfig struct {
+	// AIWorkerPoolSize specifies the maximum number of concurrent AI calls during batch schedule creation
+	AIWorkerPoolSize int `json:"aiWorkerPoolSize" yaml:"aiWorkerPoolSize"`
+
+	// MaxBatchSize specifies the maximum number of schedules created by a single batch request
+	MaxBatchSize int `json:"maxBatchSize" yaml:"maxBatchSize"`
+
+	// ServeStaleReads serves cached schedules flagged as stale when the database fails on reads
+	ServeStaleReads bool `json:"serveStaleReads" yaml:"serveStaleReads"`
+
+	// StaleReadTTL specifies how long a last-known copy of each schedule is kept for stale reads
+	StaleReadTTL time.Duration `json:"staleReadTTL" yaml:"staleReadTTL"`
+
+	// MinNotificationGap specifies the minimum time between consecutive notifications for the same task;
+	// notifications scheduled closer together are coalesced into one. Zero disables coalescing.
+	MinNotificationGap time.Duration `json:"minNotificationGap" yaml:"minNotificationGap"`
+
+	// DefaultFrequencies overrides, by task type, the frequency used when a maintenance request omits one
+	DefaultFrequencies map[string]string `json:"defaultFrequencies" yaml:"defaultFrequencies"`
+
+	// MaxConcurrentCompletions specifies the maximum number of due tasks completed at once when
+	// completing every due task in a garden
+	MaxConcurrentCompletions int `json:"maxConcurrentCompletions" yaml:"maxConcurrentCompletions"`
+
+	// AIAmountPolicy specifies how AI-recommended amounts outside the bounds for their task type are handled:
+	// "clamp" applies the nearest bound and "discard" keeps the requested amount; either way the task is
+	// created and the adjustment reported
+	AIAmountPolicy string `json:"aiAmountPolicy" yaml:"aiAmountPolicy"`
+
+	// MaxActiveNotificationsPerUser specifies the maximum number of tasks with pending notifications per user;
+	// zero disables the limit
+	MaxActiveNotificationsPerUser int `json:"maxActiveNotificationsPerUser" yaml:"maxActiveNotificationsPerUser"`
+
+	// NotificationLimitPolicy specifies how a notification beyond a user's limit is handled: "reject" fails
+	// the scheduling and "evict" replaces the user's lowest-priority pending notification when it has lower priority
+	NotificationLimitPolicy string `json:"notificationLimitPolicy" yaml:"notificationLimitPolicy"`
+
+	// TaskTypePriorities overrides, by task type, the base priority that orders due notifications and
+	// picks eviction candidates; unlisted task types keep Water=3, Fertilizer=2, and 1 for the rest
+	TaskTypePriorities map[string]int `json:"taskTypePriorities" yaml:"taskTypePriorities"`
+
+	// AccelerationRules replaces the rules bringing AI-recommended tasks forward for environmental factors;
+	// empty keeps the default of watering 6 hours earlier above 30°C
+	AccelerationRules []ScheduleAccelerationRule `json:"accelerationRules" yaml:"accelerationRules"`
+
+	// MaxScheduleAcceleration bounds how far all matching acceleration rules together bring a task forward
+	MaxScheduleAcceleration time.Duration `json:"maxScheduleAcceleration" yaml:"maxScheduleAcceleration"`
+
+	// NotificationTimeSource specifies where notification processing takes the current time from:
+	// "local" uses each replica's clock and "redis" uses Redis TIME, shared by every replica
+	NotificationTimeSource string `json:"notificationTimeSource" yaml:"notificationTimeSource"`
+
+	// ClockSkewTolerance specifies how far a replica's clock may drift from Redis TIME before notification
+	// processing is held to the tolerance; zero disables the check
+	ClockSkewTolerance time.Duration `json:"clockSkewTolerance" yaml:"clockSkewTolerance"`
+
+	// CompletionHistoryRetention specifies how long completed-task events are kept; each task's
+	// latest completion is always kept. Zero keeps the history indefinitely.
+	CompletionHistoryRetention time.Duration `json:"completionHistoryRetention" yaml:"completionHistoryRetention"`
+
+	// HistoryPurgeInterval specifies how often expired completion history is purged
+	HistoryPurgeInterval time.Duration `json:"historyPurgeInterval" yaml:"historyPurgeInterval"`
+
+	// HistoryPurgeBatchSize specifies how many expired completion events are deleted per batch
+	HistoryPurgeBatchSize int `json:"historyPurgeBatchSize" yaml:"historyPurgeBatchSize"`
+
+	// AllowPastSchedules disables the safety check that rolls a next scheduled time computed
+	// in the past, e.g. from a completion long ago, forward to the next future occurrence
+	AllowPastSchedules bool `json:"allowPastSchedules" yaml:"allowPastSchedules"`
+
+	// RepotDays overrides, by crop growth rate (fast, medium, or slow), how many days after
+	// planting a crop is reminded to move into a larger grow bag
+	RepotDays map[string]int `json:"repotDays" yaml:"repotDays"`
+}
+
+// ScheduleAccelerationRule brings a task type forward while an environmental factor is beyond a threshold.
+type ScheduleAccelerationRule struct {
+	// TaskType specifies the maintenance task type the rule applies to
+	TaskType string `json:"taskType" yaml:"taskType"`
+
+	// Factor specifies the environmental factor tested: "temperature", "humidity", or "lightLevel"
+	Factor string `json:"factor" yaml:"factor"`
+
+	// Comparison specifies whether the rule applies "above" or "below" the threshold
+	Comparison string `json:"comparison" yaml:"comparison"`
+
+	// Threshold specifies the factor value; light levels compare as low=1, medium=2, and high=3
+	Threshold float64 `json:"threshold" yaml:"threshold"`
+
+	// Advance specifies how much earlier the task is scheduled while the rule applies
+	Advance time.Duration `json:"advance" yaml:"advance"`
+}
+
+// CropManagerConfig represents crop management configuration controlling how space
+// and yield calculations treat garden inputs.
+type CropManagerConfig struct {
+	// UnknownSoilFactor specifies the soil efficiency factor applied to unrecognised soil types
+	UnknownSoilFactor float64 `json:"unknownSoilFactor" yaml:"unknownSoilFactor"`
+
+	// RejectUnknownSoil rejects calculations for unrecognised soil types instead of using the fallback factor
+	RejectUnknownSoil bool `json:"rejectUnknownSoil" yaml:"rejectUnknownSoil"`
+
+	// AdjustYieldForEnvironment adjusts yield estimates by temperature, humidity, and light when a request provides them
+	AdjustYieldForEnvironment bool `json:"adjustYieldForEnvironment" yaml:"adjustYieldForEnvironment"`
+
+	// ServeStaleReads serves cached crops flagged as stale when the database fails on reads
+	ServeStaleReads bool `json:"serveStaleReads" yaml:"serveStaleReads"`
+
+	// GrowBagLimitPolicy specifies how requests above a crop's maximum grow bags for its garden are handled:
+	// "suggest" rejects them with a suggested count, "clamp" reduces them to the maximum, and
+	// "global" only enforces the global maximum
+	GrowBagLimitPolicy string `json:"growBagLimitPolicy" yaml:"growBagLimitPolicy"`
+
+	// UndersizedBagPolicy specifies how crops assigned a grow bag below their minimum size are handled:
+	// "warn" accepts them with a warning and a reduced yield estimate, "block" rejects them
+	UndersizedBagPolicy string `json:"undersizedBagPolicy" yaml:"undersizedBagPolicy"`
+
+	// CapacityWarningPercent specifies the garden space utilization at which created crops carry an approaching-capacity warning
+	CapacityWarningPercent float64 `json:"capacityWarningPercent" yaml:"capacityWarningPercent"`
+
+	// AllowOverCapacity creates crops that exceed the garden's capacity with a strong warning instead of rejecting them
+	AllowOverCapacity bool `json:"allowOverCapacity" yaml:"allowOverCapacity"`
+
+	// RejectDuplicateNames rejects creating a crop whose name matches another crop in the same garden
+	RejectDuplicateNames bool `json:"rejectDuplicateNames" yaml:"rejectDuplicateNames"`
+
+	// AllowedCropNames lists crop names accepted in addition to the crops with known yields; they use the default yield
+	AllowedCropNames []string `json:"allowedCropNames" yaml:"allowedCropNames"`
+
+	// AllowUnknownCrops accepts any crop name, using the default yield for unrecognised crops, instead of
+	// rejecting names outside the allowlist with suggested matches
+	AllowUnknownCrops bool `json:"allowUnknownCrops" yaml:"allowUnknownCrops"`
+
+	// CapacityWebhookURL specifies the endpoint notified when crop changes move a garden's utilization
+	// across a capacity threshold; empty disables the webhook
+	CapacityWebhookURL string `json:"capacityWebhookUrl" yaml:"capacityWebhookUrl"`
+
+	// CapacityWebhookPercents specifies the garden space utilizations that fire the capacity webhook
+	CapacityWebhookPercents []float64 `json:"capacityWebhookPercents" yaml:"capacityWebhookPercents"`
+}
+
+// CalculatorConfig represents garden space calculator configuration controlling how
+// layout planning treats gardens too cramped for an accessible layout.
+type CalculatorConfig struct {
+	// AllowNonViableLayouts returns the most accessible layout with a viability warning when no
+	// layout meets the accessibility minimum, instead of rejecting the request
+	AllowNonViableLayouts bool `json:"allowNonViableLayouts" yaml:"allowNonViableLayouts"`
+}
+
+// AIConfig represents AI client configuration bounding prompt and completion sizes
+// to keep token usage and cost predictable.
+type AIConfig struct {
+	// Provider selects the AI provider: openai (the default) or stub, which answers from
+	// fixed templates without calling any external API
+	Provider string `json:"provider" yaml:"provider"`
+
+	// MaxPromptTokens specifies the maximum estimated number of tokens sent in a single prompt
+	MaxPromptTokens int `json:"maxPromptTokens" yaml:"maxPromptTokens"`
+
+	// TruncateOversizedPrompts drops input conditions to fit MaxPromptTokens instead of rejecting the request
+	TruncateOversizedPrompts bool `json:"truncateOversizedPrompts" yaml:"truncateOversizedPrompts"`
+
+	// RecommendationMaxTokens caps the completion tokens for gardening recommendations
+	RecommendationMaxTokens int `json:"recommendationMaxTokens" yaml:"recommendationMaxTokens"`
+
+	// ScheduleMaxTokens caps the completion tokens for maintenance schedules
+	ScheduleMaxTokens int `json:"scheduleMaxTokens" yaml:"scheduleMaxTokens"`
+
+	// CropSuggestionMaxTokens caps the completion tokens for crop suggestions
+	CropSuggestionMaxTokens int `json:"cropSuggestionMaxTokens" yaml:"cropSuggestionMaxTokens"`
+
+	// MonthlyBudget caps the estimated AI spend per calendar month; 0 disables the limit
+	MonthlyBudget float64 `json:"monthlyBudget" yaml:"monthlyBudget"`
+
+	// CostPer1KTokens is the estimated cost of 1,000 tokens, used to track spend against MonthlyBudget
+	CostPer1KTokens float64 `json:"costPer1KTokens" yaml:"costPer1KTokens"`
+
+	// RetryableStatusCodes lists the HTTP statuses from the AI API that are retried; other API
+	// errors fail immediately. Empty uses the default transient statuses (408, 429, and 5xx).
+	RetryableStatusCodes []int `json:"retryableStatusCodes" yaml:"retryableStatusCodes"`
+
+	// RefineSchedules sends each generated maintenance schedule back to the AI with the garden
+	// conditions for a second, refining pass before it is accepted
+	RefineSchedules bool `json:"refineSchedules" yaml:"refineSchedules"`
+
+	// EnvironmentSettings overrides the completion model, temperature, and prompt variant by
+	// growing environment (Indoor, Outdoor, or Greenhouse) for requests that name one
+	EnvironmentSettings map[string]AIEnvironmentSettings `json:"environmentSettings" yaml:"environmentSettings"`
+
+	// DuplicatePromptWindow is how long a successful completion is shared with identical
+	// prompts after it returns. Identical prompts made while a call is in flight always
+	// share it; 0 shares only in-flight calls.
+	DuplicatePromptWindow time.Duration `json:"duplicatePromptWindow" yaml:"duplicatePromptWindow"`
+}
+
+// DefaultAIConfig returns the AI limits applied when none are configured: the defaults of
+// the configuration loader and of AI clients built without AI settings.
+func DefaultAIConfig() AIConfig {
+	return AIConfig{
+		MaxPromptTokens:          1000,
+		TruncateOversizedPrompts: true,
+		RecommendationMaxTokens:  500,
+		ScheduleMaxTokens:        800,
+		CropSuggestionMaxTokens:  150,
+		RetryableStatusCodes:     []int{408, 429, 500, 502, 503, 504},
+		DuplicatePromptWindow:    2 * time.Second,
+	}
+}
+
+// AIEnvironmentSettings represents the AI completion settings used for one growing environment.
+// Empty fields fall back to the client defaults.
+type AIEnvironmentSettings struct {
+	// Model specifies the completion model
+	Model string `json:"model" yaml:"model"`
+
+	// Temperature specifies the sampling temperature between 0 and 2
+	Temperature *float32 `json:"temperature" yaml:"temperature"`
+
+	// PromptVariant selects environment-specific guidance added to prompts
+	PromptVariant string `json:"promptVariant" yaml:"promptVariant"`
+}
@@ -0,0 +1,67 @@
+package ai_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/urban-gardening/backend/internal/ai"
+)
+
+// buildPrompt mirrors the client's prompt builders, rendering conditions into the prompt text
+func buildPrompt(conditions map[string]string) string {
+	return fmt.Sprintf("Provide gardening recommendations under these conditions: %v.", conditions)
+}
+
+// oversizedConditions returns typical conditions plus one very large free-text entry
+func oversizedConditions() map[string]string {
+	return map[string]string{
+		"soilType": "loamy_soil",
+		"sunlight": "full_sun",
+		"notes":    strings.Repeat("aphids on the lower leaves ", 400),
+	}
+}
+
+// TestPromptBudget tests enforcement of the maximum prompt token estimate
+func TestPromptBudget(t *testing.T) {
+	t.Run("prompt within budget is unchanged", func(t *testing.T) {
+		conditions := map[string]string{"soilType": "loamy_soil"}
+		budget := ai.PromptBudget{MaxTokens: 100}
+
+		prompt, err := budget.Fit(conditions, buildPrompt)
+		require.NoError(t, err)
+		assert.Equal(t, buildPrompt(conditions), prompt)
+	})
+
+	t.Run("oversized input rejected", func(t *testing.T) {
+		budget := ai.PromptBudget{MaxTokens: 100}
+
+		prompt, err := budget.Fit(oversizedConditions(), buildPrompt)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ai.ErrPromptTooLarge))
+		assert.Contains(t, err.Error(), "limit is 100")
+		assert.Empty(t, prompt)
+	})
+
+	t.Run("oversized input truncated", func(t *testing.T) {
+		budget := ai.PromptBudget{MaxTokens: 100, Truncate: true}
+
+		prompt, err := budget.Fit(oversizedConditions(), buildPrompt)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, ai.EstimateTokens(prompt), 100)
+		assert.NotContains(t, prompt, "aphids", "largest condition should be dropped first")
+		assert.Contains(t, prompt, "loamy_soil")
+		assert.Contains(t, prompt, "full_sun")
+	})
+
+	t.Run("truncation cannot empty the conditions", func(t *testing.T) {
+		budget := ai.PromptBudget{MaxTokens: 10, Truncate: true}
+
+		_, err := budget.Fit(oversizedConditions(), buildPrompt)
+		assert.True(t, errors.Is(err, ai.ErrPromptTooLarge))
+	})
+}
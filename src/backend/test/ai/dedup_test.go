@@ -0,0 +1,144 @@
+package ai_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/urban-gardening/backend/internal/ai"
+	"github.com/urban-gardening/backend/pkg/types"
+)
+
+// slowCompletionClient counts upstream calls and holds each one open for delay
+type slowCompletionClient struct {
+	calls int32
+	delay time.Duration
+	text  string
+}
+
+// CreateCompletion implements ai.CompletionClient
+func (f *slowCompletionClient) CreateCompletion(ctx context.Context, request openai.CompletionRequest) (openai.CompletionResponse, error) {
+	atomic.AddInt32(&f.calls, 1)
+	time.Sleep(f.delay)
+	return openai.CompletionResponse{Choices: []openai.CompletionChoice{{Text: f.text}}}, nil
+}
+
+// TestConcurrentIdenticalPromptsShareOneCall tests that identical recommendation requests
+// racing past the cache make a single upstream call
+func TestConcurrentIdenticalPromptsShareOneCall(t *testing.T) {
+	const requests = 10
+	conditions := map[string]string{"sunlight": "full_sun", "soil": "loamy_soil"}
+	tips := `["Water the tomatoes deeply every morning","Stake the tomato plants as they grow"]`
+
+	newClient := func(t *testing.T, fake *slowCompletionClient, window time.Duration) *ai.AIClient {
+		client, err := ai.NewAIClientWithCompletionClient(&types.ServiceConfig{AI: &types.AIConfig{
+			MaxPromptTokens:         1000,
+			RecommendationMaxTokens: 500,
+			DuplicatePromptWindow:   window,
+		}}, fake)
+		require.NoError(t, err)
+		return client
+	}
+
+	t.Run("concurrent requests", func(t *testing.T) {
+		fake := &slowCompletionClient{delay: 200 * time.Millisecond, text: tips}
+		client := newClient(t, fake, 0)
+
+		start := make(chan struct{})
+		results := make([][]string, requests)
+		errs := make([]error, requests)
+		var wg sync.WaitGroup
+		for i := 0; i < requests; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				<-start
+				results[i], errs[i] = client.GetGardeningRecommendations(context.Background(), "Tomatoes", conditions)
+			}(i)
+		}
+		close(start)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&fake.calls))
+		for i := 0; i < requests; i++ {
+			require.NoError(t, errs[i])
+			assert.Len(t, results[i], 2)
+		}
+	})
+
+	t.Run("refresh within the window calls upstream again", func(t *testing.T) {
+		fake := &slowCompletionClient{text: tips}
+		client := newClient(t, fake, time.Minute)
+
+		_, err := client.GetGardeningRecommendations(context.Background(), "Tomatoes", conditions)
+		require.NoError(t, err)
+		_, err = client.GetGardeningRecommendations(ai.WithRefresh(context.Background()), "Tomatoes", conditions)
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&fake.calls))
+	})
+}
+
+// contextCompletionClient holds each upstream call open for delay unless the request
+// context ends first
+type contextCompletionClient struct {
+	calls int32
+	delay time.Duration
+	text  string
+}
+
+// CreateCompletion implements ai.CompletionClient
+func (f *contextCompletionClient) CreateCompletion(ctx context.Context, request openai.CompletionRequest) (openai.CompletionResponse, error) {
+	atomic.AddInt32(&f.calls, 1)
+	select {
+	case <-time.After(f.delay):
+		return openai.CompletionResponse{Choices: []openai.CompletionChoice{{Text: f.text}}}, nil
+	case <-ctx.Done():
+		return openai.CompletionResponse{}, ctx.Err()
+	}
+}
+
+// TestSharedPromptSurvivesCallerCancellation tests that the caller starting a shared call
+// giving up does not fail it for the callers still waiting
+func TestSharedPromptSurvivesCallerCancellation(t *testing.T) {
+	conditions := map[string]string{"sunlight": "full_sun", "soil": "loamy_soil"}
+	fake := &contextCompletionClient{
+		delay: 200 * time.Millisecond,
+		text:  `["Water the tomatoes deeply every morning","Stake the tomato plants as they grow"]`,
+	}
+	client, err := ai.NewAIClientWithCompletionClient(&types.ServiceConfig{AI: &types.AIConfig{
+		MaxPromptTokens:         1000,
+		RecommendationMaxTokens: 500,
+	}}, fake)
+	require.NoError(t, err)
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := client.GetGardeningRecommendations(leaderCtx, "Tomatoes", conditions)
+		leaderErr <- err
+	}()
+
+	// Join the call in flight, then abandon it from the caller that started it
+	time.Sleep(50 * time.Millisecond)
+	waiterResult := make(chan []string, 1)
+	waiterErr := make(chan error, 1)
+	go func() {
+		tips, err := client.GetGardeningRecommendations(context.Background(), "Tomatoes", conditions)
+		waiterResult <- tips
+		waiterErr <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	assert.ErrorIs(t, <-leaderErr, context.Canceled)
+	require.NoError(t, <-waiterErr)
+	assert.Len(t, <-waiterResult, 2)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fake.calls))
+}
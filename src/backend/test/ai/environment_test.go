@@ -0,0 +1,106 @@
+package ai_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/urban-gardening/backend/internal/ai"
+	"github.com/urban-gardening/backend/pkg/dto"
+	"github.com/urban-gardening/backend/pkg/types"
+)
+
+// recordingCompletionClient returns a fixed completion and records each request
+type recordingCompletionClient struct {
+	mu       sync.Mutex
+	text     string
+	requests []openai.CompletionRequest
+}
+
+// CreateCompletion implements ai.CompletionClient
+func (f *recordingCompletionClient) CreateCompletion(ctx context.Context, request openai.CompletionRequest) (openai.CompletionResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests = append(f.requests, request)
+	return openai.CompletionResponse{Choices: []openai.CompletionChoice{{Text: f.text}}}, nil
+}
+
+// TestEnvironmentSettings tests that the request's growing environment selects the AI settings
+func TestEnvironmentSettings(t *testing.T) {
+	ctx := context.Background()
+	schedule := `{"tasks":["water"],"frequency":"daily","duration":"90d"}`
+	greenhouseTemperature := float32(0.2)
+	indoorTemperature := float32(0.5)
+
+	newClient := func(t *testing.T, fake *recordingCompletionClient) *ai.AIClient {
+		client, err := ai.NewAIClientWithCompletionClient(&types.ServiceConfig{AI: &types.AIConfig{
+			MaxPromptTokens:          1000,
+			TruncateOversizedPrompts: true,
+			ScheduleMaxTokens:        800,
+			EnvironmentSettings: map[string]types.AIEnvironmentSettings{
+				dto.EnvironmentGreenhouse: {
+					Model:         "gpt-4",
+					Temperature:   &greenhouseTemperature,
+					PromptVariant: ai.PromptVariantClimateControlled,
+				},
+				dto.EnvironmentIndoor: {
+					Temperature:   &indoorTemperature,
+					PromptVariant: ai.PromptVariantLowLight,
+				},
+			},
+		}}, fake)
+		require.NoError(t, err)
+		return client
+	}
+
+	requestFor := func(t *testing.T, environment string) openai.CompletionRequest {
+		fake := &recordingCompletionClient{text: schedule}
+		client := newClient(t, fake)
+
+		_, err := client.GetMaintenanceSchedule(ctx, map[string]string{
+			"soilType":           "Loamy",
+			"growingEnvironment": environment,
+		}, []string{dto.TaskTypeWater})
+		require.NoError(t, err)
+		require.Len(t, fake.requests, 1)
+		return fake.requests[0]
+	}
+
+	t.Run("greenhouse uses its model, temperature, and prompt variant", func(t *testing.T) {
+		request := requestFor(t, dto.EnvironmentGreenhouse)
+		assert.Equal(t, "gpt-4", request.Model)
+		assert.Equal(t, greenhouseTemperature, request.Temperature)
+		assert.Contains(t, request.Prompt, "climate-controlled")
+	})
+
+	t.Run("indoor keeps the default model", func(t *testing.T) {
+		request := requestFor(t, dto.EnvironmentIndoor)
+		assert.Equal(t, openai.GPT3Dot5Turbo, request.Model)
+		assert.Equal(t, indoorTemperature, request.Temperature)
+		assert.Contains(t, request.Prompt, "grow lights")
+	})
+
+	t.Run("unconfigured environment uses defaults", func(t *testing.T) {
+		request := requestFor(t, dto.EnvironmentOutdoor)
+		assert.Equal(t, openai.GPT3Dot5Turbo, request.Model)
+		assert.Equal(t, float32(0.7), request.Temperature)
+		assert.NotContains(t, request.Prompt, "climate-controlled")
+		assert.NotContains(t, request.Prompt, "grow lights")
+	})
+
+	t.Run("unknown prompt variant is rejected", func(t *testing.T) {
+		_, err := ai.NewAIClientWithCompletionClient(&types.ServiceConfig{AI: &types.AIConfig{
+			MaxPromptTokens: 1000,
+			EnvironmentSettings: map[string]types.AIEnvironmentSettings{
+				dto.EnvironmentGreenhouse: {PromptVariant: "tropical"},
+			},
+		}}, &recordingCompletionClient{text: schedule})
+		assert.True(t, errors.Is(err, ai.ErrInvalidConfig))
+	})
+}
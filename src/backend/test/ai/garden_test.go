@@ -0,0 +1,48 @@
+package ai_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/urban-gardening/backend/internal/ai"
+	"github.com/urban-gardening/backend/pkg/types"
+)
+
+// TestGardenRecommendationsSingleCall tests that one completion call covers every crop
+func TestGardenRecommendationsSingleCall(t *testing.T) {
+	ctx := context.Background()
+	tips := `["Water the tomatoes and peppers deeply every morning","Shade the lettuce during the afternoon heat"]`
+
+	fake := &scriptedCompletionClient{texts: []string{tips}}
+	client, err := ai.NewAIClientWithCompletionClient(&types.ServiceConfig{}, fake)
+	require.NoError(t, err)
+
+	gardenConditions := map[string]string{"soilType": "loamy_soil", "sunlight": "full_sun"}
+	cropConditions := map[string]map[string]string{
+		"Tomatoes": {"bagSize": "12\"", "growBags": "3"},
+		"Peppers":  {"bagSize": "12\"", "growBags": "2"},
+		"Lettuce":  {"bagSize": "8\"", "growBags": "4"},
+	}
+
+	recommendations, err := client.GetGardenRecommendations(ctx, gardenConditions, cropConditions)
+	require.NoError(t, err)
+	assert.Len(t, recommendations, 2)
+
+	require.Len(t, fake.prompts, 1)
+	for name := range cropConditions {
+		assert.Contains(t, fake.prompts[0], name)
+	}
+
+	// A repeated request is answered from the cache
+	_, err = client.GetGardenRecommendations(ctx, gardenConditions, cropConditions)
+	require.NoError(t, err)
+	assert.Len(t, fake.prompts, 1)
+
+	t.Run("no crops", func(t *testing.T) {
+		_, err := client.GetGardenRecommendations(ctx, gardenConditions, nil)
+		assert.ErrorIs(t, err, ai.ErrInvalidInput)
+	})
+}
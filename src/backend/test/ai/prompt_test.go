@@ -0,0 +1,38 @@
+package ai_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/urban-gardening/backend/internal/ai"
+	"github.com/urban-gardening/backend/pkg/dto"
+	"github.com/urban-gardening/backend/pkg/types"
+)
+
+// TestSchedulePromptGrowingMethod tests that organic gardens ask the AI for organic fertilizers
+func TestSchedulePromptGrowingMethod(t *testing.T) {
+	ctx := context.Background()
+	schedule := `{"tasks":["fertilize"],"frequency":"weekly","duration":"60d"}`
+
+	prompts := make(map[string]string)
+	for _, method := range []string{dto.GrowingMethodOrganic, dto.GrowingMethodConventional} {
+		fake := &scriptedCompletionClient{texts: []string{schedule}}
+		client, err := ai.NewAIClientWithCompletionClient(&types.ServiceConfig{}, fake)
+		require.NoError(t, err)
+
+		_, err = client.GetMaintenanceSchedule(ctx, map[string]string{
+			"soilType":      "Loamy",
+			"growingMethod": method,
+		}, []string{dto.TaskTypeFertilizer})
+		require.NoError(t, err)
+		require.Len(t, fake.prompts, 1)
+		prompts[method] = fake.prompts[0]
+	}
+
+	assert.Contains(t, prompts[dto.GrowingMethodOrganic], "organic fertilizers")
+	assert.Contains(t, prompts[dto.GrowingMethodOrganic], "no synthetic fertilizers")
+	assert.NotContains(t, prompts[dto.GrowingMethodConventional], "organic fertilizers")
+}
@@ -0,0 +1,71 @@
+package ai_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/urban-gardening/backend/internal/ai"
+	"github.com/urban-gardening/backend/pkg/types"
+)
+
+// Both providers must satisfy the common interface
+var (
+	_ ai.AIProvider = (*ai.AIClient)(nil)
+	_ ai.AIProvider = (*ai.StubProvider)(nil)
+)
+
+// TestNewProvider tests provider selection from configuration
+func TestNewProvider(t *testing.T) {
+	t.Run("stub provider", func(t *testing.T) {
+		provider, err := ai.NewProvider(&types.ServiceConfig{AI: &types.AIConfig{Provider: ai.ProviderStub}}, "")
+		require.NoError(t, err)
+		assert.IsType(t, &ai.StubProvider{}, provider)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		_, err := ai.NewProvider(&types.ServiceConfig{AI: &types.AIConfig{Provider: "unknown"}}, "")
+		assert.ErrorIs(t, err, ai.ErrInvalidConfig)
+	})
+
+	t.Run("stub always healthy", func(t *testing.T) {
+		assert.True(t, ai.NewStubProvider().CheckHealth(context.Background()).Success)
+	})
+
+	t.Run("openai requires an API key", func(t *testing.T) {
+		_, err := ai.NewProvider(&types.ServiceConfig{}, "short")
+		assert.ErrorIs(t, err, ai.ErrInvalidAPIKey)
+	})
+}
+
+// TestProvidersThroughInterface tests that recommendations and schedules work the same
+// through either provider
+func TestProvidersThroughInterface(t *testing.T) {
+	schedule := `{"tasks":["watering"],"frequency":"Daily","duration":"15min"}`
+	tips := `["Water tomatoes deeply every morning","Feed tomatoes weekly with compost tea"]`
+	client, err := ai.NewAIClientWithCompletionClient(&types.ServiceConfig{}, &scriptedCompletionClient{texts: []string{tips, schedule}})
+	require.NoError(t, err)
+
+	providers := map[string]ai.AIProvider{
+		ai.ProviderOpenAI: client,
+		ai.ProviderStub:   ai.NewStubProvider(),
+	}
+	for name, provider := range providers {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			recommendations, err := provider.GetGardeningRecommendations(ctx, "Tomatoes", map[string]string{"soilType": "loamy_soil"})
+			require.NoError(t, err)
+			assert.NotEmpty(t, recommendations)
+
+			service, err := ai.NewRecommendationService(provider, 0)
+			require.NoError(t, err)
+			generated, err := service.GenerateMaintenanceSchedule(ctx, []string{"Tomatoes"}, map[string]string{"soilType": "loamy_soil"})
+			require.NoError(t, err)
+			for _, field := range []string{"tasks", "frequency", "duration", "seasonalAdjustments"} {
+				assert.Contains(t, generated, field)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package ai_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/urban-gardening/backend/internal/ai"
+	"github.com/urban-gardening/backend/pkg/types"
+)
+
+// scriptedCompletionClient returns the queued completions in order and records each prompt
+type scriptedCompletionClient struct {
+	mu      sync.Mutex
+	texts   []string
+	prompts []string
+}
+
+// CreateCompletion implements ai.CompletionClient
+func (f *scriptedCompletionClient) CreateCompletion(ctx context.Context, request openai.CompletionRequest) (openai.CompletionResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	prompt, _ := request.Prompt.(string)
+	f.prompts = append(f.prompts, prompt)
+	text := f.texts[0]
+	if len(f.texts) > 1 {
+		f.texts = f.texts[1:]
+	}
+	return openai.CompletionResponse{Choices: []openai.CompletionChoice{{Text: text}}}, nil
+}
+
+// TestScheduleRefinement tests the optional second AI pass over generated schedules
+func TestScheduleRefinement(t *testing.T) {
+	ctx := context.Background()
+	conditions := map[string]string{"sunlight": "partial_shade", "environment": "balcony"}
+	plants := []string{"Tomatoes"}
+
+	draft := `{"tasks":["water"],"frequency":"daily","duration":"90d","amountMl":900}`
+	refined := `{"tasks":["water","mulch"],"frequency":"every 2 days","duration":"90d","amountMl":600}`
+
+	newClient := func(t *testing.T, refine bool, fake *scriptedCompletionClient) *ai.AIClient {
+		client, err := ai.NewAIClientWithCompletionClient(&types.ServiceConfig{AI: &types.AIConfig{
+			MaxPromptTokens:          1000,
+			TruncateOversizedPrompts: true,
+			ScheduleMaxTokens:        800,
+			RefineSchedules:          refine,
+		}}, fake)
+		require.NoError(t, err)
+		return client
+	}
+
+	t.Run("two-pass mode returns refined schedule", func(t *testing.T) {
+		fake := &scriptedCompletionClient{texts: []string{draft, refined}}
+		client := newClient(t, true, fake)
+
+		schedule, err := client.GetMaintenanceSchedule(ctx, conditions, plants)
+		require.NoError(t, err)
+		require.Len(t, fake.prompts, 2)
+		assert.Equal(t, "every 2 days", schedule["frequency"])
+		assert.Equal(t, 600.0, schedule["amountMl"])
+
+		// The refining pass sees the draft alongside the garden constraints
+		assert.Contains(t, fake.prompts[1], `"amountMl":900`)
+		assert.Contains(t, fake.prompts[1], "partial_shade")
+	})
+
+	t.Run("single pass by default", func(t *testing.T) {
+		fake := &scriptedCompletionClient{texts: []string{draft, refined}}
+		client := newClient(t, false, fake)
+
+		schedule, err := client.GetMaintenanceSchedule(ctx, conditions, plants)
+		require.NoError(t, err)
+		assert.Len(t, fake.prompts, 1)
+		assert.Equal(t, "daily", schedule["frequency"])
+	})
+
+	t.Run("invalid refinement keeps draft", func(t *testing.T) {
+		fake := &scriptedCompletionClient{texts: []string{draft, `{"tasks":["water"]}`}}
+		client := newClient(t, true, fake)
+
+		schedule, err := client.GetMaintenanceSchedule(ctx, conditions, plants)
+		require.NoError(t, err)
+		assert.Len(t, fake.prompts, 2)
+		assert.Equal(t, "daily", schedule["frequency"])
+	})
+}
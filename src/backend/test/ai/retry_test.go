@@ -0,0 +1,112 @@
+package ai_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/urban-gardening/backend/internal/ai"
+	"github.com/urban-gardening/backend/pkg/types"
+)
+
+// fakeCompletionClient fails with the queued errors in order, then returns text
+type fakeCompletionClient struct {
+	mu    sync.Mutex
+	errs  []error
+	text  string
+	calls int
+}
+
+// CreateCompletion implements ai.CompletionClient
+func (f *fakeCompletionClient) CreateCompletion(ctx context.Context, request openai.CompletionRequest) (openai.CompletionResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return openai.CompletionResponse{}, err
+	}
+	return openai.CompletionResponse{Choices: []openai.CompletionChoice{{Text: f.text}}}, nil
+}
+
+// apiError builds an OpenAI API error with the given HTTP status
+func apiError(status int) error {
+	return &openai.APIError{HTTPStatusCode: status, Message: http.StatusText(status)}
+}
+
+// TestRetryClassification tests that only transient AI errors are retried
+func TestRetryClassification(t *testing.T) {
+	ctx := context.Background()
+	conditions := map[string]string{"sunlight": "full_sun"}
+
+	t.Run("auth error fails immediately", func(t *testing.T) {
+		fake := &fakeCompletionClient{errs: []error{apiError(http.StatusUnauthorized)}, text: `["Tomatoes"]`}
+		client, err := ai.NewAIClientWithCompletionClient(&types.ServiceConfig{}, fake)
+		require.NoError(t, err)
+
+		_, err = client.SuggestCrops(ctx, conditions)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "non-retryable")
+		var apiErr *openai.APIError
+		require.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusUnauthorized, apiErr.HTTPStatusCode)
+		assert.Equal(t, 1, fake.calls)
+	})
+
+	t.Run("service unavailable is retried", func(t *testing.T) {
+		fake := &fakeCompletionClient{
+			errs: []error{apiError(http.StatusServiceUnavailable), apiError(http.StatusTooManyRequests)},
+			text: `["Tomatoes"]`,
+		}
+		client, err := ai.NewAIClientWithCompletionClient(&types.ServiceConfig{}, fake)
+		require.NoError(t, err)
+
+		crops, err := client.SuggestCrops(ctx, conditions)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Tomatoes"}, crops)
+		assert.Equal(t, 3, fake.calls)
+	})
+
+	t.Run("retryable statuses are configurable", func(t *testing.T) {
+		fake := &fakeCompletionClient{errs: []error{apiError(http.StatusServiceUnavailable)}, text: `["Tomatoes"]`}
+		client, err := ai.NewAIClientWithCompletionClient(&types.ServiceConfig{AI: &types.AIConfig{
+			MaxPromptTokens:         1000,
+			CropSuggestionMaxTokens: 150,
+			RetryableStatusCodes:    []int{http.StatusInternalServerError},
+		}}, fake)
+		require.NoError(t, err)
+
+		_, err = client.SuggestCrops(ctx, conditions)
+		require.Error(t, err)
+		assert.Equal(t, 1, fake.calls)
+	})
+	t.Run("client errors do not open the breaker", func(t *testing.T) {
+		errs := make([]error, 0, 6)
+		for i := 0; i < 6; i++ {
+			errs = append(errs, apiError(http.StatusBadRequest))
+		}
+		fake := &fakeCompletionClient{errs: errs, text: `["Tomatoes"]`}
+		client, err := ai.NewAIClientWithCompletionClient(&types.ServiceConfig{}, fake)
+		require.NoError(t, err)
+
+		for i := 0; i < 6; i++ {
+			_, err = client.SuggestCrops(ctx, conditions)
+			require.Error(t, err)
+		}
+
+		// More rejected requests than the breaker's failure threshold, yet the next call
+		// still reaches the API
+		crops, err := client.SuggestCrops(ctx, conditions)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Tomatoes"}, crops)
+		assert.Equal(t, 7, fake.calls)
+	})
+}
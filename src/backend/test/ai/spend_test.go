@@ -0,0 +1,79 @@
+package ai_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/urban-gardening/backend/internal/ai"
+	"github.com/urban-gardening/backend/internal/utils/clock"
+)
+
+// newSpendTracker creates a tracker backed by miniredis with a clock pinned to now
+func newSpendTracker(t *testing.T, budget, costPer1K float64, now time.Time) (*ai.SpendTracker, *clock.Fake) {
+	mr := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { redisClient.Close() })
+
+	tracker, err := ai.NewSpendTracker(redisClient, budget, costPer1K)
+	require.NoError(t, err)
+	fake := clock.NewFake(now)
+	tracker.SetClock(fake)
+	return tracker, fake
+}
+
+// TestSpendTracker tests that AI calls are refused once the period budget is spent
+func TestSpendTracker(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, time.March, 30, 12, 0, 0, 0, time.UTC)
+
+	t.Run("budget blocks calls once spent", func(t *testing.T) {
+		tracker, _ := newSpendTracker(t, 1.0, 0.5, now)
+		require.NoError(t, tracker.Allow(ctx))
+
+		total, err := tracker.Record(ctx, 1000)
+		require.NoError(t, err)
+		assert.InDelta(t, 0.5, total, 1e-9)
+		require.NoError(t, tracker.Allow(ctx))
+
+		total, err = tracker.Record(ctx, 1000)
+		require.NoError(t, err)
+		assert.InDelta(t, 1.0, total, 1e-9)
+		assert.ErrorIs(t, tracker.Allow(ctx), ai.ErrBudgetExceeded)
+	})
+
+	t.Run("budget resets at the next period", func(t *testing.T) {
+		tracker, fake := newSpendTracker(t, 1.0, 0.5, now)
+		_, err := tracker.Record(ctx, 3000)
+		require.NoError(t, err)
+		assert.ErrorIs(t, tracker.Allow(ctx), ai.ErrBudgetExceeded)
+
+		// Still March
+		fake.Advance(24 * time.Hour)
+		assert.ErrorIs(t, tracker.Allow(ctx), ai.ErrBudgetExceeded)
+
+		// April starts a new period
+		fake.Advance(48 * time.Hour)
+		spent, err := tracker.Spent(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, spent)
+		assert.NoError(t, tracker.Allow(ctx))
+	})
+
+	t.Run("zero budget is unlimited", func(t *testing.T) {
+		tracker, _ := newSpendTracker(t, 0, 0.5, now)
+		_, err := tracker.Record(ctx, 1000000)
+		require.NoError(t, err)
+		assert.NoError(t, tracker.Allow(ctx))
+	})
+
+	t.Run("negative budget rejected", func(t *testing.T) {
+		_, err := ai.NewSpendTracker(redis.NewClient(&redis.Options{}), -1, 0.5)
+		assert.ErrorIs(t, err, ai.ErrInvalidConfig)
+	})
+}
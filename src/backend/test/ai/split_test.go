@@ -0,0 +1,80 @@
+package ai_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/urban-gardening/backend/internal/ai"
+)
+
+// TestSplitRecommendations tests splitting free-form AI text into clean recommendations
+func TestSplitRecommendations(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name: "numbered list with intro and wrapped item",
+			text: "Here are some recommendations for your tomatoes:\n\n" +
+				"1. Water deeply every morning so the soil stays evenly moist.\n" +
+				"2. Feed with a balanced liquid fertilizer every two weeks once\n" +
+				"   flowers appear.\n" +
+				"3) Pinch out suckers below the first flower truss.\n",
+			expected: []string{
+				"Water deeply every morning so the soil stays evenly moist.",
+				"Feed with a balanced liquid fertilizer every two weeks once flowers appear.",
+				"Pinch out suckers below the first flower truss.",
+			},
+		},
+		{
+			name: "markdown bullets with bold headings",
+			text: "**Watering:**\r\n" +
+				"- Keep the grow bag moist but never waterlogged.\r\n" +
+				"* Use **mulch** to reduce evaporation in hot weather.\r\n" +
+				"• Check drainage holes weekly.\r\n",
+			expected: []string{
+				"Keep the grow bag moist but never waterlogged.",
+				"Use mulch to reduce evaporation in hot weather.",
+				"Check drainage holes weekly.",
+			},
+		},
+		{
+			name: "inline numbered list on one line",
+			text: "1. Place the bag in full sun. 2. Water at the base of the plant. 3. Stake the stems early.",
+			expected: []string{
+				"Place the bag in full sun.",
+				"Water at the base of the plant.",
+				"Stake the stems early.",
+			},
+		},
+		{
+			name: "plain lines without markers",
+			text: "Rotate the container weekly for even growth.\nHarvest leaves from the outside in.",
+			expected: []string{
+				"Rotate the container weekly for even growth.",
+				"Harvest leaves from the outside in.",
+			},
+		},
+		{
+			name: "short and overlong items filtered",
+			text: "- Water.\n- Add compost to the top inch of soil monthly.\n- " + strings.Repeat("very long advice ", 40),
+			expected: []string{
+				"Add compost to the top inch of soil monthly.",
+			},
+		},
+		{
+			name:     "nothing usable",
+			text:     "  \n\nTips:\n",
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ai.SplitRecommendations(tc.text))
+		})
+	}
+}
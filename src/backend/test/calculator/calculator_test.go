@@ -3,6 +3,7 @@ package calculator_test
 
 import (
     "context"
+    "fmt"
     "testing"
 
     "github.com/stretchr/testify/assert"
@@ -50,6 +51,13 @@ func (m *mockUnitConverter) Convert(value float64, fromUnit, toUnit string) (flo
     return value, nil
 }
 
+// failingUnitConverter rejects every conversion
+type failingUnitConverter struct{}
+
+func (f *failingUnitConverter) Convert(value float64, fromUnit, toUnit string) (float64, error) {
+    return 0, fmt.Errorf("unsupported conversion from %s to %s", fromUnit, toUnit)
+}
+
 // setupTestCalculator creates a new calculator service instance for testing
 func setupTestCalculator() (*calculator.CalculatorService, context.Context, common.UnitConverter) {
     ctx := context.Background()
@@ -248,4 +256,273 @@ func TestValidateGrowBagPlan(t *testing.T) {
             assert.Equal(t, tt.wantValid, valid)
         })
     }
-}
\ No newline at end of file
+}
+
+// TestOptimizeGrowBagLayoutMinAccessibility tests trading accessibility for capacity
+func TestOptimizeGrowBagLayoutMinAccessibility(t *testing.T) {
+    // 1ft bags at default spacing leave 1.5ft paths, below the 2ft minimum path width
+    tightConfig := calculator.OptimizationConfig{
+        MinPathWidth:         2.0,
+        PreferredOrientation: "horizontal",
+        SpacingMultiplier:    1.0,
+    }
+
+    t.Run("Default threshold rejects narrow paths", func(t *testing.T) {
+        _, err := calculator.OptimizeGrowBagLayout(validDimensions, 1.0, tightConfig)
+        require.Error(t, err)
+        assert.Contains(t, err.Error(), "could not find viable layout")
+    })
+
+    t.Run("Lower threshold yields denser layout", func(t *testing.T) {
+        // Widening spacing to satisfy the default threshold
+        accessibleConfig := tightConfig
+        accessibleConfig.SpacingMultiplier = 2.0
+        accessible, err := calculator.OptimizeGrowBagLayout(validDimensions, 1.0, accessibleConfig)
+        require.NoError(t, err)
+        assert.GreaterOrEqual(t, accessible.AccessibilityScore, calculator.DefaultMinAccessibility)
+
+        relaxedConfig := tightConfig
+        relaxedConfig.MinAccessibility = 0.5
+        dense, err := calculator.OptimizeGrowBagLayout(validDimensions, 1.0, relaxedConfig)
+        require.NoError(t, err)
+        assert.GreaterOrEqual(t, dense.AccessibilityScore, 0.5)
+
+        assert.Greater(t, dense.Rows*dense.Columns, accessible.Rows*accessible.Columns)
+        assert.Greater(t, dense.SpaceUtilization, accessible.SpaceUtilization)
+    })
+
+    t.Run("Cramped garden returns warned layout when allowed", func(t *testing.T) {
+        warnConfig := tightConfig
+        warnConfig.AllowNonViable = true
+        layout, err := calculator.OptimizeGrowBagLayout(validDimensions, 1.0, warnConfig)
+        require.NoError(t, err)
+        require.NotNil(t, layout)
+        assert.Less(t, layout.AccessibilityScore, calculator.DefaultMinAccessibility)
+        assert.Greater(t, layout.Rows*layout.Columns, 0)
+        assert.Contains(t, layout.ViabilityWarning, "no layout meets the minimum accessibility")
+    })
+
+    t.Run("Viable layout has no warning", func(t *testing.T) {
+        warnConfig := tightConfig
+        warnConfig.AllowNonViable = true
+        warnConfig.SpacingMultiplier = 2.0
+        layout, err := calculator.OptimizeGrowBagLayout(validDimensions, 1.0, warnConfig)
+        require.NoError(t, err)
+        assert.GreaterOrEqual(t, layout.AccessibilityScore, calculator.DefaultMinAccessibility)
+        assert.Empty(t, layout.ViabilityWarning)
+    })
+
+    t.Run("Negative threshold rejected", func(t *testing.T) {
+        invalidConfig := tightConfig
+        invalidConfig.MinAccessibility = -0.1
+        _, err := calculator.OptimizeGrowBagLayout(validDimensions, 1.0, invalidConfig)
+        require.Error(t, err)
+        assert.Contains(t, err.Error(), "minimum accessibility cannot be negative")
+    })
+}
+
+// TestPlanPathArea tests path area planning against the internal area calculations
+func TestPlanPathArea(t *testing.T) {
+    calc, _, _ := setupTestCalculator()
+
+    tests := []struct {
+        name           string
+        dimensions     common.Dimensions
+        includeCorners bool
+        wantPathArea   float64
+    }{
+        {
+            name:         "Small garden needs no paths",
+            dimensions:   common.Dimensions{Length: 5.0, Width: 4.0, Unit: "feet"},
+            wantPathArea: 0.0,
+        },
+        {
+            name:           "Small garden with corner spaces",
+            dimensions:     common.Dimensions{Length: 5.0, Width: 4.0, Unit: "feet"},
+            includeCorners: true,
+            wantPathArea:   4.0,
+        },
+        {
+            name:         "Square garden with one path each way",
+            dimensions:   common.Dimensions{Length: 10.0, Width: 10.0, Unit: "feet"},
+            wantPathArea: 20.0, // 1 * 10 + 1 * 10
+        },
+        {
+            name:           "Rectangular garden with corner spaces",
+            dimensions:     validDimensions,
+            includeCorners: true,
+            wantPathArea:   89.0, // 3 * 15 + 2 * 20 + 4
+        },
+        {
+            name:         "Large garden",
+            dimensions:   common.Dimensions{Length: 30.0, Width: 30.0, Unit: "feet"},
+            wantPathArea: 300.0, // 5 * 30 + 5 * 30
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            metrics, err := calc.PlanPathArea(tt.dimensions, tt.includeCorners)
+            require.NoError(t, err)
+
+            totalArea := tt.dimensions.Length * tt.dimensions.Width
+            assert.InDelta(t, totalArea, metrics.TotalArea, 0.001)
+            assert.InDelta(t, tt.wantPathArea, metrics.PathArea, 0.001)
+            assert.InDelta(t, totalArea-tt.wantPathArea, metrics.UsableArea, 0.001)
+            assert.InDelta(t, tt.wantPathArea/totalArea, metrics.PathRatio, 0.0001)
+
+            // Matches the calculation used for usable garden space
+            internal, err := calculator.CalculatePathArea(tt.dimensions, tt.includeCorners)
+            require.NoError(t, err)
+            assert.Equal(t, internal, metrics)
+
+            usableArea, err := calculator.CalculateUsableArea(tt.dimensions, tt.includeCorners)
+            require.NoError(t, err)
+            assert.InDelta(t, usableArea, metrics.UsableArea*calculator.MaxSpaceUtilization, 0.01)
+        })
+    }
+
+    t.Run("Invalid dimensions rejected", func(t *testing.T) {
+        _, err := calc.PlanPathArea(tooLargeDimensions, false)
+        require.Error(t, err)
+        assert.Contains(t, err.Error(), "dimension validation failed")
+    })
+}
+
+// TestCompareLayouts tests comparing standard layouts across grow bag sizes
+func TestCompareLayouts(t *testing.T) {
+    calc, ctx, _ := setupTestCalculator()
+
+    t.Run("One entry per bag size", func(t *testing.T) {
+        bagSizes := []float64{10, 14, 20}
+        comparisons, err := calc.CompareLayouts(ctx, validDimensions, bagSizes)
+        require.NoError(t, err)
+        require.Len(t, comparisons, len(bagSizes))
+
+        config := calculator.OptimizationConfig{
+            IncludeCornerSpaces:  true,
+            MinPathWidth:         calculator.MinimumPathWidth,
+            PreferredOrientation: "horizontal",
+            SpacingMultiplier:    1.0,
+        }
+        for i, comparison := range comparisons {
+            assert.Equal(t, bagSizes[i], comparison.BagSize)
+            require.True(t, comparison.Viable)
+
+            // Matches the standard layout for the same diameter in feet
+            layout, err := calculator.OptimizeGrowBagLayout(validDimensions, bagSizes[i]/12, config)
+            require.NoError(t, err)
+            assert.Equal(t, layout.Rows*layout.Columns, comparison.Capacity)
+            assert.InDelta(t, layout.SpaceUtilization, comparison.SpaceUtilization, 0.0001)
+            assert.InDelta(t, layout.AccessibilityScore, comparison.AccessibilityScore, 0.0001)
+        }
+
+        // Larger bags fit fewer to the garden
+        assert.Greater(t, comparisons[0].Capacity, comparisons[1].Capacity)
+        assert.Greater(t, comparisons[1].Capacity, comparisons[2].Capacity)
+        assert.Equal(t, 9*6, comparisons[2].Capacity) // 20" bags on a 2.17ft pitch: 9 rows by 6 columns
+    })
+
+    t.Run("Invalid bag size rejected", func(t *testing.T) {
+        _, err := calc.CompareLayouts(ctx, validDimensions, []float64{10, 0})
+        require.Error(t, err)
+        assert.Contains(t, err.Error(), "bag size must be positive")
+    })
+
+    t.Run("No bag sizes rejected", func(t *testing.T) {
+        _, err := calc.CompareLayouts(ctx, validDimensions, nil)
+        require.Error(t, err)
+    })
+
+    t.Run("Invalid dimensions rejected", func(t *testing.T) {
+        _, err := calc.CompareLayouts(ctx, tooLargeDimensions, []float64{10})
+        require.Error(t, err)
+        assert.Contains(t, err.Error(), "dimension validation failed")
+    })
+
+    t.Run("Non-viable sizes reported when allowed", func(t *testing.T) {
+        // 4" bags on a 0.83ft pitch leave paths narrower than the minimum
+        comparisons, err := calc.CompareLayouts(ctx, validDimensions, []float64{4})
+        require.NoError(t, err)
+        assert.False(t, comparisons[0].Viable)
+        assert.Zero(t, comparisons[0].Capacity)
+
+        calc.SetAllowNonViableLayouts(true)
+        defer calc.SetAllowNonViableLayouts(false)
+        comparisons, err = calc.CompareLayouts(ctx, validDimensions, []float64{4})
+        require.NoError(t, err)
+        assert.False(t, comparisons[0].Viable)
+        assert.Greater(t, comparisons[0].Capacity, 0)
+        assert.NotEmpty(t, comparisons[0].ViabilityWarning)
+    })
+}
+
+// TestLayoutUnits tests that layouts are planned in feet and reported in the requested unit
+func TestLayoutUnits(t *testing.T) {
+    _, _, converter := setupTestCalculator()
+    metric := common.Dimensions{Length: 10.0, Width: 8.0, Unit: "meters"}
+    config := calculator.OptimizationConfig{
+        MinPathWidth:         calculator.MinimumPathWidth,
+        PreferredOrientation: "horizontal",
+        SpacingMultiplier:    1.0,
+    }
+
+    // 1ft bags on a 1.5ft pitch across a 32.8ft by 26.2ft garden
+    layout, err := calculator.OptimizeGrowBagLayout(metric, 1.0, config)
+    require.NoError(t, err)
+    assert.Equal(t, "feet", layout.Unit)
+    assert.Equal(t, 21, layout.Rows)
+    assert.Equal(t, 17, layout.Columns)
+    assert.InDelta(t, 1.5, layout.RowSpacing, 0.0001)
+
+    t.Run("Meters request returns metric spacing and positions", func(t *testing.T) {
+        inMeters, err := layout.InUnit("meters", converter)
+        require.NoError(t, err)
+        assert.Equal(t, "meters", inMeters.Unit)
+        assert.InDelta(t, 1.5*0.3048, inMeters.RowSpacing, 0.0001)
+        assert.InDelta(t, 1.5*0.3048, inMeters.ColumnSpacing, 0.0001)
+
+        require.Len(t, inMeters.OptimizedPositions, len(layout.OptimizedPositions))
+        assert.InDelta(t, 0.5*0.3048, inMeters.OptimizedPositions[0].X, 0.0001)
+        assert.InDelta(t, 0.5*0.3048, inMeters.OptimizedPositions[0].Y, 0.0001)
+        for _, position := range inMeters.OptimizedPositions {
+            assert.Less(t, position.X, metric.Width, "positions within the garden in meters")
+            assert.Less(t, position.Y, metric.Length, "positions within the garden in meters")
+        }
+
+        // Ratios carry over unchanged
+        assert.Equal(t, layout.SpaceUtilization, inMeters.SpaceUtilization)
+        assert.Equal(t, layout.AccessibilityScore, inMeters.AccessibilityScore)
+    })
+
+    t.Run("Metric metrics in square meters", func(t *testing.T) {
+        inMeters, err := layout.InUnit("meters", converter)
+        require.NoError(t, err)
+        feetMetrics, metricMetrics := layout.CalculateMetrics(), inMeters.CalculateMetrics()
+        assert.InDelta(t, feetMetrics.UsableArea*0.3048*0.3048, metricMetrics.UsableArea, 0.01)
+        assert.InDelta(t, feetMetrics.PathArea/(3.28084*3.28084), metricMetrics.PathArea, 0.01)
+    })
+
+    t.Run("Same unit leaves layout unchanged", func(t *testing.T) {
+        inFeet, err := layout.InUnit("feet", converter)
+        require.NoError(t, err)
+        assert.Equal(t, layout, inFeet)
+    })
+
+    t.Run("Service plans meters request without the converter", func(t *testing.T) {
+        // Feet and meters are converted directly, so a converter that rejects every
+        // conversion is never consulted
+        calc, err := calculator.NewCalculatorService(context.Background(), &failingUnitConverter{})
+        require.NoError(t, err)
+
+        feet, err := calc.PlanGrowBagLayoutIn(metric, 0.3048, false, "feet")
+        require.NoError(t, err)
+        inMeters, err := calc.PlanGrowBagLayoutIn(metric, 0.3048, false, "meters")
+        require.NoError(t, err)
+        assert.Equal(t, "meters", inMeters.Unit)
+        assert.Equal(t, feet.Rows, inMeters.Rows)
+        assert.Equal(t, feet.Columns, inMeters.Columns)
+        assert.InDelta(t, feet.RowSpacing/3.28084, inMeters.RowSpacing, 0.0001)
+        assert.InDelta(t, feet.ColumnSpacing/3.28084, inMeters.ColumnSpacing, 0.0001)
+    })
+}
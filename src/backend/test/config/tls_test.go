@@ -0,0 +1,164 @@
+package config_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/urban-gardening/backend/config"
+	"github.com/urban-gardening/backend/pkg/types"
+)
+
+// writeSelfSignedCert writes a self-signed certificate for 127.0.0.1 and its key to dir
+// and returns their paths along with the parsed certificate
+func writeSelfSignedCert(t *testing.T, dir string) (string, string, *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPath := filepath.Join(dir, "server.crt")
+	keyPath := filepath.Join(dir, "server.key")
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certPath, keyPath, cert
+}
+
+// startServer serves a handler replying "ok" with the TLS configuration built from cfg
+// and returns the listener address
+func startServer(t *testing.T, cfg *types.APIConfig) string {
+	t.Helper()
+
+	tlsConfig, err := config.ServerTLSConfig(cfg)
+	require.NoError(t, err)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("ok"))
+		}),
+		TLSConfig: tlsConfig,
+	}
+	go config.Serve(srv, ln)
+	t.Cleanup(func() { srv.Close() })
+
+	return ln.Addr().String()
+}
+
+// TestServerUsesTLSWhenConfigured tests that a server built from an API configuration with
+// TLS enabled serves HTTPS with the configured certificate
+func TestServerUsesTLSWhenConfigured(t *testing.T) {
+	certPath, keyPath, cert := writeSelfSignedCert(t, t.TempDir())
+	addr := startServer(t, &types.APIConfig{
+		EnableTLS:   true,
+		TLSCertPath: certPath,
+		TLSKeyPath:  keyPath,
+	})
+
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+	client := &http.Client{
+		Timeout:   5 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}},
+	}
+
+	resp, err := client.Get("https://" + addr + "/health")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.NotNil(t, resp.TLS)
+	assert.GreaterOrEqual(t, resp.TLS.Version, uint16(tls.VersionTLS12))
+	require.NotEmpty(t, resp.TLS.PeerCertificates)
+	assert.True(t, resp.TLS.PeerCertificates[0].Equal(cert))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(body))
+
+	// Plaintext requests are answered with a TLS-required error rather than served
+	plain, err := (&http.Client{Timeout: 5 * time.Second}).Get("http://" + addr + "/health")
+	require.NoError(t, err)
+	defer plain.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, plain.StatusCode)
+}
+
+// TestServerServesPlaintextWhenTLSDisabled tests that TLS stays off unless enabled
+func TestServerServesPlaintextWhenTLSDisabled(t *testing.T) {
+	tlsConfig, err := config.ServerTLSConfig(&types.APIConfig{EnableTLS: false, TLSCertPath: "unused.crt"})
+	require.NoError(t, err)
+	assert.Nil(t, tlsConfig)
+
+	addr := startServer(t, &types.APIConfig{})
+	resp, err := (&http.Client{Timeout: 5 * time.Second}).Get("http://" + addr + "/health")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Nil(t, resp.TLS)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+// TestServerTLSConfigValidation tests that bad certificate settings fail at startup
+func TestServerTLSConfigValidation(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath, _ := writeSelfSignedCert(t, dir)
+	otherDir := filepath.Join(dir, "other")
+	require.NoError(t, os.Mkdir(otherDir, 0700))
+	_, otherKeyPath, _ := writeSelfSignedCert(t, otherDir)
+
+	testCases := []struct {
+		name     string
+		certPath string
+		keyPath  string
+	}{
+		{name: "missing cert path", certPath: "", keyPath: keyPath},
+		{name: "missing key path", certPath: certPath, keyPath: ""},
+		{name: "cert file not found", certPath: filepath.Join(dir, "missing.crt"), keyPath: keyPath},
+		{name: "key does not match cert", certPath: certPath, keyPath: otherKeyPath},
+		{name: "key file is not a key", certPath: certPath, keyPath: certPath},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tlsConfig, err := config.ServerTLSConfig(&types.APIConfig{
+				EnableTLS:   true,
+				TLSCertPath: tc.certPath,
+				TLSKeyPath:  tc.keyPath,
+			})
+			assert.Error(t, err)
+			assert.Nil(t, tlsConfig)
+		})
+	}
+}
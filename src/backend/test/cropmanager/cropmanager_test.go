@@ -2,18 +2,33 @@ package cropmanager_test
 
 import (
     "context"
+    "encoding/json"
+    "errors"
+    "io"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "sync"
     "testing"
     "time"
 
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/prometheus/client_golang/prometheus/promhttp"
     "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/mock"
     "github.com/stretchr/testify/require"
     "go.uber.org/zap"
+    "gorm.io/gorm"
+    "gorm.io/gorm/utils/tests"
     "github.com/patrickmn/go-cache"
 
     "github.com/urban-gardening-assistant/backend/internal/cropmanager"
     "github.com/urban-gardening-assistant/backend/internal/models"
+    "github.com/urban-gardening-assistant/backend/internal/utils/database"
     "github.com/urban-gardening-assistant/backend/pkg/dto"
+    "github.com/urban-gardening-assistant/backend/pkg/types/common"
     "github.com/urban-gardening-assistant/backend/test/mocks"
+    "github.com/urban-gardening/backend/internal/utils/clock"
 )
 
 // TestSuite encapsulates the test environment
@@ -41,6 +56,9 @@ func setupTestSuite(t *testing.T) *TestSuite {
     logger, err := zap.NewDevelopment()
     require.NoError(t, err)
 
+    // Every crop mutation records a utilization snapshot
+    mockDB.On("Create", mock.AnythingOfType("*models.UtilizationSnapshot")).Return(nil, nil)
+
     // Create service instance
     service := cropmanager.NewCropService(mockDB, testCache, logger)
 
@@ -131,6 +149,28 @@ func TestCreateCrop(t *testing.T) {
         assert.Nil(t, resp)
     })
 
+    t.Run("retries on serialization failure", func(t *testing.T) {
+        suite := setupTestSuite(t)
+        req := &dto.CropRequest{
+            GardenID:       suite.testData.garden.ID,
+            Name:           "Spinach",
+            QuantityNeeded: 2,
+            GrowBags:      2,
+            BagSize:       "10\"",
+        }
+
+        suite.mockDB.On("First", &models.Garden{}, []interface{}{suite.testData.garden.ID}).
+            Return(nil, nil)
+        suite.mockDB.On("Create", &models.Crop{}).Return(nil, mocks.ErrSerialization).Once()
+        suite.mockDB.On("Create", &models.Crop{}).Return(nil, nil).Once()
+
+        resp, err := suite.service.CreateCrop(ctx, req)
+        require.NoError(t, err)
+        assert.NotNil(t, resp)
+        // The failed and retried crop saves, then the retry's utilization snapshot
+        suite.mockDB.AssertNumberOfCalls(t, "Create", 3)
+    })
+
     t.Run("invalid crop request", func(t *testing.T) {
         req := &dto.CropRequest{
             GardenID:       suite.testData.garden.ID,
@@ -259,4 +299,2183 @@ func TestYieldCalculationAccuracy(t *testing.T) {
                 "yield calculation should be within %d%% accuracy", int(tc.tolerance*100))
         })
     }
-}
\ No newline at end of file
+}
+
+// TestPlanForYieldGoal tests grow bag planning towards a weekly yield goal
+func TestPlanForYieldGoal(t *testing.T) {
+    suite := setupTestSuite(t)
+    ctx := context.Background()
+
+    suite.mockDB.On("First", &models.Garden{}, []interface{}{suite.testData.garden.ID}).
+        Return(nil, nil)
+    suite.mockDB.On("Find", &[]models.Crop{}, "garden_id = ? AND deleted_at IS NULL",
+        suite.testData.garden.ID).Return(nil, nil)
+
+    t.Run("feasible goal", func(t *testing.T) {
+        plan, err := suite.service.PlanForYieldGoal(ctx, suite.testData.garden.ID, "Tomatoes", 7.0)
+        require.NoError(t, err)
+        assert.True(t, plan.Feasible)
+        require.NotNil(t, plan.Recommended)
+        assert.Len(t, plan.Options, 4, "one option per bag size")
+
+        // 1 kg/day in loamy soil: 10" bags yield 0.225 * 1.0 * 1.2 = 0.27 kg/day each. 8" bags
+        // are below the tomato minimum, so need 8 bags at 0.138 kg/day and more space.
+        assert.Equal(t, dto.BagSize10, plan.Recommended.BagSize)
+        assert.Equal(t, 4, plan.Recommended.GrowBags)
+        assert.GreaterOrEqual(t, plan.Recommended.WeeklyYield, 7.0)
+        assert.LessOrEqual(t, plan.Recommended.SpaceRequired, plan.AvailableSpace)
+    })
+
+    t.Run("infeasible goal", func(t *testing.T) {
+        plan, err := suite.service.PlanForYieldGoal(ctx, suite.testData.garden.ID, "Tomatoes", 1000.0)
+        require.NoError(t, err)
+        assert.False(t, plan.Feasible)
+        assert.Nil(t, plan.Recommended)
+        for _, option := range plan.Options {
+            assert.False(t, option.Fits)
+        }
+        assert.Contains(t, plan.Message, "does not fit")
+    })
+
+    t.Run("invalid target", func(t *testing.T) {
+        plan, err := suite.service.PlanForYieldGoal(ctx, suite.testData.garden.ID, "Tomatoes", 0)
+        assert.Error(t, err)
+        assert.Nil(t, plan)
+    })
+}
+
+// TestValidateCropRequestAggregation tests that every failing field is reported at once
+func TestValidateCropRequestAggregation(t *testing.T) {
+    t.Run("multiple simultaneous violations", func(t *testing.T) {
+        req := &dto.CropRequest{
+            GardenID:       "not-a-uuid",
+            Name:           "T",
+            QuantityNeeded: 5000,
+            GrowBags:      0,
+            BagSize:       "9\"",
+        }
+
+        err := dto.ValidateCropRequest(req)
+        require.Error(t, err)
+
+        var validationErrs common.ValidationErrors
+        require.True(t, errors.As(err, &validationErrs), "expected aggregated validation errors")
+
+        fields := make([]string, 0, len(validationErrs))
+        for _, fieldErr := range validationErrs {
+            fields = append(fields, fieldErr.Field)
+        }
+        assert.ElementsMatch(t, []string{"gardenId", "name", "quantityNeeded", "growBags", "bagSize"}, fields)
+    })
+
+    t.Run("each field reported once", func(t *testing.T) {
+        req := &dto.CropRequest{
+            GardenID:       "3f1c2a8e-6b1d-4c55-9f8e-2b7d7c1e9a10",
+            Name:           "Tomatoes",
+            QuantityNeeded: -1,
+            GrowBags:      101,
+            BagSize:       "12\"",
+        }
+
+        err := dto.ValidateCropRequest(req)
+        var validationErrs common.ValidationErrors
+        require.True(t, errors.As(err, &validationErrs))
+        assert.Len(t, validationErrs, 2)
+        assert.True(t, validationErrs.HasField("quantityNeeded"))
+        assert.True(t, validationErrs.HasField("growBags"))
+    })
+
+    t.Run("valid request", func(t *testing.T) {
+        req := &dto.CropRequest{
+            GardenID:       "3f1c2a8e-6b1d-4c55-9f8e-2b7d7c1e9a10",
+            Name:           "Tomatoes",
+            QuantityNeeded: 5,
+            GrowBags:      3,
+            BagSize:       "12\"",
+        }
+
+        assert.NoError(t, dto.ValidateCropRequest(req))
+    })
+}
+
+// TestUnknownSoilHandling tests the configurable fallback and strict-reject mode for unknown soils
+func TestUnknownSoilHandling(t *testing.T) {
+    ctx := context.Background()
+    gardenID := "peat-garden-id"
+
+    // newPeatService returns a service whose cached garden uses an unrecognised soil type
+    newPeatService := func(t *testing.T) *cropmanager.CropService {
+        mockDB := mocks.NewMockDB(true, false)
+        testCache := cache.New(1*time.Hour, 2*time.Hour)
+        testCache.Set("garden:"+gardenID, &models.Garden{
+            ID:       gardenID,
+            UserID:   "test-user-id",
+            Length:   20.0,
+            Width:    10.0,
+            SoilType: "peat_soil",
+            Sunlight: "full_sun",
+        }, time.Hour)
+
+        logger, err := zap.NewDevelopment()
+        require.NoError(t, err)
+
+        mockDB.On("Find", &[]models.Crop{}, "garden_id = ? AND deleted_at IS NULL", gardenID).
+            Return(nil, nil)
+        return cropmanager.NewCropService(mockDB, testCache, logger)
+    }
+
+    t.Run("configurable fallback factor", func(t *testing.T) {
+        service := newPeatService(t)
+        baseline, err := service.ValidateSpaceCapacity(ctx, gardenID, 3)
+        require.NoError(t, err)
+
+        require.NoError(t, service.SetSoilConfig(cropmanager.SoilConfig{UnknownSoilFactor: 0.5}))
+        adjusted, err := service.ValidateSpaceCapacity(ctx, gardenID, 3)
+        require.NoError(t, err)
+
+        // Halving the efficiency doubles the effective space requirement
+        assert.InDelta(t, baseline.SpaceUtilization*2, adjusted.SpaceUtilization, 0.01)
+    })
+
+    t.Run("strict mode rejects unknown soil", func(t *testing.T) {
+        service := newPeatService(t)
+        require.NoError(t, service.SetSoilConfig(cropmanager.SoilConfig{
+            UnknownSoilFactor: 1.0,
+            RejectUnknownSoil: true,
+        }))
+
+        resp, err := service.ValidateSpaceCapacity(ctx, gardenID, 3)
+        require.Error(t, err)
+        assert.Nil(t, resp)
+        assert.Contains(t, err.Error(), "unknown soil type")
+    })
+
+    t.Run("yield uses fallback factor", func(t *testing.T) {
+        service := newPeatService(t)
+        baseline, err := service.PlanForYieldGoal(ctx, gardenID, "Tomatoes", 7.0)
+        require.NoError(t, err)
+        require.Len(t, baseline.Options, 4, "an unknown soil must not zero the yield")
+
+        require.NoError(t, service.SetSoilConfig(cropmanager.SoilConfig{UnknownSoilFactor: 0.5}))
+        adjusted, err := service.PlanForYieldGoal(ctx, gardenID, "Tomatoes", 7.0)
+        require.NoError(t, err)
+        require.Len(t, adjusted.Options, 4)
+
+        // Halving the efficiency halves the yield of each bag
+        for i, option := range adjusted.Options {
+            before := baseline.Options[i]
+            require.Equal(t, before.BagSize, option.BagSize)
+            assert.InDelta(t, before.WeeklyYield/float64(before.GrowBags)/2, option.WeeklyYield/float64(option.GrowBags), 0.0001)
+        }
+    })
+
+    t.Run("non-positive fallback factor rejected", func(t *testing.T) {
+        service := newPeatService(t)
+        err := service.SetSoilConfig(cropmanager.SoilConfig{UnknownSoilFactor: 0})
+        assert.Error(t, err)
+    })
+}
+
+// failingAdvisor simulates an unavailable AI advisor
+type failingAdvisor struct{}
+
+func (failingAdvisor) SuggestCrops(ctx context.Context, conditions map[string]string) ([]string, error) {
+    return nil, errors.New("advisor unavailable")
+}
+
+// TestRecommendCrops tests rule-based crop recommendations and the AI fallback path
+func TestRecommendCrops(t *testing.T) {
+    ctx := context.Background()
+
+    // assertSaneRanking checks ranks are sequential, scores descend, and yields are positive
+    assertSaneRanking := func(t *testing.T, resp *dto.CropRecommendationResponse) {
+        require.NotEmpty(t, resp.Recommendations)
+        for i, rec := range resp.Recommendations {
+            assert.Equal(t, i+1, rec.Rank)
+            assert.Greater(t, rec.SuggestedGrowBags, 0)
+            assert.Greater(t, rec.ExpectedWeeklyYield, 0.0)
+            if i > 0 {
+                assert.LessOrEqual(t, rec.Score, resp.Recommendations[i-1].Score)
+            }
+        }
+    }
+
+    t.Run("rule-based without advisor", func(t *testing.T) {
+        suite := setupTestSuite(t)
+        suite.mockDB.On("First", &models.Garden{}, []interface{}{suite.testData.garden.ID}).
+            Return(nil, nil)
+        suite.mockDB.On("Find", &[]models.Crop{}, "garden_id = ? AND deleted_at IS NULL",
+            suite.testData.garden.ID).Return(nil, nil)
+
+        resp, err := suite.service.RecommendCrops(ctx, suite.testData.garden.ID)
+        require.NoError(t, err)
+        assert.Equal(t, dto.RecommendationSourceRules, resp.Source)
+        assertSaneRanking(t, resp)
+
+        // Sun-loving fruiting crops lead in a full sun, loamy garden
+        top := []string{resp.Recommendations[0].Name, resp.Recommendations[1].Name, resp.Recommendations[2].Name}
+        assert.ElementsMatch(t, []string{"Tomatoes", "Peppers", "Eggplant"}, top)
+    })
+
+    t.Run("failing advisor falls back to rules", func(t *testing.T) {
+        suite := setupTestSuite(t)
+        suite.mockDB.On("First", &models.Garden{}, []interface{}{suite.testData.garden.ID}).
+            Return(nil, nil)
+        suite.mockDB.On("Find", &[]models.Crop{}, "garden_id = ? AND deleted_at IS NULL",
+            suite.testData.garden.ID).Return(nil, nil)
+        suite.service.SetCropAdvisor(failingAdvisor{})
+
+        resp, err := suite.service.RecommendCrops(ctx, suite.testData.garden.ID)
+        require.NoError(t, err)
+        assert.Equal(t, dto.RecommendationSourceRules, resp.Source)
+        assertSaneRanking(t, resp)
+    })
+
+    t.Run("shade garden excludes sun-only crops", func(t *testing.T) {
+        gardenID := "shade-garden-id"
+        mockDB := mocks.NewMockDB(true, false)
+        testCache := cache.New(1*time.Hour, 2*time.Hour)
+        testCache.Set("garden:"+gardenID, &models.Garden{
+            ID:       gardenID,
+            UserID:   "test-user-id",
+            Length:   10.0,
+            Width:    5.0,
+            SoilType: "clay_soil",
+            Sunlight: "full_shade",
+        }, time.Hour)
+        mockDB.On("Find", &[]models.Crop{}, "garden_id = ? AND deleted_at IS NULL", gardenID).
+            Return(nil, nil)
+
+        logger, err := zap.NewDevelopment()
+        require.NoError(t, err)
+        service := cropmanager.NewCropService(mockDB, testCache, logger)
+
+        resp, err := service.RecommendCrops(ctx, gardenID)
+        require.NoError(t, err)
+        assertSaneRanking(t, resp)
+        for _, rec := range resp.Recommendations {
+            assert.NotContains(t, []string{"Tomatoes", "Peppers", "Eggplant"}, rec.Name)
+        }
+    })
+}
+
+// countingGardenAdvisor records each garden advisor call and the crops it covered
+type countingGardenAdvisor struct {
+    calls int
+    crops map[string]map[string]string
+}
+
+func (a *countingGardenAdvisor) GetGardenRecommendations(ctx context.Context, gardenConditions map[string]string, cropConditions map[string]map[string]string) ([]string, error) {
+    a.calls++
+    a.crops = cropConditions
+    return []string{"Water all grow bags deeply in the morning"}, nil
+}
+
+// failingGardenAdvisor simulates an unavailable garden advisor
+type failingGardenAdvisor struct{}
+
+func (failingGardenAdvisor) GetGardenRecommendations(ctx context.Context, gardenConditions map[string]string, cropConditions map[string]map[string]string) ([]string, error) {
+    return nil, errors.New("advisor unavailable")
+}
+
+// TestGardenRecommendations tests that a garden's crops share one advisor call and that
+// rule-based tips are given when the advisor fails
+func TestGardenRecommendations(t *testing.T) {
+    ctx := context.Background()
+    gardenID := "tips-garden-id"
+    crops := []models.Crop{
+        {ID: "tips-tomatoes", GardenID: gardenID, Name: "Tomatoes", GrowBags: 3, BagSize: "12\""},
+        {ID: "tips-spinach", GardenID: gardenID, Name: "Spinach", GrowBags: 2, BagSize: "8\"", Organic: true},
+    }
+
+    t.Run("single advisor call covers all crops", func(t *testing.T) {
+        service := newLayoutService(t, gardenID, 10.0, 10.0, crops)
+        advisor := &countingGardenAdvisor{}
+        service.SetGardenAdvisor(advisor)
+
+        resp, err := service.GardenRecommendations(ctx, gardenID)
+        require.NoError(t, err)
+        assert.Equal(t, dto.RecommendationSourceAI, resp.Source)
+        assert.Equal(t, []string{"Tomatoes", "Spinach"}, resp.Crops)
+        assert.Equal(t, []string{"Water all grow bags deeply in the morning"}, resp.Recommendations)
+
+        assert.Equal(t, 1, advisor.calls)
+        require.Len(t, advisor.crops, 2)
+        assert.Equal(t, "3", advisor.crops["Tomatoes"]["growBags"])
+        assert.Equal(t, dto.GrowingMethodOrganic, advisor.crops["Spinach"]["growingMethod"])
+    })
+
+    t.Run("failing advisor falls back to rules", func(t *testing.T) {
+        service := newLayoutService(t, gardenID, 10.0, 10.0, crops)
+        service.SetGardenAdvisor(failingGardenAdvisor{})
+
+        resp, err := service.GardenRecommendations(ctx, gardenID)
+        require.NoError(t, err)
+        assert.Equal(t, dto.RecommendationSourceRules, resp.Source)
+        assert.NotEmpty(t, resp.Recommendations)
+
+        // Spinach prefers partial shade to the garden's full sun
+        assert.Contains(t, resp.Recommendations[0], "Spinach")
+    })
+
+    t.Run("garden without crops skips the advisor", func(t *testing.T) {
+        service := newLayoutService(t, gardenID, 10.0, 10.0, nil)
+        advisor := &countingGardenAdvisor{}
+        service.SetGardenAdvisor(advisor)
+
+        resp, err := service.GardenRecommendations(ctx, gardenID)
+        require.NoError(t, err)
+        assert.Equal(t, dto.RecommendationSourceRules, resp.Source)
+        assert.Zero(t, advisor.calls)
+        assert.Len(t, resp.Recommendations, 1)
+    })
+}
+
+// TestSuggestCropsForRemainingSpace tests that suggested crops fit the garden's free space
+// in the bag size yielding the most, ranked by expected yield
+func TestSuggestCropsForRemainingSpace(t *testing.T) {
+    ctx := context.Background()
+    gardenID := "leftover-garden-id"
+
+    // A 4 x 3 ft balcony with nothing planted yet
+    service := newBagLimitService(t, gardenID, 4, 3)
+
+    resp, err := service.SuggestCropsForRemainingSpace(ctx, gardenID)
+    require.NoError(t, err)
+    assert.Equal(t, gardenID, resp.GardenID)
+    require.Greater(t, resp.AvailableSpace, 0.0)
+    require.NotEmpty(t, resp.Suggestions)
+    assert.Empty(t, resp.Message)
+
+    garden := &models.Garden{ID: gardenID, Length: 4, Width: 3, SoilType: "loamy_soil", Sunlight: "full_sun"}
+    for i, suggestion := range resp.Suggestions {
+        assert.Equal(t, i+1, suggestion.Rank)
+        assert.GreaterOrEqual(t, suggestion.GrowBags, 1)
+        assert.LessOrEqual(t, suggestion.SpaceRequired, resp.AvailableSpace)
+        assert.Greater(t, suggestion.ExpectedWeeklyYield, 0.0)
+        if i > 0 {
+            assert.LessOrEqual(t, suggestion.ExpectedWeeklyYield, resp.Suggestions[i-1].ExpectedWeeklyYield)
+        }
+
+        // No other bag size of the crop yields more within the free space
+        for _, bagSize := range []string{dto.BagSize8, dto.BagSize10, dto.BagSize12, dto.BagSize14} {
+            probe := &models.Crop{Name: suggestion.Name, BagSize: bagSize, GrowBags: 1, Garden: garden}
+            probe.GrowBags = int(resp.AvailableSpace / probe.CalculateSpaceRequired())
+            if probe.GrowBags < 1 {
+                continue
+            }
+            assert.LessOrEqual(t, probe.CalculateYield()*7, suggestion.ExpectedWeeklyYield+1e-9,
+                "%s in %s bags", suggestion.Name, bagSize)
+        }
+    }
+
+    t.Run("shade garden excludes sun-only crops", func(t *testing.T) {
+        shadeID := "leftover-shade-garden-id"
+        mockDB := mocks.NewMockDB(true, false)
+        testCache := cache.New(1*time.Hour, 2*time.Hour)
+        testCache.Set("garden:"+shadeID, &models.Garden{
+            ID:       shadeID,
+            UserID:   "test-user-id",
+            Length:   6.0,
+            Width:    4.0,
+            SoilType: "loamy_soil",
+            Sunlight: "full_shade",
+        }, time.Hour)
+        mockDB.On("Find", &[]models.Crop{}, "garden_id = ? AND deleted_at IS NULL", shadeID).
+            Return(nil, nil)
+
+        logger, err := zap.NewDevelopment()
+        require.NoError(t, err)
+        service := cropmanager.NewCropService(mockDB, testCache, logger)
+
+        resp, err := service.SuggestCropsForRemainingSpace(ctx, shadeID)
+        require.NoError(t, err)
+        require.NotEmpty(t, resp.Suggestions)
+        for _, suggestion := range resp.Suggestions {
+            assert.NotContains(t, []string{"Tomatoes", "Peppers", "Eggplant"}, suggestion.Name)
+        }
+    })
+}
+
+// TestStarredCrops tests toggling the starred flag and filtering crop lists by it
+func TestStarredCrops(t *testing.T) {
+    suite := setupTestSuite(t)
+    ctx := context.Background()
+    cropID := suite.testData.crops[0].ID
+
+    suite.mockDB.On("First", &models.Crop{}, []interface{}{"id = ? AND deleted_at IS NULL", cropID}).
+        Return(nil, nil)
+
+    t.Run("toggle stars and unstars", func(t *testing.T) {
+        starred, err := suite.service.ToggleCropStar(ctx, cropID)
+        require.NoError(t, err)
+        assert.True(t, starred.Starred)
+
+        unstarred, err := suite.service.ToggleCropStar(ctx, cropID)
+        require.NoError(t, err)
+        assert.False(t, unstarred.Starred)
+    })
+
+    t.Run("toggle unknown crop", func(t *testing.T) {
+        suite.mockDB.On("First", &models.Crop{}, []interface{}{"id = ? AND deleted_at IS NULL", "missing-crop"}).
+            Return(nil, mocks.ErrNotFound)
+
+        resp, err := suite.service.ToggleCropStar(ctx, "missing-crop")
+        assert.Error(t, err)
+        assert.Nil(t, resp)
+    })
+
+    t.Run("filter by starred", func(t *testing.T) {
+        _, err := suite.service.ToggleCropStar(ctx, cropID)
+        require.NoError(t, err)
+
+        suite.mockDB.On("Find", &[]models.Crop{}, "deleted_at IS NULL AND garden_id = ? AND starred = ?",
+            suite.testData.garden.ID, true).Return(nil, nil)
+
+        starred := true
+        list, err := suite.service.ListCrops(ctx, dto.PaginationParams{
+            Page:     1,
+            PerPage:  10,
+            GardenID: suite.testData.garden.ID,
+            Starred:  &starred,
+        })
+        require.NoError(t, err)
+        for _, crop := range list.Crops {
+            assert.True(t, crop.Starred)
+        }
+
+        unstarred := false
+        list, err = suite.service.ListCrops(ctx, dto.PaginationParams{
+            Page:     1,
+            PerPage:  10,
+            GardenID: suite.testData.garden.ID,
+            Starred:  &unstarred,
+        })
+        require.NoError(t, err)
+        for _, crop := range list.Crops {
+            assert.False(t, crop.Starred)
+        }
+    })
+
+    t.Run("invalid pagination", func(t *testing.T) {
+        list, err := suite.service.ListCrops(ctx, dto.PaginationParams{Page: 0, PerPage: 10})
+        assert.Error(t, err)
+        assert.Nil(t, list)
+    })
+}
+
+// TestOrganicCrops tests tagging crops by growing method and filtering crop lists by it
+func TestOrganicCrops(t *testing.T) {
+    suite := setupTestSuite(t)
+    ctx := context.Background()
+
+    suite.mockDB.On("First", &models.Garden{}, []interface{}{suite.testData.garden.ID}).
+        Return(nil, nil)
+    suite.mockDB.On("Create", &models.Crop{}).Return(nil, nil)
+
+    organic, err := suite.service.CreateCrop(ctx, &dto.CropRequest{
+        GardenID:       suite.testData.garden.ID,
+        Name:           "Spinach",
+        QuantityNeeded: 2,
+        GrowBags:       1,
+        BagSize:        "10\"",
+        Organic:        true,
+    })
+    require.NoError(t, err)
+    assert.True(t, organic.Organic)
+
+    conventional, err := suite.service.CreateCrop(ctx, &dto.CropRequest{
+        GardenID:       suite.testData.garden.ID,
+        Name:           "Lettuce",
+        QuantityNeeded: 2,
+        GrowBags:       1,
+        BagSize:        "10\"",
+    })
+    require.NoError(t, err)
+    assert.False(t, conventional.Organic, "crops are conventional unless tagged organic")
+
+    listNames := func(t *testing.T, organicOnly bool) []string {
+        suite.mockDB.On("Find", &[]models.Crop{}, "deleted_at IS NULL AND garden_id = ? AND organic = ?",
+            suite.testData.garden.ID, organicOnly).Return(nil, nil)
+
+        list, err := suite.service.ListCrops(ctx, dto.PaginationParams{
+            Page:     1,
+            PerPage:  10,
+            GardenID: suite.testData.garden.ID,
+            Organic:  &organicOnly,
+        })
+        require.NoError(t, err)
+
+        names := make([]string, 0, len(list.Crops))
+        for _, crop := range list.Crops {
+            assert.Equal(t, organicOnly, crop.Organic)
+            names = append(names, crop.Name)
+        }
+        return names
+    }
+
+    t.Run("filter organic", func(t *testing.T) {
+        names := listNames(t, true)
+        assert.Contains(t, names, "Spinach")
+        assert.NotContains(t, names, "Lettuce")
+    })
+
+    t.Run("filter conventional", func(t *testing.T) {
+        names := listNames(t, false)
+        assert.Contains(t, names, "Lettuce")
+        assert.NotContains(t, names, "Spinach")
+    })
+}
+
+// TestCloseCropService tests that Close is idempotent and rejects operations afterward
+func TestCloseCropService(t *testing.T) {
+    suite := setupTestSuite(t)
+    ctx := context.Background()
+
+    suite.mockDB.On("First", &models.Garden{}, []interface{}{suite.testData.garden.ID}).
+        Return(nil, nil)
+    suite.mockDB.On("Find", &[]models.Crop{}, "garden_id = ? AND deleted_at IS NULL",
+        suite.testData.garden.ID).Return(nil, nil)
+
+    t.Run("close is safe to call repeatedly", func(t *testing.T) {
+        closeCtx, cancel := context.WithTimeout(ctx, time.Second)
+        defer cancel()
+
+        require.NoError(t, suite.service.Close(closeCtx))
+        assert.NoError(t, suite.service.Close(closeCtx))
+    })
+
+    t.Run("operations rejected after close", func(t *testing.T) {
+        resp, err := suite.service.ValidateSpaceCapacity(ctx, suite.testData.garden.ID, 1)
+        require.Error(t, err)
+        assert.Nil(t, resp)
+        assert.Contains(t, err.Error(), "shutting down")
+
+        crop, err := suite.service.ToggleCropStar(ctx, suite.testData.crops[0].ID)
+        assert.Error(t, err)
+        assert.Nil(t, crop)
+    })
+
+    t.Run("close times out on stuck operations", func(t *testing.T) {
+        busy := setupTestSuite(t)
+        busy.mockDB.SetLatency("find", 500*time.Millisecond)
+        busy.mockDB.On("First", &models.Garden{}, []interface{}{busy.testData.garden.ID}).
+            Return(nil, nil)
+        busy.mockDB.On("Find", &[]models.Crop{}, "garden_id = ? AND deleted_at IS NULL",
+            busy.testData.garden.ID).Return(nil, nil)
+
+        started := make(chan struct{})
+        go func() {
+            close(started)
+            _, _ = busy.service.ValidateSpaceCapacity(ctx, busy.testData.garden.ID, 1)
+        }()
+        <-started
+        time.Sleep(10 * time.Millisecond)
+
+        closeCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+        defer cancel()
+        assert.Error(t, busy.service.Close(closeCtx))
+    })
+}
+
+// TestEnvironmentYieldAdjustment tests optional yield adjustment by growing conditions
+func TestEnvironmentYieldAdjustment(t *testing.T) {
+    ctx := context.Background()
+
+    favorable := &dto.GrowingConditions{Temperature: 24, Humidity: 60, LightLevel: "high"}
+    unfavorable := &dto.GrowingConditions{Temperature: 35, Humidity: 90, LightLevel: "low"}
+
+    newRequest := func(suite *TestSuite, conditions *dto.GrowingConditions) *dto.CropRequest {
+        suite.mockDB.On("First", &models.Garden{}, []interface{}{suite.testData.garden.ID}).
+            Return(nil, nil)
+        suite.mockDB.On("Create", &models.Crop{}).Return(nil, nil)
+        return &dto.CropRequest{
+            GardenID:       suite.testData.garden.ID,
+            Name:           "Tomatoes",
+            QuantityNeeded: 5,
+            GrowBags:       3,
+            BagSize:        "12\"",
+            Conditions:     conditions,
+        }
+    }
+
+    t.Run("conditions ignored by default", func(t *testing.T) {
+        suite := setupTestSuite(t)
+
+        baseline, err := suite.service.CreateCrop(ctx, newRequest(suite, nil))
+        require.NoError(t, err)
+        withConditions, err := suite.service.CreateCrop(ctx, newRequest(suite, favorable))
+        require.NoError(t, err)
+
+        assert.Equal(t, baseline.EstimatedYield, withConditions.EstimatedYield)
+    })
+
+    t.Run("favorable and unfavorable conditions when enabled", func(t *testing.T) {
+        suite := setupTestSuite(t)
+        suite.service.SetYieldConfig(cropmanager.YieldConfig{AdjustForEnvironment: true})
+
+        baseline, err := suite.service.CreateCrop(ctx, newRequest(suite, nil))
+        require.NoError(t, err)
+        better, err := suite.service.CreateCrop(ctx, newRequest(suite, favorable))
+        require.NoError(t, err)
+        worse, err := suite.service.CreateCrop(ctx, newRequest(suite, unfavorable))
+        require.NoError(t, err)
+
+        assert.Greater(t, better.EstimatedYield, baseline.EstimatedYield)
+        assert.Less(t, worse.EstimatedYield, baseline.EstimatedYield)
+        // Adjustments stay within the 10% accuracy target
+        assert.InDelta(t, baseline.EstimatedYield, better.EstimatedYield, baseline.EstimatedYield*0.1)
+        assert.InDelta(t, baseline.EstimatedYield, worse.EstimatedYield, baseline.EstimatedYield*0.1)
+    })
+
+    t.Run("adjustment bounded directly on the model", func(t *testing.T) {
+        crop := &models.Crop{Name: "Lettuce", GrowBags: 2, BagSize: "10\""}
+        baseline := crop.CalculateYield()
+
+        crop.Conditions = favorable
+        assert.InDelta(t, baseline*1.1, crop.CalculateYield(), 1e-9)
+
+        crop.Conditions = unfavorable
+        assert.InDelta(t, baseline*0.9, crop.CalculateYield(), 1e-9)
+    })
+}
+
+// TestCropNameNormalization tests that crop name variants resolve to the same yield table entry
+func TestCropNameNormalization(t *testing.T) {
+    testCases := []struct {
+        input     string
+        canonical string
+    }{
+        {input: "tomato", canonical: dto.CropTomatoes},
+        {input: "Tomatoes", canonical: dto.CropTomatoes},
+        {input: "TOMATOES", canonical: dto.CropTomatoes},
+        {input: "  Tomato ", canonical: dto.CropTomatoes},
+        {input: "bell peppers", canonical: dto.CropPeppers},
+        {input: "Aubergine", canonical: dto.CropEggplant},
+        {input: "lettuces", canonical: dto.CropLettuce},
+        {input: "Okra", canonical: "Okra"},
+    }
+
+    for _, tc := range testCases {
+        t.Run(tc.input, func(t *testing.T) {
+            assert.Equal(t, tc.canonical, dto.NormalizeCropName(tc.input))
+        })
+    }
+
+    t.Run("variants share the tomato yield", func(t *testing.T) {
+        expected := (&models.Crop{Name: dto.CropTomatoes, GrowBags: 3, BagSize: "12\""}).CalculateYield()
+        for _, name := range []string{"tomato", "Tomatoes", "TOMATOES"} {
+            crop := &models.Crop{Name: name, GrowBags: 3, BagSize: "12\""}
+            assert.Equal(t, expected, crop.CalculateYield(), "yield for %q", name)
+        }
+
+        unknown := (&models.Crop{Name: "Okra", GrowBags: 3, BagSize: "12\""}).CalculateYield()
+        assert.NotEqual(t, expected, unknown)
+    })
+}
+
+// newLayoutService creates a crop service for a cached garden of the given size holding the given crops
+func newLayoutService(t *testing.T, gardenID string, length, width float64, crops []models.Crop) *cropmanager.CropService {
+    mockDB := mocks.NewMockDB(true, false)
+    testCache := cache.New(1*time.Hour, 2*time.Hour)
+    testCache.Set("garden:"+gardenID, &models.Garden{
+        ID:       gardenID,
+        UserID:   "test-user-id",
+        Length:   length,
+        Width:    width,
+        SoilType: "loamy_soil",
+        Sunlight: "full_sun",
+    }, time.Hour)
+    mockDB.On("Find", &[]models.Crop{}, "garden_id = ? AND deleted_at IS NULL", gardenID).
+        Return(crops, nil)
+    mockDB.On("Create", &models.GardenLayout{}).Return(nil, nil)
+
+    logger, err := zap.NewDevelopment()
+    require.NoError(t, err)
+    return cropmanager.NewCropService(mockDB, testCache, logger)
+}
+
+// TestComputeGardenLayout tests that layouts place every bag or report the shortfall
+func TestComputeGardenLayout(t *testing.T) {
+    ctx := context.Background()
+
+    t.Run("all bags accommodated", func(t *testing.T) {
+        gardenID := "layout-garden-id"
+        service := newLayoutService(t, gardenID, 10.0, 10.0, []models.Crop{
+            {ID: "layout-tomatoes", GardenID: gardenID, Name: "Tomatoes", GrowBags: 3, BagSize: "12\""},
+            {ID: "layout-spinach", GardenID: gardenID, Name: "Spinach", GrowBags: 2, BagSize: "8\""},
+        })
+
+        layout, err := service.ComputeGardenLayout(ctx, gardenID)
+        require.NoError(t, err)
+        assert.True(t, layout.Complete)
+        assert.Equal(t, 5, layout.RequiredBags)
+        assert.Equal(t, 5, layout.PlacedBags)
+        assert.Empty(t, layout.Shortfall)
+        require.Len(t, layout.Placements, 5)
+
+        perCrop := make(map[string]int)
+        for _, placement := range layout.Placements {
+            perCrop[placement.CropID]++
+            assert.True(t, placement.X > 0 && placement.X < 10.0, "x within garden")
+            assert.True(t, placement.Y > 0 && placement.Y < 10.0, "y within garden")
+        }
+        assert.Equal(t, map[string]int{"layout-tomatoes": 3, "layout-spinach": 2}, perCrop)
+    })
+
+    t.Run("shortfall reported when bags do not fit", func(t *testing.T) {
+        gardenID := "small-layout-garden-id"
+        service := newLayoutService(t, gardenID, 4.0, 3.0, []models.Crop{
+            {ID: "crowded-peppers", GardenID: gardenID, Name: "Peppers", GrowBags: 6, BagSize: "12\""},
+        })
+
+        layout, err := service.ComputeGardenLayout(ctx, gardenID)
+        require.NoError(t, err)
+        assert.False(t, layout.Complete)
+        assert.Equal(t, 6, layout.RequiredBags)
+        assert.Equal(t, 4, layout.PlacedBags)
+        require.Len(t, layout.Shortfall, 1)
+        assert.Equal(t, "crowded-peppers", layout.Shortfall[0].CropID)
+        assert.Equal(t, 2, layout.Shortfall[0].UnplacedBags)
+    })
+
+    t.Run("unknown garden", func(t *testing.T) {
+        suite := setupTestSuite(t)
+        suite.mockDB.On("First", &models.Garden{}, []interface{}{"missing-garden-id"}).
+            Return(nil, mocks.ErrNotFound)
+
+        layout, err := suite.service.ComputeGardenLayout(ctx, "missing-garden-id")
+        assert.Error(t, err)
+        assert.Nil(t, layout)
+    })
+}
+
+// TestLayoutEfficiencyScore tests that the layout score and its breakdown follow the
+// garden's utilization, fit, and crop compatibility
+func TestLayoutEfficiencyScore(t *testing.T) {
+    ctx := context.Background()
+
+    score := func(t *testing.T, gardenID string, length, width float64, crops []models.Crop) *dto.LayoutEfficiencyResponse {
+        service := newLayoutService(t, gardenID, length, width, crops)
+        response, err := service.LayoutEfficiencyScore(ctx, gardenID)
+        require.NoError(t, err)
+        assert.Equal(t, gardenID, response.GardenID)
+        for _, part := range []float64{response.Score, response.Breakdown.SpaceUtilization, response.Breakdown.Accessibility, response.Breakdown.Compatibility} {
+            assert.GreaterOrEqual(t, part, 0.0)
+            assert.LessOrEqual(t, part, 100.0)
+        }
+        return response
+    }
+
+    t.Run("score rises with utilization", func(t *testing.T) {
+        sparse := score(t, "sparse-garden-id", 10.0, 10.0, []models.Crop{
+            {ID: "sparse-tomatoes", GardenID: "sparse-garden-id", Name: "Tomatoes", GrowBags: 2, BagSize: "12\""},
+        })
+        full := score(t, "full-garden-id", 10.0, 10.0, []models.Crop{
+            {ID: "full-tomatoes", GardenID: "full-garden-id", Name: "Tomatoes", GrowBags: 12, BagSize: "12\""},
+        })
+
+        assert.Greater(t, full.Breakdown.SpaceUtilization, sparse.Breakdown.SpaceUtilization)
+        assert.Greater(t, full.Score, sparse.Score)
+        assert.Equal(t, sparse.Breakdown.Compatibility, full.Breakdown.Compatibility)
+        assert.Equal(t, 100.0, full.Breakdown.Accessibility)
+    })
+
+    t.Run("bags that do not fit lower accessibility", func(t *testing.T) {
+        crowded := score(t, "crowded-garden-id", 4.0, 3.0, []models.Crop{
+            {ID: "crowded-peppers", GardenID: "crowded-garden-id", Name: "Peppers", GrowBags: 6, BagSize: "12\""},
+        })
+
+        assert.Less(t, crowded.Breakdown.Accessibility, 100.0)
+    })
+
+    t.Run("poor companions lower compatibility", func(t *testing.T) {
+        alone := score(t, "alone-garden-id", 10.0, 10.0, []models.Crop{
+            {ID: "alone-tomatoes", GardenID: "alone-garden-id", Name: "Tomatoes", GrowBags: 4, BagSize: "12\""},
+        })
+        paired := score(t, "paired-garden-id", 10.0, 10.0, []models.Crop{
+            {ID: "paired-tomatoes", GardenID: "paired-garden-id", Name: "Tomatoes", GrowBags: 2, BagSize: "12\""},
+            {ID: "paired-eggplant", GardenID: "paired-garden-id", Name: "Eggplant", GrowBags: 2, BagSize: "12\""},
+        })
+
+        assert.Less(t, paired.Breakdown.Compatibility, alone.Breakdown.Compatibility)
+    })
+
+    t.Run("empty garden scores zero", func(t *testing.T) {
+        empty := score(t, "empty-garden-id", 10.0, 10.0, []models.Crop{})
+
+        assert.Equal(t, 0.0, empty.Score)
+    })
+}
+
+// TestGetCropWhileDatabaseDown tests that crop reads degrade to stale cached copies
+func TestGetCropWhileDatabaseDown(t *testing.T) {
+    ctx := context.Background()
+
+    newWarmSuite := func(t *testing.T) (*TestSuite, string) {
+        suite := setupTestSuite(t)
+        cropID := suite.testData.crops[0].ID
+        suite.mockDB.On("First", &models.Crop{}, []interface{}{"id = ? AND deleted_at IS NULL", cropID}).
+            Return(nil, nil)
+
+        crop, err := suite.service.GetCrop(ctx, cropID)
+        require.NoError(t, err)
+        assert.False(t, crop.Stale)
+        return suite, cropID
+    }
+
+    t.Run("stale crop served with warm cache", func(t *testing.T) {
+        suite, cropID := newWarmSuite(t)
+        suite.mockDB.SetTimeout(true)
+
+        crop, err := suite.service.GetCrop(ctx, cropID)
+        require.NoError(t, err)
+        assert.Equal(t, cropID, crop.ID)
+        assert.True(t, crop.Stale)
+    })
+
+    t.Run("error when stale reads disabled", func(t *testing.T) {
+        suite, cropID := newWarmSuite(t)
+        suite.service.SetReadConfig(cropmanager.ReadConfig{ServeStale: false})
+        suite.mockDB.SetTimeout(true)
+
+        crop, err := suite.service.GetCrop(ctx, cropID)
+        assert.Error(t, err)
+        assert.Nil(t, crop)
+    })
+
+    t.Run("error with cold cache", func(t *testing.T) {
+        suite := setupTestSuite(t)
+        suite.mockDB.SetTimeout(true)
+
+        crop, err := suite.service.GetCrop(ctx, "uncached-crop")
+        assert.Error(t, err)
+        assert.Nil(t, crop)
+    })
+}
+
+// TestCanopySpreadSpace tests that sprawling crops need more space than compact ones in the same bags
+func TestCanopySpreadSpace(t *testing.T) {
+    for _, bagSize := range []string{dto.BagSize8, dto.BagSize10, dto.BagSize12, dto.BagSize14} {
+        t.Run(bagSize, func(t *testing.T) {
+            tomatoes := &models.Crop{Name: dto.CropTomatoes, GrowBags: 6, BagSize: bagSize}
+            lettuce := &models.Crop{Name: dto.CropLettuce, GrowBags: 6, BagSize: bagSize}
+
+            assert.Greater(t, tomatoes.CalculateSpaceRequired(), lettuce.CalculateSpaceRequired())
+            assert.InDelta(t, lettuce.CalculateSpaceRequired()*1.5, tomatoes.CalculateSpaceRequired(), 1e-9)
+        })
+    }
+
+    t.Run("compact and unknown crops use the bare bag footprint", func(t *testing.T) {
+        lettuce := &models.Crop{Name: "lettuce", GrowBags: 4, BagSize: dto.BagSize12}
+        okra := &models.Crop{Name: "Okra", GrowBags: 4, BagSize: dto.BagSize12}
+
+        assert.InDelta(t, 4.0, lettuce.CalculateSpaceRequired(), 1e-9)
+        assert.InDelta(t, 4.0, okra.CalculateSpaceRequired(), 1e-9)
+    })
+}
+
+// newBagLimitService creates a crop service for a small cached garden with no existing crops
+func newBagLimitService(t *testing.T, gardenID string, length, width float64) *cropmanager.CropService {
+    mockDB := mocks.NewMockDB(true, false)
+    testCache := cache.New(1*time.Hour, 2*time.Hour)
+    testCache.Set("garden:"+gardenID, &models.Garden{
+        ID:       gardenID,
+        UserID:   "test-user-id",
+        Length:   length,
+        Width:    width,
+        SoilType: "loamy_soil",
+        Sunlight: "full_sun",
+    }, time.Hour)
+    mockDB.On("Find", &[]models.Crop{}, "garden_id = ? AND deleted_at IS NULL", gardenID).
+        Return(nil, nil)
+    mockDB.On("Create", &models.Crop{}).Return(nil, nil)
+    mockDB.On("Create", mock.AnythingOfType("*models.UtilizationSnapshot")).Return(nil, nil)
+
+    logger, err := zap.NewDevelopment()
+    require.NoError(t, err)
+    return cropmanager.NewCropService(mockDB, testCache, logger)
+}
+
+// TestPerCropGrowBagLimit tests that large crops are limited below the global maximum in a small garden
+func TestPerCropGrowBagLimit(t *testing.T) {
+    ctx := context.Background()
+    gardenID := "balcony-garden-id"
+
+    // A 4 x 3 ft balcony fits 8 tomato bags (1.5 sq ft each) but 12 lettuce bags (1 sq ft each)
+    newRequest := func(name string, growBags int) *dto.CropRequest {
+        return &dto.CropRequest{
+            GardenID:       gardenID,
+            Name:           name,
+            QuantityNeeded: 5,
+            GrowBags:       growBags,
+            BagSize:        dto.BagSize12,
+        }
+    }
+
+    t.Run("per-crop maximum below global maximum", func(t *testing.T) {
+        tomatoes := &models.Crop{Name: dto.CropTomatoes, BagSize: dto.BagSize12}
+        lettuce := &models.Crop{Name: dto.CropLettuce, BagSize: dto.BagSize12}
+
+        assert.Equal(t, 8, tomatoes.MaxGrowBagsFor(12))
+        assert.Equal(t, 12, lettuce.MaxGrowBagsFor(12))
+        assert.Less(t, tomatoes.MaxGrowBagsFor(12), dto.MaxGrowBags)
+        assert.Equal(t, dto.MaxGrowBags, tomatoes.MaxGrowBagsFor(10000))
+    })
+
+    t.Run("exceeding the maximum suggests a count", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 4, 3)
+
+        resp, err := service.CreateCrop(ctx, newRequest("Tomatoes", 10))
+        require.Error(t, err)
+        assert.Nil(t, resp)
+        assert.Contains(t, err.Error(), "GROW_BAG_LIMIT")
+        assert.Contains(t, err.Error(), "Try 8 grow bags")
+    })
+
+    t.Run("compact crop within its maximum accepted", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 4, 3)
+
+        resp, err := service.CreateCrop(ctx, newRequest("Lettuce", 10))
+        require.NoError(t, err)
+        assert.Equal(t, 10, resp.GrowBags)
+        assert.Zero(t, resp.RequestedGrowBags)
+    })
+
+    t.Run("clamp policy reduces to the maximum", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 4, 3)
+        require.NoError(t, service.SetBagLimitConfig(cropmanager.BagLimitConfig{Policy: cropmanager.BagLimitClamp}))
+
+        resp, err := service.CreateCrop(ctx, newRequest("Tomatoes", 10))
+        require.NoError(t, err)
+        assert.Equal(t, 8, resp.GrowBags)
+        assert.Equal(t, 10, resp.RequestedGrowBags)
+    })
+
+    t.Run("global policy only enforces the global maximum", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 4, 3)
+        require.NoError(t, service.SetBagLimitConfig(cropmanager.BagLimitConfig{Policy: cropmanager.BagLimitGlobal}))
+
+        resp, err := service.CreateCrop(ctx, newRequest("Tomatoes", 10))
+        require.NoError(t, err)
+        assert.Equal(t, 10, resp.GrowBags)
+    })
+
+    t.Run("unknown policy rejected", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 4, 3)
+        assert.Error(t, service.SetBagLimitConfig(cropmanager.BagLimitConfig{Policy: "ignore"}))
+    })
+}
+
+// TestBagSizeCompatibility tests that crops in bags below their minimum size are warned
+// about with a reduced yield, or rejected under the block policy
+func TestBagSizeCompatibility(t *testing.T) {
+    ctx := context.Background()
+    gardenID := "patio-garden-id"
+
+    newRequest := func(bagSize string) *dto.CropRequest {
+        return &dto.CropRequest{
+            GardenID:       gardenID,
+            Name:           "Tomatoes",
+            QuantityNeeded: 5,
+            GrowBags:       2,
+            BagSize:        bagSize,
+        }
+    }
+
+    t.Run("tomatoes in 8 inch bags warned with reduced yield", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 20, 10)
+
+        resp, err := service.CreateCrop(ctx, newRequest(dto.BagSize8))
+        require.NoError(t, err)
+        assert.Contains(t, resp.BagSizeWarning, "below the 10\" minimum")
+        assert.Contains(t, resp.BagSizeWarning, "reduced to 64%")
+
+        // 0.225 * 2 bags * 0.8 size multiplier, at (8/10)^2 of full yield
+        crop := &models.Crop{Name: dto.CropTomatoes, GrowBags: 2, BagSize: dto.BagSize8}
+        assert.InDelta(t, 0.64, crop.UndersizedBagFactor(), 0.0001)
+        assert.InDelta(t, 0.225*2*0.8*0.64, crop.CalculateYield(), 0.0001)
+    })
+
+    t.Run("tomatoes in 14 inch bags accepted", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 20, 10)
+
+        resp, err := service.CreateCrop(ctx, newRequest(dto.BagSize14))
+        require.NoError(t, err)
+        assert.Empty(t, resp.BagSizeWarning)
+
+        crop := &models.Crop{Name: dto.CropTomatoes, GrowBags: 2, BagSize: dto.BagSize14}
+        assert.Equal(t, 1.0, crop.UndersizedBagFactor())
+        assert.InDelta(t, 0.225*2*1.4, crop.CalculateYield(), 0.0001)
+    })
+
+    t.Run("block policy rejects undersized bags", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 20, 10)
+        require.NoError(t, service.SetBagFitConfig(cropmanager.BagFitConfig{Policy: cropmanager.BagFitBlock}))
+
+        resp, err := service.CreateCrop(ctx, newRequest(dto.BagSize8))
+        require.Error(t, err)
+        assert.Nil(t, resp)
+        assert.Contains(t, err.Error(), "BAG_TOO_SMALL")
+
+        resp, err = service.CreateCrop(ctx, newRequest(dto.BagSize14))
+        require.NoError(t, err)
+        assert.Empty(t, resp.BagSizeWarning)
+    })
+
+    t.Run("compact crops fit the smallest bag", func(t *testing.T) {
+        lettuce := &models.Crop{Name: dto.CropLettuce, GrowBags: 1, BagSize: dto.BagSize8}
+        assert.Equal(t, 1.0, lettuce.UndersizedBagFactor())
+    })
+
+    t.Run("unknown policy rejected", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 20, 10)
+        assert.Error(t, service.SetBagFitConfig(cropmanager.BagFitConfig{Policy: "ignore"}))
+    })
+}
+
+// TestPreviewCropRemoval tests that removal previews report the crop's space and yield
+func TestPreviewCropRemoval(t *testing.T) {
+    ctx := context.Background()
+    gardenID := "removal-garden-id"
+    crops := []models.Crop{
+        {ID: "removal-tomatoes", GardenID: gardenID, Name: "Tomatoes", GrowBags: 4, BagSize: "12\""},
+        {ID: "removal-lettuce", GardenID: gardenID, Name: "Lettuce", GrowBags: 2, BagSize: "8\""},
+    }
+
+    newRemovalService := func(t *testing.T) (*cropmanager.CropService, *mocks.MockDB) {
+        mockDB := mocks.NewMockDB(true, false)
+        testCache := cache.New(1*time.Hour, 2*time.Hour)
+        testCache.Set("garden:"+gardenID, &models.Garden{
+            ID:       gardenID,
+            UserID:   "test-user-id",
+            Length:   10.0,
+            Width:    5.0,
+            SoilType: "loamy_soil",
+            Sunlight: "full_sun",
+        }, time.Hour)
+        mockDB.On("Find", &[]models.Crop{}, "garden_id = ? AND deleted_at IS NULL", gardenID).
+            Return(crops, nil)
+        for i := range crops {
+            _, err := mockDB.Create(&crops[i])
+            require.NoError(t, err)
+            mockDB.On("First", &models.Crop{}, []interface{}{"id = ? AND deleted_at IS NULL", crops[i].ID}).
+                Return(nil, nil)
+        }
+
+        logger, err := zap.NewDevelopment()
+        require.NoError(t, err)
+        return cropmanager.NewCropService(mockDB, testCache, logger), mockDB
+    }
+
+    t.Run("freed space matches the crop's space requirement", func(t *testing.T) {
+        service, _ := newRemovalService(t)
+
+        preview, err := service.PreviewCropRemoval(ctx, "removal-tomatoes")
+        require.NoError(t, err)
+        assert.Equal(t, "removal-tomatoes", preview.CropID)
+        assert.Equal(t, gardenID, preview.GardenID)
+        assert.InDelta(t, crops[0].CalculateSpaceRequired(), preview.FreedSpace, 1e-9)
+        assert.Less(t, preview.ResultingUtilization, preview.CurrentUtilization)
+        assert.Greater(t, preview.LostDailyYield, 0.0)
+    })
+
+    t.Run("removing every crop empties the garden", func(t *testing.T) {
+        service, _ := newRemovalService(t)
+
+        tomatoes, err := service.PreviewCropRemoval(ctx, "removal-tomatoes")
+        require.NoError(t, err)
+        lettuce, err := service.PreviewCropRemoval(ctx, "removal-lettuce")
+        require.NoError(t, err)
+
+        assert.InDelta(t, tomatoes.CurrentUtilization,
+            (tomatoes.CurrentUtilization-tomatoes.ResultingUtilization)+(lettuce.CurrentUtilization-lettuce.ResultingUtilization), 1e-9)
+    })
+
+    t.Run("unknown crop", func(t *testing.T) {
+        service, mockDB := newRemovalService(t)
+        mockDB.On("First", &models.Crop{}, []interface{}{"id = ? AND deleted_at IS NULL", "missing-crop-id"}).
+            Return(nil, mocks.ErrNotFound)
+
+        preview, err := service.PreviewCropRemoval(ctx, "missing-crop-id")
+        assert.Error(t, err)
+        assert.Nil(t, preview)
+    })
+}
+
+// TestCreateCropCapacityWarning tests that creating a crop past 80% utilization warns in the response
+func TestCreateCropCapacityWarning(t *testing.T) {
+    ctx := context.Background()
+    gardenID := "nearly-full-garden-id"
+
+    // A 4 x 2.5 ft garden on loamy soil (1.2 efficiency): 10 lettuce bags use 83% of capacity
+    newRequest := func(growBags int) *dto.CropRequest {
+        return &dto.CropRequest{
+            GardenID:       gardenID,
+            Name:           "Lettuce",
+            QuantityNeeded: 5,
+            GrowBags:       growBags,
+            BagSize:        dto.BagSize12,
+        }
+    }
+
+    t.Run("warning past 80 percent", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 4, 2.5)
+
+        resp, err := service.CreateCrop(ctx, newRequest(10))
+        require.NoError(t, err)
+        assert.Contains(t, resp.CapacityWarning, "approaching garden capacity")
+        assert.Contains(t, resp.CapacityWarning, "83.33%")
+    })
+
+    t.Run("no warning below threshold", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 4, 2.5)
+
+        resp, err := service.CreateCrop(ctx, newRequest(5))
+        require.NoError(t, err)
+        assert.Empty(t, resp.CapacityWarning)
+    })
+
+    t.Run("configurable threshold", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 4, 2.5)
+        require.NoError(t, service.SetCapacityConfig(cropmanager.CapacityConfig{WarningThreshold: 0.9}))
+
+        resp, err := service.CreateCrop(ctx, newRequest(10))
+        require.NoError(t, err)
+        assert.Empty(t, resp.CapacityWarning)
+
+        assert.Error(t, service.SetCapacityConfig(cropmanager.CapacityConfig{WarningThreshold: 1.5}))
+    })
+}
+
+// TestCreateCropOverCapacity tests the block and warn modes for crops exceeding garden capacity
+func TestCreateCropOverCapacity(t *testing.T) {
+    ctx := context.Background()
+    gardenID := "over-capacity-garden-id"
+
+    // 13 lettuce bags in a 4 x 2.5 ft garden on loamy soil use 108% of capacity; the global
+    // bag limit policy lets the request through to the capacity check
+    request := &dto.CropRequest{
+        GardenID:       gardenID,
+        Name:           "Lettuce",
+        QuantityNeeded: 5,
+        GrowBags:       13,
+        BagSize:        dto.BagSize12,
+    }
+    newService := func(t *testing.T, allowOverCapacity bool) *cropmanager.CropService {
+        service := newBagLimitService(t, gardenID, 4, 2.5)
+        require.NoError(t, service.SetBagLimitConfig(cropmanager.BagLimitConfig{Policy: cropmanager.BagLimitGlobal}))
+        require.NoError(t, service.SetCapacityConfig(cropmanager.CapacityConfig{
+            WarningThreshold:  0.8,
+            AllowOverCapacity: allowOverCapacity,
+        }))
+        return service
+    }
+
+    t.Run("block mode rejects", func(t *testing.T) {
+        service := newService(t, false)
+
+        resp, err := service.CreateCrop(ctx, request)
+        require.Error(t, err)
+        assert.Nil(t, resp)
+        assert.Contains(t, err.Error(), "Garden capacity exceeded")
+    })
+
+    t.Run("warn mode creates with strong warning", func(t *testing.T) {
+        service := newService(t, true)
+
+        resp, err := service.CreateCrop(ctx, request)
+        require.NoError(t, err)
+        assert.True(t, resp.OverCapacity)
+        assert.Equal(t, 13, resp.GrowBags)
+        assert.Contains(t, resp.CapacityWarning, "garden is over capacity")
+        assert.Contains(t, resp.CapacityWarning, "Garden capacity exceeded")
+    })
+
+    t.Run("warn mode leaves crops within capacity unflagged", func(t *testing.T) {
+        service := newService(t, true)
+
+        within := *request
+        within.GrowBags = 10
+        resp, err := service.CreateCrop(ctx, &within)
+        require.NoError(t, err)
+        assert.False(t, resp.OverCapacity)
+        assert.Contains(t, resp.CapacityWarning, "approaching garden capacity")
+    })
+
+    t.Run("bag size counts toward capacity", func(t *testing.T) {
+        service := newService(t, false)
+
+        // Eight 14" bags use 91% of capacity where eight 12" bags would use 67%
+        larger := *request
+        larger.GrowBags = 8
+        larger.BagSize = dto.BagSize14
+        resp, err := service.CreateCrop(ctx, &larger)
+        require.NoError(t, err)
+        assert.Contains(t, resp.CapacityWarning, "90.74%")
+
+        // Ten 14" bags use 113% of capacity where ten 12" bags would fit
+        larger.GrowBags = 10
+        resp, err = newService(t, false).CreateCrop(ctx, &larger)
+        require.Error(t, err)
+        assert.Nil(t, resp)
+        assert.Contains(t, err.Error(), "Garden capacity exceeded")
+    })
+}
+
+// newSeasonalService creates a service for a roomy garden in the given environment with
+// its clock fixed at now
+func newSeasonalService(t *testing.T, gardenID, environment string, now time.Time) *cropmanager.CropService {
+    mockDB := mocks.NewMockDB(true, false)
+    testCache := cache.New(1*time.Hour, 2*time.Hour)
+    testCache.Set("garden:"+gardenID, &models.Garden{
+        ID:          gardenID,
+        UserID:      "test-user-id",
+        Length:      10,
+        Width:       10,
+        SoilType:    "loamy_soil",
+        Sunlight:    "full_sun",
+        Environment: environment,
+    }, time.Hour)
+    mockDB.On("Find", &[]models.Crop{}, "garden_id = ? AND deleted_at IS NULL", gardenID).
+        Return(nil, nil)
+    mockDB.On("Create", &models.Crop{}).Return(nil, nil)
+    mockDB.On("Create", mock.AnythingOfType("*models.UtilizationSnapshot")).Return(nil, nil)
+
+    logger, err := zap.NewDevelopment()
+    require.NoError(t, err)
+    service := cropmanager.NewCropService(mockDB, testCache, logger)
+    service.SetClock(clock.NewFake(now))
+    return service
+}
+
+// TestCreateCropSeasonalWarning tests planting window warnings for outdoor gardens
+func TestCreateCropSeasonalWarning(t *testing.T) {
+    ctx := context.Background()
+    gardenID := "seasonal-garden-id"
+    midwinter := time.Date(2024, time.January, 15, 9, 0, 0, 0, time.UTC)
+    spring := time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC)
+
+    tomatoRequest := &dto.CropRequest{
+        GardenID:       gardenID,
+        Name:           "tomato",
+        QuantityNeeded: 5,
+        GrowBags:       2,
+        BagSize:        dto.BagSize12,
+    }
+
+    t.Run("out of season outdoors warns", func(t *testing.T) {
+        service := newSeasonalService(t, gardenID, "Outdoor", midwinter)
+
+        resp, err := service.CreateCrop(ctx, tomatoRequest)
+        require.NoError(t, err)
+        assert.Contains(t, resp.SeasonalWarning, "Tomatoes planted outdoors in January")
+        assert.Contains(t, resp.SeasonalWarning, "March-June")
+    })
+
+    t.Run("unset environment treated as outdoors", func(t *testing.T) {
+        service := newSeasonalService(t, gardenID, "", midwinter)
+
+        resp, err := service.CreateCrop(ctx, tomatoRequest)
+        require.NoError(t, err)
+        assert.NotEmpty(t, resp.SeasonalWarning)
+    })
+
+    t.Run("in season outdoors passes", func(t *testing.T) {
+        service := newSeasonalService(t, gardenID, "Outdoor", spring)
+
+        resp, err := service.CreateCrop(ctx, tomatoRequest)
+        require.NoError(t, err)
+        assert.Empty(t, resp.SeasonalWarning)
+    })
+
+    t.Run("indoor garden passes out of season", func(t *testing.T) {
+        service := newSeasonalService(t, gardenID, "Indoor", midwinter)
+
+        resp, err := service.CreateCrop(ctx, tomatoRequest)
+        require.NoError(t, err)
+        assert.Empty(t, resp.SeasonalWarning)
+    })
+
+    t.Run("greenhouse garden passes out of season", func(t *testing.T) {
+        service := newSeasonalService(t, gardenID, "Greenhouse", midwinter)
+
+        resp, err := service.CreateCrop(ctx, tomatoRequest)
+        require.NoError(t, err)
+        assert.Empty(t, resp.SeasonalWarning)
+    })
+}
+
+// TestTargetYieldProgress tests that progress toward a crop's harvest goal follows logged harvests
+func TestTargetYieldProgress(t *testing.T) {
+    ctx := context.Background()
+    gardenID := "harvest-garden-id"
+    cropID := "harvest-tomatoes"
+    now := time.Date(2024, time.July, 20, 18, 0, 0, 0, time.UTC)
+
+    newHarvestService := func(t *testing.T) *cropmanager.CropService {
+        mockDB := mocks.NewMockDB(true, false)
+        testCache := cache.New(1*time.Hour, 2*time.Hour)
+        crop := &models.Crop{ID: cropID, GardenID: gardenID, Name: "Tomatoes", GrowBags: 4, BagSize: "12\""}
+        _, err := mockDB.Create(crop)
+        require.NoError(t, err)
+        mockDB.On("First", &models.Crop{}, []interface{}{"id = ? AND deleted_at IS NULL", cropID}).
+            Return(nil, nil)
+        mockDB.On("Create", &models.Harvest{}).Return(nil, nil)
+
+        logger, err := zap.NewDevelopment()
+        require.NoError(t, err)
+        service := cropmanager.NewCropService(mockDB, testCache, logger)
+        service.SetClock(clock.NewFake(now))
+        return service
+    }
+
+    t.Run("no goal reports no progress", func(t *testing.T) {
+        service := newHarvestService(t)
+
+        crop, err := service.GetCrop(ctx, cropID)
+        require.NoError(t, err)
+        assert.Nil(t, crop.TargetYield)
+    })
+
+    t.Run("progress updates as harvests are logged", func(t *testing.T) {
+        service := newHarvestService(t)
+
+        crop, err := service.SetTargetYield(ctx, cropID, &dto.TargetYieldRequest{Quantity: 2, Unit: dto.YieldUnitKilograms, Period: dto.YieldPeriodWeek})
+        require.NoError(t, err)
+        require.NotNil(t, crop.TargetYield)
+        assert.Equal(t, 0.0, crop.TargetYield.PercentToGoal)
+
+        crop, err = service.LogHarvest(ctx, cropID, &dto.HarvestRequest{Quantity: 500, Unit: dto.YieldUnitGrams})
+        require.NoError(t, err)
+        assert.InDelta(t, 0.5, crop.TargetYield.Harvested, 1e-9)
+        assert.InDelta(t, 25.0, crop.TargetYield.PercentToGoal, 1e-9)
+
+        crop, err = service.LogHarvest(ctx, cropID, &dto.HarvestRequest{Quantity: 1, Unit: dto.YieldUnitKilograms})
+        require.NoError(t, err)
+        assert.InDelta(t, 75.0, crop.TargetYield.PercentToGoal, 1e-9)
+
+        fetched, err := service.GetCrop(ctx, cropID)
+        require.NoError(t, err)
+        assert.InDelta(t, 75.0, fetched.TargetYield.PercentToGoal, 1e-9)
+    })
+
+    t.Run("harvests before the period do not count", func(t *testing.T) {
+        service := newHarvestService(t)
+
+        _, err := service.SetTargetYield(ctx, cropID, &dto.TargetYieldRequest{Quantity: 1, Unit: dto.YieldUnitKilograms, Period: dto.YieldPeriodWeek})
+        require.NoError(t, err)
+
+        tenDaysAgo := now.AddDate(0, 0, -10)
+        crop, err := service.LogHarvest(ctx, cropID, &dto.HarvestRequest{Quantity: 800, Unit: dto.YieldUnitGrams, HarvestedAt: &tenDaysAgo})
+        require.NoError(t, err)
+        assert.Equal(t, 0.0, crop.TargetYield.PercentToGoal)
+    })
+
+    t.Run("invalid input rejected", func(t *testing.T) {
+        service := newHarvestService(t)
+
+        _, err := service.SetTargetYield(ctx, cropID, &dto.TargetYieldRequest{Quantity: 2, Unit: "lb", Period: dto.YieldPeriodWeek})
+        assert.Error(t, err)
+        _, err = service.SetTargetYield(ctx, cropID, &dto.TargetYieldRequest{Quantity: 2, Unit: dto.YieldUnitKilograms, Period: "year"})
+        assert.Error(t, err)
+        _, err = service.LogHarvest(ctx, cropID, &dto.HarvestRequest{Quantity: -1, Unit: dto.YieldUnitGrams})
+        assert.Error(t, err)
+
+        tomorrow := now.AddDate(0, 0, 1)
+        _, err = service.LogHarvest(ctx, cropID, &dto.HarvestRequest{Quantity: 100, Unit: dto.YieldUnitGrams, HarvestedAt: &tomorrow})
+        assert.Error(t, err)
+    })
+}
+
+// TestMetadata tests that metadata is read from the yield tables and reflects the
+// configured yield profile
+func TestMetadata(t *testing.T) {
+    suite := setupTestSuite(t)
+
+    t.Run("lists supported values", func(t *testing.T) {
+        metadata := suite.service.Metadata()
+
+        names := make([]string, 0, len(metadata.Crops))
+        for _, crop := range metadata.Crops {
+            names = append(names, crop.Name)
+            // A single 10" bag yields exactly the listed base yield
+            model := &models.Crop{Name: crop.Name, GrowBags: 1, BagSize: dto.BagSize10}
+            assert.InDelta(t, model.CalculateYield(), crop.BaseYieldPerBag, 0.0001, crop.Name)
+        }
+        assert.Equal(t, []string{dto.CropEggplant, dto.CropLettuce, dto.CropPeppers, dto.CropSpinach, dto.CropTomatoes}, names)
+        assert.Equal(t, []dto.BagSizeMetadata{
+            {Size: dto.BagSize8, YieldMultiplier: 0.8},
+            {Size: dto.BagSize10, YieldMultiplier: 1.0},
+            {Size: dto.BagSize12, YieldMultiplier: 1.2},
+            {Size: dto.BagSize14, YieldMultiplier: 1.4},
+        }, metadata.BagSizes)
+        assert.Contains(t, metadata.SoilTypes, dto.SoilTypeMetadata{Name: "loamy_soil", Efficiency: 1.2})
+        assert.Contains(t, metadata.TaskTypes, dto.TaskTypeMetadata{Name: dto.TaskTypeWater, Unit: "ml"})
+        assert.Contains(t, metadata.Frequencies, dto.FrequencyTwiceDaily)
+        assert.Equal(t, []string{"Outdoor", "Indoor", "Greenhouse"}, metadata.Environments)
+    })
+
+    t.Run("reflects configured yield profile", func(t *testing.T) {
+        assert.Equal(t, dto.YieldProfile{
+            DefaultBaseYieldPerBag: models.DefaultCropBaseYield,
+            AdjustForEnvironment:   false,
+            UnknownSoilFactor:      1.0,
+            RejectUnknownSoil:      false,
+        }, suite.service.Metadata().YieldProfile)
+
+        suite.service.SetYieldConfig(cropmanager.YieldConfig{AdjustForEnvironment: true})
+        require.NoError(t, suite.service.SetSoilConfig(cropmanager.SoilConfig{
+            UnknownSoilFactor: 0.5,
+            RejectUnknownSoil: true,
+        }))
+
+        profile := suite.service.Metadata().YieldProfile
+        assert.True(t, profile.AdjustForEnvironment)
+        assert.Equal(t, 0.5, profile.UnknownSoilFactor)
+        assert.True(t, profile.RejectUnknownSoil)
+    })
+}
+
+// TestImportCropsCSV tests bulk crop import with per-row validation and an aggregate space check
+func TestImportCropsCSV(t *testing.T) {
+    ctx := context.Background()
+    gardenID := "3f1c2a4e-8b7d-4c6a-9e2f-1a2b3c4d5e6f"
+
+    t.Run("valid CSV creates every crop", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 10, 10)
+
+        csv := "name,quantity,growBags,bagSize\n" +
+            "Tomatoes,5,4,12\"\n" +
+            "Lettuce,3,6,10\n" +
+            "Peppers,2,2,14\"\n"
+        resp, err := service.ImportCropsCSV(ctx, gardenID, strings.NewReader(csv))
+        require.NoError(t, err)
+        assert.True(t, resp.Imported)
+        assert.Equal(t, 3, resp.CreatedCount)
+        assert.Zero(t, resp.InvalidCount)
+        require.NotNil(t, resp.SpaceValidation)
+        assert.True(t, resp.SpaceValidation.IsValid)
+
+        require.Len(t, resp.Rows, 3)
+        for i, row := range resp.Rows {
+            assert.Equal(t, i+2, row.Row)
+            assert.Equal(t, dto.ImportRowCreated, row.Status)
+            require.NotNil(t, row.Crop)
+            assert.Empty(t, row.Errors)
+        }
+        assert.Equal(t, dto.BagSize10, resp.Rows[1].Crop.BagSize)
+        assert.Equal(t, 6, resp.Rows[1].Crop.GrowBags)
+    })
+
+    t.Run("malformed rows reject the import", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 10, 10)
+
+        csv := "name,quantity,growBags,bagSize\n" +
+            "Tomatoes,5,4,12\n" +
+            "Lettuce,many,6,10\n" +
+            "Peppers,2,2\n" +
+            "Spinach,2,0,9\n"
+        resp, err := service.ImportCropsCSV(ctx, gardenID, strings.NewReader(csv))
+        require.NoError(t, err)
+        assert.False(t, resp.Imported)
+        assert.Zero(t, resp.CreatedCount)
+        assert.Equal(t, 3, resp.InvalidCount)
+        assert.Contains(t, resp.Message, "3 of 4 rows are invalid")
+
+        require.Len(t, resp.Rows, 4)
+        assert.Equal(t, dto.ImportRowValid, resp.Rows[0].Status)
+        assert.Nil(t, resp.Rows[0].Crop)
+        for _, row := range resp.Rows[1:] {
+            assert.Equal(t, dto.ImportRowInvalid, row.Status)
+            assert.NotEmpty(t, row.Errors)
+        }
+        assert.Contains(t, resp.Rows[1].Errors[0], "quantity")
+        assert.Contains(t, resp.Rows[2].Errors[0], "expected 4 fields")
+        assert.Contains(t, strings.Join(resp.Rows[3].Errors, "; "), "growBags")
+        assert.Contains(t, strings.Join(resp.Rows[3].Errors, "; "), "bagSize")
+    })
+
+    t.Run("rows that fit alone but not together reject the import", func(t *testing.T) {
+        // Each row of 10 lettuce bags fits a 4 x 3 ft garden; both together do not
+        service := newBagLimitService(t, gardenID, 4, 3)
+
+        csv := "name,quantity,growBags,bagSize\n" +
+            "Lettuce,5,10,12\n" +
+            "Lettuce,5,10,12\n"
+        resp, err := service.ImportCropsCSV(ctx, gardenID, strings.NewReader(csv))
+        require.NoError(t, err)
+        assert.False(t, resp.Imported)
+        assert.Zero(t, resp.InvalidCount)
+        require.NotNil(t, resp.SpaceValidation)
+        assert.False(t, resp.SpaceValidation.IsValid)
+        assert.Contains(t, resp.Message, "Garden capacity exceeded")
+    })
+
+    t.Run("missing column is an error", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 10, 10)
+
+        _, err := service.ImportCropsCSV(ctx, gardenID, strings.NewReader("name,quantity,bagSize\nTomatoes,5,12\n"))
+        require.Error(t, err)
+        assert.Contains(t, err.Error(), "growbags")
+    })
+
+    t.Run("body over the size limit is reported as such", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 10, 10)
+        csv := "name,quantity,growBags,bagSize\n" + strings.Repeat("Tomatoes,1,1,12\"\n", 100)
+        body := http.MaxBytesReader(httptest.NewRecorder(), io.NopCloser(strings.NewReader(csv)), 64)
+
+        _, err := service.ImportCropsCSV(ctx, gardenID, body)
+        var maxBytesErr *http.MaxBytesError
+        assert.ErrorAs(t, err, &maxBytesErr)
+    })
+}
+
+// TestSoilEfficiencyOverride tests that a garden's soil efficiency override takes precedence
+// over its soil type's factor in yield and space calculations
+func TestSoilEfficiencyOverride(t *testing.T) {
+    ctx := context.Background()
+    override := 0.7
+
+    newGarden := func(id string, override *float64) *models.Garden {
+        return &models.Garden{
+            ID:                     id,
+            UserID:                 "test-user-id",
+            Length:                 10,
+            Width:                  10,
+            SoilType:               "loamy_soil",
+            Sunlight:               "full_sun",
+            SoilEfficiencyOverride: override,
+        }
+    }
+
+    t.Run("override replaces soil type factor in yield", func(t *testing.T) {
+        crop := &models.Crop{Name: dto.CropLettuce, GrowBags: 2, BagSize: dto.BagSize10}
+
+        crop.Garden = newGarden("yield-garden-id", nil)
+        soilTypeYield := crop.CalculateYield()
+        crop.Garden = newGarden("yield-garden-id", &override)
+        overrideYield := crop.CalculateYield()
+
+        // Loamy soil scales yield by 1.2; the override scales it by 0.7 instead
+        assert.InDelta(t, soilTypeYield/1.2*0.7, overrideYield, 1e-9)
+
+        factor, known := crop.Garden.SoilEfficiency()
+        assert.True(t, known)
+        assert.Equal(t, override, factor)
+    })
+
+    t.Run("override replaces soil type factor in capacity", func(t *testing.T) {
+        availableSpace := func(garden *models.Garden) float64 {
+            mockDB := mocks.NewMockDB(true, false)
+            testCache := cache.New(1*time.Hour, 2*time.Hour)
+            testCache.Set("garden:"+garden.ID, garden, time.Hour)
+            mockDB.On("Find", &[]models.Crop{}, "garden_id = ? AND deleted_at IS NULL", garden.ID).
+                Return(nil, nil)
+
+            logger, err := zap.NewDevelopment()
+            require.NoError(t, err)
+            service := cropmanager.NewCropService(mockDB, testCache, logger)
+
+            plan, err := service.PlanForYieldGoal(ctx, garden.ID, dto.CropLettuce, 1.0)
+            require.NoError(t, err)
+            return plan.AvailableSpace
+        }
+
+        // A 10 x 10 ft garden holds 120 sq ft of crops on loamy soil but 70 with the override
+        assert.InDelta(t, 120.0, availableSpace(newGarden("loamy-garden-id", nil)), 1e-9)
+        assert.InDelta(t, 70.0, availableSpace(newGarden("amended-garden-id", &override)), 1e-9)
+    })
+
+    t.Run("override out of range rejected", func(t *testing.T) {
+        tooHigh := 3.0
+        assert.Error(t, newGarden("invalid-garden-id", &tooHigh).Validate())
+        assert.NoError(t, newGarden("valid-garden-id", &override).Validate())
+    })
+}
+
+// TestGardenCoordinates tests that garden GPS coordinates are range checked and must be
+// given as a pair, on both the model and the create and update requests
+func TestGardenCoordinates(t *testing.T) {
+    coordinate := func(v float64) *float64 { return &v }
+
+    newGarden := func(latitude, longitude *float64) *models.Garden {
+        return &models.Garden{
+            ID:        "located-garden-id",
+            UserID:    "test-user-id",
+            Length:    10,
+            Width:     10,
+            SoilType:  "loamy_soil",
+            Sunlight:  "full_sun",
+            Latitude:  latitude,
+            Longitude: longitude,
+        }
+    }
+
+    newCreateRequest := func(latitude, longitude *float64) *dto.CreateGardenRequest {
+        return &dto.CreateGardenRequest{
+            Dimensions: common.Dimensions{Length: 10, Width: 10, Unit: "feet"},
+            SoilType:   "loamy_soil",
+            Sunlight:   "full_sun",
+            Latitude:   latitude,
+            Longitude:  longitude,
+        }
+    }
+
+    t.Run("valid coordinates accepted", func(t *testing.T) {
+        for _, c := range []struct{ latitude, longitude *float64 }{
+            {nil, nil},
+            {coordinate(51.5074), coordinate(-0.1278)},
+            {coordinate(-33.8688), coordinate(151.2093)},
+            {coordinate(90), coordinate(180)},
+            {coordinate(-90), coordinate(-180)},
+        } {
+            assert.NoError(t, newGarden(c.latitude, c.longitude).Validate())
+            assert.NoError(t, newCreateRequest(c.latitude, c.longitude).Validate())
+            assert.NoError(t, (&dto.UpdateGardenRequest{Latitude: c.latitude, Longitude: c.longitude}).Validate())
+        }
+    })
+
+    t.Run("out of range coordinates rejected", func(t *testing.T) {
+        for _, c := range []struct {
+            name                string
+            latitude, longitude *float64
+            field               string
+        }{
+            {"latitude too high", coordinate(90.5), coordinate(0), "latitude"},
+            {"latitude too low", coordinate(-91), coordinate(0), "latitude"},
+            {"longitude too high", coordinate(0), coordinate(180.1), "longitude"},
+            {"longitude too low", coordinate(0), coordinate(-200), "longitude"},
+            {"latitude without longitude", coordinate(40), nil, "coordinates"},
+            {"longitude without latitude", nil, coordinate(-74), "coordinates"},
+        } {
+            t.Run(c.name, func(t *testing.T) {
+                for _, err := range []error{
+                    newGarden(c.latitude, c.longitude).Validate(),
+                    newCreateRequest(c.latitude, c.longitude).Validate(),
+                    (&dto.UpdateGardenRequest{Latitude: c.latitude, Longitude: c.longitude}).Validate(),
+                } {
+                    var validationErr *common.ValidationError
+                    require.True(t, errors.As(err, &validationErr))
+                    assert.Equal(t, c.field, validationErr.Field)
+                }
+            })
+        }
+    })
+}
+
+// TestUtilizationHistory tests that crop creation, import, and deletion each record a
+// utilization snapshot, and that the history is returned in order within its range
+func TestUtilizationHistory(t *testing.T) {
+    ctx := context.Background()
+    gardenID := "filling-garden-id"
+    now := time.Date(2024, time.May, 6, 9, 0, 0, 0, time.UTC)
+
+    newService := func(t *testing.T) (*cropmanager.CropService, *mocks.MockDB) {
+        mockDB := mocks.NewMockDB(true, false)
+        testCache := cache.New(1*time.Hour, 2*time.Hour)
+        testCache.Set("garden:"+gardenID, &models.Garden{
+            ID:       gardenID,
+            UserID:   "test-user-id",
+            Length:   10,
+            Width:    10,
+            SoilType: "loamy_soil",
+            Sunlight: "full_sun",
+        }, time.Hour)
+        mockDB.On("Find", &[]models.Crop{}, "garden_id = ? AND deleted_at IS NULL", gardenID).
+            Return(nil, nil)
+        mockDB.On("Create", &models.Crop{}).Return(nil, nil)
+        mockDB.On("Create", mock.AnythingOfType("*models.UtilizationSnapshot")).Return(nil, nil)
+
+        logger, err := zap.NewDevelopment()
+        require.NoError(t, err)
+        service := cropmanager.NewCropService(mockDB, testCache, logger)
+        service.SetClock(clock.NewFake(now))
+        return service, mockDB
+    }
+
+    snapshotFor := func(trigger string) interface{} {
+        return mock.MatchedBy(func(snapshot *models.UtilizationSnapshot) bool {
+            return snapshot.Trigger == trigger &&
+                snapshot.GardenID == gardenID &&
+                snapshot.TotalSpace == 100 &&
+                snapshot.RecordedAt.Equal(now)
+        })
+    }
+
+    t.Run("creating a crop records a snapshot", func(t *testing.T) {
+        service, mockDB := newService(t)
+
+        _, err := service.CreateCrop(ctx, &dto.CropRequest{
+            GardenID:       gardenID,
+            Name:           "Lettuce",
+            QuantityNeeded: 5,
+            GrowBags:       4,
+            BagSize:        dto.BagSize12,
+        })
+        require.NoError(t, err)
+        mockDB.AssertCalled(t, "Create", snapshotFor(models.UtilizationTriggerCropCreated))
+    })
+
+    t.Run("importing crops records one snapshot", func(t *testing.T) {
+        service, mockDB := newService(t)
+
+        csv := "name,quantity,growBags,bagSize\n" +
+            "Tomatoes,5,2,12\"\n" +
+            "Lettuce,3,3,10\"\n"
+        resp, err := service.ImportCropsCSV(ctx, gardenID, strings.NewReader(csv))
+        require.NoError(t, err)
+        require.True(t, resp.Imported)
+        mockDB.AssertCalled(t, "Create", snapshotFor(models.UtilizationTriggerCropsImported))
+        mockDB.AssertNumberOfCalls(t, "Create", 3)
+    })
+
+    t.Run("deleting a crop records a snapshot", func(t *testing.T) {
+        service, mockDB := newService(t)
+        crop := &models.Crop{ID: "doomed-crop-id", GardenID: gardenID, Name: "Spinach", GrowBags: 2, BagSize: dto.BagSize10}
+        _, err := mockDB.Create(crop)
+        require.NoError(t, err)
+        mockDB.On("First", &models.Crop{}, []interface{}{"id = ? AND deleted_at IS NULL", crop.ID}).
+            Return(nil, nil)
+        mockDB.On("Update", "deleted_at", now).Return(nil, nil)
+
+        require.NoError(t, service.DeleteCrop(ctx, crop.ID))
+        mockDB.AssertCalled(t, "Create", snapshotFor(models.UtilizationTriggerCropDeleted))
+    })
+
+    t.Run("deleting an unknown crop records nothing", func(t *testing.T) {
+        service, mockDB := newService(t)
+        mockDB.On("First", &models.Crop{}, []interface{}{"id = ? AND deleted_at IS NULL", "missing-crop-id"}).
+            Return(nil, mocks.ErrNotFound)
+
+        err := service.DeleteCrop(ctx, "missing-crop-id")
+        assert.Error(t, err)
+        mockDB.AssertNotCalled(t, "Create", snapshotFor(models.UtilizationTriggerCropDeleted))
+    })
+
+    t.Run("history is returned oldest first", func(t *testing.T) {
+        service, mockDB := newService(t)
+        from, to := now.Add(-48*time.Hour), now.Add(time.Hour)
+        mockDB.On("Find", &[]models.UtilizationSnapshot{}, "garden_id = ?", gardenID).
+            Return([]models.UtilizationSnapshot{
+                {GardenID: gardenID, Trigger: models.UtilizationTriggerCropCreated, CropID: "first-crop", CropCount: 1, UsedSpace: 20, TotalSpace: 100, Utilization: 16.67, RecordedAt: now.Add(-24 * time.Hour)},
+                {GardenID: gardenID, Trigger: models.UtilizationTriggerCropsImported, CropCount: 3, UsedSpace: 60, TotalSpace: 100, Utilization: 50, RecordedAt: now.Add(-time.Hour)},
+                {GardenID: gardenID, Trigger: models.UtilizationTriggerCropDeleted, CropID: "first-crop", CropCount: 2, UsedSpace: 40, TotalSpace: 100, Utilization: 33.33, RecordedAt: now},
+            }, nil)
+
+        history, err := service.GetUtilizationHistory(ctx, gardenID, from, to)
+        require.NoError(t, err)
+        assert.Equal(t, gardenID, history.GardenID)
+        require.NotNil(t, history.From)
+        assert.Equal(t, from, *history.From)
+        require.Len(t, history.Snapshots, 3)
+        assert.Equal(t, []string{
+            models.UtilizationTriggerCropCreated,
+            models.UtilizationTriggerCropsImported,
+            models.UtilizationTriggerCropDeleted,
+        }, []string{history.Snapshots[0].Trigger, history.Snapshots[1].Trigger, history.Snapshots[2].Trigger})
+        assert.Equal(t, 50.0, history.Snapshots[1].Utilization)
+        assert.Empty(t, history.Snapshots[1].CropID)
+    })
+
+    t.Run("inverted range is rejected", func(t *testing.T) {
+        service, _ := newService(t)
+
+        _, err := service.GetUtilizationHistory(ctx, gardenID, now, now.Add(-time.Hour))
+        require.Error(t, err)
+        assert.Contains(t, err.Error(), "from must be before to")
+    })
+}
+
+// recordingWebhook collects the capacity events delivered to it, failing the first
+// failures deliveries
+type recordingWebhook struct {
+    mu       sync.Mutex
+    failures int
+    attempts int
+    events   []dto.CapacityThresholdEvent
+}
+
+func (w *recordingWebhook) Deliver(ctx context.Context, event dto.CapacityThresholdEvent) error {
+    w.mu.Lock()
+    defer w.mu.Unlock()
+    w.attempts++
+    if w.attempts <= w.failures {
+        return errors.New("endpoint unavailable")
+    }
+    w.events = append(w.events, event)
+    return nil
+}
+
+// delivered returns the events delivered so far
+func (w *recordingWebhook) delivered() []dto.CapacityThresholdEvent {
+    w.mu.Lock()
+    defer w.mu.Unlock()
+    return append([]dto.CapacityThresholdEvent(nil), w.events...)
+}
+
+// attemptCount returns the deliveries tried so far, successful or not
+func (w *recordingWebhook) attemptCount() int {
+    w.mu.Lock()
+    defer w.mu.Unlock()
+    return w.attempts
+}
+
+// TestCapacityThresholdWebhook tests that crop changes fire the capacity webhook once per
+// threshold crossed
+func TestCapacityThresholdWebhook(t *testing.T) {
+    ctx := context.Background()
+    gardenID := "webhook-garden-id"
+
+    // A 4 x 2.5 ft garden on loamy soil (1.2 efficiency): each lettuce bag uses 8.33% of
+    // capacity and each tomato bag 12.5%
+    newRequest := func(name string, growBags int) *dto.CropRequest {
+        return &dto.CropRequest{
+            GardenID:       gardenID,
+            Name:           name,
+            QuantityNeeded: 5,
+            GrowBags:       growBags,
+            BagSize:        dto.BagSize12,
+        }
+    }
+
+    t.Run("crossing from 75 to 85 percent fires once", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 4, 2.5)
+        webhook := &recordingWebhook{}
+        require.NoError(t, service.SetCapacityWebhook(webhook, cropmanager.CapacityWebhookConfig{}))
+
+        // 9 lettuce bags reach 75%, below every threshold
+        _, err := service.CreateCrop(ctx, newRequest("Lettuce", 9))
+        require.NoError(t, err)
+        assert.Empty(t, webhook.delivered())
+
+        // A tomato bag takes the garden to 87.5%, past 80% but short of 95%
+        resp, err := service.CreateCrop(ctx, newRequest("Tomatoes", 1))
+        require.NoError(t, err)
+
+        // Delivery happens in the background; Close waits for it
+        require.NoError(t, service.Close(ctx))
+        events := webhook.delivered()
+        require.Len(t, events, 1)
+        event := events[0]
+        assert.Equal(t, gardenID, event.GardenID)
+        assert.Equal(t, 80.0, event.Threshold)
+        assert.Equal(t, cropmanager.ThresholdRising, event.Direction)
+        assert.Equal(t, models.UtilizationTriggerCropCreated, event.Trigger)
+        assert.Equal(t, resp.ID, event.CropID)
+        assert.InDelta(t, 75.0, event.PreviousUtilization, 0.01)
+        assert.InDelta(t, 87.5, event.Utilization, 0.01)
+    })
+
+    t.Run("failed delivery is retried", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 4, 2.5)
+        webhook := &recordingWebhook{failures: 2}
+        require.NoError(t, service.SetCapacityWebhook(webhook, cropmanager.CapacityWebhookConfig{
+            MaxAttempts: 3,
+            RetryDelay:  time.Millisecond,
+        }))
+
+        _, err := service.CreateCrop(ctx, newRequest("Tomatoes", 7))
+        require.NoError(t, err)
+
+        // Close abandons retries, so wait for the third attempt to deliver the event first
+        require.Eventually(t, func() bool { return len(webhook.delivered()) == 1 }, time.Second, time.Millisecond)
+        require.NoError(t, service.Close(ctx))
+        assert.Equal(t, 3, webhook.attemptCount())
+    })
+
+    t.Run("close abandons pending retries", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 4, 2.5)
+        // The fake clock never advances, so the retry backoff would wait forever
+        service.SetClock(clock.NewFake(time.Date(2024, time.May, 1, 9, 0, 0, 0, time.UTC)))
+        webhook := &recordingWebhook{failures: 3}
+        require.NoError(t, service.SetCapacityWebhook(webhook, cropmanager.CapacityWebhookConfig{
+            MaxAttempts: 3,
+            RetryDelay:  time.Minute,
+        }))
+
+        _, err := service.CreateCrop(ctx, newRequest("Tomatoes", 7))
+        require.NoError(t, err)
+
+        closeCtx, cancel := context.WithTimeout(ctx, time.Second)
+        defer cancel()
+        require.NoError(t, service.Close(closeCtx))
+        assert.Equal(t, 1, webhook.attemptCount())
+        assert.Empty(t, webhook.delivered())
+    })
+
+    t.Run("invalid threshold rejected", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 4, 2.5)
+        err := service.SetCapacityWebhook(&recordingWebhook{}, cropmanager.CapacityWebhookConfig{Thresholds: []float64{0.8, 0}})
+        assert.Error(t, err)
+    })
+
+    t.Run("HTTP webhook posts the event", func(t *testing.T) {
+        var received dto.CapacityThresholdEvent
+        server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            assert.Equal(t, http.MethodPost, r.Method)
+            assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+            w.WriteHeader(http.StatusNoContent)
+        }))
+        defer server.Close()
+
+        webhook := cropmanager.NewHTTPCapacityWebhook(server.URL, time.Second)
+        event := dto.CapacityThresholdEvent{GardenID: gardenID, Threshold: 95, Direction: cropmanager.ThresholdFalling}
+        require.NoError(t, webhook.Deliver(ctx, event))
+        assert.Equal(t, event, received)
+    })
+}
+
+// TestDuplicateCropNames tests that duplicate crop names within a garden are allowed by
+// default and rejected when configured
+func TestDuplicateCropNames(t *testing.T) {
+    ctx := context.Background()
+    gardenID := "duplicate-names-garden-id"
+
+    newService := func(t *testing.T, existing []models.Crop) *cropmanager.CropService {
+        mockDB := mocks.NewMockDB(true, false)
+        testCache := cache.New(1*time.Hour, 2*time.Hour)
+        testCache.Set("garden:"+gardenID, &models.Garden{
+            ID:       gardenID,
+            UserID:   "test-user-id",
+            Length:   10,
+            Width:    10,
+            SoilType: "loamy_soil",
+            Sunlight: "full_sun",
+        }, time.Hour)
+        mockDB.On("Find", &[]models.Crop{}, "garden_id = ? AND deleted_at IS NULL", gardenID).
+            Return(existing, nil)
+        mockDB.On("Create", &models.Crop{}).Return(nil, nil)
+        mockDB.On("Create", mock.AnythingOfType("*models.UtilizationSnapshot")).Return(nil, nil)
+
+        logger, err := zap.NewDevelopment()
+        require.NoError(t, err)
+        return cropmanager.NewCropService(mockDB, testCache, logger)
+    }
+
+    existing := []models.Crop{{
+        ID:       "existing-tomatoes",
+        GardenID: gardenID,
+        Name:     dto.CropTomatoes,
+        GrowBags: 2,
+        BagSize:  dto.BagSize12,
+    }}
+    request := &dto.CropRequest{
+        GardenID:       gardenID,
+        Name:           "tomato",
+        QuantityNeeded: 5,
+        GrowBags:       2,
+        BagSize:        dto.BagSize12,
+    }
+
+    t.Run("duplicates are allowed by default", func(t *testing.T) {
+        service := newService(t, existing)
+
+        resp, err := service.CreateCrop(ctx, request)
+        require.NoError(t, err)
+        assert.NotNil(t, resp)
+    })
+
+    t.Run("duplicates are rejected when blocking", func(t *testing.T) {
+        service := newService(t, existing)
+        service.SetDuplicateNameConfig(cropmanager.DuplicateNameConfig{RejectDuplicates: true})
+
+        _, err := service.CreateCrop(ctx, request)
+        require.Error(t, err)
+        assert.Contains(t, err.Error(), "DUPLICATE_CROP")
+        assert.Contains(t, err.Error(), dto.CropTomatoes)
+    })
+
+    t.Run("distinct names are created when blocking", func(t *testing.T) {
+        service := newService(t, existing)
+        service.SetDuplicateNameConfig(cropmanager.DuplicateNameConfig{RejectDuplicates: true})
+
+        lettuce := *request
+        lettuce.Name = dto.CropLettuce
+        resp, err := service.CreateCrop(ctx, &lettuce)
+        require.NoError(t, err)
+        assert.NotNil(t, resp)
+    })
+
+    t.Run("import rows repeating a name are invalid when blocking", func(t *testing.T) {
+        service := newService(t, existing)
+        service.SetDuplicateNameConfig(cropmanager.DuplicateNameConfig{RejectDuplicates: true})
+
+        csv := "name,quantity,growBags,bagSize\n" +
+            "Tomatoes,5,2,12\n" +
+            "Lettuce,3,2,12\n" +
+            "lettuce,3,2,12\n"
+        resp, err := service.ImportCropsCSV(ctx, gardenID, strings.NewReader(csv))
+        require.NoError(t, err)
+        assert.False(t, resp.Imported)
+        assert.Equal(t, 2, resp.InvalidCount)
+
+        require.Len(t, resp.Rows, 3)
+        assert.Equal(t, dto.ImportRowInvalid, resp.Rows[0].Status)
+        assert.Contains(t, resp.Rows[0].Errors[0], "duplicate crop names are not allowed")
+        assert.Equal(t, dto.ImportRowValid, resp.Rows[1].Status)
+        assert.Equal(t, dto.ImportRowInvalid, resp.Rows[2].Status)
+    })
+}
+
+// TestCropOperationMetrics tests that crop operations are counted and timed in the
+// registry served on /metrics
+func TestCropOperationMetrics(t *testing.T) {
+    ctx := context.Background()
+    gardenID := "metrics-garden-id"
+
+    registry := prometheus.NewRegistry()
+    metrics, err := cropmanager.NewMetrics(registry)
+    require.NoError(t, err)
+
+    service := newBagLimitService(t, gardenID, 10, 10)
+    service.SetMetrics(metrics)
+
+    _, err = service.CreateCrop(ctx, &dto.CropRequest{
+        GardenID:       gardenID,
+        Name:           dto.CropLettuce,
+        QuantityNeeded: 5,
+        GrowBags:       2,
+        BagSize:        dto.BagSize12,
+    })
+    require.NoError(t, err)
+
+    _, err = service.CreateCrop(ctx, &dto.CropRequest{GardenID: gardenID, Name: dto.CropLettuce})
+    require.Error(t, err)
+
+    _, err = service.ImportCropsCSV(ctx, gardenID, strings.NewReader("name,quantity,bagSize\nLettuce,5,12\n"))
+    require.Error(t, err)
+
+    server := httptest.NewServer(promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+    defer server.Close()
+
+    resp, err := http.Get(server.URL + "/metrics")
+    require.NoError(t, err)
+    defer resp.Body.Close()
+    assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+    body, err := io.ReadAll(resp.Body)
+    require.NoError(t, err)
+    assert.Contains(t, string(body), `cropmanager_crop_operations_total{operation="create",status="success"} 1`)
+    assert.Contains(t, string(body), `cropmanager_crop_operations_total{operation="create",status="error"} 1`)
+    assert.Contains(t, string(body), `cropmanager_crop_operation_duration_seconds_count{operation="create"} 2`)
+    assert.Contains(t, string(body), `cropmanager_crop_operations_total{operation="import",status="error"} 1`)
+
+    t.Run("registering twice is an error", func(t *testing.T) {
+        _, err := cropmanager.NewMetrics(registry)
+        assert.Error(t, err)
+    })
+}
+
+// TestDatabaseQueryMetrics tests that creating a crop records a query latency
+// observation labelled with the operation and the crops table
+func TestDatabaseQueryMetrics(t *testing.T) {
+    registry := prometheus.NewRegistry()
+    queryMetrics, err := database.NewQueryMetrics(registry)
+    require.NoError(t, err)
+
+    // A dry run builds each statement and runs every callback without a live database
+    db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{DryRun: true})
+    require.NoError(t, err)
+    require.NoError(t, db.Use(queryMetrics))
+
+    // Skip hooks, which would look up the crop's garden that a dry run cannot return
+    crop := &models.Crop{
+        ID:             "metrics-crop-id",
+        GardenID:       "metrics-garden-id",
+        Name:           dto.CropLettuce,
+        QuantityNeeded: 5,
+        GrowBags:       2,
+        BagSize:        dto.BagSize12,
+    }
+    require.NoError(t, db.Session(&gorm.Session{SkipHooks: true}).Create(crop).Error)
+
+    server := httptest.NewServer(promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+    defer server.Close()
+
+    resp, err := http.Get(server.URL + "/metrics")
+    require.NoError(t, err)
+    defer resp.Body.Close()
+
+    body, err := io.ReadAll(resp.Body)
+    require.NoError(t, err)
+    assert.Contains(t, string(body), `urban_gardening_database_query_duration_seconds_count{model="crops",operation="create"} 1`)
+    assert.NotContains(t, string(body), `operation="delete"`)
+
+    t.Run("registering twice is an error", func(t *testing.T) {
+        _, err := database.NewQueryMetrics(registry)
+        assert.Error(t, err)
+    })
+
+    t.Run("plugin is used once per connection", func(t *testing.T) {
+        assert.Error(t, db.Use(queryMetrics))
+    })
+}
+
+// TestCropNameAllowlist tests that unknown crop names are rejected with suggested close
+// matches, and that the allowlist can be extended or disabled
+func TestCropNameAllowlist(t *testing.T) {
+    ctx := context.Background()
+    gardenID := "allowlist-garden-id"
+
+    newRequest := func(name string) *dto.CropRequest {
+        return &dto.CropRequest{
+            GardenID:       gardenID,
+            Name:           name,
+            QuantityNeeded: 5,
+            GrowBags:       2,
+            BagSize:        dto.BagSize12,
+        }
+    }
+
+    t.Run("typo suggests the closest crop", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 10, 10)
+
+        _, err := service.CreateCrop(ctx, newRequest("Tomatos"))
+        require.Error(t, err)
+        assert.Contains(t, err.Error(), "VALIDATION_ERROR")
+        assert.Contains(t, err.Error(), `unknown crop "Tomatos"; did you mean "Tomatoes"?`)
+    })
+
+    t.Run("synonyms and plurals are recognised", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 10, 10)
+
+        for _, name := range []string{"tomato", "aubergine", "Bell Peppers"} {
+            _, err := service.CreateCrop(ctx, newRequest(name))
+            assert.NoError(t, err, name)
+        }
+    })
+
+    t.Run("names with no close match list the supported crops", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 10, 10)
+
+        _, err := service.CreateCrop(ctx, newRequest("Watermelon"))
+        require.Error(t, err)
+        assert.Contains(t, err.Error(), "supported crops are")
+        assert.Contains(t, err.Error(), dto.CropSpinach)
+    })
+
+    t.Run("configured names extend the allowlist", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 10, 10)
+        service.SetCropNameConfig(cropmanager.CropNameConfig{AllowedNames: []string{"Okra", " Swiss  Chard "}})
+
+        _, err := service.CreateCrop(ctx, newRequest("okra"))
+        assert.NoError(t, err)
+
+        _, err = service.CreateCrop(ctx, newRequest("Swiss Chard"))
+        assert.NoError(t, err)
+
+        _, err = service.CreateCrop(ctx, newRequest("Okraa"))
+        require.Error(t, err)
+        assert.Contains(t, err.Error(), `did you mean "Okra"?`)
+    })
+
+    t.Run("unknown names are accepted when allowed", func(t *testing.T) {
+        service := newBagLimitService(t, gardenID, 10, 10)
+        service.SetCropNameConfig(cropmanager.CropNameConfig{AllowUnknown: true})
+
+        resp, err := service.CreateCrop(ctx, newRequest("Tomatos"))
+        require.NoError(t, err)
+        assert.Positive(t, resp.EstimatedYield)
+    })
+}
@@ -0,0 +1,88 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/urban-gardening/backend/api/gateway/middleware"
+	"github.com/urban-gardening/backend/pkg/types"
+)
+
+// newCompressedHandler wraps a handler writing a body of the given size with compression configured by cfg
+func newCompressedHandler(t *testing.T, cfg *types.APIConfig, bodySize int) http.Handler {
+	compression, err := middleware.CompressionMiddleware(cfg)
+	require.NoError(t, err)
+
+	body := strings.Repeat("a", bodySize)
+	return compression(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+// TestCompressionMiddleware tests gzip encoding driven by APIConfig compression settings
+func TestCompressionMiddleware(t *testing.T) {
+	testCases := []struct {
+		name       string
+		cfg        *types.APIConfig
+		bodySize   int
+		expectGzip bool
+	}{
+		{
+			name:       "large response compressed",
+			cfg:        &types.APIConfig{EnableCompression: true, CompressionLevel: 5, CompressionMinSize: 1000},
+			bodySize:   4096,
+			expectGzip: true,
+		},
+		{
+			name:       "response below minimum size not compressed",
+			cfg:        &types.APIConfig{EnableCompression: true, CompressionLevel: 5, CompressionMinSize: 1000},
+			bodySize:   200,
+			expectGzip: false,
+		},
+		{
+			name:       "lower minimum size compresses small response",
+			cfg:        &types.APIConfig{EnableCompression: true, CompressionLevel: 1, CompressionMinSize: 100},
+			bodySize:   200,
+			expectGzip: true,
+		},
+		{
+			name:       "compression disabled",
+			cfg:        &types.APIConfig{EnableCompression: false, CompressionLevel: 5, CompressionMinSize: 1000},
+			bodySize:   4096,
+			expectGzip: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := newCompressedHandler(t, tc.cfg, tc.bodySize)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/gardens", nil)
+			req.Header.Set("Accept-Encoding", "gzip")
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			if tc.expectGzip {
+				assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+				assert.Less(t, rec.Body.Len(), tc.bodySize)
+			} else {
+				assert.Empty(t, rec.Header().Get("Content-Encoding"))
+				assert.Equal(t, tc.bodySize, rec.Body.Len())
+			}
+		})
+	}
+
+	t.Run("invalid level rejected", func(t *testing.T) {
+		_, err := middleware.CompressionMiddleware(&types.APIConfig{EnableCompression: true, CompressionLevel: 42})
+		assert.Error(t, err)
+	})
+}
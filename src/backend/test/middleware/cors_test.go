@@ -0,0 +1,86 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/urban-gardening/backend/api/gateway/middleware"
+	"github.com/urban-gardening/backend/config"
+	"github.com/urban-gardening/backend/pkg/types"
+)
+
+// newCORSHandler wraps a no-op handler with CORS configured for the given origins
+func newCORSHandler(origins []string) http.Handler {
+	cfg := &types.APIConfig{
+		EnableCORS:     true,
+		AllowedOrigins: origins,
+		AllowedMethods: []string{http.MethodGet, http.MethodPost},
+	}
+
+	return middleware.CORSMiddleware(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+// TestCORSMiddleware tests origin filtering driven by APIConfig.AllowedOrigins
+func TestCORSMiddleware(t *testing.T) {
+	handler := newCORSHandler([]string{"https://app.urban-gardening.com"})
+
+	testCases := []struct {
+		name          string
+		origin        string
+		expectAllowed bool
+	}{
+		{
+			name:          "configured origin allowed",
+			origin:        "https://app.urban-gardening.com",
+			expectAllowed: true,
+		},
+		{
+			name:          "disallowed origin blocked",
+			origin:        "https://evil.example.com",
+			expectAllowed: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, "/api/v1/calculate", nil)
+			req.Header.Set("Origin", tc.origin)
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			allowOrigin := rec.Header().Get("Access-Control-Allow-Origin")
+			if tc.expectAllowed {
+				assert.Equal(t, tc.origin, allowOrigin)
+			} else {
+				assert.Empty(t, allowOrigin)
+			}
+		})
+	}
+}
+
+// TestValidateAllowedOrigins tests environment-specific CORS origin validation
+func TestValidateAllowedOrigins(t *testing.T) {
+	t.Run("wildcard rejected in production", func(t *testing.T) {
+		err := config.ValidateAllowedOrigins("production", []string{"https://*"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not allowed in production")
+	})
+
+	t.Run("explicit origins accepted in production", func(t *testing.T) {
+		err := config.ValidateAllowedOrigins("production", []string{"https://app.urban-gardening.com"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("wildcard accepted in development", func(t *testing.T) {
+		err := config.ValidateAllowedOrigins("development", []string{"http://*"})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,78 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/httprate"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/urban-gardening/backend/api/gateway/middleware"
+	"github.com/urban-gardening/backend/pkg/dto"
+)
+
+// newLayeredHandler wraps a no-op handler with the per-IP limit in front of the per-user
+// limit, the same order the gateway router applies them around authentication
+func newLayeredHandler(ipLimit, userLimit int) http.Handler {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	return httprate.LimitByIP(ipLimit, time.Minute)(middleware.NewUserRateLimiter(userLimit, time.Minute)(handler))
+}
+
+// doRequest issues a request from the given address, authenticated as userID when non-empty
+func doRequest(handler http.Handler, remoteAddr, userID string) int {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/gardens", nil)
+	req.RemoteAddr = remoteAddr
+	if userID != "" {
+		req = req.WithContext(middleware.ContextWithUser(req.Context(), &dto.UserResponseDTO{ID: userID}))
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+// TestUserRateLimiter tests that the stricter of the per-IP and per-user limits applies
+func TestUserRateLimiter(t *testing.T) {
+	t.Run("users sharing an IP are bound by the IP limit", func(t *testing.T) {
+		handler := newLayeredHandler(3, 10)
+		natAddr := "203.0.113.10:40000"
+
+		users := []string{"user-a", "user-b", "user-c"}
+		for _, user := range users {
+			assert.Equal(t, http.StatusOK, doRequest(handler, natAddr, user))
+		}
+
+		// Each user is well under their own limit, but the shared IP is exhausted
+		assert.Equal(t, http.StatusTooManyRequests, doRequest(handler, natAddr, "user-d"))
+	})
+
+	t.Run("roaming user is bound by the user limit", func(t *testing.T) {
+		handler := newLayeredHandler(10, 3)
+
+		addrs := []string{"198.51.100.1:1000", "198.51.100.2:1000", "198.51.100.3:1000"}
+		for _, addr := range addrs {
+			assert.Equal(t, http.StatusOK, doRequest(handler, addr, "roaming-user"))
+		}
+
+		// A fresh IP does not reset the user's allowance
+		assert.Equal(t, http.StatusTooManyRequests, doRequest(handler, "198.51.100.4:1000", "roaming-user"))
+
+		// Other users on those IPs are unaffected
+		assert.Equal(t, http.StatusOK, doRequest(handler, "198.51.100.1:1000", "other-user"))
+	})
+
+	t.Run("anonymous requests are not limited per user", func(t *testing.T) {
+		handler := middleware.NewUserRateLimiter(1, time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		addr := "192.0.2.50:5000"
+
+		assert.Equal(t, http.StatusOK, doRequest(handler, addr, ""))
+		assert.Equal(t, http.StatusOK, doRequest(handler, addr, ""))
+	})
+}
@@ -0,0 +1,86 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"testing"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/urban-gardening/backend/api/gateway/middleware"
+	"github.com/urban-gardening/backend/pkg/types"
+)
+
+// newProxyGateway fronts a backend with the request ID middleware and a reverse proxy,
+// recording the request ID seen by the gateway and the headers received by the backend
+func newProxyGateway(t *testing.T, cfg *types.APIConfig) (http.Handler, *string, *http.Header) {
+	var gatewayID string
+	var backendHeaders http.Header
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		backendHeaders = r.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(backend.Close)
+
+	target, err := url.Parse(backend.URL)
+	require.NoError(t, err)
+	proxy := httputil.NewSingleHostReverseProxy(target)
+
+	handler := middleware.RequestIDMiddleware(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gatewayID = chiMiddleware.GetReqID(r.Context())
+		proxy.ServeHTTP(w, r)
+	}))
+
+	return handler, &gatewayID, &backendHeaders
+}
+
+// TestRequestIDMiddleware tests request ID propagation using the configured header name
+func TestRequestIDMiddleware(t *testing.T) {
+	t.Run("upstream correlation ID preserved end-to-end", func(t *testing.T) {
+		handler, gatewayID, backendHeaders := newProxyGateway(t, &types.APIConfig{RequestIDHeader: "X-Correlation-ID"})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/gardens", nil)
+		req.Header.Set("X-Correlation-ID", "upstream-correlation-123")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "upstream-correlation-123", *gatewayID)
+		assert.Equal(t, "upstream-correlation-123", backendHeaders.Get("X-Correlation-ID"))
+		assert.Equal(t, "upstream-correlation-123", rec.Header().Get("X-Correlation-ID"))
+		assert.Empty(t, rec.Header().Get("X-Request-Id"))
+	})
+
+	t.Run("ID generated when upstream sends none", func(t *testing.T) {
+		handler, gatewayID, backendHeaders := newProxyGateway(t, &types.APIConfig{RequestIDHeader: "X-Correlation-ID"})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/gardens", nil)
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		generated := rec.Header().Get("X-Correlation-ID")
+		require.NotEmpty(t, generated)
+		assert.Equal(t, generated, *gatewayID)
+		assert.Equal(t, generated, backendHeaders.Get("X-Correlation-ID"))
+	})
+
+	t.Run("defaults to chi request ID header", func(t *testing.T) {
+		handler, gatewayID, _ := newProxyGateway(t, &types.APIConfig{})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/gardens", nil)
+		req.Header.Set("X-Request-Id", "upstream-request-456")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, "upstream-request-456", *gatewayID)
+		assert.Equal(t, "upstream-request-456", rec.Header().Get("X-Request-Id"))
+	})
+}
@@ -6,9 +6,11 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/urban-gardening-assistant/backend/pkg/dto"
 	"github.com/urban-gardening-assistant/backend/pkg/types"
 )
 
@@ -56,6 +58,9 @@ type MockAIClient struct {
 	mockRecommendations map[string][]string
 	mockSchedules       map[string]map[string]interface{}
 	simulateErrors      bool
+	inFlight            int32
+	maxInFlight         int32
+	callCount           int32
 }
 
 // NewMockAIClient creates a new instance of MockAIClient with thread-safe initialization
@@ -99,6 +104,8 @@ func (m *MockAIClient) GetGardeningRecommendations(ctx context.Context, plantTyp
 		return nil, mockErrors["invalid_input"]
 	}
 
+	defer m.trackCall()()
+
 	// Simulate processing delay
 	time.Sleep(m.mockDelay)
 
@@ -132,6 +139,8 @@ func (m *MockAIClient) GetMaintenanceSchedule(ctx context.Context, gardenConditi
 		return nil, mockErrors["invalid_input"]
 	}
 
+	defer m.trackCall()()
+
 	// Simulate processing delay
 	time.Sleep(m.mockDelay)
 
@@ -159,6 +168,52 @@ func (m *MockAIClient) GetMaintenanceSchedule(ctx context.Context, gardenConditi
 	return m.mockSchedules["default"], nil
 }
 
+// SuggestCrops returns the mock recommendations registered under "crops" with error simulation
+func (m *MockAIClient) SuggestCrops(ctx context.Context, conditions map[string]string) ([]string, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	defer m.trackCall()()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	time.Sleep(m.mockDelay)
+
+	if m.simulateErrors {
+		return nil, mockErrors["service_unavailable"]
+	}
+	if crops, exists := m.mockRecommendations["crops"]; exists {
+		return crops, nil
+	}
+	return nil, mockErrors["invalid_input"]
+}
+
+// CheckAIHealth returns a mock health check result that fails when error simulation is enabled
+func (m *MockAIClient) CheckAIHealth(ctx context.Context) *dto.AIHealthResponse {
+	defer m.trackCall()()
+
+	m.mu.RLock()
+	delay, simulateErrors := m.mockDelay, m.simulateErrors
+	m.mu.RUnlock()
+
+	time.Sleep(delay)
+
+	result := &dto.AIHealthResponse{
+		Model:     "mock-model",
+		LatencyMs: delay.Milliseconds(),
+		Success:   !simulateErrors,
+		CheckedAt: time.Now(),
+	}
+	if simulateErrors {
+		result.Error = mockErrors["service_unavailable"].Error()
+	}
+	return result
+}
+
 // SetMockRecommendations allows setting custom recommendations for specific plant types
 func (m *MockAIClient) SetMockRecommendations(plantType string, recommendations []string) {
 	if plantType == "" || len(recommendations) == 0 {
@@ -188,4 +243,36 @@ func (m *MockAIClient) SetErrorSimulation(simulate bool) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.simulateErrors = simulate
-}
\ No newline at end of file
+}
+
+// SetMockDelay configures the simulated processing time for mock AI calls
+func (m *MockAIClient) SetMockDelay(delay time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mockDelay = delay
+}
+
+// CallCount returns the total number of AI calls made against the mock
+func (m *MockAIClient) CallCount() int {
+	return int(atomic.LoadInt32(&m.callCount))
+}
+
+// MaxConcurrentCalls returns the highest number of AI calls observed in flight at once
+func (m *MockAIClient) MaxConcurrentCalls() int {
+	return int(atomic.LoadInt32(&m.maxInFlight))
+}
+
+// trackCall records an in-flight AI call and returns a function that releases it
+func (m *MockAIClient) trackCall() func() {
+	atomic.AddInt32(&m.callCount, 1)
+	current := atomic.AddInt32(&m.inFlight, 1)
+	for {
+		max := atomic.LoadInt32(&m.maxInFlight)
+		if current <= max || atomic.CompareAndSwapInt32(&m.maxInFlight, max, current) {
+			break
+		}
+	}
+	return func() {
+		atomic.AddInt32(&m.inFlight, -1)
+	}
+}
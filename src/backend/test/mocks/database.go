@@ -21,6 +21,7 @@ var (
 	ErrNotFound         = errors.New("record not found")
 	ErrDuplicateKey     = errors.New("duplicate key violation")
 	ErrTransactionError = errors.New("transaction failed")
+	ErrSerialization    = errors.New("ERROR: could not serialize access due to concurrent update (SQLSTATE 40001)")
 )
 
 // MockDB implements a comprehensive mock database for testing with enhanced
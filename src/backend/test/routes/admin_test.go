@@ -0,0 +1,289 @@
+package routes_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sony/gobreaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/urban-gardening/backend/api/gateway/middleware"
+	"github.com/urban-gardening/backend/api/gateway/routes"
+	"github.com/urban-gardening/backend/config"
+	"github.com/urban-gardening/backend/internal/utils/breaker"
+	"github.com/urban-gardening/backend/pkg/dto"
+	"github.com/urban-gardening/backend/pkg/types"
+	"github.com/urban-gardening/backend/test/mocks"
+)
+
+// stubDeadLetters records the last dead-letter listing request
+type stubDeadLetters struct {
+	page     int
+	pageSize int
+	filter   dto.DeadLetterFilter
+}
+
+func (s *stubDeadLetters) ListDeadLetters(ctx context.Context, page, pageSize int, filter dto.DeadLetterFilter) (*dto.DeadLetterListResponse, error) {
+	s.page, s.pageSize, s.filter = page, pageSize, filter
+	return &dto.DeadLetterListResponse{DeadLetters: []dto.DeadLetter{}, Page: page, PageSize: pageSize}, nil
+}
+
+// newAdminRouter registers admin routes behind a stub authenticator for the given role
+func newAdminRouter(t *testing.T, role string, simulateErrors bool) (http.Handler, *mocks.MockAIClient) {
+	return newAdminRouterWithConfig(t, role, simulateErrors, &types.ServiceConfig{ServiceName: "test-gateway", Environment: "test"})
+}
+
+// newAdminRouterWithConfig registers admin routes exposing cfg behind a stub authenticator for the given role
+func newAdminRouterWithConfig(t *testing.T, role string, simulateErrors bool, cfg *types.ServiceConfig) (http.Handler, *mocks.MockAIClient) {
+	mockAI, err := mocks.NewMockAIClient(t, &types.ServiceConfig{ServiceName: "test-gateway", Environment: "test"})
+	require.NoError(t, err)
+	mockAI.SetMockDelay(5 * time.Millisecond)
+	mockAI.SetErrorSimulation(simulateErrors)
+
+	router := chi.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := &dto.UserResponseDTO{ID: "user-1", Email: "ops@example.com", Role: role}
+			next.ServeHTTP(w, r.WithContext(middleware.ContextWithUser(r.Context(), user)))
+		})
+	})
+	routes.RegisterAdminRoutes(router, mockAI, &stubDeadLetters{}, cfg)
+
+	return router, mockAI
+}
+
+// TestAIHealthEndpoint tests the role-guarded AI health check
+func TestAIHealthEndpoint(t *testing.T) {
+	testCases := []struct {
+		name           string
+		role           string
+		simulateErrors bool
+		expectedStatus int
+		expectSuccess  bool
+		expectAICall   bool
+	}{
+		{
+			name:           "healthy AI",
+			role:           dto.RoleAdmin,
+			expectedStatus: http.StatusOK,
+			expectSuccess:  true,
+			expectAICall:   true,
+		},
+		{
+			name:           "failing AI",
+			role:           dto.RoleAdmin,
+			simulateErrors: true,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectSuccess:  false,
+			expectAICall:   true,
+		},
+		{
+			name:           "non-admin forbidden",
+			role:           dto.RoleUser,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router, mockAI := newAdminRouter(t, tc.role, tc.simulateErrors)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/ai/health", nil)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if !tc.expectAICall {
+				assert.Zero(t, mockAI.CallCount())
+				return
+			}
+
+			var result dto.AIHealthResponse
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&result))
+			assert.Equal(t, tc.expectSuccess, result.Success)
+			assert.NotEmpty(t, result.Model)
+			assert.GreaterOrEqual(t, result.LatencyMs, int64(0))
+			if tc.expectSuccess {
+				assert.Empty(t, result.Error)
+			} else {
+				assert.NotEmpty(t, result.Error)
+			}
+		})
+	}
+}
+
+// TestEffectiveConfigEndpoint tests that the loaded configuration is reported with secrets masked
+func TestEffectiveConfigEndpoint(t *testing.T) {
+	cfg := &types.ServiceConfig{
+		ServiceName: "test-gateway",
+		Environment: "staging",
+		Version:     "1.4.0",
+		Database: &types.DatabaseConfig{
+			Host:     "db.internal",
+			Port:     5432,
+			User:     "gardener",
+			Password: "db-secret-password",
+			DBName:   "gardens",
+		},
+		Redis: &types.RedisConfig{
+			Host:     "cache.internal",
+			Port:     6379,
+			Password: "redis-secret-password",
+		},
+		API: &types.APIConfig{
+			Port:           8080,
+			AllowedOrigins: []string{"https://app.urban-gardening.com"},
+		},
+		CropManager: &types.CropManagerConfig{
+			CapacityWebhookURL: "https://hooks.example.com/capacity?token=webhook-secret-token",
+		},
+		FeatureFlags: map[string]string{"weekly_checklist": "true"},
+	}
+
+	t.Run("secrets masked", func(t *testing.T) {
+		router, _ := newAdminRouterWithConfig(t, dto.RoleAdmin, false, cfg)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/config", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "db-secret-password")
+		assert.NotContains(t, rec.Body.String(), "redis-secret-password")
+		assert.NotContains(t, rec.Body.String(), "webhook-secret-token")
+
+		var effective types.ServiceConfig
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&effective))
+		require.NotNil(t, effective.Database)
+		require.NotNil(t, effective.Redis)
+		assert.Equal(t, config.RedactedValue, effective.Database.Password)
+		assert.Equal(t, config.RedactedValue, effective.Redis.Password)
+		require.NotNil(t, effective.CropManager)
+		assert.Equal(t, config.RedactedValue, effective.CropManager.CapacityWebhookURL)
+
+		t.Run("non-secret fields present", func(t *testing.T) {
+			assert.Equal(t, "staging", effective.Environment)
+			assert.Equal(t, "1.4.0", effective.Version)
+			assert.Equal(t, "db.internal", effective.Database.Host)
+			assert.Equal(t, "gardener", effective.Database.User)
+			assert.Equal(t, 6379, effective.Redis.Port)
+			require.NotNil(t, effective.API)
+			assert.Equal(t, []string{"https://app.urban-gardening.com"}, effective.API.AllowedOrigins)
+			assert.Equal(t, "true", effective.FeatureFlags["weekly_checklist"])
+		})
+
+		// The running instance keeps its real credentials
+		assert.Equal(t, "db-secret-password", cfg.Database.Password)
+	})
+
+	t.Run("non-admin forbidden", func(t *testing.T) {
+		router, _ := newAdminRouterWithConfig(t, dto.RoleUser, false, cfg)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/config", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "db-secret-password")
+	})
+}
+
+// TestBreakerStatesEndpoint tests that a dependency breaker forced open is reported as open
+func TestBreakerStatesEndpoint(t *testing.T) {
+	cb := breaker.New(gobreaker.Settings{
+		Name:    "test-dependency",
+		Timeout: time.Minute,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= 2
+		},
+	})
+	for i := 0; i < 2; i++ {
+		_, err := cb.Execute(func() (interface{}, error) {
+			return nil, errors.New("dependency unavailable")
+		})
+		require.Error(t, err)
+	}
+	require.Equal(t, gobreaker.StateOpen, cb.State())
+
+	getStates := func(t *testing.T, role string) *httptest.ResponseRecorder {
+		router, _ := newAdminRouter(t, role, false)
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/breakers", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("open breaker reported", func(t *testing.T) {
+		rec := getStates(t, dto.RoleAdmin)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var response dto.BreakerStatesResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+		states := make(map[string]string, len(response.Breakers))
+		for _, state := range response.Breakers {
+			states[state.Name] = state.State
+		}
+		assert.Equal(t, "open", states["test-dependency"])
+	})
+
+	t.Run("non-admin forbidden", func(t *testing.T) {
+		rec := getStates(t, dto.RoleUser)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
+
+// TestDeadLettersEndpoint tests that paging and filter parameters reach the dead-letter queue
+func TestDeadLettersEndpoint(t *testing.T) {
+	newRouter := func(role string) (http.Handler, *stubDeadLetters) {
+		mockAI, err := mocks.NewMockAIClient(t, &types.ServiceConfig{ServiceName: "test-gateway", Environment: "test"})
+		require.NoError(t, err)
+		deadLetters := &stubDeadLetters{}
+
+		router := chi.NewRouter()
+		router.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				user := &dto.UserResponseDTO{ID: "user-1", Email: "ops@example.com", Role: role}
+				next.ServeHTTP(w, r.WithContext(middleware.ContextWithUser(r.Context(), user)))
+			})
+		})
+		routes.RegisterAdminRoutes(router, mockAI, deadLetters, &types.ServiceConfig{ServiceName: "test-gateway", Environment: "test"})
+		return router, deadLetters
+	}
+
+	t.Run("parameters passed through", func(t *testing.T) {
+		router, deadLetters := newRouter(dto.RoleAdmin)
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/notifications/dead-letters?page=2&pageSize=5&taskType=Water&reason=no_sender", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 2, deadLetters.page)
+		assert.Equal(t, 5, deadLetters.pageSize)
+		assert.Equal(t, dto.DeadLetterFilter{TaskType: "Water", Reason: "no_sender"}, deadLetters.filter)
+	})
+
+	t.Run("invalid page rejected", func(t *testing.T) {
+		router, _ := newRouter(dto.RoleAdmin)
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/notifications/dead-letters?page=zero", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("non-admin forbidden", func(t *testing.T) {
+		router, _ := newRouter(dto.RoleUser)
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/notifications/dead-letters", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
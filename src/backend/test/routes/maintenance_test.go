@@ -0,0 +1,284 @@
+package routes_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/urban-gardening/backend/api/gateway/routes"
+	"github.com/urban-gardening/backend/internal/scheduler"
+	"github.com/urban-gardening/backend/pkg/dto"
+	"github.com/urban-gardening/backend/pkg/types"
+	"github.com/urban-gardening/backend/test/mocks"
+)
+
+// newMaintenanceRouter registers maintenance routes backed by a mock database and miniredis cache
+func newMaintenanceRouter(t *testing.T, serveStale bool) (http.Handler, *scheduler.SchedulerService, *mocks.MockDB, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { redisClient.Close() })
+
+	cfg := &types.ServiceConfig{
+		ServiceName: "test-gateway",
+		Environment: "test",
+		Scheduler: &types.SchedulerConfig{
+			AIWorkerPoolSize: 5,
+			ServeStaleReads:  serveStale,
+			StaleReadTTL:     24 * time.Hour,
+		},
+	}
+	mockDB := mocks.NewMockDB(true, false)
+	mockAI, err := mocks.NewMockAIClient(t, cfg)
+	require.NoError(t, err)
+
+	service, err := scheduler.NewSchedulerService(mockDB, redisClient, mockAI, cfg)
+	require.NoError(t, err)
+
+	router := chi.NewRouter()
+	routes.RegisterMaintenanceRoutes(router, service)
+
+	return router, service, mockDB, mr
+}
+
+// warmSchedule creates a schedule, reads it once to populate the cache, and lets the
+// short-lived cache entry expire so later reads must go to the database
+func warmSchedule(t *testing.T, router http.Handler, service *scheduler.SchedulerService, mr *miniredis.Miniredis) string {
+	schedule, err := service.CreateSchedule(context.Background(), &dto.MaintenanceRequest{
+		CropID:             "stale-crop-id",
+		TaskType:           dto.TaskTypeWater,
+		Frequency:          dto.FrequencyDaily,
+		Amount:             500,
+		Unit:               "ml",
+		PreferredTime:      "09:00",
+		SoilType:           "Loamy",
+		GrowBagSize:        "12\"",
+		GrowingEnvironment: dto.EnvironmentOutdoor,
+		EnvironmentalFactors: map[string]interface{}{
+			"temperature": 24.0,
+			"humidity":    55.0,
+			"lightLevel":  "high",
+		},
+	})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/maintenance/"+schedule.ID, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	mr.FastForward(2 * time.Hour)
+	return schedule.ID
+}
+
+// TestGetScheduleWhileDatabaseDown tests that schedule reads degrade to stale cached copies
+func TestGetScheduleWhileDatabaseDown(t *testing.T) {
+	t.Run("stale schedule served with warm cache", func(t *testing.T) {
+		router, service, mockDB, mr := newMaintenanceRouter(t, true)
+		scheduleID := warmSchedule(t, router, service, mr)
+		mockDB.SetTimeout(true)
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/maintenance/"+scheduleID, nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var body dto.MaintenanceResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+		assert.Equal(t, scheduleID, body.ID)
+		assert.True(t, body.Stale)
+	})
+
+	t.Run("fresh read not flagged stale", func(t *testing.T) {
+		router, service, _, mr := newMaintenanceRouter(t, true)
+		scheduleID := warmSchedule(t, router, service, mr)
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/maintenance/"+scheduleID, nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var body dto.MaintenanceResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+		assert.False(t, body.Stale)
+	})
+
+	t.Run("error when stale reads disabled", func(t *testing.T) {
+		router, service, mockDB, mr := newMaintenanceRouter(t, false)
+		scheduleID := warmSchedule(t, router, service, mr)
+		mockDB.SetTimeout(true)
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/maintenance/"+scheduleID, nil))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+// TestGetSchedulesByID tests that fetching schedules by ID returns JSON listing found and missing IDs
+func TestGetSchedulesByID(t *testing.T) {
+	router, service, _, mr := newMaintenanceRouter(t, true)
+	scheduleID := warmSchedule(t, router, service, mr)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/maintenance?ids="+scheduleID+",missing-id", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var body dto.MaintenanceMultiResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	require.Len(t, body.Schedules, 1)
+	assert.Equal(t, scheduleID, body.Schedules[0].ID)
+	assert.Equal(t, []string{"missing-id"}, body.Missing)
+}
+
+// validMaintenanceBody returns a maintenance request body that passes validation, for
+// tests to break one field at a time
+func validMaintenanceBody() map[string]interface{} {
+	return map[string]interface{}{
+		"cropId":             "validate-crop-id",
+		"taskType":           dto.TaskTypeWater,
+		"frequency":          dto.FrequencyDaily,
+		"amount":             500,
+		"unit":               "ml",
+		"preferredTime":      "09:00",
+		"soilType":           "Loamy",
+		"growBagSize":        "12\"",
+		"growingEnvironment": dto.EnvironmentOutdoor,
+		"environmentalFactors": map[string]interface{}{
+			"temperature": 24.0,
+			"humidity":    55.0,
+			"lightLevel":  "high",
+		},
+	}
+}
+
+// TestValidateMaintenanceRequest tests that the validate endpoint aggregates field and
+// cross-field errors without creating a schedule
+func TestValidateMaintenanceRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(body map[string]interface{})
+		fields []string
+	}{
+		{
+			name:   "valid request",
+			modify: func(body map[string]interface{}) {},
+		},
+		{
+			name: "wrong unit and night time outdoors",
+			modify: func(body map[string]interface{}) {
+				body["unit"] = "g"
+				body["preferredTime"] = "22:00"
+			},
+			fields: []string{"unit", "preferredTime"},
+		},
+		{
+			name: "fertilizer over maximum with missing factors",
+			modify: func(body map[string]interface{}) {
+				body["taskType"] = dto.TaskTypeFertilizer
+				body["unit"] = "g"
+				body["amount"] = 5000
+				body["environmentalFactors"] = map[string]interface{}{"humidity": 55.0}
+			},
+			fields: []string{"amount", "environmentalFactors.temperature", "environmentalFactors.lightLevel"},
+		},
+		{
+			name: "factors out of range with missing crop",
+			modify: func(body map[string]interface{}) {
+				body["cropId"] = ""
+				body["environmentalFactors"] = map[string]interface{}{
+					"temperature": 85.0,
+					"humidity":    140.0,
+					"lightLevel":  "blinding",
+				}
+			},
+			fields: []string{"cropId", "environmentalFactors.temperature", "environmentalFactors.humidity", "environmentalFactors.lightLevel"},
+		},
+		{
+			name: "water below model minimum",
+			modify: func(body map[string]interface{}) {
+				body["amount"] = 20
+			},
+			fields: []string{"amount"},
+		},
+		{
+			name: "night time allowed indoors",
+			modify: func(body map[string]interface{}) {
+				body["growingEnvironment"] = dto.EnvironmentIndoor
+				body["preferredTime"] = "22:00"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, _, mockDB, _ := newMaintenanceRouter(t, false)
+			// Validation never touches the database, so it still answers while it is down
+			mockDB.SetTimeout(true)
+
+			body := validMaintenanceBody()
+			tt.modify(body)
+			payload, err := json.Marshal(body)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/maintenance/validate", bytes.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			var result dto.MaintenanceValidationResponse
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&result))
+
+			assert.Equal(t, len(tt.fields) == 0, result.Valid)
+			fields := make([]string, 0, len(result.Errors))
+			for _, fieldErr := range result.Errors {
+				fields = append(fields, fieldErr.Field)
+			}
+			assert.ElementsMatch(t, tt.fields, fields)
+		})
+	}
+
+	t.Run("validate reports the first aggregated error", func(t *testing.T) {
+		request := &dto.MaintenanceRequest{
+			CropID:               "0b8f4a8e-3f5c-4a57-9a43-6a1f1d2c7e10",
+			TaskType:             dto.TaskTypeWater,
+			Frequency:            dto.FrequencyDaily,
+			Amount:               20,
+			Unit:                 "ml",
+			PreferredTime:        "09:00",
+			SoilType:             "Loamy",
+			GrowBagSize:          "12\"",
+			GrowingEnvironment:   dto.EnvironmentOutdoor,
+			EnvironmentalFactors: map[string]interface{}{"temperature": 24.0, "humidity": 140.0, "lightLevel": "high"},
+		}
+
+		all, ok := request.ValidateAll().(types.ValidationErrors)
+		require.True(t, ok)
+		require.Len(t, all, 2)
+
+		err := request.Validate()
+		var first *types.ValidationError
+		require.ErrorAs(t, err, &first)
+		assert.Equal(t, all[0].Field, first.Field)
+		assert.Equal(t, "amount", first.Field)
+	})
+
+	t.Run("malformed body rejected", func(t *testing.T) {
+		router, _, _, _ := newMaintenanceRouter(t, false)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/maintenance/validate", bytes.NewBufferString("{"))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -2,15 +2,29 @@
 package scheduler_test
 
 import (
+    "bytes"
     "context"
+    "encoding/csv"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "strings"
+    "sync"
+    "sync/atomic"
     "testing"
     "time"
 
+    "github.com/alicebob/miniredis/v2"
+    "github.com/go-redis/redis/v8"
+    "github.com/sashabaranov/go-openai"
     "github.com/stretchr/testify/assert"
     "github.com/stretchr/testify/require"
     "github.com/stretchr/testify/suite"
 
+    "github.com/urban-gardening/backend/internal/ai"
+    "github.com/urban-gardening/backend/internal/models"
     "github.com/urban-gardening/backend/internal/scheduler"
+    "github.com/urban-gardening/backend/internal/utils/clock"
     "github.com/urban-gardening/backend/pkg/dto"
     "github.com/urban-gardening/backend/pkg/types"
     "github.com/urban-gardening/backend/test/mocks"
@@ -272,7 +286,7 @@ func (s *SchedulerTestSuite) TestCompleteTask() {
     for _, tc := range tests {
         s.Run(tc.name, func() {
             // Test execution
-            response, err := s.scheduler.CompleteTask(s.ctx, tc.taskID)
+            response, err := s.scheduler.CompleteTask(s.ctx, tc.taskID, nil, "")
 
             // Verify results
             if tc.expectError {
@@ -352,4 +366,3380 @@ func (s *SchedulerTestSuite) TestGetSchedule() {
             }
         })
     }
-}
\ No newline at end of file
+}
+
+// TestCompleteTaskBackdated tests completing a task with a past completion time
+func (s *SchedulerTestSuite) TestGetSchedules() {
+    crop := &models.Crop{ID: "multi-fetch-crop-id", GardenID: "multi-fetch-garden-id", Name: "Spinach", GrowBags: 1, BagSize: "10\""}
+    _, err := s.mockDB.Create(crop)
+    require.NoError(s.T(), err)
+
+    seed := []*models.Maintenance{
+        {ID: "multi-water", CropID: crop.ID, TaskType: "Water", Frequency: "Daily", Amount: 400, Unit: "ml", PreferredTime: "07:00", Active: true},
+        {ID: "multi-fertilizer", CropID: crop.ID, TaskType: "Fertilizer", Frequency: "Monthly", Amount: 15, Unit: "g", PreferredTime: "08:00", Active: true},
+        {ID: "multi-compost", CropID: crop.ID, TaskType: "Composting", Frequency: "Monthly", Amount: 200, Unit: "g", PreferredTime: "09:00", Active: true},
+    }
+    for _, maintenance := range seed {
+        _, err := s.mockDB.Create(maintenance)
+        require.NoError(s.T(), err)
+    }
+
+    // Warm the cache for one schedule so the batch mixes cached and loaded schedules
+    _, err = s.scheduler.GetSchedule(s.ctx, "multi-water")
+    require.NoError(s.T(), err)
+
+    s.Run("Found And Missing In Requested Order", func() {
+        response, err := s.scheduler.GetSchedules(s.ctx, []string{"multi-compost", "missing-one", "multi-water", "multi-fertilizer", "missing-two"})
+        require.NoError(s.T(), err)
+
+        ids := make([]string, len(response.Schedules))
+        for i, schedule := range response.Schedules {
+            ids[i] = schedule.ID
+        }
+        assert.Equal(s.T(), []string{"multi-compost", "multi-water", "multi-fertilizer"}, ids)
+        assert.Equal(s.T(), []string{"missing-one", "missing-two"}, response.Missing)
+        assert.Equal(s.T(), "Composting", response.Schedules[0].TaskType)
+    })
+
+    s.Run("Duplicates And Blanks Ignored", func() {
+        response, err := s.scheduler.GetSchedules(s.ctx, []string{"multi-water", "", "multi-water"})
+        require.NoError(s.T(), err)
+        require.Len(s.T(), response.Schedules, 1)
+        assert.Equal(s.T(), "multi-water", response.Schedules[0].ID)
+        assert.Empty(s.T(), response.Missing)
+    })
+
+    s.Run("All Missing", func() {
+        response, err := s.scheduler.GetSchedules(s.ctx, []string{"missing-one"})
+        require.NoError(s.T(), err)
+        assert.Empty(s.T(), response.Schedules)
+        assert.Equal(s.T(), []string{"missing-one"}, response.Missing)
+    })
+
+    s.Run("No IDs", func() {
+        _, err := s.scheduler.GetSchedules(s.ctx, []string{""})
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+    })
+}
+
+func (s *SchedulerTestSuite) TestCompleteTaskBackdated() {
+    request := &dto.MaintenanceRequest{
+        CropID:             "test-crop-id",
+        TaskType:           "Water",
+        Frequency:          "Daily",
+        Amount:            500.0,
+        Unit:              "ml",
+        PreferredTime:     "09:00",
+        AIRecommended:     true,
+        SoilType:          "Loamy",
+        GrowingEnvironment: "Indoor",
+        EnvironmentalFactors: map[string]interface{}{
+            "temperature": 25.0,
+            "humidity":    60.0,
+            "lightLevel":  "medium",
+        },
+    }
+
+    schedule, err := s.scheduler.CreateSchedule(s.ctx, request)
+    require.NoError(s.T(), err)
+
+    twoDaysAgo := time.Now().Add(-48 * time.Hour)
+    response, err := s.scheduler.CompleteTask(s.ctx, schedule.ID, &twoDaysAgo, "")
+    require.NoError(s.T(), err)
+    assert.WithinDuration(s.T(), twoDaysAgo, response.LastCompletedTime, time.Second)
+    assert.Equal(s.T(), 1, response.CompletionStreak)
+
+    s.Run("Completion Before Last Rejected", func() {
+        threeDaysAgo := time.Now().Add(-72 * time.Hour)
+        response, err := s.scheduler.CompleteTask(s.ctx, schedule.ID, &threeDaysAgo, "")
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+        assert.Nil(s.T(), response)
+    })
+}
+
+// TestCompleteStaleTaskRollsForward tests that a completion backdated by several intervals
+// schedules the next occurrence in the future at the task's cadence
+func (s *SchedulerTestSuite) TestCompleteStaleTaskRollsForward() {
+    request := newTestMaintenanceRequest("stale-crop-id", "Water", "ml", 500.0)
+    request.Frequency = "Daily"
+    schedule, err := s.scheduler.CreateSchedule(s.ctx, request)
+    require.NoError(s.T(), err)
+
+    now := time.Now()
+    completedAt := now.Add(-10*24*time.Hour - 3*time.Hour)
+    response, err := s.scheduler.CompleteTask(s.ctx, schedule.ID, &completedAt, "")
+    require.NoError(s.T(), err)
+
+    next := response.NextScheduledTime
+    assert.True(s.T(), next.After(now), "next time must not be in the past")
+    assert.LessOrEqual(s.T(), next.Sub(now), 24*time.Hour, "next time must be the first future occurrence")
+    assert.Equal(s.T(), "09:00", next.Format("15:04"), "next time must keep the preferred time")
+
+    stored, err := s.scheduler.GetSchedule(s.ctx, schedule.ID)
+    require.NoError(s.T(), err)
+    assert.True(s.T(), next.Equal(stored.NextScheduledTime), "returned next time must be the persisted one")
+}
+
+// TestCompleteMonthlyTask tests that completing a Monthly task schedules the next
+// occurrence a calendar month later at the preferred time
+func (s *SchedulerTestSuite) TestCompleteMonthlyTask() {
+    request := newTestMaintenanceRequest("monthly-crop-id", "Composting", "g", 200.0)
+    request.Frequency = dto.FrequencyMonthly
+    schedule, err := s.scheduler.CreateSchedule(s.ctx, request)
+    require.NoError(s.T(), err)
+
+    completedAt := time.Now().Add(-time.Hour)
+    response, err := s.scheduler.CompleteTask(s.ctx, schedule.ID, &completedAt, "")
+    require.NoError(s.T(), err)
+
+    expected := time.Date(completedAt.Year(), completedAt.Month(), completedAt.Day(), 9, 0, 0, 0, completedAt.Location()).AddDate(0, 1, 0)
+    assert.WithinDuration(s.T(), expected, response.NextScheduledTime, time.Second)
+}
+
+// TestCompleteTaskIdempotent tests that replaying a completion key changes the task once
+func (s *SchedulerTestSuite) TestCompleteTaskIdempotent() {
+    cropID := "crop-with-device"
+    schedule, err := s.scheduler.CreateSchedule(s.ctx, newTestMaintenanceRequest(cropID, "Water", "ml", 500.0))
+    require.NoError(s.T(), err)
+
+    twoDaysAgo := time.Now().Add(-48 * time.Hour)
+    first, err := s.scheduler.CompleteTask(s.ctx, schedule.ID, &twoDaysAgo, "valve-7:event-1")
+    require.NoError(s.T(), err)
+    assert.Equal(s.T(), 1, first.CompletionStreak)
+
+    // A retry reporting a later time must not move the completion or bump the streak
+    oneDayAgo := time.Now().Add(-24 * time.Hour)
+    for i := 0; i < 3; i++ {
+        replay, err := s.scheduler.CompleteTask(s.ctx, schedule.ID, &oneDayAgo, "valve-7:event-1")
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), 1, replay.CompletionStreak)
+        assert.WithinDuration(s.T(), twoDaysAgo, replay.LastCompletedTime, time.Second)
+    }
+
+    countCompletions := func() int {
+        history, err := s.scheduler.GetScheduleChangeLog(s.ctx, cropID)
+        require.NoError(s.T(), err)
+        completions := 0
+        for _, event := range history {
+            if event.EventType == models.ScheduleEventCompleted {
+                completions++
+            }
+        }
+        return completions
+    }
+    assert.Equal(s.T(), 1, countCompletions())
+
+    s.Run("New Key Completes Again", func() {
+        response, err := s.scheduler.CompleteTask(s.ctx, schedule.ID, &oneDayAgo, "valve-7:event-2")
+        require.NoError(s.T(), err)
+        assert.WithinDuration(s.T(), oneDayAgo, response.LastCompletedTime, time.Second)
+        assert.Equal(s.T(), 2, countCompletions())
+    })
+
+    s.Run("Key Too Long Rejected", func() {
+        _, err := s.scheduler.CompleteTask(s.ctx, schedule.ID, nil, strings.Repeat("k", 101))
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+    })
+}
+
+// TestValidatePreferredTime tests the preferred time windows for each growing environment
+func (s *SchedulerTestSuite) TestValidatePreferredTime() {
+    testCases := []struct {
+        name          string
+        environment   string
+        preferredTime string
+        allowed       bool
+        windowStart   string
+        windowEnd     string
+    }{
+        {"Indoor Late Night Allowed", dto.EnvironmentIndoor, "23:30", true, "00:00", "23:59"},
+        {"Indoor Early Morning Allowed", dto.EnvironmentIndoor, "03:00", true, "00:00", "23:59"},
+        {"Outdoor Midday Allowed", dto.EnvironmentOutdoor, "12:00", true, "06:00", "18:59"},
+        {"Outdoor Dawn Boundary Allowed", dto.EnvironmentOutdoor, "06:00", true, "06:00", "18:59"},
+        {"Outdoor Before Dawn Rejected", dto.EnvironmentOutdoor, "05:59", false, "06:00", "18:59"},
+        {"Outdoor Night Rejected", dto.EnvironmentOutdoor, "21:00", false, "06:00", "18:59"},
+        {"Greenhouse Night Rejected", dto.EnvironmentGreenhouse, "20:00", false, "06:00", "18:59"},
+        {"Environment Defaults To Outdoor", "", "22:00", false, "06:00", "18:59"},
+    }
+
+    for _, tc := range testCases {
+        s.Run(tc.name, func() {
+            result, err := s.scheduler.ValidatePreferredTime(&dto.PreferredTimeValidationRequest{
+                Environment:   tc.environment,
+                PreferredTime: tc.preferredTime,
+            })
+            require.NoError(s.T(), err)
+            assert.Equal(s.T(), tc.allowed, result.Allowed)
+            assert.Equal(s.T(), tc.windowStart, result.WindowStart)
+            assert.Equal(s.T(), tc.windowEnd, result.WindowEnd)
+            if tc.allowed {
+                assert.Empty(s.T(), result.Reason)
+            } else {
+                assert.Contains(s.T(), result.Reason, "daylight")
+            }
+        })
+    }
+
+    s.Run("Malformed Input Rejected", func() {
+        _, err := s.scheduler.ValidatePreferredTime(&dto.PreferredTimeValidationRequest{Environment: dto.EnvironmentIndoor, PreferredTime: "9am"})
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+
+        _, err = s.scheduler.ValidatePreferredTime(&dto.PreferredTimeValidationRequest{Environment: "Basement", PreferredTime: "09:00"})
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+    })
+
+    s.Run("Schedule Creation Follows The Same Rules", func() {
+        indoor := newTestMaintenanceRequest("indoor-night-crop", "Water", "ml", 300.0)
+        indoor.GrowingEnvironment = dto.EnvironmentIndoor
+        indoor.PreferredTime = "22:00"
+        _, err := s.scheduler.CreateSchedule(s.ctx, indoor)
+        assert.NoError(s.T(), err)
+
+        outdoor := newTestMaintenanceRequest("outdoor-night-crop", "Water", "ml", 300.0)
+        outdoor.PreferredTime = "22:00"
+        _, err = s.scheduler.CreateSchedule(s.ctx, outdoor)
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+    })
+}
+
+// TestMarkCompleteAtStreak tests streak math for backdated completions
+func TestMarkCompleteAtStreak(t *testing.T) {
+    newTask := func() *models.Maintenance {
+        return &models.Maintenance{
+            TaskType:      "Water",
+            Frequency:     "Daily",
+            PreferredTime: "09:00",
+        }
+    }
+
+    t.Run("backdated completions within interval extend streak", func(t *testing.T) {
+        task := newTask()
+        now := time.Now()
+
+        require.NoError(t, task.MarkCompleteAt(now.Add(-47*time.Hour)))
+        require.NoError(t, task.MarkCompleteAt(now.Add(-24*time.Hour)))
+        require.NoError(t, task.MarkCompleteAt(now))
+        assert.Equal(t, 3, task.CompletionStreak)
+    })
+
+    t.Run("backdated gap resets streak", func(t *testing.T) {
+        task := newTask()
+        now := time.Now()
+
+        require.NoError(t, task.MarkCompleteAt(now.Add(-96*time.Hour)))
+        require.NoError(t, task.MarkCompleteAt(now.Add(-72*time.Hour)))
+        assert.Equal(t, 2, task.CompletionStreak)
+
+        // Missed two days before catching up
+        require.NoError(t, task.MarkCompleteAt(now))
+        assert.Equal(t, 1, task.CompletionStreak)
+    })
+
+    t.Run("next schedule is based on completion time", func(t *testing.T) {
+        task := newTask()
+        completedAt := time.Now().Add(-30 * time.Hour)
+
+        require.NoError(t, task.MarkCompleteAt(completedAt))
+        expected := time.Date(completedAt.Year(), completedAt.Month(), completedAt.Day(), 9, 0, 0, 0, completedAt.Location()).AddDate(0, 0, 1)
+        assert.Equal(t, expected, task.NextScheduledTime)
+    })
+
+    t.Run("completion before last is rejected", func(t *testing.T) {
+        task := newTask()
+        now := time.Now()
+
+        require.NoError(t, task.MarkCompleteAt(now.Add(-time.Hour)))
+        err := task.MarkCompleteAt(now.Add(-2 * time.Hour))
+        assert.ErrorIs(t, err, models.ErrCompletionBeforeLast)
+        assert.Equal(t, 1, task.CompletionStreak)
+    })
+
+    t.Run("future completion is rejected", func(t *testing.T) {
+        task := newTask()
+        err := task.MarkCompleteAt(time.Now().Add(time.Hour))
+        assert.ErrorIs(t, err, models.ErrCompletionInFuture)
+    })
+}
+
+// TestNextScheduleWithFakeClock tests next-schedule math against a pinned clock
+func TestNextScheduleWithFakeClock(t *testing.T) {
+    now := time.Date(2024, time.March, 10, 14, 30, 0, 0, time.UTC)
+
+    tests := []struct {
+        frequency string
+        expected  time.Time
+    }{
+        {"Daily", time.Date(2024, time.March, 11, 9, 0, 0, 0, time.UTC)},
+        {"Twice-Daily", time.Date(2024, time.March, 10, 21, 0, 0, 0, time.UTC)},
+        {"Weekly", time.Date(2024, time.March, 17, 9, 0, 0, 0, time.UTC)},
+        {"Bi-weekly", time.Date(2024, time.March, 24, 9, 0, 0, 0, time.UTC)},
+        {"Monthly", time.Date(2024, time.April, 10, 9, 0, 0, 0, time.UTC)},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.frequency, func(t *testing.T) {
+            task := &models.Maintenance{TaskType: "Water", Frequency: tt.frequency, PreferredTime: "09:00"}
+            task.SetClock(clock.NewFake(now))
+
+            next, err := task.CalculateNextSchedule()
+            require.NoError(t, err)
+            assert.Equal(t, tt.expected, next)
+        })
+    }
+
+    t.Run("completion uses clock time", func(t *testing.T) {
+        fake := clock.NewFake(now)
+        task := &models.Maintenance{TaskType: "Water", Frequency: "Daily", PreferredTime: "09:00"}
+        task.SetClock(fake)
+
+        require.NoError(t, task.MarkComplete())
+        require.NotNil(t, task.LastCompletedTime)
+        assert.Equal(t, now, *task.LastCompletedTime)
+        assert.Equal(t, time.Date(2024, time.March, 11, 9, 0, 0, 0, time.UTC), task.NextScheduledTime)
+
+        // A day later the streak continues and the schedule moves with the clock
+        fake.Advance(24 * time.Hour)
+        require.NoError(t, task.MarkComplete())
+        assert.Equal(t, 2, task.CompletionStreak)
+        assert.Equal(t, time.Date(2024, time.March, 12, 9, 0, 0, 0, time.UTC), task.NextScheduledTime)
+    })
+
+    t.Run("future is relative to clock", func(t *testing.T) {
+        task := &models.Maintenance{TaskType: "Water", Frequency: "Daily", PreferredTime: "09:00"}
+        task.SetClock(clock.NewFake(now))
+
+        assert.ErrorIs(t, task.MarkCompleteAt(now.Add(time.Minute)), models.ErrCompletionInFuture)
+        assert.NoError(t, task.MarkCompleteAt(now))
+    })
+
+    t.Run("one-off task deactivated on completion", func(t *testing.T) {
+        dueAt := time.Date(2024, time.March, 20, 9, 0, 0, 0, time.UTC)
+        task := &models.Maintenance{TaskType: "Re-pot", Frequency: "Once", PreferredTime: "09:00", Active: true, NextScheduledTime: dueAt}
+        task.SetClock(clock.NewFake(now))
+
+        next, err := task.CalculateNextSchedule()
+        require.NoError(t, err)
+        assert.Equal(t, dueAt, next)
+
+        require.NoError(t, task.MarkComplete())
+        assert.False(t, task.Active)
+        assert.Equal(t, dueAt, task.NextScheduledTime)
+    })
+}
+
+// TestNextScheduleNearestOccurrence tests that new tasks start at the nearest upcoming
+// preferred time rather than always skipping today
+func TestNextScheduleNearestOccurrence(t *testing.T) {
+    morning := time.Date(2024, time.March, 10, 7, 0, 0, 0, time.UTC)
+    afternoon := time.Date(2024, time.March, 10, 14, 0, 0, 0, time.UTC)
+
+    tests := []struct {
+        name          string
+        now           time.Time
+        frequency     string
+        preferredTime string
+        expected      time.Time
+    }{
+        {"daily preferred time still ahead is today", morning, "Daily", "09:00", time.Date(2024, time.March, 10, 9, 0, 0, 0, time.UTC)},
+        {"daily preferred time passed is tomorrow", afternoon, "Daily", "09:00", time.Date(2024, time.March, 11, 9, 0, 0, 0, time.UTC)},
+        {"daily preferred time exactly now is tomorrow", afternoon, "Daily", "14:00", time.Date(2024, time.March, 11, 14, 0, 0, 0, time.UTC)},
+        {"twice-daily earlier slot ahead", morning, "Twice-Daily", "21:00", time.Date(2024, time.March, 10, 9, 0, 0, 0, time.UTC)},
+        {"twice-daily later slot ahead", afternoon, "Twice-Daily", "09:00", time.Date(2024, time.March, 10, 21, 0, 0, 0, time.UTC)},
+        {"weekly unchanged", morning, "Weekly", "09:00", time.Date(2024, time.March, 17, 9, 0, 0, 0, time.UTC)},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            task := &models.Maintenance{TaskType: "Water", Frequency: tt.frequency, PreferredTime: tt.preferredTime}
+            task.SetClock(clock.NewFake(tt.now))
+
+            next, err := task.CalculateNextSchedule()
+            require.NoError(t, err)
+            assert.Equal(t, tt.expected, next)
+            assert.True(t, next.After(tt.now))
+        })
+    }
+
+    t.Run("completed today is due tomorrow even before preferred time", func(t *testing.T) {
+        task := &models.Maintenance{TaskType: "Water", Frequency: "Daily", PreferredTime: "09:00"}
+        task.SetClock(clock.NewFake(morning))
+
+        require.NoError(t, task.MarkComplete())
+        assert.Equal(t, time.Date(2024, time.March, 11, 9, 0, 0, 0, time.UTC), task.NextScheduledTime)
+    })
+}
+
+// TestNextScheduleStaleTask tests that a task last completed long ago is rolled forward to
+// its next future occurrence at its own cadence rather than scheduled in the past
+func TestNextScheduleStaleTask(t *testing.T) {
+    now := time.Date(2024, time.March, 10, 7, 0, 0, 0, time.UTC)
+
+    tests := []struct {
+        frequency     string
+        lastCompleted time.Time
+        expected      time.Time
+    }{
+        {"Daily", time.Date(2024, time.February, 20, 18, 0, 0, 0, time.UTC), time.Date(2024, time.March, 10, 9, 0, 0, 0, time.UTC)},
+        {"Twice-Daily", time.Date(2024, time.March, 1, 9, 30, 0, 0, time.UTC), time.Date(2024, time.March, 10, 9, 0, 0, 0, time.UTC)},
+        {"Weekly", time.Date(2024, time.February, 1, 9, 0, 0, 0, time.UTC), time.Date(2024, time.March, 14, 9, 0, 0, 0, time.UTC)},
+        {"Bi-weekly", time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC), time.Date(2024, time.March, 11, 9, 0, 0, 0, time.UTC)},
+        {"Monthly", time.Date(2023, time.December, 10, 9, 0, 0, 0, time.UTC), time.Date(2024, time.March, 10, 9, 0, 0, 0, time.UTC)},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.frequency, func(t *testing.T) {
+            lastCompleted := tt.lastCompleted
+            task := &models.Maintenance{TaskType: "Water", Frequency: tt.frequency, PreferredTime: "09:00", LastCompletedTime: &lastCompleted}
+            task.SetClock(clock.NewFake(now))
+
+            next, err := task.CalculateNextSchedule()
+            require.NoError(t, err)
+            assert.Equal(t, tt.expected, next)
+            assert.True(t, next.After(now))
+        })
+    }
+
+    t.Run("past kept when check disabled", func(t *testing.T) {
+        lastCompleted := time.Date(2024, time.February, 20, 18, 0, 0, 0, time.UTC)
+        task := &models.Maintenance{TaskType: "Water", Frequency: "Daily", PreferredTime: "09:00", LastCompletedTime: &lastCompleted}
+        task.SetClock(clock.NewFake(now))
+        task.SetAllowPastSchedule(true)
+
+        next, err := task.CalculateNextSchedule()
+        require.NoError(t, err)
+        assert.Equal(t, time.Date(2024, time.February, 21, 9, 0, 0, 0, time.UTC), next)
+    })
+}
+
+// TestScheduleAcceleration tests that acceleration rules for heat, dry air, and bright light
+// combine into a bounded advance of AI-recommended tasks
+func TestScheduleAcceleration(t *testing.T) {
+    morning := time.Date(2024, time.March, 10, 7, 0, 0, 0, time.UTC)
+    midnight := time.Date(2024, time.March, 10, 0, 30, 0, 0, time.UTC)
+
+    policy := &models.AccelerationPolicy{
+        Rules: []models.AccelerationRule{
+            {TaskType: "Water", Factor: models.FactorTemperature, Comparison: models.AccelerateAbove, Threshold: 30, Advance: 6 * time.Hour},
+            {TaskType: "Water", Factor: models.FactorHumidity, Comparison: models.AccelerateBelow, Threshold: 40, Advance: 3 * time.Hour},
+            {TaskType: "Water", Factor: models.FactorLightLevel, Comparison: models.AccelerateAbove, Threshold: 2, Advance: 2 * time.Hour},
+        },
+        MaxAdvance: 8 * time.Hour,
+    }
+    require.NoError(t, policy.Validate())
+
+    factors := func(temperature, humidity float64, lightLevel string) json.RawMessage {
+        raw, err := json.Marshal(map[string]interface{}{
+            "temperature": temperature,
+            "humidity":    humidity,
+            "lightLevel":  lightLevel,
+        })
+        require.NoError(t, err)
+        return raw
+    }
+
+    tests := []struct {
+        name      string
+        now       time.Time
+        taskType  string
+        frequency string
+        factors   json.RawMessage
+        policy    *models.AccelerationPolicy
+        expected  time.Time
+    }{
+        {"mild conditions unchanged", morning, "Water", "Weekly", factors(25, 60, "medium"), policy, time.Date(2024, time.March, 17, 9, 0, 0, 0, time.UTC)},
+        {"heat alone", morning, "Water", "Weekly", factors(32, 60, "medium"), policy, time.Date(2024, time.March, 17, 3, 0, 0, 0, time.UTC)},
+        {"dry air and bright light add up", morning, "Water", "Weekly", factors(25, 30, "high"), policy, time.Date(2024, time.March, 17, 4, 0, 0, 0, time.UTC)},
+        {"heat and dry air capped at maximum", morning, "Water", "Weekly", factors(32, 30, "high"), policy, time.Date(2024, time.March, 17, 1, 0, 0, 0, time.UTC)},
+        {"other task types unaffected", morning, "Fertilizer", "Weekly", factors(32, 30, "high"), policy, time.Date(2024, time.March, 17, 9, 0, 0, 0, time.UTC)},
+        {"capped at half the interval", midnight, "Water", "Twice-Daily", factors(32, 30, "high"), policy, time.Date(2024, time.March, 10, 3, 0, 0, 0, time.UTC)},
+        {"never brought into the past", morning, "Water", "Daily", factors(32, 30, "high"), policy, time.Date(2024, time.March, 10, 9, 0, 0, 0, time.UTC)},
+        {"default policy waters earlier in heat", morning, "Water", "Weekly", factors(32, 30, "high"), nil, time.Date(2024, time.March, 17, 3, 0, 0, 0, time.UTC)},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            task := &models.Maintenance{
+                TaskType:             tt.taskType,
+                Frequency:            tt.frequency,
+                PreferredTime:        "09:00",
+                AIRecommended:        true,
+                EnvironmentalFactors: tt.factors,
+            }
+            task.SetClock(clock.NewFake(tt.now))
+            task.SetAccelerationPolicy(tt.policy)
+
+            next, err := task.CalculateNextSchedule()
+            require.NoError(t, err)
+            assert.Equal(t, tt.expected, next)
+        })
+    }
+
+    t.Run("invalid rules rejected", func(t *testing.T) {
+        invalid := []models.AccelerationPolicy{
+            {MaxAdvance: time.Hour, Rules: []models.AccelerationRule{{TaskType: "Water", Factor: "wind", Comparison: models.AccelerateAbove, Advance: time.Hour}}},
+            {MaxAdvance: time.Hour, Rules: []models.AccelerationRule{{TaskType: "Water", Factor: models.FactorHumidity, Comparison: "equal", Advance: time.Hour}}},
+            {MaxAdvance: time.Hour, Rules: []models.AccelerationRule{{TaskType: "Water", Factor: models.FactorHumidity, Comparison: models.AccelerateBelow}}},
+            {MaxAdvance: time.Hour, Rules: []models.AccelerationRule{{TaskType: "Harvest", Factor: models.FactorHumidity, Comparison: models.AccelerateBelow, Advance: time.Hour}}},
+            {Rules: policy.Rules},
+        }
+        for _, p := range invalid {
+            assert.ErrorIs(t, p.Validate(), models.ErrInvalidAccelerationRule)
+        }
+    })
+}
+
+// newTestMaintenanceRequest builds a valid maintenance request for the given task type
+func newTestMaintenanceRequest(cropID, taskType, unit string, amount float64) *dto.MaintenanceRequest {
+    return &dto.MaintenanceRequest{
+        CropID:             cropID,
+        TaskType:           taskType,
+        Frequency:          "Weekly",
+        Amount:            amount,
+        Unit:              unit,
+        PreferredTime:     "09:00",
+        AIRecommended:     true,
+        SoilType:          "Loamy",
+        GrowBagSize:       "12\"",
+        GrowingEnvironment: "Outdoor",
+        EnvironmentalFactors: map[string]interface{}{
+            "temperature": 25.0,
+            "humidity":    60.0,
+            "lightLevel":  "medium",
+        },
+    }
+}
+
+// TestGetCropSchedules tests retrieval of the active schedules attached to a crop
+func (s *SchedulerTestSuite) TestGetCropSchedules() {
+    cropID := "crop-with-schedules"
+    requests := []*dto.MaintenanceRequest{
+        newTestMaintenanceRequest(cropID, "Water", "ml", 500.0),
+        newTestMaintenanceRequest(cropID, "Fertilizer", "g", 50.0),
+        newTestMaintenanceRequest(cropID, "Composting", "g", 200.0),
+    }
+    for _, req := range requests {
+        _, err := s.scheduler.CreateSchedule(s.ctx, req)
+        require.NoError(s.T(), err)
+    }
+
+    // A schedule on another crop must not leak into the result
+    _, err := s.scheduler.CreateSchedule(s.ctx, newTestMaintenanceRequest("other-crop", "Water", "ml", 300.0))
+    require.NoError(s.T(), err)
+
+    // Nor must a paused schedule on the crop
+    _, err = s.mockDB.Create(&models.Maintenance{ID: "paused-crop-pruning", CropID: cropID, TaskType: "Pruning", Frequency: "Monthly", Unit: "n/a", PreferredTime: "09:00", Active: false})
+    require.NoError(s.T(), err)
+
+    s.Run("Returns Every Task Type", func() {
+        schedules, err := s.scheduler.GetCropSchedules(s.ctx, cropID)
+        require.NoError(s.T(), err)
+        require.Len(s.T(), schedules, len(requests))
+
+        taskTypes := make([]string, 0, len(schedules))
+        for _, schedule := range schedules {
+            assert.Equal(s.T(), cropID, schedule.CropID)
+            taskTypes = append(taskTypes, schedule.TaskType)
+        }
+        assert.ElementsMatch(s.T(), []string{"Water", "Fertilizer", "Composting"}, taskTypes)
+    })
+
+    s.Run("Missing Crop ID", func() {
+        schedules, err := s.scheduler.GetCropSchedules(s.ctx, "")
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+        assert.Nil(s.T(), schedules)
+    })
+}
+
+// TestCreateSchedulesBoundedConcurrency tests that batch creation never exceeds the AI worker pool
+func (s *SchedulerTestSuite) TestCreateSchedulesBoundedConcurrency() {
+    const (
+        poolSize     = 2
+        maxBatchSize = 12
+    )
+
+    cfg := &types.ServiceConfig{
+        ServiceName: "test-scheduler",
+        Environment: "test",
+        Scheduler:   &types.SchedulerConfig{AIWorkerPoolSize: poolSize, MaxBatchSize: maxBatchSize},
+    }
+    mockAI, err := mocks.NewMockAIClient(s.T(), cfg)
+    require.NoError(s.T(), err)
+    mockAI.SetMockDelay(20 * time.Millisecond)
+
+    service, err := scheduler.NewSchedulerService(s.mockDB, nil, mockAI, cfg)
+    require.NoError(s.T(), err)
+
+    taskTypes := []struct {
+        taskType string
+        unit     string
+        amount   float64
+    }{
+        {"Water", "ml", 500.0},
+        {"Fertilizer", "g", 50.0},
+        {"Composting", "g", 200.0},
+    }
+
+    requests := make([]*dto.MaintenanceRequest, 0, maxBatchSize)
+    for i := 0; i < maxBatchSize; i++ {
+        task := taskTypes[i%len(taskTypes)]
+        requests = append(requests, newTestMaintenanceRequest(fmt.Sprintf("batch-crop-%d", i), task.taskType, task.unit, task.amount))
+    }
+
+    response, err := service.CreateSchedules(s.ctx, requests)
+    require.NoError(s.T(), err)
+    require.Len(s.T(), response.Results, len(requests))
+    assert.Equal(s.T(), len(requests), response.Succeeded)
+    assert.Zero(s.T(), response.Failed)
+
+    assert.Greater(s.T(), mockAI.CallCount(), 0)
+    assert.LessOrEqual(s.T(), mockAI.MaxConcurrentCalls(), poolSize,
+        "concurrent AI calls must never exceed the worker pool size")
+
+    s.Run("Empty Batch Rejected", func() {
+        response, err := service.CreateSchedules(s.ctx, nil)
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+        assert.Nil(s.T(), response)
+    })
+
+    s.Run("Oversized Batch Rejected", func() {
+        calls := mockAI.CallCount()
+        oversized := append(requests, newTestMaintenanceRequest("batch-crop-extra", "Water", "ml", 500.0))
+
+        response, err := service.CreateSchedules(s.ctx, oversized)
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+        assert.Nil(s.T(), response)
+        assert.Equal(s.T(), calls, mockAI.CallCount(), "an oversized batch must not reach the AI service")
+    })
+}
+
+// TestCompleteDueTasksBoundedConcurrency tests that completing a garden's due tasks never
+// exceeds the configured concurrency and reports progress for every due task
+func (s *SchedulerTestSuite) TestCompleteDueTasksBoundedConcurrency() {
+    const maxCompletions = 2
+
+    cfg := &types.ServiceConfig{
+        ServiceName: "test-scheduler",
+        Environment: "test",
+        Scheduler:   &types.SchedulerConfig{MaxConcurrentCompletions: maxCompletions},
+    }
+    service, err := scheduler.NewSchedulerService(s.mockDB, nil, s.mockAI, cfg)
+    require.NoError(s.T(), err)
+
+    now := time.Date(2024, time.May, 6, 12, 0, 0, 0, time.UTC)
+    tracked := &concurrencyClock{Clock: clock.NewFake(now), hold: 10 * time.Millisecond}
+    service.SetClock(tracked)
+
+    gardenID := "due-garden-id"
+    crop := &models.Crop{ID: "due-crop-id", GardenID: gardenID, Name: "Lettuce", GrowBags: 2, BagSize: "12\""}
+    _, err = s.mockDB.Create(crop)
+    require.NoError(s.T(), err)
+
+    var dueIDs []string
+    for i := 0; i < 8; i++ {
+        id := fmt.Sprintf("due-water-%d", i)
+        dueIDs = append(dueIDs, id)
+        _, err := s.mockDB.Create(&models.Maintenance{ID: id, CropID: crop.ID, TaskType: "Water", Frequency: "Daily", Amount: 300, Unit: "ml", Active: true, NextScheduledTime: now.Add(-time.Duration(i+1) * time.Hour)})
+        require.NoError(s.T(), err)
+    }
+    for _, id := range []string{"later-water", "tomorrow-water"} {
+        _, err := s.mockDB.Create(&models.Maintenance{ID: id, CropID: crop.ID, TaskType: "Water", Frequency: "Daily", Amount: 300, Unit: "ml", Active: true, NextScheduledTime: now.Add(3 * time.Hour)})
+        require.NoError(s.T(), err)
+    }
+
+    response, err := service.CompleteDueTasks(s.ctx, gardenID)
+    require.NoError(s.T(), err)
+    assert.Equal(s.T(), gardenID, response.GardenID)
+    assert.Equal(s.T(), len(dueIDs), response.Total)
+    assert.Equal(s.T(), len(dueIDs), response.Completed)
+    assert.Zero(s.T(), response.Failed)
+    assert.Zero(s.T(), response.Remaining)
+    assert.Equal(s.T(), maxCompletions, response.MaxConcurrency)
+    assert.GreaterOrEqual(s.T(), tracked.maxConcurrent(), 1)
+    assert.LessOrEqual(s.T(), tracked.maxConcurrent(), maxCompletions,
+        "concurrent completions must never exceed the configured limit")
+
+    completedIDs := make([]string, len(response.Results))
+    for i, result := range response.Results {
+        completedIDs[i] = result.TaskID
+        assert.Empty(s.T(), result.Error)
+        require.NotNil(s.T(), result.Task)
+        assert.True(s.T(), result.Task.NextScheduledTime.After(now))
+    }
+    assert.ElementsMatch(s.T(), dueIDs, completedIDs)
+
+    s.Run("Nothing Due", func() {
+        response, err := service.CompleteDueTasks(s.ctx, "empty-garden-id")
+        require.NoError(s.T(), err)
+        assert.Zero(s.T(), response.Total)
+        assert.Empty(s.T(), response.Results)
+    })
+
+    s.Run("Garden Required", func() {
+        response, err := service.CompleteDueTasks(s.ctx, "")
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+        assert.Nil(s.T(), response)
+    })
+}
+
+// concurrencyClock holds each caller of Now for a moment, recording the most callers
+// seen at once so a test can observe how many completions run concurrently
+type concurrencyClock struct {
+    clock.Clock
+    hold     time.Duration
+    inFlight int32
+    peak     int32
+}
+
+func (c *concurrencyClock) Now() time.Time {
+    current := atomic.AddInt32(&c.inFlight, 1)
+    defer atomic.AddInt32(&c.inFlight, -1)
+    for {
+        observed := atomic.LoadInt32(&c.peak)
+        if current <= observed || atomic.CompareAndSwapInt32(&c.peak, observed, current) {
+            break
+        }
+    }
+    time.Sleep(c.hold)
+    return c.Clock.Now()
+}
+
+// maxConcurrent returns the most callers observed in Now at once
+func (c *concurrencyClock) maxConcurrent() int {
+    return int(atomic.LoadInt32(&c.peak))
+}
+
+// TestSuggestFrequencyAdjustment tests that completion history drives frequency suggestions
+func (s *SchedulerTestSuite) TestSuggestFrequencyAdjustment() {
+    start := time.Date(2024, time.April, 1, 8, 0, 0, 0, time.UTC)
+    crop := &models.Crop{ID: "frequency-crop-id", GardenID: "frequency-garden-id", Name: "Basil", GrowBags: 1, BagSize: "12\""}
+    _, err := s.mockDB.Create(crop)
+    require.NoError(s.T(), err)
+
+    seedTask := func(id string, frequency string, gaps ...time.Duration) {
+        maintenance := &models.Maintenance{ID: id, CropID: crop.ID, TaskType: "Water", Frequency: frequency, Amount: 300, Unit: "ml", Active: true, NextScheduledTime: start}
+        _, err := s.mockDB.Create(maintenance)
+        require.NoError(s.T(), err)
+
+        completedAt := start
+        for _, gap := range gaps {
+            completedAt = completedAt.Add(gap)
+            event := models.NewScheduleChangeEvent(maintenance, models.ScheduleEventCompleted)
+            event.CreatedAt = completedAt
+            _, err := s.mockDB.Create(event)
+            require.NoError(s.T(), err)
+        }
+    }
+
+    day := 24 * time.Hour
+
+    s.Run("Consistently Late Suggests Less Frequent", func() {
+        // A daily task watered every three days or so
+        seedTask("late-water", "Daily", 0, 3*day, 3*day+2*time.Hour, 70*time.Hour, 3*day, 4*day)
+
+        suggestion, err := s.scheduler.SuggestFrequencyAdjustment(s.ctx, "late-water")
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), "Daily", suggestion.CurrentFrequency)
+        assert.Equal(s.T(), "Weekly", suggestion.SuggestedFrequency)
+        assert.Equal(s.T(), dto.FrequencyChangeLessFrequent, suggestion.Change)
+        assert.Equal(s.T(), 6, suggestion.CompletionsAnalyzed)
+        assert.Equal(s.T(), 72.0, suggestion.MedianIntervalHours)
+        assert.Equal(s.T(), 24.0, suggestion.ScheduledIntervalHours)
+        assert.NotEmpty(s.T(), suggestion.Reason)
+    })
+
+    s.Run("On Schedule Keeps Frequency", func() {
+        seedTask("steady-water", "Daily", 0, day, day+time.Hour, day-2*time.Hour, day)
+
+        suggestion, err := s.scheduler.SuggestFrequencyAdjustment(s.ctx, "steady-water")
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), "Daily", suggestion.SuggestedFrequency)
+        assert.Equal(s.T(), dto.FrequencyChangeNone, suggestion.Change)
+    })
+
+    s.Run("Consistently Early Suggests More Frequent", func() {
+        seedTask("early-water", "Weekly", 0, day, 2*day, day, 2*day)
+
+        suggestion, err := s.scheduler.SuggestFrequencyAdjustment(s.ctx, "early-water")
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), "Daily", suggestion.SuggestedFrequency)
+        assert.Equal(s.T(), dto.FrequencyChangeMoreFrequent, suggestion.Change)
+    })
+
+    s.Run("Too Little History", func() {
+        seedTask("new-water", "Daily", 0, 3*day)
+
+        suggestion, err := s.scheduler.SuggestFrequencyAdjustment(s.ctx, "new-water")
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), dto.FrequencyChangeNone, suggestion.Change)
+        assert.Equal(s.T(), 2, suggestion.CompletionsAnalyzed)
+        assert.Zero(s.T(), suggestion.MedianIntervalHours)
+    })
+
+    s.Run("Unknown Task", func() {
+        _, err := s.scheduler.SuggestFrequencyAdjustment(s.ctx, "missing-task")
+        assert.ErrorIs(s.T(), err, scheduler.ErrScheduleNotFound)
+    })
+
+    s.Run("Task Required", func() {
+        _, err := s.scheduler.SuggestFrequencyAdjustment(s.ctx, "")
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+    })
+}
+
+// TestGetScheduleChangeLog tests that schedule create and update events are recorded
+func (s *SchedulerTestSuite) TestGetScheduleChangeLog() {
+    cropID := "crop-with-history"
+    schedule, err := s.scheduler.CreateSchedule(s.ctx, newTestMaintenanceRequest(cropID, "Water", "ml", 500.0))
+    require.NoError(s.T(), err)
+
+    update := newTestMaintenanceRequest(cropID, "Water", "ml", 750.0)
+    update.PreferredTime = "17:00"
+    _, err = s.scheduler.UpdateSchedule(s.ctx, schedule.ID, update)
+    require.NoError(s.T(), err)
+
+    history, err := s.scheduler.GetScheduleChangeLog(s.ctx, cropID)
+    require.NoError(s.T(), err)
+    require.Len(s.T(), history, 2)
+
+    assert.Equal(s.T(), models.ScheduleEventCreated, history[0].EventType)
+    assert.Equal(s.T(), schedule.ID, history[0].ScheduleID)
+    assert.Equal(s.T(), 500.0, history[0].Amount)
+
+    assert.Equal(s.T(), models.ScheduleEventUpdated, history[1].EventType)
+    assert.Equal(s.T(), schedule.ID, history[1].ScheduleID)
+    assert.Equal(s.T(), 750.0, history[1].Amount)
+    assert.Equal(s.T(), "17:00", history[1].PreferredTime)
+    assert.False(s.T(), history[1].OccurredAt.Before(history[0].OccurredAt))
+
+    s.Run("Missing Crop ID", func() {
+        history, err := s.scheduler.GetScheduleChangeLog(s.ctx, "")
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+        assert.Nil(s.T(), history)
+    })
+}
+
+// TestEnvironmentReadingsInfluenceSchedule tests that pushed sensor readings drive the next computed schedule
+func (s *SchedulerTestSuite) TestEnvironmentReadingsInfluenceSchedule() {
+    cropID := "sensor-crop-id"
+    gardenID := "sensor-garden-id"
+    _, err := s.mockDB.Create(&models.Crop{ID: cropID, GardenID: gardenID, Name: "Tomatoes", GrowBags: 2, BagSize: "12\""})
+    require.NoError(s.T(), err)
+
+    request := newTestMaintenanceRequest(cropID, "Water", "ml", 500.0)
+    request.Frequency = "Daily"
+    schedule, err := s.scheduler.CreateSchedule(s.ctx, request)
+    require.NoError(s.T(), err)
+
+    baseline, err := s.scheduler.CompleteTask(s.ctx, schedule.ID, nil, "")
+    require.NoError(s.T(), err)
+    baselineInterval := baseline.NextScheduledTime.Sub(baseline.LastCompletedTime)
+
+    temperature, humidity := 35.0, 25.0
+    reading, err := s.scheduler.RecordEnvironmentReading(s.ctx, gardenID, &dto.EnvironmentReadingRequest{
+        Temperature: &temperature,
+        Humidity:    &humidity,
+        LightLevel:  dto.LightLevelHigh,
+    })
+    require.NoError(s.T(), err)
+    assert.Equal(s.T(), gardenID, reading.GardenID)
+
+    adjusted, err := s.scheduler.CompleteTask(s.ctx, schedule.ID, nil, "")
+    require.NoError(s.T(), err)
+    adjustedInterval := adjusted.NextScheduledTime.Sub(adjusted.LastCompletedTime)
+
+    // Hot, dry readings should bring the next watering forward
+    assert.Less(s.T(), adjustedInterval, baselineInterval)
+
+    s.Run("Invalid Reading Rejected", func() {
+        badHumidity := 150.0
+        response, err := s.scheduler.RecordEnvironmentReading(s.ctx, gardenID, &dto.EnvironmentReadingRequest{
+            Temperature: &temperature,
+            Humidity:    &badHumidity,
+            LightLevel:  dto.LightLevelHigh,
+        })
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+        assert.Nil(s.T(), response)
+    })
+}
+
+// TestConcurrentCompletions tests that concurrent completions of one task each increment the streak exactly once
+func (s *SchedulerTestSuite) TestConcurrentCompletions() {
+    request := newTestMaintenanceRequest("concurrent-crop-id", "Water", "ml", 500.0)
+    request.Frequency = "Daily"
+    schedule, err := s.scheduler.CreateSchedule(s.ctx, request)
+    require.NoError(s.T(), err)
+
+    const completions = 10
+    streaks := make(chan int, completions)
+    errs := make(chan error, completions)
+
+    var wg sync.WaitGroup
+    for i := 0; i < completions; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            response, err := s.scheduler.CompleteTask(s.ctx, schedule.ID, nil, "")
+            if err != nil {
+                errs <- err
+                return
+            }
+            streaks <- response.CompletionStreak
+        }()
+    }
+    wg.Wait()
+    close(streaks)
+    close(errs)
+
+    for err := range errs {
+        s.T().Errorf("concurrent completion failed: %v", err)
+    }
+
+    // Every completion must observe a distinct streak value: 1, 2, ..., completions
+    seen := make(map[int]bool, completions)
+    for streak := range streaks {
+        assert.False(s.T(), seen[streak], "streak %d observed twice; an increment was lost", streak)
+        seen[streak] = true
+    }
+    assert.Len(s.T(), seen, completions)
+    for streak := 1; streak <= completions; streak++ {
+        assert.True(s.T(), seen[streak], "missing streak value %d", streak)
+    }
+}
+
+// TestFindStaleSchedules tests that AI recommendations made longer than the threshold before the latest reading are flagged
+func (s *SchedulerTestSuite) TestFindStaleSchedules() {
+    gardenID := "stale-garden-id"
+    cropID := "stale-crop-id"
+    _, err := s.mockDB.Create(&models.Crop{ID: cropID, GardenID: gardenID, Name: "Tomatoes", GrowBags: 2, BagSize: "12\""})
+    require.NoError(s.T(), err)
+
+    now := time.Now()
+    recommendedAt := func(age time.Duration) *time.Time {
+        at := now.Add(-age)
+        return &at
+    }
+    // The stale watering was completed an hour ago, which does not refresh its recommendation
+    seed := []*models.Maintenance{
+        {ID: "stale-water", CropID: cropID, TaskType: "Water", Frequency: "Daily", AIRecommended: true, AIRecommendedAt: recommendedAt(72 * time.Hour), Active: true, LastModifiedAt: now.Add(-1 * time.Hour)},
+        {ID: "stale-fertilizer", CropID: cropID, TaskType: "Fertilizer", Frequency: "Weekly", AIRecommended: true, AIRecommendedAt: recommendedAt(48 * time.Hour), Active: true, LastModifiedAt: now.Add(-48 * time.Hour)},
+        {ID: "fresh-water", CropID: cropID, TaskType: "Water", Frequency: "Daily", AIRecommended: true, AIRecommendedAt: recommendedAt(1 * time.Hour), Active: true, LastModifiedAt: now.Add(-1 * time.Hour)},
+    }
+    for _, maintenance := range seed {
+        _, err := s.mockDB.Create(maintenance)
+        require.NoError(s.T(), err)
+    }
+
+    temperature, humidity := 32.0, 35.0
+    _, err = s.scheduler.RecordEnvironmentReading(s.ctx, gardenID, &dto.EnvironmentReadingRequest{
+        Temperature: &temperature,
+        Humidity:    &humidity,
+        LightLevel:  dto.LightLevelHigh,
+        RecordedAt:  &now,
+    })
+    require.NoError(s.T(), err)
+
+    s.Run("Tasks Older Than Threshold Flagged", func() {
+        stale, err := s.scheduler.FindStaleSchedules(s.ctx, gardenID, dto.StaleScheduleFactors{Threshold: 24 * time.Hour})
+        require.NoError(s.T(), err)
+
+        ids := make([]string, len(stale))
+        for i, task := range stale {
+            ids[i] = task.ID
+        }
+        assert.ElementsMatch(s.T(), []string{"stale-water", "stale-fertilizer"}, ids)
+    })
+
+    s.Run("Task Type Filter", func() {
+        stale, err := s.scheduler.FindStaleSchedules(s.ctx, gardenID, dto.StaleScheduleFactors{
+            Threshold: 24 * time.Hour,
+            TaskTypes: []string{"Water"},
+        })
+        require.NoError(s.T(), err)
+        require.Len(s.T(), stale, 1)
+        assert.Equal(s.T(), "stale-water", stale[0].ID)
+    })
+
+    s.Run("Wider Threshold Flags Fewer", func() {
+        stale, err := s.scheduler.FindStaleSchedules(s.ctx, gardenID, dto.StaleScheduleFactors{Threshold: 60 * time.Hour})
+        require.NoError(s.T(), err)
+        require.Len(s.T(), stale, 1)
+        assert.Equal(s.T(), "stale-water", stale[0].ID)
+    })
+
+    s.Run("Negative Threshold Rejected", func() {
+        stale, err := s.scheduler.FindStaleSchedules(s.ctx, gardenID, dto.StaleScheduleFactors{Threshold: -time.Hour})
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+        assert.Nil(s.T(), stale)
+    })
+}
+
+// TestRecommendFertilizerSchedule tests that fertilizer recommendations ramp up with growth stage
+func (s *SchedulerTestSuite) TestRecommendFertilizerSchedule() {
+    now := time.Now()
+    seedlingCrop := &models.Crop{ID: "seedling-crop-id", GardenID: "fertilizer-garden-id", Name: "Tomatoes", GrowBags: 2, BagSize: "12\"", CreatedAt: now.AddDate(0, 0, -5)}
+    floweringCrop := &models.Crop{ID: "flowering-crop-id", GardenID: "fertilizer-garden-id", Name: "Tomatoes", GrowBags: 2, BagSize: "12\"", CreatedAt: now.AddDate(0, 0, -50)}
+    for _, crop := range []*models.Crop{seedlingCrop, floweringCrop} {
+        _, err := s.mockDB.Create(crop)
+        require.NoError(s.T(), err)
+    }
+
+    seedling, err := s.scheduler.RecommendFertilizerSchedule(s.ctx, seedlingCrop.ID)
+    require.NoError(s.T(), err)
+    assert.Equal(s.T(), dto.GrowthStageSeedling, seedling.GrowthStage)
+
+    flowering, err := s.scheduler.RecommendFertilizerSchedule(s.ctx, floweringCrop.ID)
+    require.NoError(s.T(), err)
+    assert.Equal(s.T(), dto.GrowthStageFlowering, flowering.GrowthStage)
+
+    s.Run("Flowering Feeds More Than Seedling", func() {
+        intervals := map[string]int{
+            dto.FrequencyDaily:    1,
+            dto.FrequencyWeekly:   7,
+            dto.FrequencyBiWeekly: 14,
+            dto.FrequencyMonthly:  30,
+        }
+        assert.Less(s.T(), intervals[flowering.Frequency], intervals[seedling.Frequency], "flowering should fertilize more often")
+        assert.Greater(s.T(), flowering.Amount, seedling.Amount, "flowering should fertilize more per application")
+        assert.Equal(s.T(), "g", flowering.Unit)
+    })
+
+    s.Run("Single Seedling Bag Gets Minimum Dose", func() {
+        singleBag := &models.Crop{ID: "single-seedling-crop-id", GardenID: "fertilizer-garden-id", Name: "Tomatoes", GrowBags: 1, BagSize: "12\"", CreatedAt: now.AddDate(0, 0, -5)}
+        _, err := s.mockDB.Create(singleBag)
+        require.NoError(s.T(), err)
+
+        recommendation, err := s.scheduler.RecommendFertilizerSchedule(s.ctx, singleBag.ID)
+        require.NoError(s.T(), err)
+        minGrams, _, _ := dto.AmountBounds(dto.TaskTypeFertilizer)
+        assert.Equal(s.T(), minGrams, recommendation.Amount)
+    })
+
+    s.Run("Creatable Via Schedule Flow", func() {
+        request := newTestMaintenanceRequest(floweringCrop.ID, "Water", "ml", 500.0)
+        flowering.ApplyTo(request)
+
+        schedule, err := s.scheduler.CreateSchedule(s.ctx, request)
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), dto.TaskTypeFertilizer, schedule.TaskType)
+        assert.Equal(s.T(), flowering.Frequency, schedule.Frequency)
+        assert.Equal(s.T(), flowering.Amount, schedule.Amount)
+    })
+
+    s.Run("Organic Crop Gets Organic Fertilizer", func() {
+        assert.Equal(s.T(), dto.GrowingMethodConventional, flowering.GrowingMethod)
+
+        organicCrop := &models.Crop{ID: "organic-flowering-crop-id", GardenID: "fertilizer-garden-id", Name: "Tomatoes", GrowBags: 2, BagSize: "12\"", Organic: true, CreatedAt: now.AddDate(0, 0, -50)}
+        _, err := s.mockDB.Create(organicCrop)
+        require.NoError(s.T(), err)
+
+        organic, err := s.scheduler.RecommendFertilizerSchedule(s.ctx, organicCrop.ID)
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), dto.GrowingMethodOrganic, organic.GrowingMethod)
+        assert.Equal(s.T(), "bone meal", organic.Fertilizer)
+        assert.NotEqual(s.T(), flowering.Fertilizer, organic.Fertilizer)
+        assert.Equal(s.T(), flowering.Amount, organic.Amount, "growing method changes the fertilizer, not the dose")
+    })
+
+    s.Run("Unknown Crop", func() {
+        recommendation, err := s.scheduler.RecommendFertilizerSchedule(s.ctx, "missing-crop-id")
+        assert.ErrorIs(s.T(), err, scheduler.ErrCropNotFound)
+        assert.Nil(s.T(), recommendation)
+    })
+}
+
+// TestScheduleRepotReminder tests that re-pot reminders fall due after the lead time for the
+// crop's growth rate and suggest the next larger grow bag
+func (s *SchedulerTestSuite) TestScheduleRepotReminder() {
+    now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+    s.scheduler.SetClock(clock.NewFake(now))
+
+    newCrop := func(id, name, bagSize string, plantedDaysAgo int) *models.Crop {
+        crop := &models.Crop{ID: id, GardenID: "repot-garden-id", Name: name, GrowBags: 2, BagSize: bagSize, CreatedAt: now.AddDate(0, 0, -plantedDaysAgo)}
+        _, err := s.mockDB.Create(crop)
+        require.NoError(s.T(), err)
+        return crop
+    }
+
+    tomatoes := newCrop("repot-tomatoes-id", "Tomatoes", "10\"", 5)
+    reminder, err := s.scheduler.ScheduleRepotReminder(s.ctx, tomatoes.ID)
+    require.NoError(s.T(), err)
+
+    assert.Equal(s.T(), dto.GrowthRateFast, reminder.GrowthRate)
+    assert.Equal(s.T(), 21, reminder.DaysAfterPlanting)
+    assert.Equal(s.T(), "10\"", reminder.CurrentBagSize)
+    assert.Equal(s.T(), "12\"", reminder.SuggestedBagSize)
+    require.NotNil(s.T(), reminder.Task)
+    assert.Equal(s.T(), dto.TaskTypeRepot, reminder.Task.TaskType)
+    assert.Equal(s.T(), dto.FrequencyOnce, reminder.Task.Frequency)
+    assert.Equal(s.T(), time.Date(2024, 5, 26, 9, 0, 0, 0, time.UTC), reminder.Task.NextScheduledTime)
+
+    s.Run("Pending Reminder Rejected", func() {
+        _, err := s.scheduler.ScheduleRepotReminder(s.ctx, tomatoes.ID)
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+    })
+
+    s.Run("Slow Growth Uses Configured Lead Time", func() {
+        cfg := &types.ServiceConfig{
+            Scheduler: &types.SchedulerConfig{RepotDays: map[string]int{dto.GrowthRateSlow: 60}},
+        }
+        service, err := scheduler.NewSchedulerService(s.mockDB, nil, s.mockAI, cfg)
+        require.NoError(s.T(), err)
+        service.SetClock(clock.NewFake(now))
+
+        lettuce := newCrop("repot-lettuce-id", "Lettuce", "8\"", 10)
+        reminder, err := service.ScheduleRepotReminder(s.ctx, lettuce.ID)
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), dto.GrowthRateSlow, reminder.GrowthRate)
+        assert.Equal(s.T(), 60, reminder.DaysAfterPlanting)
+        assert.Equal(s.T(), "10\"", reminder.SuggestedBagSize)
+        assert.Equal(s.T(), time.Date(2024, 6, 29, 9, 0, 0, 0, time.UTC), reminder.Task.NextScheduledTime)
+    })
+
+    s.Run("Overdue Reminder Falls Due Next Morning", func() {
+        peppers := newCrop("repot-peppers-id", "Peppers", "12\"", 90)
+        reminder, err := s.scheduler.ScheduleRepotReminder(s.ctx, peppers.ID)
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), 35, reminder.DaysAfterPlanting)
+        assert.Equal(s.T(), "14\"", reminder.SuggestedBagSize)
+        assert.Equal(s.T(), time.Date(2024, 5, 11, 9, 0, 0, 0, time.UTC), reminder.Task.NextScheduledTime)
+    })
+
+    s.Run("Reminder Notification Queued", func() {
+        mr := miniredis.RunT(s.T())
+        redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+        defer redisClient.Close()
+
+        service, err := scheduler.NewSchedulerService(s.mockDB, redisClient, s.mockAI, &types.ServiceConfig{})
+        require.NoError(s.T(), err)
+        service.SetClock(clock.NewFake(now))
+
+        kale := newCrop("repot-kale-id", "Kale", "10\"", 5)
+        reminder, err := service.ScheduleRepotReminder(s.ctx, kale.ID)
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), []string{reminder.Task.ID}, pendingNotificationTaskIDs(s.T(), mr, dto.TaskTypeRepot))
+        assert.Equal(s.T(), "10\"", reminder.Task.CurrentBagSize)
+        assert.Equal(s.T(), "12\"", reminder.Task.SuggestedBagSize)
+    })
+
+    s.Run("Reminder Editable", func() {
+        updated, err := s.scheduler.UpdateSchedule(s.ctx, reminder.Task.ID, &dto.MaintenanceRequest{
+            CropID:               tomatoes.ID,
+            TaskType:             dto.TaskTypeRepot,
+            Frequency:            dto.FrequencyOnce,
+            Unit:                 "n/a",
+            PreferredTime:        "17:00",
+            SoilType:             "Loamy",
+            GrowBagSize:          "10\"",
+            GrowingEnvironment:   dto.EnvironmentOutdoor,
+            EnvironmentalFactors: map[string]interface{}{"temperature": 24.0, "humidity": 60.0, "lightLevel": "high"},
+        })
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), "17:00", updated.PreferredTime)
+        assert.Equal(s.T(), dto.FrequencyOnce, updated.Frequency)
+    })
+
+    s.Run("Largest Bag Rejected", func() {
+        eggplant := newCrop("repot-eggplant-id", "Eggplant", "14\"", 5)
+        reminder, err := s.scheduler.ScheduleRepotReminder(s.ctx, eggplant.ID)
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+        assert.Nil(s.T(), reminder)
+    })
+
+    s.Run("Unknown Crop", func() {
+        _, err := s.scheduler.ScheduleRepotReminder(s.ctx, "missing-crop-id")
+        assert.ErrorIs(s.T(), err, scheduler.ErrCropNotFound)
+    })
+}
+
+// pendingNotificationTaskIDs returns the task IDs of notifications queued in Redis for a task type
+func pendingNotificationTaskIDs(t require.TestingT, mr *miniredis.Miniredis, taskType string) []string {
+    key := "notifications:" + taskType
+    if !mr.Exists(key) {
+        return nil
+    }
+
+    members, err := mr.ZMembers(key)
+    require.NoError(t, err)
+
+    ids := make([]string, 0, len(members))
+    for _, member := range members {
+        var queued struct {
+            TaskID string `json:"taskId"`
+        }
+        require.NoError(t, json.Unmarshal([]byte(member), &queued))
+        ids = append(ids, queued.TaskID)
+    }
+    return ids
+}
+
+// TestDeleteAndRestoreSchedule tests that soft deletion removes notifications and restoring re-creates them
+func (s *SchedulerTestSuite) TestDeleteAndRestoreSchedule() {
+    mr := miniredis.RunT(s.T())
+    redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+    defer redisClient.Close()
+
+    cfg := &types.ServiceConfig{
+        ServiceName: "test-scheduler",
+        Environment: "test",
+    }
+    service, err := scheduler.NewSchedulerService(s.mockDB, redisClient, s.mockAI, cfg)
+    require.NoError(s.T(), err)
+
+    schedule, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest("deletable-crop-id", "Water", "ml", 500.0))
+    require.NoError(s.T(), err)
+    other, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest("other-crop-id", "Water", "ml", 300.0))
+    require.NoError(s.T(), err)
+    require.ElementsMatch(s.T(), []string{schedule.ID, other.ID}, pendingNotificationTaskIDs(s.T(), mr, "Water"))
+
+    s.Run("Delete Removes Notifications", func() {
+        require.NoError(s.T(), service.DeleteSchedule(s.ctx, schedule.ID))
+
+        assert.Equal(s.T(), []string{other.ID}, pendingNotificationTaskIDs(s.T(), mr, "Water"))
+
+        deleted, err := service.GetSchedule(s.ctx, schedule.ID)
+        assert.Error(s.T(), err)
+        assert.Nil(s.T(), deleted)
+    })
+
+    s.Run("Delete Twice Not Found", func() {
+        err := service.DeleteSchedule(s.ctx, schedule.ID)
+        assert.ErrorIs(s.T(), err, scheduler.ErrScheduleNotFound)
+    })
+
+    s.Run("Update Deleted Not Found", func() {
+        updated, err := service.UpdateSchedule(s.ctx, schedule.ID, newTestMaintenanceRequest("deletable-crop-id", "Water", "ml", 250.0))
+        assert.ErrorIs(s.T(), err, scheduler.ErrScheduleNotFound)
+        assert.Nil(s.T(), updated)
+
+        // The failed update must not have revived the schedule
+        deleted, err := service.GetSchedule(s.ctx, schedule.ID)
+        assert.Error(s.T(), err)
+        assert.Nil(s.T(), deleted)
+    })
+
+    s.Run("Complete Deleted Not Found", func() {
+        completed, err := service.CompleteTask(s.ctx, schedule.ID, nil, "")
+        require.Error(s.T(), err)
+        assert.Contains(s.T(), err.Error(), "task not found")
+        assert.Nil(s.T(), completed)
+    })
+
+    s.Run("Restore Re-creates Notifications", func() {
+        restored, err := service.RestoreSchedule(s.ctx, schedule.ID)
+        require.NoError(s.T(), err)
+        assert.True(s.T(), restored.Active)
+        assert.True(s.T(), restored.NextScheduledTime.After(time.Now()))
+
+        assert.ElementsMatch(s.T(), []string{schedule.ID, other.ID}, pendingNotificationTaskIDs(s.T(), mr, "Water"))
+    })
+
+    s.Run("Restore Active Schedule Not Found", func() {
+        restored, err := service.RestoreSchedule(s.ctx, other.ID)
+        assert.ErrorIs(s.T(), err, scheduler.ErrScheduleNotFound)
+        assert.Nil(s.T(), restored)
+    })
+
+    s.Run("History Records Delete And Restore", func() {
+        history, err := service.GetScheduleChangeLog(s.ctx, "deletable-crop-id")
+        require.NoError(s.T(), err)
+
+        eventTypes := make([]string, len(history))
+        for i, event := range history {
+            eventTypes[i] = event.EventType
+        }
+        assert.Equal(s.T(), []string{
+            models.ScheduleEventCreated,
+            models.ScheduleEventDeleted,
+            models.ScheduleEventRestored,
+        }, eventTypes)
+    })
+}
+
+// TestGardenSnapshots tests that restoring a snapshot returns a garden's crops and
+// schedules, along with their pending notifications, to the state it was taken in
+func (s *SchedulerTestSuite) TestGardenSnapshots() {
+    mr := miniredis.RunT(s.T())
+    redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+    defer redisClient.Close()
+
+    cfg := &types.ServiceConfig{
+        ServiceName: "test-scheduler",
+        Environment: "test",
+    }
+    service, err := scheduler.NewSchedulerService(s.mockDB, redisClient, s.mockAI, cfg)
+    require.NoError(s.T(), err)
+    observer := &recordingCropObserver{}
+    service.SetCropRestoreObserver(observer)
+
+    garden := &models.Garden{ID: "snapshot-garden-id", UserID: "snapshot-user-id", Length: 4, Width: 3, SoilType: "loamy_soil", Sunlight: "full_sun"}
+    _, err = s.mockDB.Create(garden)
+    require.NoError(s.T(), err)
+    crops := []*models.Crop{
+        {ID: "snapshot-tomatoes-id", GardenID: garden.ID, Name: "Tomatoes", GrowBags: 2, BagSize: "12\""},
+        {ID: "snapshot-lettuce-id", GardenID: garden.ID, Name: "Lettuce", GrowBags: 3, BagSize: "10\""},
+    }
+    for _, crop := range crops {
+        _, err := s.mockDB.Create(crop)
+        require.NoError(s.T(), err)
+    }
+
+    water, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest(crops[0].ID, "Water", "ml", 500.0))
+    require.NoError(s.T(), err)
+    fertilizer, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest(crops[1].ID, "Fertilizer", "g", 30.0))
+    require.NoError(s.T(), err)
+
+    snapshot, err := service.SnapshotGarden(s.ctx, garden.ID, "before experiment")
+    require.NoError(s.T(), err)
+    assert.Equal(s.T(), garden.ID, snapshot.GardenID)
+    assert.Equal(s.T(), "before experiment", snapshot.Label)
+    assert.Equal(s.T(), 2, snapshot.CropCount)
+    assert.Equal(s.T(), 2, snapshot.ScheduleCount)
+
+    // Experiment: drop the watering and plant peppers with their own schedule
+    require.NoError(s.T(), service.DeleteSchedule(s.ctx, water.ID))
+    peppers := &models.Crop{ID: "snapshot-peppers-id", GardenID: garden.ID, Name: "Peppers", GrowBags: 1, BagSize: "12\""}
+    _, err = s.mockDB.Create(peppers)
+    require.NoError(s.T(), err)
+    pepperWater, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest(peppers.ID, "Water", "ml", 300.0))
+    require.NoError(s.T(), err)
+    require.Equal(s.T(), []string{pepperWater.ID}, pendingNotificationTaskIDs(s.T(), mr, "Water"))
+
+    s.Run("Lists Snapshots", func() {
+        snapshots, err := service.ListGardenSnapshots(s.ctx, garden.ID)
+        require.NoError(s.T(), err)
+        require.Len(s.T(), snapshots, 1)
+        assert.Equal(s.T(), snapshot.ID, snapshots[0].ID)
+    })
+
+    s.Run("Restore Returns Garden To Snapshot", func() {
+        restored, err := service.RestoreSnapshot(s.ctx, garden.ID, snapshot.ID)
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), snapshot.ID, restored.Snapshot.ID)
+        assert.Equal(s.T(), []string{pepperWater.ID}, restored.RemovedScheduleIDs)
+
+        scheduleIDs := make([]string, len(restored.Schedules))
+        for i, schedule := range restored.Schedules {
+            scheduleIDs[i] = schedule.ID
+        }
+        assert.ElementsMatch(s.T(), []string{water.ID, fertilizer.ID}, scheduleIDs)
+
+        restoredWater, err := service.GetSchedule(s.ctx, water.ID)
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), 500.0, restoredWater.Amount)
+
+        pepperSchedules, err := service.GetCropSchedules(s.ctx, peppers.ID)
+        require.NoError(s.T(), err)
+        assert.Empty(s.T(), pepperSchedules)
+
+        assert.Equal(s.T(), []string{water.ID}, pendingNotificationTaskIDs(s.T(), mr, "Water"))
+        assert.Equal(s.T(), []string{fertilizer.ID}, pendingNotificationTaskIDs(s.T(), mr, "Fertilizer"))
+        assert.Empty(s.T(), restored.UnnotifiedScheduleIDs)
+
+        // The crop service is told of the restored crops and the peppers removed
+        assert.Equal(s.T(), garden.ID, observer.gardenID)
+        assert.ElementsMatch(s.T(), []string{crops[0].ID, crops[1].ID, peppers.ID}, observer.cropIDs)
+    })
+
+    s.Run("Unknown Snapshot", func() {
+        restored, err := service.RestoreSnapshot(s.ctx, garden.ID, "missing-snapshot-id")
+        assert.ErrorIs(s.T(), err, scheduler.ErrSnapshotNotFound)
+        assert.Nil(s.T(), restored)
+    })
+
+    s.Run("Unknown Garden", func() {
+        _, err := service.SnapshotGarden(s.ctx, "missing-garden-id", "")
+        assert.ErrorIs(s.T(), err, scheduler.ErrGardenNotFound)
+    })
+
+    s.Run("Label Too Long", func() {
+        _, err := service.SnapshotGarden(s.ctx, garden.ID, strings.Repeat("a", 101))
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+    })
+}
+
+// TestSnapshotRestoreOverNotificationLimit tests that a restore is kept when a restored
+// schedule no longer fits under the user's notification limit, reporting the schedule
+// instead of failing
+func (s *SchedulerTestSuite) TestSnapshotRestoreOverNotificationLimit() {
+    mr := miniredis.RunT(s.T())
+    redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+    defer redisClient.Close()
+
+    cfg := &types.ServiceConfig{
+        ServiceName: "test-scheduler",
+        Environment: "test",
+        Scheduler: &types.SchedulerConfig{
+            AIWorkerPoolSize:              5,
+            MaxActiveNotificationsPerUser: 1,
+            NotificationLimitPolicy:       scheduler.NotificationLimitReject,
+        },
+    }
+    service, err := scheduler.NewSchedulerService(s.mockDB, redisClient, s.mockAI, cfg)
+    require.NoError(s.T(), err)
+
+    userID := "restore-limit-user-id"
+    gardens := []*models.Garden{
+        {ID: "restore-limit-garden-id", UserID: userID, Length: 4, Width: 3, SoilType: "loamy_soil", Sunlight: "full_sun"},
+        {ID: "restore-limit-other-garden-id", UserID: userID, Length: 4, Width: 3, SoilType: "loamy_soil", Sunlight: "full_sun"},
+    }
+    crops := []*models.Crop{
+        {ID: "restore-limit-tomatoes-id", GardenID: gardens[0].ID, Name: "Tomatoes", GrowBags: 2, BagSize: "12\""},
+        {ID: "restore-limit-lettuce-id", GardenID: gardens[1].ID, Name: "Lettuce", GrowBags: 2, BagSize: "10\""},
+    }
+    for i := range gardens {
+        _, err := s.mockDB.Create(gardens[i])
+        require.NoError(s.T(), err)
+        _, err = s.mockDB.Create(crops[i])
+        require.NoError(s.T(), err)
+    }
+
+    water, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest(crops[0].ID, "Water", "ml", 500.0))
+    require.NoError(s.T(), err)
+    snapshot, err := service.SnapshotGarden(s.ctx, gardens[0].ID, "")
+    require.NoError(s.T(), err)
+
+    // The other garden takes the user's only notification slot once the watering is gone
+    require.NoError(s.T(), service.DeleteSchedule(s.ctx, water.ID))
+    fertilizer, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest(crops[1].ID, "Fertilizer", "g", 20.0))
+    require.NoError(s.T(), err)
+
+    restored, err := service.RestoreSnapshot(s.ctx, gardens[0].ID, snapshot.ID)
+    require.NoError(s.T(), err)
+    assert.Equal(s.T(), []string{water.ID}, restored.UnnotifiedScheduleIDs)
+
+    restoredWater, err := service.GetSchedule(s.ctx, water.ID)
+    require.NoError(s.T(), err, "the restore should be kept")
+    assert.Equal(s.T(), 500.0, restoredWater.Amount)
+    assert.Empty(s.T(), pendingNotificationTaskIDs(s.T(), mr, "Water"))
+    assert.Equal(s.T(), []string{fertilizer.ID}, pendingNotificationTaskIDs(s.T(), mr, "Fertilizer"))
+}
+
+// recordingCropObserver captures the crops reported by the last snapshot restore
+type recordingCropObserver struct {
+    gardenID string
+    cropIDs  []string
+}
+
+func (r *recordingCropObserver) CropsRestored(ctx context.Context, gardenID string, cropIDs []string) {
+    r.gardenID = gardenID
+    r.cropIDs = cropIDs
+}
+
+// TestWeeklyChecklist tests that recurring tasks land on the correct days of the coming week
+func (s *SchedulerTestSuite) TestWeeklyChecklist() {
+    gardenID := "checklist-garden-id"
+    crop := &models.Crop{ID: "checklist-crop-id", GardenID: gardenID, Name: "Tomatoes", GrowBags: 2, BagSize: "12\""}
+    _, err := s.mockDB.Create(crop)
+    require.NoError(s.T(), err)
+
+    // Pin the clock late in the day so the checklist window cannot roll over mid-test
+    now := time.Date(2024, time.March, 13, 23, 59, 0, 0, time.UTC)
+    s.scheduler.SetClock(clock.NewFake(now))
+    today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+    at := func(days, hour int) time.Time {
+        return today.AddDate(0, 0, days).Add(time.Duration(hour) * time.Hour)
+    }
+
+    seed := []*models.Maintenance{
+        {ID: "daily-water", CropID: crop.ID, TaskType: "Water", Frequency: "Daily", Amount: 500, Unit: "ml", Active: true, NextScheduledTime: at(0, 9)},
+        {ID: "weekly-fertilizer", CropID: crop.ID, TaskType: "Fertilizer", Frequency: "Weekly", Amount: 20, Unit: "g", Active: true, NextScheduledTime: at(2, 10)},
+        {ID: "overdue-pruning", CropID: crop.ID, TaskType: "Pruning", Frequency: "Weekly", Unit: "n/a", Active: true, NextScheduledTime: at(-1, 8)},
+        {ID: "biweekly-compost", CropID: crop.ID, TaskType: "Composting", Frequency: "Bi-weekly", Amount: 200, Unit: "g", Active: true, NextScheduledTime: at(10, 7)},
+        {ID: "inactive-water", CropID: crop.ID, TaskType: "Water", Frequency: "Daily", Amount: 100, Unit: "ml", Active: false, NextScheduledTime: at(0, 12)},
+        {ID: "once-repot", CropID: crop.ID, TaskType: "Re-pot", Frequency: "Once", Unit: "n/a", Active: true, NextScheduledTime: at(3, 11)},
+        {ID: "overdue-repot", CropID: crop.ID, TaskType: "Re-pot", Frequency: "Once", Unit: "n/a", Active: true, NextScheduledTime: at(-3, 10)},
+    }
+    for _, maintenance := range seed {
+        _, err := s.mockDB.Create(maintenance)
+        require.NoError(s.T(), err)
+    }
+
+    checklist, err := s.scheduler.WeeklyChecklist(s.ctx, gardenID)
+    require.NoError(s.T(), err)
+    require.Len(s.T(), checklist.Days, 7)
+    assert.Equal(s.T(), today.Format("2006-01-02"), checklist.StartDate)
+    assert.Equal(s.T(), at(6, 0).Format("2006-01-02"), checklist.EndDate)
+
+    scheduleIDs := func(day dto.ChecklistDay) []string {
+        ids := make([]string, len(day.Items))
+        for i, item := range day.Items {
+            ids[i] = item.ScheduleID
+        }
+        return ids
+    }
+
+    s.Run("Tasks Grouped By Due Day", func() {
+        expected := map[int][]string{
+            0: {"overdue-pruning", "daily-water", "overdue-repot"},
+            1: {"daily-water"},
+            2: {"daily-water", "weekly-fertilizer"},
+            3: {"daily-water", "once-repot"},
+            4: {"daily-water"},
+            5: {"daily-water"},
+            6: {"overdue-pruning", "daily-water"},
+        }
+        for i, day := range checklist.Days {
+            assert.Equal(s.T(), at(i, 0).Weekday().String(), day.Weekday)
+            assert.Equal(s.T(), expected[i], scheduleIDs(day), "unexpected tasks on day %d", i)
+        }
+    })
+
+    s.Run("Items Carry Crop Names And Amounts", func() {
+        fertilizer := checklist.Days[2].Items[1]
+        assert.Equal(s.T(), "Tomatoes", fertilizer.CropName)
+        assert.Equal(s.T(), 20.0, fertilizer.Amount)
+        assert.Equal(s.T(), "g", fertilizer.Unit)
+        assert.Equal(s.T(), "10:00", fertilizer.Time)
+        assert.False(s.T(), fertilizer.Overdue)
+    })
+
+    s.Run("Overdue Task Flagged On First Day", func() {
+        pruning := checklist.Days[0].Items[0]
+        assert.Equal(s.T(), "overdue-pruning", pruning.ScheduleID)
+        assert.True(s.T(), pruning.Overdue)
+        assert.False(s.T(), checklist.Days[6].Items[0].Overdue)
+    })
+
+    s.Run("Garden ID Required", func() {
+        checklist, err := s.scheduler.WeeklyChecklist(s.ctx, "")
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+        assert.Nil(s.T(), checklist)
+    })
+}
+
+func (s *SchedulerTestSuite) TestTodayView() {
+    userID := "today-user-id"
+    gardens := []*models.Garden{
+        {ID: "today-balcony-id", UserID: userID, Length: 4, Width: 3, SoilType: "loamy_soil", Sunlight: "full_sun"},
+        {ID: "today-terrace-id", UserID: userID, Length: 8, Width: 5, SoilType: "red_soil", Sunlight: "partial_shade"},
+        {ID: "today-neighbour-id", UserID: "other-user-id", Length: 5, Width: 5, SoilType: "clay_soil", Sunlight: "full_sun"},
+    }
+    crops := []*models.Crop{
+        {ID: "today-tomatoes-id", GardenID: "today-balcony-id", Name: "Tomatoes", GrowBags: 2, BagSize: "12\""},
+        {ID: "today-lettuce-id", GardenID: "today-terrace-id", Name: "Lettuce", GrowBags: 4, BagSize: "10\""},
+        {ID: "today-spinach-id", GardenID: "today-neighbour-id", Name: "Spinach", GrowBags: 2, BagSize: "10\""},
+    }
+    for _, garden := range gardens {
+        _, err := s.mockDB.Create(garden)
+        require.NoError(s.T(), err)
+    }
+    for _, crop := range crops {
+        _, err := s.mockDB.Create(crop)
+        require.NoError(s.T(), err)
+    }
+
+    now := time.Date(2024, time.March, 13, 6, 0, 0, 0, time.UTC)
+    s.scheduler.SetClock(clock.NewFake(now))
+    today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+    at := func(days, hour int) time.Time {
+        return today.AddDate(0, 0, days).Add(time.Duration(hour) * time.Hour)
+    }
+
+    seed := []*models.Maintenance{
+        {ID: "today-lettuce-water", CropID: "today-lettuce-id", TaskType: "Water", Frequency: "Twice-Daily", Amount: 300, Unit: "ml", Active: true, NextScheduledTime: at(0, 7)},
+        {ID: "today-tomato-water", CropID: "today-tomatoes-id", TaskType: "Water", Frequency: "Daily", Amount: 500, Unit: "ml", Active: true, NextScheduledTime: at(0, 9)},
+        {ID: "today-tomato-pruning", CropID: "today-tomatoes-id", TaskType: "Pruning", Frequency: "Weekly", Unit: "n/a", Active: true, NextScheduledTime: at(-2, 8)},
+        {ID: "today-tomato-fertilizer", CropID: "today-tomatoes-id", TaskType: "Fertilizer", Frequency: "Weekly", Amount: 20, Unit: "g", Active: true, NextScheduledTime: at(1, 10)},
+        {ID: "today-lettuce-inactive", CropID: "today-lettuce-id", TaskType: "Water", Frequency: "Daily", Amount: 100, Unit: "ml", Active: false, NextScheduledTime: at(0, 12)},
+        {ID: "today-neighbour-water", CropID: "today-spinach-id", TaskType: "Water", Frequency: "Daily", Amount: 200, Unit: "ml", Active: true, NextScheduledTime: at(0, 8)},
+    }
+    for _, maintenance := range seed {
+        _, err := s.mockDB.Create(maintenance)
+        require.NoError(s.T(), err)
+    }
+
+    view, err := s.scheduler.TodayView(s.ctx, userID)
+    require.NoError(s.T(), err)
+    assert.Equal(s.T(), userID, view.UserID)
+    assert.Equal(s.T(), "2024-03-13", view.Date)
+
+    scheduleIDs := func(garden dto.TodayGarden) []string {
+        ids := make([]string, len(garden.Items))
+        for i, item := range garden.Items {
+            ids[i] = item.ScheduleID
+        }
+        return ids
+    }
+
+    s.Run("Tasks Grouped By Garden", func() {
+        require.Len(s.T(), view.Gardens, 2)
+        assert.Equal(s.T(), "today-balcony-id", view.Gardens[0].GardenID)
+        assert.Equal(s.T(), []string{"today-tomato-pruning", "today-tomato-water"}, scheduleIDs(view.Gardens[0]))
+        assert.Equal(s.T(), "today-terrace-id", view.Gardens[1].GardenID)
+        assert.Equal(s.T(), []string{"today-lettuce-water", "today-lettuce-water"}, scheduleIDs(view.Gardens[1]))
+        assert.Equal(s.T(), 4, view.TotalCount)
+        assert.Equal(s.T(), 1, view.OverdueCount)
+    })
+
+    s.Run("Overdue Task Flagged", func() {
+        pruning := view.Gardens[0].Items[0]
+        assert.True(s.T(), pruning.Overdue)
+        assert.Equal(s.T(), "Tomatoes", pruning.CropName)
+        assert.False(s.T(), view.Gardens[0].Items[1].Overdue)
+    })
+
+    s.Run("Recurring Task Listed At Each Due Time", func() {
+        assert.Equal(s.T(), "07:00", view.Gardens[1].Items[0].Time)
+        assert.Equal(s.T(), "19:00", view.Gardens[1].Items[1].Time)
+    })
+
+    s.Run("User Without Gardens Has Empty View", func() {
+        empty, err := s.scheduler.TodayView(s.ctx, "gardenless-user-id")
+        require.NoError(s.T(), err)
+        assert.Empty(s.T(), empty.Gardens)
+        assert.Zero(s.T(), empty.TotalCount)
+    })
+
+    s.Run("User ID Required", func() {
+        view, err := s.scheduler.TodayView(s.ctx, "")
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+        assert.Nil(s.T(), view)
+    })
+}
+
+// recordingSender captures notifications delivered to each recipient
+type recordingSender struct {
+    mu        sync.Mutex
+    delivered map[string][]scheduler.NotificationMessage
+}
+
+func newRecordingSender() *recordingSender {
+    return &recordingSender{delivered: make(map[string][]scheduler.NotificationMessage)}
+}
+
+func (r *recordingSender) Send(ctx context.Context, recipient dto.NotificationRecipient, message scheduler.NotificationMessage) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.delivered[recipient.ID] = append(r.delivered[recipient.ID], message)
+    return nil
+}
+
+func (r *recordingSender) count(recipientID string) int {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    return len(r.delivered[recipientID])
+}
+
+// TestNotificationFanOut tests that a due notification reaches every recipient of the garden
+func (s *SchedulerTestSuite) TestNotificationFanOut() {
+    mr := miniredis.RunT(s.T())
+    redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+    defer redisClient.Close()
+
+    cfg := &types.ServiceConfig{
+        ServiceName: "test-scheduler",
+        Environment: "test",
+    }
+    service, err := scheduler.NewSchedulerService(s.mockDB, redisClient, s.mockAI, cfg)
+    require.NoError(s.T(), err)
+
+    sender := newRecordingSender()
+    for _, channel := range []string{dto.ChannelEmail, dto.ChannelSMS, dto.ChannelPush} {
+        service.SetNotificationSender(channel, sender)
+    }
+
+    gardenID := "family-garden-id"
+    crop := &models.Crop{ID: "shared-crop-id", GardenID: gardenID, Name: "Tomatoes", GrowBags: 2, BagSize: "12\""}
+    _, err = s.mockDB.Create(crop)
+    require.NoError(s.T(), err)
+
+    // Deliver well after the schedule's first run so its notification is due
+    dueAt := time.Now().UTC().Add(48 * time.Hour)
+
+    recipients := []*dto.NotificationRecipient{
+        {Name: "Asha", Channel: dto.ChannelEmail, Address: "asha@example.com"},
+        {Name: "Ravi", Channel: dto.ChannelSMS, Address: "+15550100"},
+        {
+            Name:            "Meera",
+            Channel:         dto.ChannelPush,
+            Address:         "device-token",
+            QuietHoursStart: dueAt.Add(-time.Hour).Format("15:04"),
+            QuietHoursEnd:   dueAt.Add(time.Hour).Format("15:04"),
+        },
+    }
+    for _, recipient := range recipients {
+        registered, err := service.RegisterNotificationRecipient(s.ctx, gardenID, recipient)
+        require.NoError(s.T(), err)
+        require.NotEmpty(s.T(), registered.ID)
+    }
+    asha, ravi, meera := recipients[0], recipients[1], recipients[2]
+
+    schedule, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest(crop.ID, "Water", "ml", 500.0))
+    require.NoError(s.T(), err)
+
+    s.Run("Recipients Listed By Name", func() {
+        listed, err := service.ListNotificationRecipients(s.ctx, gardenID)
+        require.NoError(s.T(), err)
+        require.Len(s.T(), listed, 3)
+        assert.Equal(s.T(), []string{"Asha", "Meera", "Ravi"}, []string{listed[0].Name, listed[1].Name, listed[2].Name})
+    })
+
+    s.Run("Due Notification Delivered To All Recipients", func() {
+        require.NoError(s.T(), service.DeliverDueNotifications(s.ctx, dueAt))
+
+        assert.Equal(s.T(), 1, sender.count(asha.ID))
+        assert.Equal(s.T(), 1, sender.count(ravi.ID))
+        assert.Equal(s.T(), schedule.ID, sender.delivered[asha.ID][0].TaskID)
+        assert.Equal(s.T(), gardenID, sender.delivered[ravi.ID][0].GardenID)
+    })
+
+    s.Run("Quiet Hours Defer Delivery", func() {
+        assert.Equal(s.T(), 0, sender.count(meera.ID))
+        assert.Equal(s.T(), []string{schedule.ID}, pendingNotificationTaskIDs(s.T(), mr, "Water"))
+    })
+
+    s.Run("Deferred Delivery Sent After Quiet Hours", func() {
+        require.NoError(s.T(), service.DeliverDueNotifications(s.ctx, dueAt.Add(2*time.Hour)))
+
+        assert.Equal(s.T(), 1, sender.count(meera.ID))
+        assert.Equal(s.T(), 1, sender.count(asha.ID), "deferred copy should only go to the quiet recipient")
+        assert.Equal(s.T(), 1, sender.count(ravi.ID))
+        assert.Empty(s.T(), pendingNotificationTaskIDs(s.T(), mr, "Water"))
+    })
+
+    s.Run("Invalid Recipient Rejected", func() {
+        _, err := service.RegisterNotificationRecipient(s.ctx, gardenID, &dto.NotificationRecipient{Channel: "pigeon", Address: "roof"})
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRecipient)
+    })
+}
+
+// TestNotificationsCoalescedWithinMinimumGap tests that rescheduling a task's notification
+// close to a pending one replaces it rather than queueing both
+func (s *SchedulerTestSuite) TestNotificationsCoalescedWithinMinimumGap() {
+    mr := miniredis.RunT(s.T())
+    redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+    defer redisClient.Close()
+
+    cfg := &types.ServiceConfig{
+        ServiceName: "test-scheduler",
+        Environment: "test",
+        Scheduler: &types.SchedulerConfig{
+            AIWorkerPoolSize:   5,
+            MinNotificationGap: time.Hour,
+        },
+    }
+    service, err := scheduler.NewSchedulerService(s.mockDB, redisClient, s.mockAI, cfg)
+    require.NoError(s.T(), err)
+
+    schedule, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest("coalesced-crop-id", "Water", "ml", 500.0))
+    require.NoError(s.T(), err)
+    other, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest("separate-crop-id", "Water", "ml", 300.0))
+    require.NoError(s.T(), err)
+
+    // A quick follow-up change lands within the gap of the notification already queued
+    _, err = service.UpdateSchedule(s.ctx, schedule.ID, newTestMaintenanceRequest("coalesced-crop-id", "Water", "ml", 400.0))
+    require.NoError(s.T(), err)
+
+    s.Run("Same Task Coalesced Into One", func() {
+        pending := pendingNotificationTaskIDs(s.T(), mr, "Water")
+        assert.ElementsMatch(s.T(), []string{schedule.ID, other.ID}, pending)
+    })
+
+    s.Run("Other Tasks Unaffected", func() {
+        assert.Contains(s.T(), pendingNotificationTaskIDs(s.T(), mr, "Water"), other.ID)
+    })
+}
+
+// TestNotificationCoalescingDisabled tests that a zero minimum gap queues every
+// notification for a task rather than coalescing them
+func (s *SchedulerTestSuite) TestNotificationCoalescingDisabled() {
+    mr := miniredis.RunT(s.T())
+    redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+    defer redisClient.Close()
+
+    cfg := &types.ServiceConfig{
+        ServiceName: "test-scheduler",
+        Environment: "test",
+        Scheduler: &types.SchedulerConfig{
+            AIWorkerPoolSize:   5,
+            MinNotificationGap: 0,
+        },
+    }
+    service, err := scheduler.NewSchedulerService(s.mockDB, redisClient, s.mockAI, cfg)
+    require.NoError(s.T(), err)
+
+    schedule, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest("uncoalesced-crop-id", "Water", "ml", 500.0))
+    require.NoError(s.T(), err)
+
+    _, err = service.UpdateSchedule(s.ctx, schedule.ID, newTestMaintenanceRequest("uncoalesced-crop-id", "Water", "ml", 400.0))
+    require.NoError(s.T(), err)
+
+    assert.Equal(s.T(), []string{schedule.ID, schedule.ID}, pendingNotificationTaskIDs(s.T(), mr, "Water"))
+}
+
+// TestActiveNotificationLimit tests that each user's tasks with pending notifications are
+// capped, rejecting or evicting by priority at the limit
+func (s *SchedulerTestSuite) TestActiveNotificationLimit() {
+    userID := "limit-user-id"
+    garden := &models.Garden{ID: "limit-garden-id", UserID: userID, Length: 4, Width: 3, SoilType: "loamy_soil", Sunlight: "full_sun"}
+    _, err := s.mockDB.Create(garden)
+    require.NoError(s.T(), err)
+
+    crops := []*models.Crop{
+        {ID: "limit-tomatoes-id", GardenID: garden.ID, Name: "Tomatoes", GrowBags: 2, BagSize: "12\""},
+        {ID: "limit-lettuce-id", GardenID: garden.ID, Name: "Lettuce", GrowBags: 2, BagSize: "10\""},
+        {ID: "limit-peppers-id", GardenID: garden.ID, Name: "Peppers", GrowBags: 2, BagSize: "12\""},
+    }
+    for _, crop := range crops {
+        _, err := s.mockDB.Create(crop)
+        require.NoError(s.T(), err)
+    }
+
+    newService := func(limit int, policy string) (*scheduler.SchedulerService, *miniredis.Miniredis) {
+        mr := miniredis.RunT(s.T())
+        redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+        s.T().Cleanup(func() { redisClient.Close() })
+
+        cfg := &types.ServiceConfig{
+            ServiceName: "test-scheduler",
+            Environment: "test",
+            Scheduler: &types.SchedulerConfig{
+                AIWorkerPoolSize:              5,
+                MaxActiveNotificationsPerUser: limit,
+                NotificationLimitPolicy:       policy,
+            },
+        }
+        service, err := scheduler.NewSchedulerService(s.mockDB, redisClient, s.mockAI, cfg)
+        require.NoError(s.T(), err)
+        return service, mr
+    }
+
+    s.Run("Reject At And Over Limit", func() {
+        service, mr := newService(2, scheduler.NotificationLimitReject)
+
+        fertilizer, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest(crops[0].ID, "Fertilizer", "g", 20.0))
+        require.NoError(s.T(), err)
+        water, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest(crops[1].ID, "Water", "ml", 400.0))
+        require.NoError(s.T(), err, "scheduling up to the limit should succeed")
+
+        over, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest(crops[2].ID, "Composting", "g", 200.0))
+        assert.ErrorIs(s.T(), err, scheduler.ErrNotificationLimit)
+        assert.Nil(s.T(), over)
+        assert.Empty(s.T(), pendingNotificationTaskIDs(s.T(), mr, "Composting"))
+        assert.Equal(s.T(), []string{water.ID}, pendingNotificationTaskIDs(s.T(), mr, "Water"))
+
+        schedules, err := service.GetCropSchedules(s.ctx, crops[2].ID)
+        require.NoError(s.T(), err)
+        assert.Empty(s.T(), schedules, "a task rejected at the limit should not be kept")
+
+        // Rescheduling a task that already holds a slot is not counted again
+        _, err = service.UpdateSchedule(s.ctx, fertilizer.ID, newTestMaintenanceRequest(crops[0].ID, "Fertilizer", "g", 25.0))
+        require.NoError(s.T(), err)
+
+        // Deleting a schedule frees its slot
+        require.NoError(s.T(), service.DeleteSchedule(s.ctx, fertilizer.ID))
+        _, err = service.CreateSchedule(s.ctx, newTestMaintenanceRequest(crops[2].ID, "Composting", "g", 200.0))
+        assert.NoError(s.T(), err)
+    })
+
+    s.Run("Evict Lowest Priority Over Limit", func() {
+        service, mr := newService(1, scheduler.NotificationLimitEvict)
+
+        compost, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest(crops[0].ID, "Composting", "g", 200.0))
+        require.NoError(s.T(), err)
+
+        water, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest(crops[1].ID, "Water", "ml", 400.0))
+        require.NoError(s.T(), err, "a higher-priority task should evict the lowest-priority one")
+        assert.NotContains(s.T(), pendingNotificationTaskIDs(s.T(), mr, "Composting"), compost.ID)
+        assert.Equal(s.T(), []string{water.ID}, pendingNotificationTaskIDs(s.T(), mr, "Water"))
+
+        _, err = service.CreateSchedule(s.ctx, newTestMaintenanceRequest(crops[2].ID, "Fertilizer", "g", 20.0))
+        assert.ErrorIs(s.T(), err, scheduler.ErrNotificationLimit, "nothing of lower priority is left to evict")
+        assert.Equal(s.T(), []string{water.ID}, pendingNotificationTaskIDs(s.T(), mr, "Water"))
+    })
+
+    s.Run("Concurrent Schedules Respect Limit", func() {
+        const limit = 2
+        service, mr := newService(limit, scheduler.NotificationLimitReject)
+
+        var wg sync.WaitGroup
+        var created, rejected int32
+        for i := 0; i < 6; i++ {
+            wg.Add(1)
+            go func(crop *models.Crop) {
+                defer wg.Done()
+                _, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest(crop.ID, "Water", "ml", 400.0))
+                switch {
+                case err == nil:
+                    atomic.AddInt32(&created, 1)
+                case errors.Is(err, scheduler.ErrNotificationLimit):
+                    atomic.AddInt32(&rejected, 1)
+                }
+            }(crops[i%len(crops)])
+        }
+        wg.Wait()
+
+        assert.EqualValues(s.T(), limit, created, "concurrent schedules must not overshoot the limit")
+        assert.EqualValues(s.T(), 6-limit, rejected)
+        assert.Len(s.T(), pendingNotificationTaskIDs(s.T(), mr, "Water"), limit)
+    })
+}
+
+// TestTaskTypePriorityOrdering tests that configured task type priorities decide the order
+// due notifications are delivered in
+func (s *SchedulerTestSuite) TestTaskTypePriorityOrdering() {
+    mr := miniredis.RunT(s.T())
+    redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+    defer redisClient.Close()
+
+    // Composting outranks the default Water=3 and Fertilizer=2, as during a compost-heavy season
+    cfg := &types.ServiceConfig{
+        ServiceName: "test-scheduler",
+        Environment: "test",
+        Scheduler: &types.SchedulerConfig{
+            AIWorkerPoolSize:   5,
+            TaskTypePriorities: map[string]int{"Composting": 5},
+        },
+    }
+    service, err := scheduler.NewSchedulerService(s.mockDB, redisClient, s.mockAI, cfg)
+    require.NoError(s.T(), err)
+
+    sender := newRecordingSender()
+    service.SetNotificationSender(dto.ChannelEmail, sender)
+
+    gardenID := "priority-garden-id"
+    crops := []*models.Crop{
+        {ID: "priority-tomatoes-id", GardenID: gardenID, Name: "Tomatoes", GrowBags: 2, BagSize: "12\""},
+        {ID: "priority-lettuce-id", GardenID: gardenID, Name: "Lettuce", GrowBags: 2, BagSize: "10\""},
+        {ID: "priority-peppers-id", GardenID: gardenID, Name: "Peppers", GrowBags: 2, BagSize: "12\""},
+    }
+    for _, crop := range crops {
+        _, err := s.mockDB.Create(crop)
+        require.NoError(s.T(), err)
+    }
+
+    recipient := &dto.NotificationRecipient{Name: "Asha", Channel: dto.ChannelEmail, Address: "asha@example.com"}
+    _, err = service.RegisterNotificationRecipient(s.ctx, gardenID, recipient)
+    require.NoError(s.T(), err)
+
+    fertilizer, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest(crops[0].ID, "Fertilizer", "g", 20.0))
+    require.NoError(s.T(), err)
+    water, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest(crops[1].ID, "Water", "ml", 400.0))
+    require.NoError(s.T(), err)
+    compost, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest(crops[2].ID, "Composting", "g", 200.0))
+    require.NoError(s.T(), err)
+
+    require.NoError(s.T(), service.DeliverDueNotifications(s.ctx, time.Now().UTC().Add(48*time.Hour)))
+
+    delivered := sender.delivered[recipient.ID]
+    require.Len(s.T(), delivered, 3)
+    assert.Equal(s.T(), []string{compost.ID, water.ID, fertilizer.ID},
+        []string{delivered[0].TaskID, delivered[1].TaskID, delivered[2].TaskID})
+    assert.Equal(s.T(), []int{5, 3, 2},
+        []int{delivered[0].Priority, delivered[1].Priority, delivered[2].Priority})
+}
+
+// TestNotificationClockSkew tests that a replica whose clock is skewed from Redis TIME
+// processes notifications neither earlier nor later than the configured tolerance
+func (s *SchedulerTestSuite) TestNotificationClockSkew() {
+    // newSkewService starts a service on its own Redis with one pending Water notification
+    // for a garden named after name, returning when that notification is due
+    newSkewService := func(name, timeSource string, tolerance time.Duration) (*scheduler.SchedulerService, *miniredis.Miniredis, *recordingSender, string, time.Time) {
+        mr := miniredis.RunT(s.T())
+        redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+        s.T().Cleanup(func() { redisClient.Close() })
+
+        cfg := &types.ServiceConfig{
+            ServiceName: "test-scheduler",
+            Environment: "test",
+            Scheduler: &types.SchedulerConfig{
+                AIWorkerPoolSize:       5,
+                NotificationTimeSource: timeSource,
+                ClockSkewTolerance:     tolerance,
+            },
+        }
+        service, err := scheduler.NewSchedulerService(s.mockDB, redisClient, s.mockAI, cfg)
+        require.NoError(s.T(), err)
+
+        sender := newRecordingSender()
+        service.SetNotificationSender(dto.ChannelEmail, sender)
+
+        gardenID := name + "-garden-id"
+        crop := &models.Crop{ID: name + "-crop-id", GardenID: gardenID, Name: "Basil", GrowBags: 1, BagSize: "12\""}
+        _, err = s.mockDB.Create(crop)
+        require.NoError(s.T(), err)
+
+        recipient := &dto.NotificationRecipient{Name: "Asha", Channel: dto.ChannelEmail, Address: "asha@example.com"}
+        _, err = service.RegisterNotificationRecipient(s.ctx, gardenID, recipient)
+        require.NoError(s.T(), err)
+
+        _, err = service.CreateSchedule(s.ctx, newTestMaintenanceRequest(crop.ID, "Water", "ml", 250.0))
+        require.NoError(s.T(), err)
+
+        members, err := mr.ZMembers("notifications:Water")
+        require.NoError(s.T(), err)
+        require.Len(s.T(), members, 1)
+        score, err := mr.ZScore("notifications:Water", members[0])
+        require.NoError(s.T(), err)
+
+        return service, mr, sender, recipient.ID, time.Unix(int64(score), 0)
+    }
+
+    s.Run("Fast Clock Held Within Tolerance", func() {
+        service, mr, sender, recipientID, dueAt := newSkewService("fast-clock", scheduler.TimeSourceLocal, 5*time.Minute)
+
+        // The replica's clock is two hours ahead of Redis, which is an hour before the due time
+        mr.SetTime(dueAt.Add(-time.Hour))
+        require.NoError(s.T(), service.DeliverDueNotifications(s.ctx, dueAt.Add(time.Hour)))
+        assert.Equal(s.T(), 0, sender.count(recipientID))
+        assert.Len(s.T(), pendingNotificationTaskIDs(s.T(), mr, "Water"), 1)
+
+        // Skew within the tolerance is trusted
+        mr.SetTime(dueAt.Add(-2 * time.Minute))
+        require.NoError(s.T(), service.DeliverDueNotifications(s.ctx, dueAt.Add(time.Minute)))
+        assert.Equal(s.T(), 1, sender.count(recipientID))
+        assert.Empty(s.T(), pendingNotificationTaskIDs(s.T(), mr, "Water"))
+    })
+
+    s.Run("Slow Clock Caught Up To Tolerance", func() {
+        service, mr, sender, recipientID, dueAt := newSkewService("slow-clock", scheduler.TimeSourceLocal, 5*time.Minute)
+
+        // The replica's clock is an hour behind Redis, which is past the due time
+        mr.SetTime(dueAt.Add(10 * time.Minute))
+        require.NoError(s.T(), service.DeliverDueNotifications(s.ctx, dueAt.Add(-50*time.Minute)))
+        assert.Equal(s.T(), 1, sender.count(recipientID))
+    })
+
+    s.Run("Redis Time Source Ignores Local Clock", func() {
+        service, mr, sender, recipientID, dueAt := newSkewService("redis-time", scheduler.TimeSourceRedis, 0)
+
+        mr.SetTime(dueAt.Add(-time.Minute))
+        require.NoError(s.T(), service.DeliverDueNotifications(s.ctx, dueAt.Add(24*time.Hour)))
+        assert.Equal(s.T(), 0, sender.count(recipientID))
+
+        mr.SetTime(dueAt)
+        require.NoError(s.T(), service.DeliverDueNotifications(s.ctx, dueAt.Add(-24*time.Hour)))
+        assert.Equal(s.T(), 1, sender.count(recipientID))
+    })
+}
+
+// TestShiftPreferredTimes tests that only active tasks within the source range move to the new time
+func (s *SchedulerTestSuite) TestShiftPreferredTimes() {
+    gardenID := "routine-garden-id"
+    indoorGardenID := "routine-indoor-garden-id"
+    gardens := []*models.Garden{
+        {ID: gardenID, UserID: "routine-user-id", Length: 4, Width: 3, SoilType: "loamy_soil", Sunlight: "full_sun", Environment: dto.EnvironmentOutdoor},
+        {ID: indoorGardenID, UserID: "routine-user-id", Length: 4, Width: 3, SoilType: "loamy_soil", Sunlight: "full_sun", Environment: dto.EnvironmentIndoor},
+    }
+    for _, garden := range gardens {
+        _, err := s.mockDB.Create(garden)
+        require.NoError(s.T(), err)
+    }
+    crop := &models.Crop{ID: "routine-crop-id", GardenID: gardenID, Name: "Peppers", GrowBags: 2, BagSize: "12\""}
+    otherCrop := &models.Crop{ID: "neighbour-crop-id", GardenID: "neighbour-garden-id", Name: "Peppers", GrowBags: 2, BagSize: "12\""}
+    indoorCrop := &models.Crop{ID: "routine-indoor-crop-id", GardenID: indoorGardenID, Name: "Lettuce", GrowBags: 2, BagSize: "10\""}
+    for _, c := range []*models.Crop{crop, otherCrop, indoorCrop} {
+        _, err := s.mockDB.Create(c)
+        require.NoError(s.T(), err)
+    }
+
+    seed := []*models.Maintenance{
+        {ID: "early-water", CropID: crop.ID, TaskType: "Water", Frequency: "Daily", Amount: 500, Unit: "ml", PreferredTime: "06:00", Active: true},
+        {ID: "morning-fertilizer", CropID: crop.ID, TaskType: "Fertilizer", Frequency: "Weekly", Amount: 20, Unit: "g", PreferredTime: "09:30", Active: true},
+        {ID: "noon-water", CropID: crop.ID, TaskType: "Water", Frequency: "Daily", Amount: 300, Unit: "ml", PreferredTime: "12:00", Active: true},
+        {ID: "afternoon-compost", CropID: crop.ID, TaskType: "Composting", Frequency: "Monthly", Amount: 200, Unit: "g", PreferredTime: "15:00", Active: true},
+        {ID: "paused-water", CropID: crop.ID, TaskType: "Water", Frequency: "Daily", Amount: 100, Unit: "ml", PreferredTime: "08:00", Active: false},
+        {ID: "neighbour-water", CropID: otherCrop.ID, TaskType: "Water", Frequency: "Daily", Amount: 400, Unit: "ml", PreferredTime: "08:00", Active: true},
+        {ID: "indoor-water", CropID: indoorCrop.ID, TaskType: "Water", Frequency: "Daily", Amount: 300, Unit: "ml", PreferredTime: "08:00", Active: true},
+    }
+    for _, maintenance := range seed {
+        _, err := s.mockDB.Create(maintenance)
+        require.NoError(s.T(), err)
+    }
+
+    morning := dto.TimeRange{Start: "06:00", End: "12:00"}
+
+    s.Run("Only Tasks In Range Move", func() {
+        response, err := s.scheduler.ShiftPreferredTimes(s.ctx, gardenID, morning, "17:00")
+        require.NoError(s.T(), err)
+
+        shiftedIDs := make([]string, len(response.Shifted))
+        for i, task := range response.Shifted {
+            shiftedIDs[i] = task.ID
+            assert.Equal(s.T(), "17:00", task.PreferredTime)
+            assert.Equal(s.T(), 17, task.NextScheduledTime.Hour())
+        }
+        assert.ElementsMatch(s.T(), []string{"early-water", "morning-fertilizer"}, shiftedIDs)
+    })
+
+    s.Run("Tasks Outside Range Unchanged", func() {
+        for id, preferred := range map[string]string{
+            "noon-water":        "12:00",
+            "afternoon-compost": "15:00",
+            "paused-water":      "08:00",
+            "neighbour-water":   "08:00",
+        } {
+            var stored models.Maintenance
+            _, err := s.mockDB.First(&stored, "id = ?", id)
+            require.NoError(s.T(), err)
+            assert.Equal(s.T(), preferred, stored.PreferredTime, "task %s should not move", id)
+        }
+    })
+
+    s.Run("Shift Recorded In History", func() {
+        history, err := s.scheduler.GetScheduleChangeLog(s.ctx, crop.ID)
+        require.NoError(s.T(), err)
+        require.NotEmpty(s.T(), history)
+        assert.Equal(s.T(), models.ScheduleEventUpdated, history[len(history)-1].EventType)
+        assert.Equal(s.T(), "17:00", history[len(history)-1].PreferredTime)
+    })
+
+    s.Run("Invalid Range Rejected", func() {
+        _, err := s.scheduler.ShiftPreferredTimes(s.ctx, gardenID, dto.TimeRange{Start: "12:00", End: "06:00"}, "17:00")
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+    })
+
+    s.Run("Target Outside Daylight Rejected", func() {
+        _, err := s.scheduler.ShiftPreferredTimes(s.ctx, gardenID, morning, "21:00")
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+    })
+
+    s.Run("Indoor Garden Accepts Night Target", func() {
+        response, err := s.scheduler.ShiftPreferredTimes(s.ctx, indoorGardenID, morning, "21:00")
+        require.NoError(s.T(), err)
+        require.Len(s.T(), response.Shifted, 1)
+        assert.Equal(s.T(), "indoor-water", response.Shifted[0].ID)
+        assert.Equal(s.T(), "21:00", response.Shifted[0].PreferredTime)
+    })
+
+    s.Run("Unknown Garden", func() {
+        _, err := s.scheduler.ShiftPreferredTimes(s.ctx, "missing-garden-id", morning, "17:00")
+        assert.ErrorIs(s.T(), err, scheduler.ErrGardenNotFound)
+    })
+}
+
+// TestCreateScheduleRefreshBypassesCache tests that refresh skips cached schedules on read
+// but still caches the freshly generated schedule
+func (s *SchedulerTestSuite) TestCreateScheduleRefreshBypassesCache() {
+    mr := miniredis.RunT(s.T())
+    redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+    defer redisClient.Close()
+
+    cfg := &types.ServiceConfig{
+        ServiceName: "test-scheduler",
+        Environment: "test",
+    }
+    service, err := scheduler.NewSchedulerService(s.mockDB, redisClient, s.mockAI, cfg)
+    require.NoError(s.T(), err)
+
+    request := newTestMaintenanceRequest("refresh-crop-id", "Water", "ml", 500.0)
+    cacheKey := fmt.Sprintf("schedule:%s:%s:%s", request.TaskType, request.SoilType, request.GrowingEnvironment)
+    cachedID := func() string {
+        data, err := mr.Get(cacheKey)
+        require.NoError(s.T(), err)
+        var cached dto.MaintenanceResponse
+        require.NoError(s.T(), json.Unmarshal([]byte(data), &cached))
+        return cached.ID
+    }
+
+    first, err := service.CreateSchedule(s.ctx, request)
+    require.NoError(s.T(), err)
+    assert.Equal(s.T(), first.ID, cachedID())
+    calls := s.mockAI.CallCount()
+
+    s.Run("Cached Schedule Served Without Refresh", func() {
+        again, err := service.CreateSchedule(s.ctx, request)
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), first.ID, again.ID)
+        assert.Equal(s.T(), calls, s.mockAI.CallCount())
+    })
+
+    s.Run("Refresh Skips Cache But Stores Result", func() {
+        fresh, err := service.CreateSchedule(ai.WithRefresh(s.ctx), request)
+        require.NoError(s.T(), err)
+        assert.NotEqual(s.T(), first.ID, fresh.ID)
+        assert.Greater(s.T(), s.mockAI.CallCount(), calls)
+        assert.Equal(s.T(), fresh.ID, cachedID())
+    })
+}
+
+// TestScheduleCacheTTLJitter tests that cached schedule TTLs vary within the configured jitter band
+func (s *SchedulerTestSuite) TestScheduleCacheTTLJitter() {
+    mr := miniredis.RunT(s.T())
+    redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+    defer redisClient.Close()
+
+    cfg := &types.ServiceConfig{
+        ServiceName: "test-scheduler",
+        Environment: "test",
+        Redis:       &types.RedisConfig{TTLJitterPercent: 10},
+    }
+    service, err := scheduler.NewSchedulerService(s.mockDB, redisClient, s.mockAI, cfg)
+    require.NoError(s.T(), err)
+
+    request := newTestMaintenanceRequest("jitter-crop-id", "Water", "ml", 500.0)
+    cacheKey := fmt.Sprintf("schedule:%s:%s:%s", request.TaskType, request.SoilType, request.GrowingEnvironment)
+
+    // Refreshing regenerates and re-caches the schedule, drawing a new TTL each time
+    ttls := make(map[time.Duration]bool)
+    for i := 0; i < 10; i++ {
+        _, err := service.CreateSchedule(ai.WithRefresh(s.ctx), request)
+        require.NoError(s.T(), err)
+
+        ttl := mr.TTL(cacheKey)
+        assert.GreaterOrEqual(s.T(), ttl, 54*time.Minute)
+        assert.LessOrEqual(s.T(), ttl, 66*time.Minute)
+        ttls[ttl] = true
+    }
+    assert.Greater(s.T(), len(ttls), 1, "expected jittered TTLs to differ")
+}
+
+// TestPreviewNextSchedule tests that previews match the schedule creation would store
+// without persisting anything
+func (s *SchedulerTestSuite) TestPreviewNextSchedule() {
+    now := time.Date(2024, time.June, 3, 6, 30, 0, 0, time.UTC)
+    s.scheduler.SetClock(clock.NewFake(now))
+
+    for _, frequency := range []string{"Daily", "Weekly"} {
+        frequency := frequency
+        s.Run(frequency, func() {
+            cropID := "preview-crop-" + strings.ToLower(frequency)
+            request := newTestMaintenanceRequest(cropID, "Water", "ml", 500.0)
+            request.Frequency = frequency
+
+            preview, err := s.scheduler.PreviewNextSchedule(s.ctx, request)
+            require.NoError(s.T(), err)
+
+            schedules, err := s.scheduler.GetCropSchedules(s.ctx, cropID)
+            require.NoError(s.T(), err)
+            assert.Empty(s.T(), schedules, "preview must not persist a task")
+
+            created, err := s.scheduler.CreateSchedule(ai.WithRefresh(s.ctx), request)
+            require.NoError(s.T(), err)
+            assert.True(s.T(), created.NextScheduledTime.Equal(preview.NextScheduledTime),
+                "preview %v, created %v", preview.NextScheduledTime, created.NextScheduledTime)
+            assert.Equal(s.T(), created.Frequency, preview.Frequency)
+            assert.Equal(s.T(), created.AIRecommended, preview.AIRecommended)
+            if preview.AIRecommended {
+                assert.NotEmpty(s.T(), preview.AISuggestions)
+            }
+        })
+    }
+
+    s.Run("Daily Task Before Its Time Fires Today", func() {
+        request := newTestMaintenanceRequest("preview-crop-today", "Water", "ml", 500.0)
+        request.Frequency = "Daily"
+
+        preview, err := s.scheduler.PreviewNextSchedule(s.ctx, request)
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), now.Day(), preview.NextScheduledTime.Day())
+    })
+
+    s.Run("Invalid Request Rejected", func() {
+        request := newTestMaintenanceRequest("preview-crop-invalid", "Water", "ml", 500.0)
+        request.PreferredTime = "25:00"
+
+        _, err := s.scheduler.PreviewNextSchedule(s.ctx, request)
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+    })
+}
+
+// TestSuggestSchedulesForCrop tests that suggested schedules fit the crop type and are not persisted
+func (s *SchedulerTestSuite) TestListTasksByCompletionRate() {
+    gardenID := "completion-garden-id"
+    crop := &models.Crop{ID: "completion-crop-id", GardenID: gardenID, Name: "Peppers", GrowBags: 1, BagSize: "12\""}
+    _, err := s.mockDB.Create(crop)
+    require.NoError(s.T(), err)
+
+    seed := []*models.Maintenance{
+        {ID: "rarely-watered", CropID: crop.ID, TaskType: "Water", Frequency: "Daily", Amount: 500, Unit: "ml", Active: true, CompletionRate: 20},
+        {ID: "sometimes-fed", CropID: crop.ID, TaskType: "Fertilizer", Frequency: "Weekly", Amount: 20, Unit: "g", Active: true, CompletionRate: 45},
+        {ID: "always-pruned", CropID: crop.ID, TaskType: "Pruning", Frequency: "Weekly", Unit: "n/a", Active: true, CompletionRate: 90},
+        {ID: "threshold-compost", CropID: crop.ID, TaskType: "Composting", Frequency: "Monthly", Amount: 200, Unit: "g", Active: true, CompletionRate: 50},
+        {ID: "inactive-water", CropID: crop.ID, TaskType: "Water", Frequency: "Daily", Amount: 100, Unit: "ml", Active: false, CompletionRate: 0},
+    }
+    for _, maintenance := range seed {
+        _, err := s.mockDB.Create(maintenance)
+        require.NoError(s.T(), err)
+    }
+
+    taskIDs := func(tasks []*dto.MaintenanceResponse) []string {
+        ids := make([]string, len(tasks))
+        for i, task := range tasks {
+            ids[i] = task.ID
+        }
+        return ids
+    }
+
+    s.Run("Active Tasks Below Threshold Lowest First", func() {
+        tasks, err := s.scheduler.ListTasksByCompletionRate(s.ctx, gardenID, 50)
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), []string{"rarely-watered", "sometimes-fed"}, taskIDs(tasks))
+        for _, task := range tasks {
+            assert.Less(s.T(), task.CompletionRate, 50.0)
+        }
+    })
+
+    s.Run("Full Threshold Includes Every Incomplete Task", func() {
+        tasks, err := s.scheduler.ListTasksByCompletionRate(s.ctx, gardenID, 100)
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), []string{"rarely-watered", "sometimes-fed", "threshold-compost", "always-pruned"}, taskIDs(tasks))
+    })
+
+    s.Run("Zero Threshold Matches Nothing", func() {
+        tasks, err := s.scheduler.ListTasksByCompletionRate(s.ctx, gardenID, 0)
+        require.NoError(s.T(), err)
+        assert.Empty(s.T(), tasks)
+    })
+
+    s.Run("Invalid Threshold", func() {
+        _, err := s.scheduler.ListTasksByCompletionRate(s.ctx, gardenID, 150)
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+
+        _, err = s.scheduler.ListTasksByCompletionRate(s.ctx, gardenID, -1)
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+    })
+
+    s.Run("Missing Garden ID", func() {
+        _, err := s.scheduler.ListTasksByCompletionRate(s.ctx, "", 50)
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+    })
+}
+
+func (s *SchedulerTestSuite) TestSuggestSchedulesForCrop() {
+    now := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+    s.scheduler.SetClock(clock.NewFake(now))
+
+    tomatoes := &models.Crop{ID: "suggest-tomatoes-id", GardenID: "suggest-garden-id", Name: "Tomatoes", GrowBags: 1, BagSize: "12\"", CreatedAt: now}
+    lettuce := &models.Crop{ID: "suggest-lettuce-id", GardenID: "suggest-garden-id", Name: "Lettuce", GrowBags: 1, BagSize: "8\"", CreatedAt: now}
+    bigTomatoes := &models.Crop{ID: "suggest-big-tomatoes-id", GardenID: "suggest-garden-id", Name: "Tomatoes", GrowBags: 5, BagSize: "14\"", CreatedAt: now}
+    for _, crop := range []*models.Crop{tomatoes, lettuce, bigTomatoes} {
+        _, err := s.mockDB.Create(crop)
+        require.NoError(s.T(), err)
+    }
+
+    byTask := func(response *dto.SuggestedSchedulesResponse) map[string]dto.ScheduleSuggestion {
+        suggestions := make(map[string]dto.ScheduleSuggestion, len(response.Suggestions))
+        for _, suggestion := range response.Suggestions {
+            suggestions[suggestion.TaskType] = suggestion
+        }
+        return suggestions
+    }
+
+    tomatoSuggestions, err := s.scheduler.SuggestSchedulesForCrop(s.ctx, tomatoes.ID)
+    require.NoError(s.T(), err)
+    lettuceSuggestions, err := s.scheduler.SuggestSchedulesForCrop(s.ctx, lettuce.ID)
+    require.NoError(s.T(), err)
+
+    s.Run("Water Fertilizer And Pruning Suggested", func() {
+        assert.Equal(s.T(), tomatoes.ID, tomatoSuggestions.CropID)
+        assert.Equal(s.T(), dto.RecommendationSourceRules, tomatoSuggestions.Source)
+        suggestions := byTask(tomatoSuggestions)
+        require.Len(s.T(), suggestions, 3)
+        assert.Contains(s.T(), suggestions, dto.TaskTypeWater)
+        assert.Contains(s.T(), suggestions, dto.TaskTypeFertilizer)
+        assert.Contains(s.T(), suggestions, dto.TaskTypePruning)
+        for _, suggestion := range suggestions {
+            assert.NoError(s.T(), suggestion.Validate(), "%s suggestion must be acceptable as is", suggestion.TaskType)
+            assert.NotEmpty(s.T(), suggestion.PreferredTime)
+        }
+        assert.Equal(s.T(), "n/a", suggestions[dto.TaskTypePruning].Unit)
+    })
+
+    s.Run("Fruiting Crops Need More Water And Pruning", func() {
+        tomato, leafy := byTask(tomatoSuggestions), byTask(lettuceSuggestions)
+        assert.Greater(s.T(), tomato[dto.TaskTypeWater].Amount, leafy[dto.TaskTypeWater].Amount)
+        assert.Equal(s.T(), dto.FrequencyWeekly, tomato[dto.TaskTypePruning].Frequency)
+        assert.Equal(s.T(), dto.FrequencyBiWeekly, leafy[dto.TaskTypePruning].Frequency)
+    })
+
+    s.Run("New Crop Gets Seedling Feeding", func() {
+        fertilizer := byTask(tomatoSuggestions)[dto.TaskTypeFertilizer]
+        assert.Equal(s.T(), dto.FrequencyMonthly, fertilizer.Frequency)
+        assert.Equal(s.T(), "g", fertilizer.Unit)
+        minGrams, _, _ := dto.AmountBounds(dto.TaskTypeFertilizer)
+        assert.GreaterOrEqual(s.T(), fertilizer.Amount, minGrams, "a single seedling bag still gets a valid dose")
+    })
+
+    s.Run("Water Capped At Request Limit", func() {
+        big, err := s.scheduler.SuggestSchedulesForCrop(s.ctx, bigTomatoes.ID)
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), 2000.0, byTask(big)[dto.TaskTypeWater].Amount)
+    })
+
+    s.Run("Suggestions Not Persisted", func() {
+        schedules, err := s.scheduler.GetCropSchedules(s.ctx, tomatoes.ID)
+        require.NoError(s.T(), err)
+        assert.Empty(s.T(), schedules)
+    })
+
+    s.Run("Unknown Crop", func() {
+        response, err := s.scheduler.SuggestSchedulesForCrop(s.ctx, "missing-crop-id")
+        assert.ErrorIs(s.T(), err, scheduler.ErrCropNotFound)
+        assert.Nil(s.T(), response)
+    })
+}
+
+// TestSuggestSchedulesRespectDisabledTaskTypes tests that task types a crop has switched
+// off are neither suggested nor schedulable
+func (s *SchedulerTestSuite) TestSuggestSchedulesRespectDisabledTaskTypes() {
+    now := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+    s.scheduler.SetClock(clock.NewFake(now))
+
+    cactus := &models.Crop{ID: "toggle-cactus-id", GardenID: "toggle-garden-id", Name: "Tomatoes", GrowBags: 1, BagSize: "12\"", CreatedAt: now}
+    cactus.SetDisabledTaskTypes([]string{dto.TaskTypeWater})
+    _, err := s.mockDB.Create(cactus)
+    require.NoError(s.T(), err)
+
+    s.Run("No Water Suggestion", func() {
+        response, err := s.scheduler.SuggestSchedulesForCrop(s.ctx, cactus.ID)
+        require.NoError(s.T(), err)
+
+        taskTypes := make([]string, 0, len(response.Suggestions))
+        for _, suggestion := range response.Suggestions {
+            taskTypes = append(taskTypes, suggestion.TaskType)
+        }
+        assert.NotContains(s.T(), taskTypes, dto.TaskTypeWater)
+        assert.Contains(s.T(), taskTypes, dto.TaskTypeFertilizer)
+        assert.Contains(s.T(), taskTypes, dto.TaskTypePruning)
+    })
+
+    s.Run("Disabled Task Type Rejected", func() {
+        s.mockAI.SetMockSchedule("default", map[string]interface{}{"frequency": "Daily"})
+        response, err := s.scheduler.CreateSchedule(s.ctx, &dto.MaintenanceRequest{
+            CropID:             cactus.ID,
+            TaskType:           dto.TaskTypeWater,
+            Frequency:          dto.FrequencyDaily,
+            Amount:             200,
+            Unit:               "ml",
+            PreferredTime:      "07:00",
+            SoilType:           "Sandy",
+            GrowBagSize:        "12\"",
+            GrowingEnvironment: "Indoor",
+        })
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+        assert.Nil(s.T(), response)
+
+        schedules, err := s.scheduler.GetCropSchedules(s.ctx, cactus.ID)
+        require.NoError(s.T(), err)
+        assert.Empty(s.T(), schedules)
+    })
+
+    s.Run("Disabled Task Type Preview Rejected", func() {
+        preview, err := s.scheduler.PreviewNextSchedule(s.ctx, newTestMaintenanceRequest(cactus.ID, dto.TaskTypeWater, "ml", 200.0))
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+        assert.Nil(s.T(), preview)
+    })
+
+    s.Run("Enabled Task Type Allowed", func() {
+        s.mockAI.SetMockSchedule("default", map[string]interface{}{"frequency": "Monthly"})
+        response, err := s.scheduler.CreateSchedule(s.ctx, &dto.MaintenanceRequest{
+            CropID:             cactus.ID,
+            TaskType:           dto.TaskTypeFertilizer,
+            Frequency:          dto.FrequencyMonthly,
+            Amount:             10,
+            Unit:               "g",
+            PreferredTime:      "08:00",
+            SoilType:           "Sandy",
+            GrowBagSize:        "12\"",
+            GrowingEnvironment: "Indoor",
+        })
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), dto.TaskTypeFertilizer, response.TaskType)
+    })
+}
+
+// TestPestIncidents tests recording pest incidents and their effect on suggested pest control
+func (s *SchedulerTestSuite) TestPestIncidents() {
+    now := time.Date(2024, time.June, 10, 12, 0, 0, 0, time.UTC)
+    s.scheduler.SetClock(clock.NewFake(now))
+
+    crop := &models.Crop{ID: "incident-crop-id", GardenID: "incident-garden-id", Name: "Tomatoes", GrowBags: 2, BagSize: "12\"", CreatedAt: now}
+    _, err := s.mockDB.Create(crop)
+    require.NoError(s.T(), err)
+
+    pestControl := func() (dto.ScheduleSuggestion, bool) {
+        response, err := s.scheduler.SuggestSchedulesForCrop(s.ctx, crop.ID)
+        require.NoError(s.T(), err)
+        for _, suggestion := range response.Suggestions {
+            if suggestion.TaskType == dto.TaskTypePestControl {
+                return suggestion, true
+            }
+        }
+        return dto.ScheduleSuggestion{}, false
+    }
+    at := func(d time.Duration) *time.Time {
+        t := now.Add(-d)
+        return &t
+    }
+
+    s.Run("No Pest Control Without Incidents", func() {
+        _, ok := pestControl()
+        assert.False(s.T(), ok)
+    })
+
+    s.Run("Record And Retrieve", func() {
+        recorded, err := s.scheduler.RecordPestIncident(s.ctx, crop.ID, &dto.PestIncidentRequest{
+            Type:       "aphids",
+            Severity:   dto.IncidentSeverityLow,
+            OccurredAt: at(48 * time.Hour),
+            Notes:      "clusters under the lower leaves",
+            Treatment:  "neem oil spray",
+        })
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), crop.ID, recorded.CropID)
+        assert.Equal(s.T(), "neem oil spray", recorded.Treatment)
+
+        fetched, err := s.scheduler.GetPestIncident(s.ctx, recorded.ID)
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), "aphids", fetched.Type)
+        assert.True(s.T(), fetched.OccurredAt.Equal(*at(48 * time.Hour)))
+
+        incidents, err := s.scheduler.ListPestIncidents(s.ctx, crop.ID)
+        require.NoError(s.T(), err)
+        require.Len(s.T(), incidents, 1)
+
+        // Recorded without opting in, so suggestions are unchanged
+        _, ok := pestControl()
+        assert.False(s.T(), ok)
+    })
+
+    s.Run("Flagged Incident Raises Pest Control By Severity", func() {
+        recorded, err := s.scheduler.RecordPestIncident(s.ctx, crop.ID, &dto.PestIncidentRequest{
+            Type:             "whitefly",
+            Severity:         dto.IncidentSeverityMedium,
+            OccurredAt:       at(24 * time.Hour),
+            RaisePestControl: true,
+        })
+        require.NoError(s.T(), err)
+
+        suggestion, ok := pestControl()
+        require.True(s.T(), ok)
+        assert.Equal(s.T(), dto.FrequencyWeekly, suggestion.Frequency)
+        assert.Equal(s.T(), 100.0, suggestion.Amount)
+        assert.Equal(s.T(), "ml", suggestion.Unit)
+
+        // Escalating the incident raises the frequency further
+        _, err = s.scheduler.UpdatePestIncident(s.ctx, recorded.ID, &dto.PestIncidentRequest{
+            Type:             "whitefly",
+            Severity:         dto.IncidentSeverityHigh,
+            OccurredAt:       at(24 * time.Hour),
+            RaisePestControl: true,
+        })
+        require.NoError(s.T(), err)
+
+        suggestion, ok = pestControl()
+        require.True(s.T(), ok)
+        assert.Equal(s.T(), dto.FrequencyDaily, suggestion.Frequency)
+
+        // Deleting it drops the pest control suggestion again
+        require.NoError(s.T(), s.scheduler.DeletePestIncident(s.ctx, recorded.ID))
+        _, ok = pestControl()
+        assert.False(s.T(), ok)
+
+        _, err = s.scheduler.GetPestIncident(s.ctx, recorded.ID)
+        assert.ErrorIs(s.T(), err, scheduler.ErrIncidentNotFound)
+    })
+
+    s.Run("Old Incidents No Longer Raise Pest Control", func() {
+        _, err := s.scheduler.RecordPestIncident(s.ctx, crop.ID, &dto.PestIncidentRequest{
+            Type:             "blight",
+            Severity:         dto.IncidentSeverityHigh,
+            OccurredAt:       at(60 * 24 * time.Hour),
+            RaisePestControl: true,
+        })
+        require.NoError(s.T(), err)
+
+        _, ok := pestControl()
+        assert.False(s.T(), ok)
+    })
+
+    s.Run("Invalid Severity Rejected", func() {
+        _, err := s.scheduler.RecordPestIncident(s.ctx, crop.ID, &dto.PestIncidentRequest{
+            Type:     "aphids",
+            Severity: "catastrophic",
+        })
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+    })
+
+    s.Run("Unknown Crop", func() {
+        _, err := s.scheduler.RecordPestIncident(s.ctx, "missing-crop-id", &dto.PestIncidentRequest{
+            Type:     "aphids",
+            Severity: dto.IncidentSeverityLow,
+        })
+        assert.ErrorIs(s.T(), err, scheduler.ErrCropNotFound)
+    })
+}
+
+func (s *SchedulerTestSuite) TestMultipleServicesShareMetrics() {
+    cfg := &types.ServiceConfig{
+        ServiceName: "test-scheduler-second",
+        Environment: "test",
+    }
+
+    // The suite already built one service in SetupTest; further ones must not panic on
+    // duplicate metric registration
+    require.NotPanics(s.T(), func() {
+        for i := 0; i < 2; i++ {
+            service, err := scheduler.NewSchedulerService(s.mockDB, nil, s.mockAI, cfg)
+            require.NoError(s.T(), err)
+            require.NotNil(s.T(), service)
+        }
+    })
+}
+
+func (s *SchedulerTestSuite) TestNotificationPreferences() {
+    userID := "preferences-user-id"
+    now := time.Date(2024, time.June, 3, 9, 0, 0, 0, time.UTC)
+    s.scheduler.SetClock(clock.NewFake(now))
+
+    s.Run("Defaults Before Any Are Saved", func() {
+        preferences, err := s.scheduler.GetNotificationPreferences(s.ctx, userID)
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), []string{dto.ChannelPush}, preferences.Channels)
+        assert.Empty(s.T(), preferences.QuietHoursStart)
+        assert.Nil(s.T(), preferences.UpdatedAt)
+    })
+
+    s.Run("Set And Retrieve", func() {
+        saved, err := s.scheduler.SetNotificationPreferences(s.ctx, userID, &dto.NotificationPreferences{
+            Channels:        []string{dto.ChannelEmail, dto.ChannelSMS},
+            QuietHoursStart: "22:00",
+            QuietHoursEnd:   "07:00",
+            TimeZone:        "Asia/Kolkata",
+        })
+        require.NoError(s.T(), err)
+        require.NotNil(s.T(), saved.UpdatedAt)
+        assert.True(s.T(), saved.UpdatedAt.Equal(now))
+
+        preferences, err := s.scheduler.GetNotificationPreferences(s.ctx, userID)
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), []string{dto.ChannelEmail, dto.ChannelSMS}, preferences.Channels)
+        assert.Equal(s.T(), "22:00", preferences.QuietHoursStart)
+        assert.Equal(s.T(), "07:00", preferences.QuietHoursEnd)
+        assert.Equal(s.T(), "Asia/Kolkata", preferences.TimeZone)
+    })
+
+    s.Run("Reset Restores Defaults", func() {
+        reset, err := s.scheduler.ResetNotificationPreferences(s.ctx, userID)
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), dto.DefaultNotificationPreferences(), reset)
+
+        preferences, err := s.scheduler.GetNotificationPreferences(s.ctx, userID)
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), dto.DefaultNotificationPreferences(), preferences)
+    })
+
+    s.Run("Invalid Preferences Rejected", func() {
+        invalid := map[string]*dto.NotificationPreferences{
+            "no channels":        {Channels: []string{}},
+            "unknown channel":    {Channels: []string{"pigeon"}},
+            "duplicate channel":  {Channels: []string{dto.ChannelSMS, dto.ChannelSMS}},
+            "start without end":  {Channels: []string{dto.ChannelPush}, QuietHoursStart: "22:00"},
+            "malformed time":     {Channels: []string{dto.ChannelPush}, QuietHoursStart: "25:00", QuietHoursEnd: "07:00"},
+            "empty quiet window": {Channels: []string{dto.ChannelPush}, QuietHoursStart: "22:00", QuietHoursEnd: "22:00"},
+            "unknown time zone":  {Channels: []string{dto.ChannelPush}, QuietHoursStart: "22:00", QuietHoursEnd: "07:00", TimeZone: "Mars/Olympus"},
+        }
+        for name, preferences := range invalid {
+            _, err := s.scheduler.SetNotificationPreferences(s.ctx, userID, preferences)
+            assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest, name)
+        }
+
+        preferences, err := s.scheduler.GetNotificationPreferences(s.ctx, userID)
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), dto.DefaultNotificationPreferences(), preferences)
+    })
+
+    s.Run("Missing User ID", func() {
+        _, err := s.scheduler.GetNotificationPreferences(s.ctx, "")
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+    })
+}
+
+// TestApplyNotificationPreferenceToAll tests applying one set of preferences to every garden a user owns
+func (s *SchedulerTestSuite) TestApplyNotificationPreferenceToAll() {
+    userID := "bulk-preferences-user-id"
+    now := time.Date(2024, time.June, 3, 9, 0, 0, 0, time.UTC)
+    s.scheduler.SetClock(clock.NewFake(now))
+
+    gardens := []*models.Garden{
+        {ID: "balcony-garden-id", UserID: userID, Length: 4, Width: 3, SoilType: "loamy_soil", Sunlight: "full_sun", CreatedAt: now.Add(-48 * time.Hour)},
+        {ID: "terrace-garden-id", UserID: userID, Length: 8, Width: 5, SoilType: "red_soil", Sunlight: "partial_shade", CreatedAt: now.Add(-24 * time.Hour)},
+        {ID: "neighbour-garden-id", UserID: "other-user-id", Length: 5, Width: 5, SoilType: "clay_soil", Sunlight: "full_sun", CreatedAt: now},
+    }
+    for _, garden := range gardens {
+        _, err := s.mockDB.Create(garden)
+        require.NoError(s.T(), err)
+    }
+
+    quietNights := &dto.NotificationPreferences{
+        Channels:        []string{dto.ChannelEmail},
+        QuietHoursStart: "21:30",
+        QuietHoursEnd:   "06:30",
+        TimeZone:        "Europe/London",
+    }
+
+    s.Run("All Gardens Reflect Applied Preference", func() {
+        applied, err := s.scheduler.ApplyNotificationPreferenceToAll(s.ctx, userID, quietNights)
+        require.NoError(s.T(), err)
+        require.Len(s.T(), applied, 2)
+        assert.Equal(s.T(), "balcony-garden-id", applied[0].GardenID)
+        assert.Equal(s.T(), "terrace-garden-id", applied[1].GardenID)
+
+        for _, gardenID := range []string{"balcony-garden-id", "terrace-garden-id"} {
+            preferences, err := s.scheduler.GetGardenNotificationPreferences(s.ctx, userID, gardenID)
+            require.NoError(s.T(), err)
+            assert.Equal(s.T(), []string{dto.ChannelEmail}, preferences.Channels, gardenID)
+            assert.Equal(s.T(), "21:30", preferences.QuietHoursStart, gardenID)
+            assert.Equal(s.T(), "06:30", preferences.QuietHoursEnd, gardenID)
+            assert.Equal(s.T(), "Europe/London", preferences.TimeZone, gardenID)
+            require.NotNil(s.T(), preferences.UpdatedAt)
+            assert.True(s.T(), preferences.UpdatedAt.Equal(now))
+        }
+    })
+
+    s.Run("Other Users And Own Preferences Untouched", func() {
+        preferences, err := s.scheduler.GetGardenNotificationPreferences(s.ctx, "other-user-id", "neighbour-garden-id")
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), dto.DefaultNotificationPreferences(), preferences)
+
+        own, err := s.scheduler.GetNotificationPreferences(s.ctx, userID)
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), dto.DefaultNotificationPreferences(), own)
+    })
+
+    s.Run("Reapplying Replaces Previous Preference", func() {
+        _, err := s.scheduler.ApplyNotificationPreferenceToAll(s.ctx, userID, &dto.NotificationPreferences{
+            Channels: []string{dto.ChannelPush, dto.ChannelSMS},
+        })
+        require.NoError(s.T(), err)
+
+        preferences, err := s.scheduler.GetGardenNotificationPreferences(s.ctx, userID, "terrace-garden-id")
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), []string{dto.ChannelPush, dto.ChannelSMS}, preferences.Channels)
+        assert.Empty(s.T(), preferences.QuietHoursStart)
+    })
+
+    s.Run("Invalid Preference Rejected", func() {
+        _, err := s.scheduler.ApplyNotificationPreferenceToAll(s.ctx, userID, &dto.NotificationPreferences{
+            Channels:        []string{dto.ChannelPush},
+            QuietHoursStart: "22:00",
+        })
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+
+        _, err = s.scheduler.ApplyNotificationPreferenceToAll(s.ctx, "", quietNights)
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+    })
+}
+
+// TestDefaultFrequencyByTaskType tests that requests omitting a frequency get the task type's default
+func (s *SchedulerTestSuite) TestDefaultFrequencyByTaskType() {
+    withoutFrequency := func(taskType, unit string, amount float64) *dto.MaintenanceRequest {
+        request := newTestMaintenanceRequest("default-frequency-crop-id", taskType, unit, amount)
+        request.Frequency = ""
+        return request
+    }
+
+    s.Run("Water Defaults To Daily", func() {
+        response, err := s.scheduler.CreateSchedule(s.ctx, withoutFrequency(dto.TaskTypeWater, "ml", 500))
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), dto.FrequencyDaily, response.Frequency)
+    })
+
+    s.Run("Fertilizer Defaults To Weekly", func() {
+        response, err := s.scheduler.CreateSchedule(s.ctx, withoutFrequency(dto.TaskTypeFertilizer, "g", 20))
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), dto.FrequencyWeekly, response.Frequency)
+    })
+
+    s.Run("Explicit Frequency Kept", func() {
+        request := withoutFrequency(dto.TaskTypeWater, "ml", 500)
+        request.Frequency = dto.FrequencyTwiceDaily
+        response, err := s.scheduler.CreateSchedule(s.ctx, request)
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), dto.FrequencyTwiceDaily, response.Frequency)
+    })
+
+    s.Run("Defaults Are Configurable", func() {
+        cfg := &types.ServiceConfig{
+            ServiceName: "test-scheduler",
+            Environment: "test",
+            Scheduler: &types.SchedulerConfig{
+                AIWorkerPoolSize:   2,
+                MinNotificationGap: time.Minute,
+                DefaultFrequencies: map[string]string{dto.TaskTypeFertilizer: dto.FrequencyBiWeekly},
+            },
+        }
+        configured, err := scheduler.NewSchedulerService(s.mockDB, nil, s.mockAI, cfg)
+        require.NoError(s.T(), err)
+
+        response, err := configured.CreateSchedule(s.ctx, withoutFrequency(dto.TaskTypeFertilizer, "g", 20))
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), dto.FrequencyBiWeekly, response.Frequency)
+
+        // Task types without an override keep the built-in default
+        response, err = configured.CreateSchedule(s.ctx, withoutFrequency(dto.TaskTypeWater, "ml", 500))
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), dto.FrequencyDaily, response.Frequency)
+    })
+
+    s.Run("Invalid Configured Defaults Rejected", func() {
+        for name, frequencies := range map[string]map[string]string{
+            "unknown task type": {"Harvesting": dto.FrequencyWeekly},
+            "unknown frequency": {dto.TaskTypeWater: "Hourly"},
+        } {
+            cfg := &types.ServiceConfig{Scheduler: &types.SchedulerConfig{DefaultFrequencies: frequencies}}
+            _, err := scheduler.NewSchedulerService(s.mockDB, nil, s.mockAI, cfg)
+            assert.Error(s.T(), err, name)
+        }
+    })
+}
+
+// TestExportHistoryCSV tests that a garden's schedule and completion events export as CSV
+func (s *SchedulerTestSuite) TestExportHistoryCSV() {
+    gardenID := "history-garden-id"
+    crop := &models.Crop{ID: "history-crop-id", GardenID: gardenID, Name: "Basil", GrowBags: 1, BagSize: "12\""}
+    _, err := s.mockDB.Create(crop)
+    require.NoError(s.T(), err)
+
+    schedule, err := s.scheduler.CreateSchedule(s.ctx, newTestMaintenanceRequest(crop.ID, "Water", "ml", 250.0))
+    require.NoError(s.T(), err)
+
+    completedAt := time.Now().Add(-2 * time.Hour).UTC().Truncate(time.Second)
+    _, err = s.scheduler.CompleteTask(s.ctx, schedule.ID, &completedAt, "")
+    require.NoError(s.T(), err)
+
+    export, err := s.scheduler.ExportHistoryCSV(s.ctx, gardenID, time.Time{}, time.Time{})
+    require.NoError(s.T(), err)
+
+    rows, err := csv.NewReader(bytes.NewReader(export)).ReadAll()
+    require.NoError(s.T(), err)
+    require.Len(s.T(), rows, 3)
+
+    assert.Equal(s.T(), []string{
+        "occurred_at", "event", "schedule_id", "crop_id", "crop_name",
+        "task_type", "frequency", "amount", "unit", "preferred_time",
+    }, rows[0])
+    assert.Equal(s.T(), models.ScheduleEventCreated, rows[1][1])
+    assert.Equal(s.T(), []string{
+        completedAt.Format(time.RFC3339), models.ScheduleEventCompleted, schedule.ID, crop.ID, "Basil",
+        "Water", "Weekly", "250", "ml", "09:00",
+    }, rows[2])
+
+    s.Run("Range Excludes Earlier Events", func() {
+        export, err := s.scheduler.ExportHistoryCSV(s.ctx, gardenID, completedAt.Add(-time.Minute), completedAt.Add(time.Minute))
+        require.NoError(s.T(), err)
+
+        rows, err := csv.NewReader(bytes.NewReader(export)).ReadAll()
+        require.NoError(s.T(), err)
+        require.Len(s.T(), rows, 2)
+        assert.Equal(s.T(), models.ScheduleEventCompleted, rows[1][1])
+    })
+
+    s.Run("Invalid Range", func() {
+        _, err := s.scheduler.ExportHistoryCSV(s.ctx, gardenID, completedAt, completedAt.Add(-time.Hour))
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+    })
+
+    s.Run("Missing Garden ID", func() {
+        _, err := s.scheduler.ExportHistoryCSV(s.ctx, "", time.Time{}, time.Time{})
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+    })
+}
+
+// TestPurgeCompletionHistory tests that completion events past the retention window are
+// purged while the task's streak and latest completion are kept
+func (s *SchedulerTestSuite) TestPurgeCompletionHistory() {
+    mr := miniredis.RunT(s.T())
+    redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+    s.T().Cleanup(func() { redisClient.Close() })
+
+    cfg := &types.ServiceConfig{
+        ServiceName: "test-scheduler",
+        Environment: "test",
+        Scheduler: &types.SchedulerConfig{
+            AIWorkerPoolSize:           5,
+            CompletionHistoryRetention: 10 * 24 * time.Hour,
+            HistoryPurgeBatchSize:      2,
+        },
+    }
+    service, err := scheduler.NewSchedulerService(s.mockDB, redisClient, s.mockAI, cfg)
+    require.NoError(s.T(), err)
+
+    gardenID := "retention-garden-id"
+    crop := &models.Crop{ID: "retention-crop-id", GardenID: gardenID, Name: "Basil", GrowBags: 1, BagSize: "12\""}
+    _, err = s.mockDB.Create(crop)
+    require.NoError(s.T(), err)
+
+    schedule, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest(crop.ID, "Water", "ml", 250.0))
+    require.NoError(s.T(), err)
+
+    // Weekly completions on schedule build a streak of five
+    now := time.Now().UTC().Truncate(time.Second)
+    for _, daysAgo := range []int{30, 23, 16, 9, 2} {
+        completedAt := now.AddDate(0, 0, -daysAgo)
+        _, err := service.CompleteTask(s.ctx, schedule.ID, &completedAt, "")
+        require.NoError(s.T(), err)
+    }
+
+    // completionTimes lists the task's remaining completion events from its history export
+    completionTimes := func() []string {
+        export, err := service.ExportHistoryCSV(s.ctx, gardenID, time.Time{}, time.Time{})
+        require.NoError(s.T(), err)
+        rows, err := csv.NewReader(bytes.NewReader(export)).ReadAll()
+        require.NoError(s.T(), err)
+
+        var times []string
+        for _, row := range rows[1:] {
+            if row[1] == models.ScheduleEventCompleted {
+                times = append(times, row[0])
+            }
+        }
+        return times
+    }
+    require.Len(s.T(), completionTimes(), 5)
+
+    s.Run("Another Replica Holds The Lock", func() {
+        require.NoError(s.T(), mr.Set("locks:completion-history-purge", "other-replica"))
+        defer mr.Del("locks:completion-history-purge")
+
+        _, err := service.PurgeCompletionHistory(s.ctx, now)
+        assert.ErrorIs(s.T(), err, scheduler.ErrPurgeInProgress)
+        assert.Len(s.T(), completionTimes(), 5)
+    })
+
+    s.Run("Old Events Purged", func() {
+        // Three expired events take two batches of two
+        purged, err := service.PurgeCompletionHistory(s.ctx, now)
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), int64(3), purged)
+        assert.Equal(s.T(), []string{
+            now.AddDate(0, 0, -9).Format(time.RFC3339),
+            now.AddDate(0, 0, -2).Format(time.RFC3339),
+        }, completionTimes())
+
+        // The lock is released for the next run
+        assert.False(s.T(), mr.Exists("locks:completion-history-purge"))
+    })
+
+    s.Run("Streak Intact", func() {
+        task, err := service.GetSchedule(s.ctx, schedule.ID)
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), 5, task.CompletionStreak)
+        assert.True(s.T(), now.AddDate(0, 0, -2).Equal(task.LastCompletedTime))
+    })
+
+    s.Run("Latest Completion Kept Past Retention", func() {
+        purged, err := service.PurgeCompletionHistory(s.ctx, now.AddDate(0, 1, 0))
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), int64(1), purged)
+        assert.Equal(s.T(), []string{now.AddDate(0, 0, -2).Format(time.RFC3339)}, completionTimes())
+    })
+}
+
+// TestNotificationDigest tests that a garden in digest mode receives one notification
+// covering the day's tasks instead of one per task
+func (s *SchedulerTestSuite) TestNotificationDigest() {
+    mr := miniredis.RunT(s.T())
+    redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+    defer redisClient.Close()
+
+    cfg := &types.ServiceConfig{
+        ServiceName: "test-scheduler",
+        Environment: "test",
+    }
+    service, err := scheduler.NewSchedulerService(s.mockDB, redisClient, s.mockAI, cfg)
+    require.NoError(s.T(), err)
+
+    sender := newRecordingSender()
+    service.SetNotificationSender(dto.ChannelEmail, sender)
+
+    gardenID := "digest-garden-id"
+    crop := &models.Crop{ID: "digest-crop-id", GardenID: gardenID, Name: "Tomatoes", GrowBags: 2, BagSize: "12\""}
+    _, err = s.mockDB.Create(crop)
+    require.NoError(s.T(), err)
+
+    recipient := &dto.NotificationRecipient{Name: "Asha", Channel: dto.ChannelEmail, Address: "asha@example.com"}
+    _, err = service.RegisterNotificationRecipient(s.ctx, gardenID, recipient)
+    require.NoError(s.T(), err)
+
+    settings, err := service.GetNotificationDigest(s.ctx, gardenID)
+    require.NoError(s.T(), err)
+    assert.False(s.T(), settings.Enabled, "digest mode should be off until enabled")
+
+    _, err = service.SetNotificationDigest(s.ctx, gardenID, &dto.DigestSettings{Enabled: true, DeliveryTime: "07:00"})
+    require.NoError(s.T(), err)
+
+    water, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest(crop.ID, "Water", "ml", 500.0))
+    require.NoError(s.T(), err)
+    fertilizer, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest(crop.ID, "Fertilizer", "g", 20.0))
+    require.NoError(s.T(), err)
+
+    s.Run("Individual Notifications Suppressed", func() {
+        assert.Empty(s.T(), pendingNotificationTaskIDs(s.T(), mr, "Water"))
+        assert.Empty(s.T(), pendingNotificationTaskIDs(s.T(), mr, "Fertilizer"))
+    })
+
+    s.Run("One Digest Covers Every Task", func() {
+        due := water.NextScheduledTime.UTC()
+        digestTime := time.Date(due.Year(), due.Month(), due.Day(), 7, 0, 0, 0, time.UTC)
+        require.NoError(s.T(), service.DeliverDueNotifications(s.ctx, digestTime.Add(time.Minute)))
+
+        require.Equal(s.T(), 1, sender.count(recipient.ID))
+        digest := sender.delivered[recipient.ID][0]
+        assert.Equal(s.T(), gardenID, digest.GardenID)
+        require.Len(s.T(), digest.Digest, 2)
+        taskIDs := []string{digest.Digest[0].TaskID, digest.Digest[1].TaskID}
+        assert.ElementsMatch(s.T(), []string{water.ID, fertilizer.ID}, taskIDs)
+        assert.Equal(s.T(), crop.ID, digest.Digest[0].CropID)
+    })
+
+    s.Run("Nothing Sent Again Later That Day", func() {
+        require.NoError(s.T(), service.DeliverDueNotifications(s.ctx, water.NextScheduledTime.Add(time.Hour)))
+        assert.Equal(s.T(), 1, sender.count(recipient.ID))
+    })
+
+    s.Run("Invalid Settings Rejected", func() {
+        _, err := service.SetNotificationDigest(s.ctx, gardenID, &dto.DigestSettings{Enabled: true})
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidDigestSettings)
+
+        _, err = service.SetNotificationDigest(s.ctx, gardenID, &dto.DigestSettings{Enabled: true, DeliveryTime: "7am"})
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidDigestSettings)
+    })
+}
+
+// failingSender rejects every notification, as an unreachable provider would
+type failingSender struct{}
+
+func (failingSender) Send(ctx context.Context, recipient dto.NotificationRecipient, message scheduler.NotificationMessage) error {
+    return fmt.Errorf("provider unavailable")
+}
+
+// TestListDeadLetters tests paging and filtering over notifications that exhausted their retries
+func (s *SchedulerTestSuite) TestListDeadLetters() {
+    mr := miniredis.RunT(s.T())
+    redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+    defer redisClient.Close()
+
+    cfg := &types.ServiceConfig{
+        ServiceName: "test-scheduler",
+        Environment: "test",
+    }
+    service, err := scheduler.NewSchedulerService(s.mockDB, redisClient, s.mockAI, cfg)
+    require.NoError(s.T(), err)
+
+    // The email provider is down and no SMS sender is configured at all
+    service.SetNotificationSender(dto.ChannelEmail, failingSender{})
+
+    emailGarden, smsGarden := "dlq-email-garden", "dlq-sms-garden"
+    _, err = service.RegisterNotificationRecipient(s.ctx, emailGarden, &dto.NotificationRecipient{Name: "Asha", Channel: dto.ChannelEmail, Address: "asha@example.com"})
+    require.NoError(s.T(), err)
+    _, err = service.RegisterNotificationRecipient(s.ctx, smsGarden, &dto.NotificationRecipient{Name: "Ravi", Channel: dto.ChannelSMS, Address: "+15550100"})
+    require.NoError(s.T(), err)
+
+    crops := []*models.Crop{
+        {ID: "dlq-crop-1", GardenID: emailGarden, Name: "Tomatoes", GrowBags: 2, BagSize: "12\""},
+        {ID: "dlq-crop-2", GardenID: emailGarden, Name: "Spinach", GrowBags: 1, BagSize: "10\""},
+        {ID: "dlq-crop-3", GardenID: smsGarden, Name: "Lettuce", GrowBags: 1, BagSize: "10\""},
+    }
+    for _, crop := range crops {
+        _, err := s.mockDB.Create(crop)
+        require.NoError(s.T(), err)
+    }
+    for _, request := range []*dto.MaintenanceRequest{
+        newTestMaintenanceRequest("dlq-crop-1", "Water", "ml", 500.0),
+        newTestMaintenanceRequest("dlq-crop-1", "Fertilizer", "g", 20.0),
+        newTestMaintenanceRequest("dlq-crop-2", "Water", "ml", 300.0),
+        newTestMaintenanceRequest("dlq-crop-3", "Water", "ml", 250.0),
+    } {
+        _, err := service.CreateSchedule(s.ctx, request)
+        require.NoError(s.T(), err)
+    }
+
+    // The first attempt and every retry fail, after which each notification is dead-lettered
+    dueAt := time.Now().UTC().Add(8 * 24 * time.Hour)
+    for attempt := 0; attempt < 5; attempt++ {
+        require.NoError(s.T(), service.DeliverDueNotifications(s.ctx, dueAt.Add(time.Duration(attempt)*time.Hour)))
+    }
+    require.Empty(s.T(), pendingNotificationTaskIDs(s.T(), mr, "Water"))
+
+    s.Run("Pages Cover Every Entry Once", func() {
+        first, err := service.ListDeadLetters(s.ctx, 1, 3, dto.DeadLetterFilter{})
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), 4, first.Total)
+        assert.Equal(s.T(), 2, first.TotalPages)
+        assert.Len(s.T(), first.DeadLetters, 3)
+        assert.True(s.T(), first.HasNext)
+        assert.False(s.T(), first.HasPrevious)
+
+        second, err := service.ListDeadLetters(s.ctx, 2, 3, dto.DeadLetterFilter{})
+        require.NoError(s.T(), err)
+        assert.Len(s.T(), second.DeadLetters, 1)
+        assert.False(s.T(), second.HasNext)
+        assert.True(s.T(), second.HasPrevious)
+
+        ids := make(map[string]bool)
+        for _, letter := range append(first.DeadLetters, second.DeadLetters...) {
+            ids[letter.ID] = true
+        }
+        assert.Len(s.T(), ids, 4)
+    })
+
+    s.Run("Page Past The End Is Empty", func() {
+        page, err := service.ListDeadLetters(s.ctx, 5, 3, dto.DeadLetterFilter{})
+        require.NoError(s.T(), err)
+        assert.Empty(s.T(), page.DeadLetters)
+        assert.Equal(s.T(), 4, page.Total)
+    })
+
+    s.Run("Filtered By Task Type", func() {
+        page, err := service.ListDeadLetters(s.ctx, 1, 10, dto.DeadLetterFilter{TaskType: "Fertilizer"})
+        require.NoError(s.T(), err)
+        require.Equal(s.T(), 1, page.Total)
+        assert.Equal(s.T(), "Fertilizer", page.DeadLetters[0].TaskType)
+        assert.Equal(s.T(), emailGarden, page.DeadLetters[0].GardenID)
+    })
+
+    s.Run("Filtered By Reason", func() {
+        page, err := service.ListDeadLetters(s.ctx, 1, 10, dto.DeadLetterFilter{Reason: scheduler.DeadLetterReasonNoSender})
+        require.NoError(s.T(), err)
+        require.Equal(s.T(), 1, page.Total)
+        letter := page.DeadLetters[0]
+        assert.Equal(s.T(), smsGarden, letter.GardenID)
+        assert.Equal(s.T(), 3, letter.RetryCount)
+        assert.Contains(s.T(), letter.Error, "no sender")
+    })
+
+    s.Run("Filtered By Task Type And Reason", func() {
+        page, err := service.ListDeadLetters(s.ctx, 1, 10, dto.DeadLetterFilter{TaskType: "Water", Reason: scheduler.DeadLetterReasonDeliveryFailed})
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), 2, page.Total)
+        for _, letter := range page.DeadLetters {
+            assert.Equal(s.T(), "Water", letter.TaskType)
+            assert.Contains(s.T(), letter.Error, "provider unavailable")
+        }
+    })
+
+    s.Run("Unknown Reason Rejected", func() {
+        _, err := service.ListDeadLetters(s.ctx, 1, 10, dto.DeadLetterFilter{Reason: "gremlins"})
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+    })
+}
+
+// TestNotificationChannelFallback tests that delivery tries a recipient's channels in
+// priority order and records the channel that delivered
+func (s *SchedulerTestSuite) TestNotificationChannelFallback() {
+    mr := miniredis.RunT(s.T())
+    redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+    defer redisClient.Close()
+
+    cfg := &types.ServiceConfig{
+        ServiceName: "test-scheduler",
+        Environment: "test",
+    }
+    service, err := scheduler.NewSchedulerService(s.mockDB, redisClient, s.mockAI, cfg)
+    require.NoError(s.T(), err)
+
+    // Push is down, email works, and no SMS sender is configured
+    emailSender := newRecordingSender()
+    service.SetNotificationSender(dto.ChannelPush, failingSender{})
+    service.SetNotificationSender(dto.ChannelEmail, emailSender)
+
+    gardenID := "fallback-garden-id"
+    crop := &models.Crop{ID: "fallback-crop-id", GardenID: gardenID, Name: "Peppers", GrowBags: 1, BagSize: "12\""}
+    _, err = s.mockDB.Create(crop)
+    require.NoError(s.T(), err)
+
+    asha := &dto.NotificationRecipient{
+        Name:      "Asha",
+        Channel:   dto.ChannelPush,
+        Address:   "device-token",
+        Fallbacks: []dto.NotificationChannel{{Channel: dto.ChannelEmail, Address: "asha@example.com"}},
+    }
+    ravi := &dto.NotificationRecipient{
+        Name:    "Ravi",
+        Channel: dto.ChannelSMS,
+        Address: "+15550100",
+        Fallbacks: []dto.NotificationChannel{
+            {Channel: dto.ChannelPush, Address: "ravi-device"},
+            {Channel: dto.ChannelEmail, Address: "ravi@example.com"},
+        },
+    }
+    meera := &dto.NotificationRecipient{Name: "Meera", Channel: dto.ChannelPush, Address: "meera-device"}
+    for _, recipient := range []*dto.NotificationRecipient{asha, ravi, meera} {
+        _, err := service.RegisterNotificationRecipient(s.ctx, gardenID, recipient)
+        require.NoError(s.T(), err)
+    }
+
+    schedule, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest(crop.ID, "Water", "ml", 500.0))
+    require.NoError(s.T(), err)
+    require.NoError(s.T(), service.DeliverDueNotifications(s.ctx, time.Now().UTC().Add(48*time.Hour)))
+
+    deliveries, err := service.ListNotificationDeliveries(s.ctx, gardenID)
+    require.NoError(s.T(), err)
+    byRecipient := make(map[string]dto.NotificationDelivery, len(deliveries))
+    for _, delivery := range deliveries {
+        byRecipient[delivery.RecipientID] = delivery
+    }
+
+    s.Run("Falls Back To Second Channel", func() {
+        assert.Equal(s.T(), 1, emailSender.count(asha.ID))
+
+        delivery, ok := byRecipient[asha.ID]
+        require.True(s.T(), ok)
+        assert.Equal(s.T(), dto.ChannelEmail, delivery.Channel)
+        assert.Equal(s.T(), []string{dto.ChannelPush}, delivery.FailedChannels)
+        assert.Equal(s.T(), schedule.ID, delivery.TaskID)
+    })
+
+    s.Run("Tries Every Channel In Order", func() {
+        assert.Equal(s.T(), 1, emailSender.count(ravi.ID))
+
+        delivery, ok := byRecipient[ravi.ID]
+        require.True(s.T(), ok)
+        assert.Equal(s.T(), dto.ChannelEmail, delivery.Channel)
+        assert.Equal(s.T(), []string{dto.ChannelSMS, dto.ChannelPush}, delivery.FailedChannels)
+    })
+
+    s.Run("No Delivery Recorded When Every Channel Fails", func() {
+        _, ok := byRecipient[meera.ID]
+        assert.False(s.T(), ok)
+        assert.Len(s.T(), deliveries, 2)
+        assert.Equal(s.T(), []string{schedule.ID}, pendingNotificationTaskIDs(s.T(), mr, "Water"), "failed recipient should be retried")
+    })
+
+    s.Run("Invalid Fallback Rejected", func() {
+        _, err := service.RegisterNotificationRecipient(s.ctx, gardenID, &dto.NotificationRecipient{
+            Channel:   dto.ChannelPush,
+            Address:   "device-token",
+            Fallbacks: []dto.NotificationChannel{{Channel: "pigeon", Address: "roof"}},
+        })
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRecipient)
+    })
+}
+
+// TestAIAmountBounds tests that AI-recommended amounts outside the validated bounds are
+// clamped or discarded and flagged instead of failing the schedule
+func (s *SchedulerTestSuite) TestAIAmountBounds() {
+    mr := miniredis.RunT(s.T())
+    redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+    defer redisClient.Close()
+
+    newService := func(policy string) *scheduler.SchedulerService {
+        cfg := &types.ServiceConfig{
+            ServiceName: "test-scheduler",
+            Environment: "test",
+            Scheduler:   &types.SchedulerConfig{AIAmountPolicy: policy},
+        }
+        service, err := scheduler.NewSchedulerService(s.mockDB, redisClient, s.mockAI, cfg)
+        require.NoError(s.T(), err)
+        return service
+    }
+    recommend := func(amount interface{}) {
+        s.mockAI.SetMockSchedule("default", map[string]interface{}{
+            "tasks":     []string{"watering"},
+            "frequency": "daily",
+            "duration":  "10min",
+            "amount":    amount,
+        })
+    }
+
+    s.Run("Over Max Amount Clamped And Flagged", func() {
+        recommend(5000.0)
+        service := newService(scheduler.AIAmountClamp)
+
+        response, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest("clamp-crop-id", "Water", "ml", 500.0))
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), 2000.0, response.Amount)
+        require.NotNil(s.T(), response.AmountAdjustment)
+        assert.Equal(s.T(), 5000.0, response.AmountAdjustment.RecommendedAmount)
+        assert.Equal(s.T(), 2000.0, response.AmountAdjustment.AppliedAmount)
+        assert.Equal(s.T(), 2000.0, response.AmountAdjustment.MaxAmount)
+        assert.Equal(s.T(), scheduler.AIAmountClamp, response.AmountAdjustment.Policy)
+    })
+
+    s.Run("Below Min Amount Clamped", func() {
+        recommend(-20.0)
+        preview, err := newService(scheduler.AIAmountClamp).PreviewNextSchedule(s.ctx, newTestMaintenanceRequest("clamp-crop-id", "Fertilizer", "g", 50.0))
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), 10.0, preview.Amount)
+        require.NotNil(s.T(), preview.AmountAdjustment)
+        assert.Equal(s.T(), -20.0, preview.AmountAdjustment.RecommendedAmount)
+        assert.Equal(s.T(), 10.0, preview.AmountAdjustment.MinAmount)
+    })
+
+    s.Run("Over Max Amount Discarded", func() {
+        recommend("750")
+        preview, err := newService(scheduler.AIAmountDiscard).PreviewNextSchedule(s.ctx, newTestMaintenanceRequest("discard-crop-id", "Fertilizer", "g", 50.0))
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), 50.0, preview.Amount)
+        require.NotNil(s.T(), preview.AmountAdjustment)
+        assert.Equal(s.T(), 750.0, preview.AmountAdjustment.RecommendedAmount)
+        assert.Equal(s.T(), 50.0, preview.AmountAdjustment.AppliedAmount)
+        assert.Equal(s.T(), scheduler.AIAmountDiscard, preview.AmountAdjustment.Policy)
+    })
+
+    s.Run("In Bounds Amount Applied And Reported", func() {
+        recommend(800.0)
+        preview, err := newService(scheduler.AIAmountClamp).PreviewNextSchedule(s.ctx, newTestMaintenanceRequest("in-bounds-crop-id", "Water", "ml", 500.0))
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), 800.0, preview.Amount)
+        require.NotNil(s.T(), preview.AmountAdjustment)
+        assert.Equal(s.T(), 500.0, preview.AmountAdjustment.RequestedAmount)
+        assert.Equal(s.T(), 800.0, preview.AmountAdjustment.AppliedAmount)
+        assert.Empty(s.T(), preview.AmountAdjustment.Policy)
+    })
+
+    s.Run("Matching Amount Unflagged", func() {
+        recommend(500.0)
+        preview, err := newService(scheduler.AIAmountClamp).PreviewNextSchedule(s.ctx, newTestMaintenanceRequest("in-bounds-crop-id", "Water", "ml", 500.0))
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), 500.0, preview.Amount)
+        assert.Nil(s.T(), preview.AmountAdjustment)
+    })
+
+    s.Run("Below Model Minimum Water Clamped", func() {
+        recommend(20.0)
+        preview, err := newService(scheduler.AIAmountClamp).PreviewNextSchedule(s.ctx, newTestMaintenanceRequest("min-crop-id", "Water", "ml", 500.0))
+        require.NoError(s.T(), err)
+        assert.Equal(s.T(), 50.0, preview.Amount)
+        require.NotNil(s.T(), preview.AmountAdjustment)
+        assert.Equal(s.T(), scheduler.AIAmountClamp, preview.AmountAdjustment.Policy)
+    })
+
+    s.Run("Unknown Policy Rejected", func() {
+        _, err := scheduler.NewSchedulerService(s.mockDB, redisClient, s.mockAI, &types.ServiceConfig{
+            Scheduler: &types.SchedulerConfig{AIAmountPolicy: "ignore"},
+        })
+        assert.ErrorIs(s.T(), err, scheduler.ErrInvalidRequest)
+    })
+}
+
+// scheduleCompletionClient answers every completion with a valid maintenance schedule
+type scheduleCompletionClient struct{}
+
+// CreateCompletion implements ai.CompletionClient
+func (scheduleCompletionClient) CreateCompletion(ctx context.Context, request openai.CompletionRequest) (openai.CompletionResponse, error) {
+    text := `{"tasks":["watering"],"frequency":"Daily","duration":"15min"}`
+    return openai.CompletionResponse{Choices: []openai.CompletionChoice{{Text: text}}}, nil
+}
+
+// TestCreateScheduleWithAIProviders tests that the scheduler works against either AI
+// provider through the common interface
+func (s *SchedulerTestSuite) TestCreateScheduleWithAIProviders() {
+    cfg := &types.ServiceConfig{
+        ServiceName: "test-scheduler",
+        Environment: "test",
+    }
+    client, err := ai.NewAIClientWithCompletionClient(cfg, scheduleCompletionClient{})
+    require.NoError(s.T(), err)
+
+    providers := map[string]ai.AIProvider{
+        ai.ProviderOpenAI: client,
+        ai.ProviderStub:   ai.NewStubProvider(),
+    }
+    for name, provider := range providers {
+        s.Run(name, func() {
+            recService, err := ai.NewRecommendationService(provider, 0)
+            require.NoError(s.T(), err)
+            service, err := scheduler.NewSchedulerService(s.mockDB, nil, recService, cfg)
+            require.NoError(s.T(), err)
+
+            schedule, err := service.CreateSchedule(s.ctx, newTestMaintenanceRequest(name+"-crop-id", "Water", "ml", 500.0))
+            require.NoError(s.T(), err)
+            assert.NotEmpty(s.T(), schedule.ID)
+            assert.Equal(s.T(), "Water", schedule.TaskType)
+            assert.True(s.T(), schedule.NextScheduledTime.After(time.Now()))
+        })
+    }
+}